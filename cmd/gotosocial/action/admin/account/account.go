@@ -383,3 +383,51 @@ var Password action.GTSAction = func(ctx context.Context) error {
 		"encrypted_password",
 	)
 }
+
+// ResetTwoFactor disables two-factor authentication
+// for the target account, eg., if the user has lost
+// access to both their authenticator and backup codes.
+var ResetTwoFactor action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorLastStep = 0
+	user.TwoFactorFailures = 0
+	user.TwoFactorFailedAt = time.Time{}
+	return state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+		"two_factor_failures",
+		"two_factor_failed_at",
+	)
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// Dedupe moves all existing files in storage into content-addressed
+// blob storage, so that files with identical contents are stored once.
+var Dedupe action.GTSAction = func(ctx context.Context) error {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+	defer state.Caches.Stop()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	defer func() {
+		if err := dbService.Close(); err != nil {
+			log.Errorf(ctx, "error stopping database: %v", err)
+		}
+	}()
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+	storage.Blobs = dbService
+
+	defer func() {
+		if err := storage.Close(); err != nil {
+			log.Errorf(ctx, "error closing storage backend: %v", err)
+		}
+	}()
+
+	// Gather keys up-front, as deduping
+	// while walking would modify storage.
+	var keys []string
+	if err := storage.WalkKeys(ctx, func(_ context.Context, key string) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error walking storage: %w", err)
+	}
+
+	var deduped, failed int
+	for _, key := range keys {
+		ok, err := storage.Dedupe(ctx, key)
+		if err != nil {
+			log.Errorf(ctx, "error deduplicating %s: %v", key, err)
+			failed++
+			continue
+		}
+
+		if ok {
+			deduped++
+		}
+	}
+
+	log.Infof(ctx, "moved %d of %d files into blob storage (%d errors)", deduped, len(keys), failed)
+
+	// Perform a cleanup of storage (for removed local dirs).
+	if err := storage.Storage.Clean(ctx); err != nil {
+		log.Errorf(ctx, "error cleaning storage: %v", err)
+	}
+
+	return nil
+}
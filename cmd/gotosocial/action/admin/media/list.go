@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 type list struct {
@@ -145,6 +146,18 @@ func setupList(ctx context.Context) (*list, error) {
 	}, nil
 }
 
+// storagePath returns the path on disk of the file stored
+// at key, taking into account content-addressed blobs.
+func (l *list) storagePath(ctx context.Context, mediaPath string, key string) string {
+	hash, err := l.dbService.GetStorageBlobRef(ctx, key)
+	if err != nil {
+		log.Errorf(ctx, "error getting blob ref for %s: %v", key, err)
+	} else if hash != "" {
+		key = gtsstorage.BlobKey(hash)
+	}
+	return path.Join(mediaPath, key)
+}
+
 func (l *list) shutdown() error {
 	l.out.Flush()
 	err := l.dbService.Close()
@@ -181,7 +194,7 @@ var ListAttachments action.GTSAction = func(ctx context.Context) error {
 				return ""
 			}
 
-			return path.Join(mediaPath, m.File.Path)
+			return list.storagePath(ctx, mediaPath, m.File.Path)
 		}
 
 	case list.remoteOnly:
@@ -192,12 +205,12 @@ var ListAttachments action.GTSAction = func(ctx context.Context) error {
 				return ""
 			}
 
-			return path.Join(mediaPath, m.File.Path)
+			return list.storagePath(ctx, mediaPath, m.File.Path)
 		}
 
 	default:
 		filter = func(m *gtsmodel.MediaAttachment) string {
-			return path.Join(mediaPath, m.File.Path)
+			return list.storagePath(ctx, mediaPath, m.File.Path)
 		}
 	}
 
@@ -240,7 +253,7 @@ var ListEmojis action.GTSAction = func(ctx context.Context) error {
 				return ""
 			}
 
-			return path.Join(mediaPath, e.ImagePath)
+			return list.storagePath(ctx, mediaPath, e.ImagePath)
 		}
 
 	case list.remoteOnly:
@@ -251,12 +264,12 @@ var ListEmojis action.GTSAction = func(ctx context.Context) error {
 				return ""
 			}
 
-			return path.Join(mediaPath, e.ImagePath)
+			return list.storagePath(ctx, mediaPath, e.ImagePath)
 		}
 
 	default:
 		filter = func(e *gtsmodel.Emoji) string {
-			return path.Join(mediaPath, e.ImagePath)
+			return list.storagePath(ctx, mediaPath, e.ImagePath)
 		}
 	}
 
@@ -57,6 +57,7 @@ func setupPrune(ctx context.Context) (*prune, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating storage backend: %w", err)
 	}
+	storage.Blobs = dbService
 	state.Storage = storage
 
 	//nolint:contextcheck
@@ -60,6 +60,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 
 	// Inherit memory limit if set from cgroup
 	_ "github.com/KimMachineGun/automemlimit"
@@ -113,6 +114,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error creating storage backend: %w", err)
 	}
 
+	// Deduplicate stored media by content,
+	// using the database to track references.
+	storage.Blobs = dbService
+
 	// Set the state storage driver
 	state.Storage = storage
 
@@ -150,6 +155,49 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
 	federator := federation.NewFederator(&state, federatingDB, transportController, typeConverter, visFilter, mediaManager)
 
+	// Add a task to the scheduler to process any queued
+	// outgoing deliveries that are due to be (re)attempted,
+	// including those left over from a previous run.
+	// Frequency = 1 * minute
+	_ = state.Workers.Scheduler.AddRecurring(
+		"@deliveries", // id
+		time.Time{},   // start
+		time.Minute,   // freq
+		func(ctx context.Context, _ time.Time) {
+			if err := transportController.ProcessDeliveries(ctx); err != nil {
+				log.Errorf(ctx, "error processing deliveries: %v", err)
+			}
+		},
+	)
+
+	// Add a task to the scheduler to check whether any
+	// instances marked unavailable due to sustained
+	// delivery failures have become reachable again.
+	// Frequency = 1 * hour
+	_ = state.Workers.Scheduler.AddRecurring(
+		"@instanceprobe", // id
+		time.Time{},      // start
+		time.Hour,        // freq
+		func(ctx context.Context, _ time.Time) {
+			if err := transportController.ProbeUnavailableInstances(ctx); err != nil {
+				log.Errorf(ctx, "error probing unavailable instances: %v", err)
+			}
+		},
+	)
+
+	// Add a task to the scheduler to re-crawl remote
+	// threads that were recently viewed by local users,
+	// fetching any new replies that weren't delivered.
+	// Frequency = 15 * minute
+	_ = state.Workers.Scheduler.AddRecurring(
+		"@threadrecrawl", // id
+		time.Time{},      // start
+		15*time.Minute,   // freq
+		func(ctx context.Context, _ time.Time) {
+			federator.RecrawlThreads(ctx)
+		},
+	)
+
 	// Decide whether to create a noop email
 	// sender (won't send emails) or a real one.
 	var emailSender email.Sender
@@ -167,6 +215,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
+	// Create a Web Push sender
+	// using the shared http client.
+	webPushSender := webpush.NewSender(client, &state)
+
 	// Initialize timelines.
 	state.Timelines.Home = timeline.NewManager(
 		tlprocessor.HomeTimelineGrab(&state),
@@ -200,6 +252,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		&state,
 		emailSender,
+		webPushSender,
 	)
 
 	// Set state client / federator asynchronous worker enqueue functions
@@ -210,13 +263,34 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.ProcessFromClientAPI = processor.Workers().ProcessFromClientAPI
 	state.Workers.ProcessFromFediAPI = processor.Workers().ProcessFromFediAPI
 
+	// Replay any client / federator worker messages
+	// left unprocessed by a previous run, if persisted.
+	if err := processor.Workers().ReplayQueued(ctx); err != nil {
+		return fmt.Errorf("error replaying queued worker messages: %w", err)
+	}
+
 	// Schedule tasks for all existing poll expiries.
 	if err := processor.Polls().ScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule tasks for posting all pending scheduled statuses.
+	if err := processor.Status().ScheduledStatusesScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling statuses: %w", err)
+	}
+
+	// Resume transcoding of any videos left unfinished by a previous run.
+	if err := mediaManager.TranscodePending(ctx); err != nil {
+		return fmt.Errorf("error resuming video transcodes: %w", err)
+	}
+
+	// Schedule domain permission subscriptions processing.
+	if err := processor.Admin().DomainPermissionSubscriptionsSchedule(); err != nil {
+		return fmt.Errorf("error scheduling domain permission subscriptions: %w", err)
+	}
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state.DB, &state.Workers); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
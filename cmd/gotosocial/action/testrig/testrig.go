@@ -143,7 +143,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	processor := testrig.NewTestProcessor(&state, federator, emailSender, mediaManager)
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state.DB, &state.Workers); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -22,6 +22,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/search"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
@@ -146,6 +147,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountResetTwoFactorCmd := &cobra.Command{
+		Use:   "reset-2fa",
+		Short: "disable two-factor authentication for the given local account, so it can sign in with just a password",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.ResetTwoFactor)
+		},
+	}
+	config.AddAdminAccount(adminAccountResetTwoFactorCmd)
+	adminAccountCmd.AddCommand(adminAccountResetTwoFactorCmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -266,7 +280,42 @@ func adminCommands() *cobra.Command {
 
 	adminMediaCmd.AddCommand(adminMediaPruneCmd)
 
+	adminMediaDedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "move existing media in storage into content-addressed blobs, so identical files are only stored once",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.Dedupe)
+		},
+	}
+	adminMediaCmd.AddCommand(adminMediaDedupeCmd)
+
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN SEARCH COMMANDS
+	*/
+
+	adminSearchCmd := &cobra.Command{
+		Use:   "search",
+		Short: "admin commands related to the search index",
+	}
+
+	adminSearchReindexCmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "rebuild the full-text search index of accounts and statuses",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), search.Reindex)
+		},
+	}
+	adminSearchCmd.AddCommand(adminSearchReindexCmd)
+
+	adminCmd.AddCommand(adminSearchCmd)
+
 	return adminCmd
 }
@@ -79,6 +79,41 @@ const (
 	TagHashtag = "Hashtag"
 )
 
+// Quote posts are not in the AS spec, but statuses quoting other
+// statuses are widely federated using FEP-e232 object links with
+// Misskey's quote rel, alongside a handful of non-standard props.
+//
+// See https://codeberg.org/fediverse/fep/src/branch/main/fep/e232/fep-e232.md
+const (
+	// ObjectLinkMediaType is the media type of a Link
+	// that points to another ActivityStreams object.
+	ObjectLinkMediaType = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+
+	// QuoteRel is the rel of an object Link that
+	// points to the status being quoted.
+	QuoteRel = "https://misskey-hub.net/ns#_misskey_quote"
+)
+
+// Interaction policies are not in the AS spec either, but are
+// federated as an "interactionPolicy" object, listing for each
+// kind of interaction who may always perform it, and who may
+// perform it pending approval by the author of the object.
+const (
+	PolicyCanReply    = "canReply"
+	PolicyCanAnnounce = "canAnnounce"
+	PolicyCanLike     = "canLike"
+	PolicyCanQuote    = "canQuote"
+)
+
+// quoteProps are the non-standard properties that may
+// contain the URI of a quoted status, in order of preference.
+var quoteProps = []string{
+	"quote",
+	"quoteUri",
+	"quoteUrl",
+	"_misskey_quote",
+}
+
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
 func isActivity(typeName string) bool {
 	switch typeName {
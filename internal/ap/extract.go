@@ -231,6 +231,33 @@ func ExtractName(i WithName) string {
 	return ""
 }
 
+// ExtractLocation returns the name of the first
+// object (usually a Place) it can find in an interface's
+// location property, or an empty string if not found.
+func ExtractLocation(i WithLocation) string {
+	locationProp := i.GetActivityStreamsLocation()
+	if locationProp == nil {
+		return ""
+	}
+
+	for iter := locationProp.Begin(); iter != locationProp.End(); iter = iter.Next() {
+		withName, ok := iter.GetType().(WithName)
+		if !ok {
+			continue
+		}
+
+		// Name *must not* include any HTML
+		// markup, and location isn't normalized
+		// on the way in, so make sure of this.
+		name := text.SanitizeToPlaintext(ExtractName(withName))
+		if name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
 // ExtractInReplyToURI extracts the first inReplyTo URI
 // property it can find from an interface. Will return
 // nil if no valid URI can be found.
@@ -251,6 +278,66 @@ func ExtractInReplyToURI(i WithInReplyTo) *url.URL {
 	return nil
 }
 
+// ExtractQuoteURI extracts the URI of the status quoted
+// by the given statusable, preferring FEP-e232 object links
+// with the quote rel, and falling back to the non-standard
+// quote properties. Will return nil if not a quote.
+func ExtractQuoteURI(statusable Statusable) *url.URL {
+	if tagsProp := statusable.GetActivityStreamsTag(); tagsProp != nil {
+		for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+			if !iter.IsActivityStreamsLink() {
+				continue
+			}
+
+			link := iter.GetActivityStreamsLink()
+			if link == nil || !isQuoteLink(link) {
+				continue
+			}
+
+			hrefProp := link.GetActivityStreamsHref()
+			if hrefProp == nil || !hrefProp.IsXMLSchemaAnyURI() {
+				continue
+			}
+
+			// Found one we can use.
+			return hrefProp.Get()
+		}
+	}
+
+	return GetQuoteURI(statusable)
+}
+
+// isQuoteLink returns whether the given
+// link is an object link with quote rel.
+func isQuoteLink(link vocab.ActivityStreamsLink) bool {
+	mediaTypeProp := link.GetActivityStreamsMediaType()
+	if mediaTypeProp == nil || !mediaTypeProp.IsRFCRfc2045() {
+		return false
+	}
+
+	switch mediaTypeProp.Get() {
+	case ObjectLinkMediaType, "application/activity+json":
+	default:
+		return false
+	}
+
+	relProp := link.GetActivityStreamsRel()
+	if relProp == nil {
+		return false
+	}
+
+	for iter := relProp.Begin(); iter != relProp.End(); iter = iter.Next() {
+		switch {
+		case iter.IsRFCRfc5988() && iter.Get() == QuoteRel:
+			return true
+		case iter.IsIRI() && iter.GetIRI().String() == QuoteRel:
+			return true
+		}
+	}
+
+	return false
+}
+
 // ExtractItemsURIs extracts each URI it can
 // find for an item from the provided WithItems.
 func ExtractItemsURIs(i WithItems) []*url.URL {
@@ -0,0 +1,115 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLink() {
+	t, _ := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01HZ8RNHTP3GD7RZ7N9CW4EKQ7",
+  "type": "Note",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "<p>look at this</p><p class=\"quote-inline\">RE: https://example.org/users/someone_else/statuses/01HZ8RQ0BS0RJ5PTJ8ZC8VT6QF</p>",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "rel": "https://misskey-hub.net/ns#_misskey_quote",
+      "href": "https://example.org/users/someone_else/statuses/01HZ8RQ0BS0RJ5PTJ8ZC8VT6QF",
+      "name": "RE: https://example.org/users/someone_else/statuses/01HZ8RQ0BS0RJ5PTJ8ZC8VT6QF"
+    }
+  ]
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not Statusable")
+	}
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.NotNil(quoteURI)
+	suite.Equal("https://example.org/users/someone_else/statuses/01HZ8RQ0BS0RJ5PTJ8ZC8VT6QF", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteMisskey() {
+	t, _ := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://misskey.example.org/notes/9tq8ss4v5n",
+  "type": "Note",
+  "attributedTo": "https://misskey.example.org/users/9tq8rlc0nl",
+  "content": "<p>look at this</p>",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "_misskey_quote": "https://misskey.example.org/notes/9tq8s1z4yh",
+  "quoteUrl": "https://misskey.example.org/notes/9tq8s1z4yh"
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not Statusable")
+	}
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.NotNil(quoteURI)
+	suite.Equal("https://misskey.example.org/notes/9tq8s1z4yh", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	quoteURI := ap.ExtractQuoteURI(suite.noteWithMentions1)
+	suite.Nil(quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestGetCanQuote() {
+	t, _ := suite.jsonToType(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01HZ8RNHTP3GD7RZ7N9CW4EKQ7",
+  "type": "Note",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "<p>please don't quote me</p>",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "interactionPolicy": {
+    "canQuote": {
+      "always": [
+        "https://example.org/users/someone"
+      ]
+    }
+  }
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not Statusable")
+	}
+
+	suite.False(ap.GetCanQuote(statusable))
+	suite.True(ap.GetCanQuote(suite.noteWithMentions1))
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
@@ -153,6 +153,7 @@ type Accountable interface {
 	WithSummary
 	WithAttachment
 	WithDiscoverable
+	WithIndexable
 	WithURL
 	WithPublicKey
 	WithInbox
@@ -178,6 +179,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -188,6 +190,8 @@ type Statusable interface {
 	WithAttachment
 	WithTag
 	WithReplies
+	WithQuote
+	WithInteractionPolicy
 }
 
 // Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
@@ -410,6 +414,29 @@ type WithDiscoverable interface {
 	SetTootDiscoverable(vocab.TootDiscoverableProperty)
 }
 
+// WithIndexable represents an activity which may contain
+// the Mastodon 'indexable' extension property. This isn't
+// (yet) part of our vocab, so is accessed via the map of
+// properties unknown to the vocab.
+type WithIndexable interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
+// WithQuote represents an activity which may contain one of
+// the various non-standard quote post properties, accessed via
+// the map of properties unknown to the vocab.
+type WithQuote interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
+// WithInteractionPolicy represents an activity which may
+// contain an interactionPolicy property. This isn't (yet)
+// part of our vocab, so is accessed via the map of
+// properties unknown to the vocab.
+type WithInteractionPolicy interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithURL represents an activity with ActivityStreamsUrlProperty
 type WithURL interface {
 	GetActivityStreamsUrl() vocab.ActivityStreamsUrlProperty
@@ -586,6 +613,12 @@ type WithTarget interface {
 	SetActivityStreamsTarget(vocab.ActivityStreamsTargetProperty)
 }
 
+// WithFirst represents a collection with ActivityStreamsFirstProperty
+type WithFirst interface {
+	GetActivityStreamsFirst() vocab.ActivityStreamsFirstProperty
+	SetActivityStreamsFirst(vocab.ActivityStreamsFirstProperty)
+}
+
 // WithNext represents an activity with ActivityStreamsNextProperty
 type WithNext interface {
 	GetActivityStreamsNext() vocab.ActivityStreamsNextProperty
@@ -628,12 +661,24 @@ type WithAnyOf interface {
 	SetActivityStreamsAnyOf(vocab.ActivityStreamsAnyOfProperty)
 }
 
+// WithStartTime represents an activity with the startTime property.
+type WithStartTime interface {
+	GetActivityStreamsStartTime() vocab.ActivityStreamsStartTimeProperty
+	SetActivityStreamsStartTime(vocab.ActivityStreamsStartTimeProperty)
+}
+
 // WithEndTime represents an activity with the endTime property.
 type WithEndTime interface {
 	GetActivityStreamsEndTime() vocab.ActivityStreamsEndTimeProperty
 	SetActivityStreamsEndTime(vocab.ActivityStreamsEndTimeProperty)
 }
 
+// WithLocation represents an activity with the location property.
+type WithLocation interface {
+	GetActivityStreamsLocation() vocab.ActivityStreamsLocationProperty
+	SetActivityStreamsLocation(vocab.ActivityStreamsLocationProperty)
+}
+
 // WithClosed represents an activity with the closed property.
 type WithClosed interface {
 	GetActivityStreamsClosed() vocab.ActivityStreamsClosedProperty
@@ -20,8 +20,10 @@ package ap
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"time"
 
+	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -408,6 +410,44 @@ func SetPublished(with WithPublished, published time.Time) {
 	publishProp.Set(published)
 }
 
+// GetUpdated returns the time contained in the Updated property of 'with'.
+func GetUpdated(with WithUpdated) time.Time {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil || !updateProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return updateProp.Get()
+}
+
+// SetUpdated sets the given time on the Updated property of 'with'.
+func SetUpdated(with WithUpdated, updated time.Time) {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil {
+		updateProp = streams.NewActivityStreamsUpdatedProperty()
+		with.SetActivityStreamsUpdated(updateProp)
+	}
+	updateProp.Set(updated)
+}
+
+// GetStartTime returns the time contained in the StartTime property of 'with'.
+func GetStartTime(with WithStartTime) time.Time {
+	startTimeProp := with.GetActivityStreamsStartTime()
+	if startTimeProp == nil || !startTimeProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return startTimeProp.Get()
+}
+
+// SetStartTime sets the given time on the StartTime property of 'with'.
+func SetStartTime(with WithStartTime, start time.Time) {
+	startTimeProp := with.GetActivityStreamsStartTime()
+	if startTimeProp == nil {
+		startTimeProp = streams.NewActivityStreamsStartTimeProperty()
+		with.SetActivityStreamsStartTime(startTimeProp)
+	}
+	startTimeProp.Set(start)
+}
+
 // GetEndTime returns the time contained in the EndTime property of 'with'.
 func GetEndTime(with WithEndTime) time.Time {
 	endTimeProp := with.GetActivityStreamsEndTime()
@@ -499,6 +539,147 @@ func SetDiscoverable(with WithDiscoverable, discoverable bool) {
 	discoverProp.Set(discoverable)
 }
 
+// GetIndexable returns the boolean contained in the Indexable property of 'with'.
+//
+// Returns default 'false' if property unusable or not set.
+func GetIndexable(with WithIndexable) bool {
+	indexable, _ := with.GetUnknownProperties()["indexable"].(bool)
+	return indexable
+}
+
+// SetIndexable sets the given boolean on the Indexable property of 'with'.
+func SetIndexable(with WithIndexable, indexable bool) {
+	with.GetUnknownProperties()["indexable"] = indexable
+}
+
+// GetQuoteURI returns the URI contained in the first set
+// of the non-standard quote properties of 'with', if any.
+//
+// Returns nil if no quote property is set or usable.
+func GetQuoteURI(with WithQuote) *url.URL {
+	props := with.GetUnknownProperties()
+	for _, prop := range quoteProps {
+		var uriStr string
+
+		switch v := props[prop].(type) {
+		case string:
+			uriStr = v
+		case map[string]interface{}:
+			// Object, try to get ID.
+			uriStr, _ = v["id"].(string)
+		}
+
+		if uriStr == "" {
+			continue
+		}
+
+		uri, err := url.Parse(uriStr)
+		if err != nil {
+			continue
+		}
+
+		return uri
+	}
+	return nil
+}
+
+// SetQuoteURI sets the given URI on each
+// of the non-standard quote properties of 'with'.
+func SetQuoteURI(with WithQuote, quote *url.URL) {
+	props := with.GetUnknownProperties()
+	for _, prop := range quoteProps {
+		props[prop] = quote.String()
+	}
+}
+
+// GetCanQuote returns whether the canQuote part of the interaction
+// policy of 'with' permits anyone to quote it, ie., whether the
+// public collection is included as 'always' permitted to quote.
+//
+// Returns default 'true' if no interaction policy is set, or if
+// the policy does not specify who can quote.
+func GetCanQuote(with WithInteractionPolicy) bool {
+	always, ok := GetInteractionPolicyAlways(with, PolicyCanQuote)
+	if !ok {
+		return true
+	}
+	return slices.ContainsFunc(always, pub.IsPublic)
+}
+
+// SetCanQuote sets the canQuote part of the interaction policy
+// of 'with' to the given IRIs, who are always permitted to quote.
+func SetCanQuote(with WithInteractionPolicy, always ...*url.URL) {
+	SetInteractionPolicy(with, PolicyCanQuote, always, nil)
+}
+
+// GetInteractionPolicyAlways returns the IRIs that are 'always'
+// permitted to perform the given interaction (eg., PolicyCanReply)
+// according to the interaction policy of 'with', and whether the
+// policy specifies that interaction at all.
+func GetInteractionPolicyAlways(with WithInteractionPolicy, interaction string) ([]string, bool) {
+	policy, _ := with.GetUnknownProperties()["interactionPolicy"].(map[string]interface{})
+	rule, ok := policy[interaction].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	switch always := rule["always"].(type) {
+	case string:
+		// Single IRI.
+		return []string{always}, true
+	case []interface{}:
+		// Array of IRIs.
+		iris := make([]string, 0, len(always))
+		for _, v := range always {
+			if iri, ok := v.(string); ok {
+				iris = append(iris, iri)
+			}
+		}
+		return iris, true
+	}
+
+	return nil, true
+}
+
+// SetInteractionPolicy sets the given interaction (eg., PolicyCanReply)
+// part of the interaction policy of 'with' to the given IRIs who are
+// always permitted to perform it, and those who may perform it pending
+// approval. If approvalRequired is empty, it is left out.
+func SetInteractionPolicy(
+	with WithInteractionPolicy,
+	interaction string,
+	always []*url.URL,
+	approvalRequired []*url.URL,
+) {
+	props := with.GetUnknownProperties()
+
+	policy, _ := props["interactionPolicy"].(map[string]interface{})
+	if policy == nil {
+		policy = make(map[string]interface{}, 1)
+		props["interactionPolicy"] = policy
+	}
+
+	rule := map[string]interface{}{
+		"always": iriStrings(always),
+	}
+
+	if len(approvalRequired) > 0 {
+		rule["approvalRequired"] = iriStrings(approvalRequired)
+	}
+
+	policy[interaction] = rule
+}
+
+// iriStrings converts a slice of IRIs
+// to a slice of their string forms.
+func iriStrings(iris []*url.URL) []interface{} {
+	strs := make([]interface{}, 0, len(iris))
+	for _, iri := range iris {
+		strs = append(strs, iri.String())
+	}
+	return strs
+}
+
 // GetManuallyApprovesFollowers returns the boolean contained in the ManuallyApprovesFollowers property of 'with'.
 //
 // Returns default 'true' if property unusable or not set.
@@ -43,6 +43,8 @@ const (
 	AuthAccountDisabledPath = "/account_disabled"
 	// AuthCallbackPath is the API path for receiving callback tokens from external OIDC providers
 	AuthCallbackPath = "/callback"
+	// AuthTwoFactorPath users land here after signing in, if they have two-factor authentication enabled
+	AuthTwoFactorPath = "/2fa"
 
 	/*
 		paths prefixed with 'oauth'
@@ -73,6 +75,7 @@ const (
 	sessionClientState   = "client_state"
 	sessionClaims        = "claims"
 	sessionAppID         = "app_id"
+	session2FAUserID     = "2fa_userid"
 )
 
 type Module struct {
@@ -97,6 +100,8 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
+	attachHandler(http.MethodGet, AuthTwoFactorPath, m.TwoFactorGETHandler)
+	attachHandler(http.MethodPost, AuthTwoFactorPath, m.TwoFactorPOSTHandler)
 }
 
 // RouteOauth routes all paths that should have an 'oauth' prefix
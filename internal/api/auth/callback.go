@@ -165,13 +165,7 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
-	s.Set(sessionUserID, user.ID)
-	if err := s.Save(); err != nil {
-		m.clearSession(s)
-		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
-		return
-	}
-	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+	m.signedIn(c, s, user)
 }
 
 // FinalizePOSTHandler registers the user after additional data has been provided
@@ -28,6 +28,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -100,13 +101,37 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	s.Set(sessionUserID, userid)
+	user, err := m.db.GetUserByID(c.Request.Context(), userid)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userid, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	m.signedIn(c, s, user)
+}
+
+// signedIn stores the given user on the session after they've
+// successfully signed in, and redirects to the authorize page.
+// If the user has two-factor authentication enabled, the user is
+// held on the session until they've provided a valid code, and
+// they're redirected to the two-factor page instead.
+func (m *Module) signedIn(c *gin.Context, s sessions.Session, user *gtsmodel.User) {
+	key, redirect := sessionUserID, "/oauth"+OauthAuthorizePath
+	if user.TwoFactorEnabled() {
+		key, redirect = session2FAUserID, "/auth"+AuthTwoFactorPath
+	}
+
+	s.Set(key, user.ID)
 	if err := s.Save(); err != nil {
+		m.clearSession(s)
 		err := fmt.Errorf("error saving user id onto session: %s", err)
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
 	}
 
-	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+	c.Redirect(http.StatusFound, redirect)
 }
 
 // ValidatePassword takes an email address and a password.
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// twoFactor wraps a form-submitted two-factor code.
+type twoFactor struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/2fa.
+// Users who have two-factor authentication enabled land here after
+// entering their password (or signing in via OIDC), and are presented
+// with a form to enter a code from their authenticator app. The form
+// will then POST to TwoFactorPOSTHandler.
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+
+	user, errWithCode := m.twoFactorUser(c, s)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	m.templateTwoFactor(c, user, "")
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/2fa.
+// It checks the submitted code against the user held on the session, and
+// if valid, redirects to the authorize page as a normal sign in would.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	user, errWithCode := m.twoFactorUser(c, s)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &twoFactor{}
+	if err := c.ShouldBind(form); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	valid, err := m.processor.User().TwoFactorCheck(c.Request.Context(), user, form.Code)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error checking two-factor code for user %s: %w", user.ID, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !valid {
+		if m.processor.User().TwoFactorLocked(user) {
+			// Too many tries, make them
+			// wait and start over again.
+			m.clearSession(s)
+			err := fmt.Errorf("too many incorrect two-factor codes for user %s", user.ID)
+			const safe = "too many incorrect codes, please wait a while and sign in again"
+			apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, safe, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		m.templateTwoFactor(c, user, "The code you entered was incorrect, please try again.")
+		return
+	}
+
+	// Code was good, swap the
+	// held user over to a real
+	// signed in user and proceed.
+	s.Delete(session2FAUserID)
+	s.Set(sessionUserID, user.ID)
+	if err := s.Save(); err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error saving user id onto session: %w", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
+
+// twoFactorUser returns the user held on the
+// session awaiting two-factor authentication.
+func (m *Module) twoFactorUser(c *gin.Context, s sessions.Session) (*gtsmodel.User, gtserror.WithCode) {
+	userID, ok := s.Get(session2FAUserID).(string)
+	if !ok || userID == "" {
+		err := fmt.Errorf("key %s was not found in session", session2FAUserID)
+		return nil, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice)
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userID, err)
+		return nil, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice)
+	}
+
+	if !user.TwoFactorEnabled() {
+		// 2FA was reset since sign in
+		// began; just start over again.
+		m.clearSession(s)
+		err := errors.New("two-factor authentication is not enabled for user")
+		return nil, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice)
+	}
+
+	return user, nil
+}
+
+// templateTwoFactor renders the two-factor
+// code entry page, with optional error text.
+func (m *Module) templateTwoFactor(c *gin.Context, user *gtsmodel.User, errText string) {
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	var username string
+	if user.Account != nil {
+		username = user.Account.Username
+	}
+
+	page := apiutil.WebPage{
+		Template: "sign-in-2fa.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"user":  username,
+			"error": errText,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
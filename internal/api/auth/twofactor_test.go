@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+type AuthTwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+const (
+	session2FAUserID = "2fa_userid"
+	testTOTPSecret   = "JBSWY3DPEHPK3PXP"
+)
+
+// enableTwoFactor turns on 2FA for the given test user.
+func (suite *AuthTwoFactorTestSuite) enableTwoFactor(user *gtsmodel.User) {
+	user.TwoFactorSecret = testTOTPSecret
+	user.TwoFactorEnabledAt = time.Now()
+	if err := suite.db.UpdateUser(
+		context.Background(), user,
+		"two_factor_secret",
+		"two_factor_enabled_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *AuthTwoFactorTestSuite) twoFactorPOST(userID string, code string) (sessions.Session, *http.Response) {
+	body := url.Values{"code": {code}}.Encode()
+	ctx, recorder := suite.newContext(http.MethodPost, auth.AuthTwoFactorPath, []byte(body), "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(session2FAUserID, userID)
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+	return s, recorder.Result()
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInRedirectsToTwoFactor() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	body := url.Values{"username": {user.Email}, "password": {"password"}}.Encode()
+	ctx, recorder := suite.newContext(http.MethodPost, auth.AuthSignInPath, []byte(body), "application/x-www-form-urlencoded")
+
+	suite.authModule.SignInPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusFound, result.StatusCode)
+	suite.Equal("/auth"+auth.AuthTwoFactorPath, result.Header.Get("Location"))
+
+	// User must not be signed in yet.
+	s := sessions.Default(ctx)
+	suite.Nil(s.Get(sessionUserID))
+	suite.Equal(user.ID, s.Get(session2FAUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInWithoutTwoFactor() {
+	user := suite.testUsers["local_account_1"]
+
+	body := url.Values{"username": {user.Email}, "password": {"password"}}.Encode()
+	ctx, recorder := suite.newContext(http.MethodPost, auth.AuthSignInPath, []byte(body), "application/x-www-form-urlencoded")
+
+	suite.authModule.SignInPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusFound, result.StatusCode)
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, result.Header.Get("Location"))
+	suite.Equal(user.ID, sessions.Default(ctx).Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorCodeOK() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	code, err := totp.Code(testTOTPSecret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	s, result := suite.twoFactorPOST(user.ID, code)
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusFound, result.StatusCode)
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, result.Header.Get("Location"))
+	suite.Equal(user.ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(session2FAUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorCodeWrong() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	s, result := suite.twoFactorPOST(user.ID, "not a code")
+	defer result.Body.Close()
+
+	// Page is rendered again with an error.
+	suite.Equal(http.StatusOK, result.StatusCode)
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(string(b), "The code you entered was incorrect")
+
+	suite.Nil(s.Get(sessionUserID))
+	suite.Equal(user.ID, s.Get(session2FAUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorCodeReused() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	code, err := totp.Code(testTOTPSecret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, result := suite.twoFactorPOST(user.ID, code)
+	result.Body.Close()
+	suite.Equal(http.StatusFound, result.StatusCode)
+
+	// Same code again from another
+	// session should be rejected.
+	s, result := suite.twoFactorPOST(user.ID, code)
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusOK, result.StatusCode)
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(string(b), "The code you entered was incorrect")
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorTooManyAttempts() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	// Each attempt is made with a fresh session, as if the
+	// user had signed in with their password again, which
+	// shouldn't reset the count of incorrect codes.
+	for i := 0; i < 4; i++ {
+		_, result := suite.twoFactorPOST(user.ID, "abcdef")
+		result.Body.Close()
+		suite.Equal(http.StatusOK, result.StatusCode)
+	}
+
+	s, result := suite.twoFactorPOST(user.ID, "abcdef")
+	result.Body.Close()
+	suite.Equal(http.StatusUnauthorized, result.StatusCode)
+	suite.Nil(s.Get(session2FAUserID))
+
+	// Even a good code is now rejected.
+	code, err := totp.Code(testTOTPSecret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	s, result = suite.twoFactorPOST(user.ID, code)
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusUnauthorized, result.StatusCode)
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorNoSession() {
+	ctx, recorder := suite.newContext(http.MethodGet, auth.AuthTwoFactorPath, nil, "")
+
+	suite.authModule.TwoFactorGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, result.StatusCode)
+}
+
+func TestAuthTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthTwoFactorTestSuite))
+}
@@ -56,6 +56,11 @@ import (
 //		description: Account should be made discoverable and shown in the profile directory (if enabled).
 //		type: boolean
 //	-
+//		name: indexable
+//		in: formData
+//		description: Account's public statuses may be included in full-text search results for other users.
+//		type: boolean
+//	-
 //		name: bot
 //		in: formData
 //		description: Account is flagged as a bot.
@@ -215,9 +220,12 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		oauth.ScopeWriteAccounts,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -311,6 +319,7 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 
 	if form == nil ||
 		(form.Discoverable == nil &&
+			form.Indexable == nil &&
 			form.Bot == nil &&
 			form.DisplayName == nil &&
 			form.Note == nil &&
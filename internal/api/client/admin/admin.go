@@ -22,6 +22,7 @@ import (
 
 	"codeberg.org/gruf/go-debug"
 	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -35,6 +36,9 @@ const (
 	DomainAllowsPath        = BasePath + "/domain_allows"
 	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + IDKey
 	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
+	DomainPermSubsPath      = BasePath + "/domain_permission_subscriptions"
+	DomainPermSubPathWithID = DomainPermSubsPath + "/:" + IDKey
+	DomainPermSubTestPath   = DomainPermSubPathWithID + "/test"
 	HeaderAllowsPath        = BasePath + "/header_allows"
 	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + IDKey
 	HeaderBlocksPath        = BasePath + "/header_blocks"
@@ -51,6 +55,13 @@ const (
 	EmailTestPath           = EmailPath + "/test"
 	InstanceRulesPath       = BasePath + "/instance/rules"
 	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
+	InstancesPath           = BasePath + "/instances"
+	InstancesPathWithDomain = InstancesPath + "/:" + apiutil.InstanceDomainKey
+	DeliveriesPath          = BasePath + "/deliveries"
+	DeliveriesPathWithID    = DeliveriesPath + "/:" + IDKey
+	DeliveryRequeuePath     = DeliveriesPathWithID + "/requeue"
+	RelaysPath              = BasePath + "/relays"
+	RelaysPathWithID        = RelaysPath + "/:" + IDKey
 	DebugPath               = BasePath + "/debug"
 	DebugAPUrlPath          = DebugPath + "/apurl"
 
@@ -66,6 +77,9 @@ const (
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
 	MinIDKey              = "min_id"
+	PermissionTypeKey     = "permission_type"
+	DeliveryStatusKey     = "status"
+	InboxURIKey           = "inbox_uri"
 )
 
 type Module struct {
@@ -99,6 +113,14 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// domain permission subscription stuff
+	attachHandler(http.MethodPost, DomainPermSubsPath, m.DomainPermissionSubscriptionPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermSubsPath, m.DomainPermissionSubscriptionsGETHandler)
+	attachHandler(http.MethodGet, DomainPermSubPathWithID, m.DomainPermissionSubscriptionGETHandler)
+	attachHandler(http.MethodPatch, DomainPermSubPathWithID, m.DomainPermissionSubscriptionPATCHHandler)
+	attachHandler(http.MethodDelete, DomainPermSubPathWithID, m.DomainPermissionSubscriptionDELETEHandler)
+	attachHandler(http.MethodPost, DomainPermSubTestPath, m.DomainPermissionSubscriptionTestPOSTHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -134,6 +156,21 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// remote instances stuff
+	attachHandler(http.MethodGet, InstancesPath, m.InstancesGETHandler)
+	attachHandler(http.MethodGet, InstancesPathWithDomain, m.InstanceGETHandler)
+
+	// delivery queue stuff
+	attachHandler(http.MethodGet, DeliveriesPath, m.DeliveriesGETHandler)
+	attachHandler(http.MethodGet, DeliveriesPathWithID, m.DeliveryGETHandler)
+	attachHandler(http.MethodDelete, DeliveriesPathWithID, m.DeliveryDELETEHandler)
+	attachHandler(http.MethodPost, DeliveryRequeuePath, m.DeliveryRequeuePOSTHandler)
+
+	// relay stuff
+	attachHandler(http.MethodGet, RelaysPath, m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, m.RelayPOSTHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, m.RelayDELETEHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
@@ -50,6 +50,25 @@ type multiDomainPermCreate func(
 	*multipart.FileHeader, // domains
 ) (*apimodel.MultiStatus, gtserror.WithCode)
 
+// domainPermissionScope returns the OAuth scope
+// required to read or write permissions of permType.
+func domainPermissionScope(permType gtsmodel.DomainPermissionType, write bool) oauth.Scope {
+	switch {
+	case permType == gtsmodel.DomainPermissionBlock && write:
+		return oauth.ScopeAdminWriteDomainBlocks
+	case permType == gtsmodel.DomainPermissionBlock:
+		return oauth.ScopeAdminReadDomainBlocks
+	case permType == gtsmodel.DomainPermissionAllow && write:
+		return oauth.ScopeAdminWriteDomainAllows
+	case permType == gtsmodel.DomainPermissionAllow:
+		return oauth.ScopeAdminReadDomainAllows
+	case write:
+		return oauth.ScopeAdminWrite
+	default:
+		return oauth.ScopeAdminRead
+	}
+}
+
 // createDomainPemissions either creates a single domain
 // permission entry (block/allow) or imports multiple domain
 // permission entries (multiple blocks, multiple allows)
@@ -63,9 +82,12 @@ func (m *Module) createDomainPermissions(
 	single singleDomainPermCreate,
 	multi multiDomainPermCreate,
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, true),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -98,6 +120,7 @@ func (m *Module) createDomainPermissions(
 		return
 	}
 
+	var err error
 	if importing && form.Domains.Size == 0 {
 		err = errors.New("import was specified but list of domains is empty")
 	} else if !importing && form.Domain == "" {
@@ -171,9 +194,12 @@ func (m *Module) deleteDomainPermission(
 	c *gin.Context,
 	permType gtsmodel.DomainPermissionType, // block/allow
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, true),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -218,9 +244,12 @@ func (m *Module) getDomainPermission(
 	c *gin.Context,
 	permType gtsmodel.DomainPermissionType,
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, false),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -266,9 +295,12 @@ func (m *Module) getDomainPermissions(
 	c *gin.Context,
 	permType gtsmodel.DomainPermissionType,
 ) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		domainPermissionScope(permType, false),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) postPermSub(body string) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(body), admin.DomainPermSubsPath, "application/json")
+
+	suite.adminModule.DomainPermissionSubscriptionPOSTHandler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, b
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestCreate() {
+	code, b := suite.postPermSub(`{
+  "priority": 50,
+  "title": "some blocks",
+  "permission_type": "block",
+  "adopt_orphans": true,
+  "uri": "https://lists.example.org/blocklist.csv",
+  "content_type": "text/csv"
+}`)
+	suite.Equal(http.StatusOK, code, string(b))
+
+	permSub := new(apimodel.DomainPermissionSubscription)
+	if err := json.Unmarshal(b, permSub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(permSub.ID)
+	suite.EqualValues(50, permSub.Priority)
+	suite.Equal("some blocks", permSub.Title)
+	suite.Equal("block", permSub.PermissionType)
+	suite.True(permSub.AdoptOrphans)
+	suite.Equal(suite.testAccounts["admin_account"].ID, permSub.CreatedBy)
+	suite.Equal("https://lists.example.org/blocklist.csv", permSub.URI)
+	suite.Equal("text/csv", permSub.ContentType)
+	suite.Empty(permSub.FetchedAt)
+	suite.Empty(permSub.Error)
+	suite.Zero(permSub.Count)
+
+	// Same URI again should conflict.
+	code, b = suite.postPermSub(`{
+  "permission_type": "block",
+  "uri": "https://lists.example.org/blocklist.csv",
+  "content_type": "text/csv"
+}`)
+	suite.Equal(http.StatusConflict, code, string(b))
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestCreateInvalid() {
+	for body, expect := range map[string]string{
+		`{"uri":"https://lists.example.org/blocklist.csv","content_type":"text/csv"}`:                                          `{"error":"Bad Request: permission_type must be set"}`,
+		`{"permission_type":"silence","uri":"https://lists.example.org/blocklist.csv","content_type":"text/csv"}`:              `{"error":"Bad Request: permission_type silence not recognized, valid values are block, allow"}`,
+		`{"permission_type":"block","uri":"ftp://lists.example.org/blocklist.csv","content_type":"text/csv"}`:                  `{"error":"Bad Request: uri ftp://lists.example.org/blocklist.csv must be an absolute http or https uri"}`,
+		`{"permission_type":"block","uri":"https://lists.example.org/blocklist.csv","content_type":"text/html"}`:               `{"error":"Bad Request: content_type text/html not recognized, valid values are text/csv, application/json, text/plain"}`,
+		`{"permission_type":"block","uri":"https://lists.example.org/blocklist.csv","content_type":"text/csv","priority":256}`: `{"error":"Bad Request: priority 256 out of range, must be between 0 and 255 (inclusive)"}`,
+	} {
+		code, b := suite.postPermSub(body)
+		suite.Equal(http.StatusBadRequest, code)
+		suite.Equal(expect, string(b))
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestTest() {
+	code, b := suite.postPermSub(`{
+  "permission_type": "block",
+  "uri": "https://lists.example.org/blocklist.txt",
+  "content_type": "text/plain",
+  "adopt_orphans": true
+}`)
+	suite.Equal(http.StatusOK, code, string(b))
+
+	permSub := new(apimodel.DomainPermissionSubscription)
+	if err := json.Unmarshal(b, permSub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, nil, admin.DomainPermSubTestPath, "")
+	ctx.AddParam(admin.IDKey, permSub.ID)
+
+	suite.adminModule.DomainPermissionSubscriptionTestPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	preview := new(apimodel.DomainPermissionSubscriptionPreview)
+	if err := json.NewDecoder(recorder.Body).Decode(preview); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(preview.Create, 3)
+	suite.Len(preview.Adopt, 1)
+	suite.Equal("replyguys.com", preview.Adopt[0].Domain.Domain)
+	suite.Empty(preview.Remove)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestUpdatePermissionType() {
+	code, b := suite.postPermSub(`{
+  "permission_type": "block",
+  "uri": "https://lists.example.org/blocklist.txt",
+  "content_type": "text/plain"
+}`)
+	suite.Equal(http.StatusOK, code, string(b))
+
+	permSub := new(apimodel.DomainPermissionSubscription)
+	if err := json.Unmarshal(b, permSub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPatch, []byte(`{"permission_type":"allow"}`), admin.DomainPermSubPathWithID, "application/json")
+	ctx.AddParam(admin.IDKey, permSub.ID)
+
+	suite.adminModule.DomainPermissionSubscriptionPATCHHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"Bad Request: permission_type cannot be changed after creation"}`, recorder.Body.String())
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, &DomainPermissionSubscriptionTestSuite{})
+}
@@ -0,0 +1,252 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainPermissionSubscriptionPOSTHandler swagger:operation POST /api/v1/admin/domain_permission_subscriptions domainPermissionSubscriptionCreate
+//
+// Create a domain permission subscription with the given parameters.
+//
+// The list at the given URI will be fetched and processed on the next scheduled run
+// (see `instance-subscriptions-process-from` and `instance-subscriptions-process-every`),
+// creating domain permissions of the given type for each listed domain, and removing
+// permissions created by the subscription for domains that are no longer listed.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/x-www-form-urlencoded
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: priority
+//		in: formData
+//		description: >-
+//			Priority of this subscription compared to others of the same permission type.
+//			0-255 (higher = higher priority). Higher priority subscriptions will overwrite
+//			permissions generated by lower priority subscriptions. When two subscriptions
+//			have the same priority, the first one to create a permission wins.
+//		type: integer
+//		minimum: 0
+//		maximum: 255
+//		default: 0
+//	-
+//		name: title
+//		in: formData
+//		description: Optional title for this subscription.
+//		type: string
+//	-
+//		name: permission_type
+//		required: true
+//		in: formData
+//		description: >-
+//			Type of permissions to create by parsing the targeted list (allow, block).
+//			Cannot be changed after creation.
+//		type: string
+//	-
+//		name: adopt_orphans
+//		in: formData
+//		description: >-
+//			If true, this subscription will "adopt" domain permissions which already exist
+//			on the instance and are listed in the targeted list, but were not created by
+//			any (existing) subscription.
+//		type: boolean
+//		default: false
+//	-
+//		name: uri
+//		required: true
+//		in: formData
+//		description: URI to call in order to fetch the permissions list.
+//		type: string
+//	-
+//		name: content_type
+//		required: true
+//		in: formData
+//		description: >-
+//			MIME content type to use when parsing the permissions list.
+//			One of "text/plain", "text/csv", and "application/json".
+//		type: string
+//	-
+//		name: fetch_username
+//		in: formData
+//		description: >-
+//			Optional basic auth username to provide when fetching given uri.
+//			If set, will be transmitted along with `fetch_password` when doing the fetch.
+//		type: string
+//	-
+//		name: fetch_password
+//		in: formData
+//		description: >-
+//			Optional basic auth password to provide when fetching given uri.
+//			If set, will be transmitted along with `fetch_username` when doing the fetch.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The newly created domain permission subscription.
+//			schema:
+//				"$ref": "#/definitions/domainPermissionSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict; a subscription already exists with the given uri
+//		'500':
+//			description: internal server error
+func (m *Module) DomainPermissionSubscriptionPOSTHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		oauth.ScopeAdminWrite,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.DomainPermissionSubscriptionRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateDomainPermSubRequest(form, true); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	permSub, errWithCode := m.processor.Admin().DomainPermissionSubscriptionCreate(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, permSub)
+}
+
+// validateDomainPermSubRequest validates the given
+// domain permission subscription create or update
+// request. When creating, permission type, uri, and
+// content type are required. When updating, all
+// fields are optional, but permission type may not
+// be changed.
+func validateDomainPermSubRequest(
+	form *apimodel.DomainPermissionSubscriptionRequest,
+	creating bool,
+) error {
+	if form.PermissionType != nil {
+		if !creating {
+			return errors.New("permission_type cannot be changed after creation")
+		}
+
+		permType := gtsmodel.NewDomainPermissionType(*form.PermissionType)
+		if permType == gtsmodel.DomainPermissionUnknown {
+			return fmt.Errorf("permission_type %s not recognized, valid values are block, allow", *form.PermissionType)
+		}
+	} else if creating {
+		return errors.New("permission_type must be set")
+	}
+
+	if form.Priority != nil {
+		if p := *form.Priority; p < 0 || p > 255 {
+			return fmt.Errorf("priority %d out of range, must be between 0 and 255 (inclusive)", p)
+		}
+	}
+
+	if form.URI != nil {
+		uri, err := url.Parse(*form.URI)
+		if err != nil {
+			return fmt.Errorf("uri %s could not be parsed: %w", *form.URI, err)
+		}
+
+		if (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "" {
+			return fmt.Errorf("uri %s must be an absolute http or https uri", *form.URI)
+		}
+	} else if creating {
+		return errors.New("uri must be set")
+	}
+
+	if form.ContentType != nil {
+		switch *form.ContentType {
+		case gtsmodel.DomainPermSubContentTypeCSV,
+			gtsmodel.DomainPermSubContentTypeJSON,
+			gtsmodel.DomainPermSubContentTypePlain:
+			// Fine.
+		default:
+			return fmt.Errorf(
+				"content_type %s not recognized, valid values are %s, %s, %s",
+				*form.ContentType,
+				gtsmodel.DomainPermSubContentTypeCSV,
+				gtsmodel.DomainPermSubContentTypeJSON,
+				gtsmodel.DomainPermSubContentTypePlain,
+			)
+		}
+	} else if creating {
+		return errors.New("content_type must be set")
+	}
+
+	return nil
+}
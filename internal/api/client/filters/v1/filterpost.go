@@ -87,7 +87,7 @@ import (
 //		name: irreversible
 //		in: formData
 //		description: |-
-//			Should matching entities be removed from the user's timelines/views, instead of hidden? Not supported yet.
+//			Should matching entities be removed from the user's timelines/views, instead of hidden?
 //
 //			Sample: false
 //		type: boolean
@@ -125,9 +125,12 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) FilterPOSTHandler(c *gin.Context) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		oauth.ScopeWriteFilters,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -165,6 +165,19 @@ func (suite *FiltersTestSuite) TestPostFilterFullJSON() {
 	}
 }
 
+func (suite *FiltersTestSuite) TestPostFilterIrreversible() {
+	phrase := "GNU/Linux"
+	context := []string{"home"}
+	irreversible := true
+	filter, err := suite.postFilter(&phrase, &context, &irreversible, nil, nil, nil, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(phrase, filter.Phrase)
+	suite.True(filter.Irreversible)
+}
+
 func (suite *FiltersTestSuite) TestPostFilterMinimal() {
 	phrase := "GNU/Linux"
 	context := []string{"home"}
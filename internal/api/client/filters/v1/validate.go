@@ -18,7 +18,6 @@
 package v1
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 
@@ -39,10 +38,6 @@ func validateNormalizeCreateUpdateFilter(form *model.FilterCreateUpdateRequestV1
 	form.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
 	form.Irreversible = util.Ptr(util.PtrValueOr(form.Irreversible, false))
 
-	if *form.Irreversible {
-		return errors.New("irreversible aka server-side drop filters are not supported yet")
-	}
-
 	// Normalize filter expiry if necessary.
 	// If we parsed this as JSON, expires_in
 	// may be either a float64 or a string.
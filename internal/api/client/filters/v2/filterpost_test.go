@@ -0,0 +1,235 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *FiltersTestSuite) postFilter(
+	form url.Values,
+	requestJson *string,
+	expectedHTTPStatus int,
+	expectedBody string,
+) (*apimodel.FilterV2, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodPost, config.GetProtocol()+"://"+config.GetHost()+"/api/"+filtersV2.BasePath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if requestJson != nil {
+		ctx.Request.Header.Set("content-type", "application/json")
+		ctx.Request.Body = io.NopCloser(strings.NewReader(*requestJson))
+	} else {
+		ctx.Request.Form = form
+	}
+
+	// trigger the handler
+	suite.filtersModule.FilterPOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(2)
+
+	// check code + body
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+	}
+
+	// if we got an expected body, return early
+	if expectedBody != "" {
+		if string(b) != expectedBody {
+			errs.Appendf("expected %s got %s", expectedBody, string(b))
+		}
+		return nil, errs.Combine()
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	resp := &apimodel.FilterV2{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *FiltersTestSuite) TestPostFilterFull() {
+	form := url.Values{
+		"title":                             {"GNU/Linux"},
+		"context[]":                         {"home", "public"},
+		"filter_action":                     {"hide"},
+		"expires_in":                        {"86400"},
+		"keywords_attributes[][keyword]":    {"GNU", "Linux"},
+		"keywords_attributes[][whole_word]": {"true", "false"},
+		"statuses_attributes[][status_id]":  {suite.testStatuses["admin_account_status_1"].ID},
+	}
+	filter, err := suite.postFilter(form, nil, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("GNU/Linux", filter.Title)
+	suite.ElementsMatch(
+		[]apimodel.FilterContext{
+			apimodel.FilterContextHome,
+			apimodel.FilterContextPublic,
+		},
+		filter.Context,
+	)
+	suite.Equal(apimodel.FilterActionHide, filter.FilterAction)
+	if suite.NotNil(filter.ExpiresAt) {
+		suite.NotEmpty(*filter.ExpiresAt)
+	}
+
+	if suite.Len(filter.Keywords, 2) {
+		keywords := make(map[string]bool, len(filter.Keywords))
+		for _, k := range filter.Keywords {
+			keywords[k.Keyword] = k.WholeWord
+		}
+		suite.Equal(map[string]bool{"GNU": true, "Linux": false}, keywords)
+	}
+
+	if suite.Len(filter.Statuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, filter.Statuses[0].StatusID)
+	}
+}
+
+func (suite *FiltersTestSuite) TestPostFilterFullJSON() {
+	// Use a numeric literal with a fractional part to test the JSON-specific handling for non-integer "expires_in".
+	requestJson := `{
+		"title": "GNU/Linux",
+		"context": ["home", "public"],
+		"filter_action": "warn",
+		"expires_in": 86400.1,
+		"keywords_attributes": [
+			{"keyword": "GNU", "whole_word": true}
+		]
+	}`
+	filter, err := suite.postFilter(nil, &requestJson, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("GNU/Linux", filter.Title)
+	suite.ElementsMatch(
+		[]apimodel.FilterContext{
+			apimodel.FilterContextHome,
+			apimodel.FilterContextPublic,
+		},
+		filter.Context,
+	)
+	suite.Equal(apimodel.FilterActionWarn, filter.FilterAction)
+	if suite.NotNil(filter.ExpiresAt) {
+		suite.NotEmpty(*filter.ExpiresAt)
+	}
+	if suite.Len(filter.Keywords, 1) {
+		suite.Equal("GNU", filter.Keywords[0].Keyword)
+		suite.True(filter.Keywords[0].WholeWord)
+	}
+	suite.Empty(filter.Statuses)
+}
+
+func (suite *FiltersTestSuite) TestPostFilterMinimal() {
+	form := url.Values{
+		"title":     {"GNU/Linux"},
+		"context[]": {"home"},
+	}
+	filter, err := suite.postFilter(form, nil, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("GNU/Linux", filter.Title)
+	suite.ElementsMatch([]apimodel.FilterContext{apimodel.FilterContextHome}, filter.Context)
+	suite.Equal(apimodel.FilterActionWarn, filter.FilterAction)
+	suite.Nil(filter.ExpiresAt)
+	suite.Empty(filter.Keywords)
+	suite.Empty(filter.Statuses)
+}
+
+func (suite *FiltersTestSuite) TestPostFilterEmptyTitle() {
+	form := url.Values{
+		"title":     {""},
+		"context[]": {"home"},
+	}
+	_, err := suite.postFilter(form, nil, http.StatusUnprocessableEntity, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPostFilterMissingContext() {
+	form := url.Values{
+		"title": {"GNU/Linux"},
+	}
+	_, err := suite.postFilter(form, nil, http.StatusUnprocessableEntity, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPostFilterInvalidAction() {
+	form := url.Values{
+		"title":         {"GNU/Linux"},
+		"context[]":     {"home"},
+		"filter_action": {"shout"},
+	}
+	_, err := suite.postFilter(form, nil, http.StatusUnprocessableEntity, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPostFilterDuplicateTitle() {
+	form := url.Values{
+		"title":     {suite.testFilters["local_account_1_filter_1"].Title},
+		"context[]": {"home"},
+	}
+	_, err := suite.postFilter(form, nil, http.StatusConflict, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
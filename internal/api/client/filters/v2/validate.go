@@ -0,0 +1,273 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func validateNormalizeCreateFilter(form *apimodel.FilterCreateRequestV2) error {
+	if err := validate.FilterTitle(form.Title); err != nil {
+		return err
+	}
+	action := util.PtrValueOr(form.FilterAction, apimodel.FilterActionWarn)
+	if err := validate.FilterAction(action); err != nil {
+		return err
+	}
+	if err := validate.FilterContexts(form.Context); err != nil {
+		return err
+	}
+
+	// Apply defaults for missing fields.
+	form.FilterAction = util.Ptr(action)
+
+	// Normalize filter expiry if necessary.
+	expiresIn, err := parseExpiresIn(form.ExpiresInI)
+	if err != nil {
+		return err
+	}
+	if expiresIn != nil {
+		form.ExpiresIn = expiresIn
+	}
+
+	// If we parsed this as form data, keywords and statuses
+	// are spread across arrays of attributes: zip them up.
+	if len(form.KeywordsAttributesKeyword) > 0 {
+		if err := checkAttributesLength(
+			len(form.KeywordsAttributesKeyword),
+			len(form.KeywordsAttributesWholeWord),
+		); err != nil {
+			return fmt.Errorf("keywords_attributes: %w", err)
+		}
+
+		form.Keywords = make([]apimodel.FilterKeywordCreateUpdateRequest, 0, len(form.KeywordsAttributesKeyword))
+		for i, keyword := range form.KeywordsAttributesKeyword {
+			formKeyword := apimodel.FilterKeywordCreateUpdateRequest{
+				Keyword: keyword,
+			}
+			if i < len(form.KeywordsAttributesWholeWord) {
+				formKeyword.WholeWord = util.Ptr(form.KeywordsAttributesWholeWord[i])
+			}
+			form.Keywords = append(form.Keywords, formKeyword)
+		}
+	}
+	if len(form.StatusesAttributesStatusID) > 0 {
+		form.Statuses = make([]apimodel.FilterStatusCreateRequest, 0, len(form.StatusesAttributesStatusID))
+		for _, statusID := range form.StatusesAttributesStatusID {
+			form.Statuses = append(form.Statuses, apimodel.FilterStatusCreateRequest{
+				StatusID: statusID,
+			})
+		}
+	}
+
+	// Validate keywords and statuses.
+	for i := range form.Keywords {
+		if err := validateNormalizeCreateUpdateFilterKeyword(&form.Keywords[i]); err != nil {
+			return err
+		}
+	}
+	for i := range form.Statuses {
+		if err := validateCreateFilterStatus(&form.Statuses[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
+	if form.Title != nil {
+		if err := validate.FilterTitle(*form.Title); err != nil {
+			return err
+		}
+	}
+	if form.FilterAction != nil {
+		if err := validate.FilterAction(*form.FilterAction); err != nil {
+			return err
+		}
+	}
+	if form.Context != nil {
+		if err := validate.FilterContexts(*form.Context); err != nil {
+			return err
+		}
+	}
+
+	// Normalize filter expiry if necessary.
+	expiresIn, err := parseExpiresIn(form.ExpiresInI)
+	if err != nil {
+		return err
+	}
+	if expiresIn != nil {
+		form.ExpiresIn = expiresIn
+	}
+
+	// If we parsed this as form data, keywords and statuses
+	// are spread across arrays of attributes: zip them up.
+	if numKeywords := max(
+		len(form.KeywordsAttributesID),
+		len(form.KeywordsAttributesKeyword),
+		len(form.KeywordsAttributesWholeWord),
+		len(form.KeywordsAttributesDestroy),
+	); numKeywords > 0 {
+		if err := checkAttributesLength(
+			numKeywords,
+			len(form.KeywordsAttributesID),
+			len(form.KeywordsAttributesKeyword),
+			len(form.KeywordsAttributesWholeWord),
+			len(form.KeywordsAttributesDestroy),
+		); err != nil {
+			return fmt.Errorf("keywords_attributes: %w", err)
+		}
+
+		form.Keywords = make([]apimodel.FilterKeywordCreateUpdateDeleteRequest, numKeywords)
+		for i := range form.Keywords {
+			formKeyword := &form.Keywords[i]
+			if i < len(form.KeywordsAttributesID) && form.KeywordsAttributesID[i] != "" {
+				formKeyword.ID = &form.KeywordsAttributesID[i]
+			}
+			if i < len(form.KeywordsAttributesKeyword) {
+				formKeyword.Keyword = &form.KeywordsAttributesKeyword[i]
+			}
+			if i < len(form.KeywordsAttributesWholeWord) {
+				formKeyword.WholeWord = &form.KeywordsAttributesWholeWord[i]
+			}
+			if i < len(form.KeywordsAttributesDestroy) {
+				formKeyword.Destroy = &form.KeywordsAttributesDestroy[i]
+			}
+		}
+	}
+	if numStatuses := max(
+		len(form.StatusesAttributesID),
+		len(form.StatusesAttributesStatusID),
+		len(form.StatusesAttributesDestroy),
+	); numStatuses > 0 {
+		if err := checkAttributesLength(
+			numStatuses,
+			len(form.StatusesAttributesID),
+			len(form.StatusesAttributesStatusID),
+			len(form.StatusesAttributesDestroy),
+		); err != nil {
+			return fmt.Errorf("statuses_attributes: %w", err)
+		}
+
+		form.Statuses = make([]apimodel.FilterStatusCreateDeleteRequest, numStatuses)
+		for i := range form.Statuses {
+			formStatus := &form.Statuses[i]
+			if i < len(form.StatusesAttributesID) && form.StatusesAttributesID[i] != "" {
+				formStatus.ID = &form.StatusesAttributesID[i]
+			}
+			if i < len(form.StatusesAttributesStatusID) {
+				formStatus.StatusID = &form.StatusesAttributesStatusID[i]
+			}
+			if i < len(form.StatusesAttributesDestroy) {
+				formStatus.Destroy = &form.StatusesAttributesDestroy[i]
+			}
+		}
+	}
+
+	// Validate keyword and status changes.
+	for _, formKeyword := range form.Keywords {
+		destroy := util.PtrValueOr(formKeyword.Destroy, false)
+		if formKeyword.ID == nil {
+			if destroy {
+				return errors.New("can't delete a filter keyword without an ID")
+			}
+			if formKeyword.Keyword == nil {
+				return errors.New("can't create a filter keyword without a keyword")
+			}
+		}
+		if formKeyword.Keyword != nil && !destroy {
+			if err := validate.FilterKeyword(*formKeyword.Keyword); err != nil {
+				return err
+			}
+		}
+	}
+	for _, formStatus := range form.Statuses {
+		destroy := util.PtrValueOr(formStatus.Destroy, false)
+		if formStatus.ID == nil {
+			if destroy {
+				return errors.New("can't delete a filter status without an ID")
+			}
+			if formStatus.StatusID == nil || *formStatus.StatusID == "" {
+				return errors.New("can't create a filter status without a status ID")
+			}
+		} else if !destroy {
+			return errors.New("filter statuses can't be changed, only added or deleted")
+		}
+	}
+
+	return nil
+}
+
+func validateNormalizeCreateUpdateFilterKeyword(form *apimodel.FilterKeywordCreateUpdateRequest) error {
+	if err := validate.FilterKeyword(form.Keyword); err != nil {
+		return err
+	}
+
+	// Apply defaults for missing fields.
+	form.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+
+	return nil
+}
+
+func validateCreateFilterStatus(form *apimodel.FilterStatusCreateRequest) error {
+	if form.StatusID == "" {
+		return errors.New("filter status must have a status ID")
+	}
+	return nil
+}
+
+// parseExpiresIn normalizes filter expiry if necessary.
+// If we parsed this as JSON, expires_in may be either
+// a float64 or a string, and will be nil otherwise.
+func parseExpiresIn(ei interface{}) (*int, error) {
+	switch e := ei.(type) {
+	case nil:
+		return nil, nil
+
+	case float64:
+		return util.Ptr(int(e)), nil
+
+	case string:
+		expiresIn, err := strconv.Atoi(e)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse expires_in value %s as integer: %w", e, err)
+		}
+		return &expiresIn, nil
+
+	default:
+		return nil, fmt.Errorf("could not parse expires_in type %T as integer", ei)
+	}
+}
+
+// checkAttributesLength checks that each form data attribute
+// array is either empty, or has the expected number of entries.
+func checkAttributesLength(expected int, lengths ...int) error {
+	for _, length := range lengths {
+		if length != 0 && length != expected {
+			return fmt.Errorf("all attribute arrays must either be empty or have the same number of entries (%d)", expected)
+		}
+	}
+	return nil
+}
@@ -107,7 +107,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -228,7 +237,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -349,7 +367,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -471,7 +498,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch5() {
 	b, err := io.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"Forbidden: user is not an admin so cannot update instance settings"}`, string(b))
+	suite.Equal(`{"error":"Forbidden: token scopes \"read write follow push\" do not permit \"admin:write\""}`, string(b))
 }
 
 func (suite *InstancePatchTestSuite) TestInstancePatch6() {
@@ -521,7 +548,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -664,7 +700,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -822,7 +867,16 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+const (
+	// BasePath is the base path for serving the push API, minus the 'api' prefix
+	BasePath = "/v1/push"
+	// SubscriptionPath is the path for serving the current token's Web Push subscription
+	SubscriptionPath = BasePath + "/subscription"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodPost, SubscriptionPath, m.PushSubscriptionPOSTHandler)
+	attachHandler(http.MethodGet, SubscriptionPath, m.PushSubscriptionGETHandler)
+	attachHandler(http.MethodPut, SubscriptionPath, m.PushSubscriptionPUTHandler)
+	attachHandler(http.MethodDelete, SubscriptionPath, m.PushSubscriptionDELETEHandler)
+}
+
+func validateCreateSubscription(form *apimodel.WebPushSubscriptionCreateRequest) error {
+	subscription := form.GetSubscription()
+
+	if subscription.Endpoint == "" {
+		return errors.New("subscription[endpoint] must be provided")
+	}
+
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("subscription[endpoint] %q is not a valid https URL", subscription.Endpoint)
+	}
+
+	if err := webpush.ValidateSubscriptionKeys(
+		subscription.Keys.P256dh,
+		subscription.Keys.Auth,
+	); err != nil {
+		return fmt.Errorf("subscription[keys] invalid: %w", err)
+	}
+
+	return validateUpdateSubscription(&form.WebPushSubscriptionUpdateRequest)
+}
+
+func validateUpdateSubscription(form *apimodel.WebPushSubscriptionUpdateRequest) error {
+	switch policy := form.GetData().Policy; policy {
+	case "",
+		apimodel.WebPushNotificationPolicyAll,
+		apimodel.WebPushNotificationPolicyFollowed,
+		apimodel.WebPushNotificationPolicyFollower,
+		apimodel.WebPushNotificationPolicyNone:
+		return nil
+	default:
+		return fmt.Errorf("data[policy] %q not recognized; must be one of all, followed, follower, or none", policy)
+	}
+}
@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SubscriptionTestSuite struct {
+	PushTestSuite
+}
+
+// clientKeys returns new base64url-encoded
+// p256dh and auth keys for a subscription.
+func (suite *SubscriptionTestSuite) clientKeys() (string, string) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(auth)
+}
+
+// request calls the given handler as local_account_1, checks the
+// response code, and returns the response body as a subscription.
+func (suite *SubscriptionTestSuite) request(
+	handler gin.HandlerFunc,
+	method string,
+	contentType string,
+	body io.Reader,
+	expectedHTTPStatus int,
+) *apimodel.WebPushSubscription {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api"+push.SubscriptionPath, body)
+	ctx.Request.Header.Set("accept", "application/json")
+	if contentType != "" {
+		ctx.Request.Header.Set("content-type", contentType)
+	}
+
+	// trigger the handler
+	handler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Equal(expectedHTTPStatus, recorder.Code, string(b)) ||
+		expectedHTTPStatus != http.StatusOK ||
+		method == http.MethodDelete {
+		return nil
+	}
+
+	resp := &apimodel.WebPushSubscription{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *SubscriptionTestSuite) createForm(fields map[string][]string, expectedHTTPStatus int) *apimodel.WebPushSubscription {
+	body, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return suite.request(
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.MethodPost,
+		w.FormDataContentType(),
+		bytes.NewReader(body.Bytes()),
+		expectedHTTPStatus,
+	)
+}
+
+func (suite *SubscriptionTestSuite) TestCreateForm() {
+	p256dh, auth := suite.clientKeys()
+
+	subscription := suite.createForm(map[string][]string{
+		"subscription[endpoint]":     {"https://push.example.org/some-client"},
+		"subscription[keys][p256dh]": {p256dh},
+		"subscription[keys][auth]":   {auth},
+		"data[alerts][follow]":       {"true"},
+		"data[alerts][mention]":      {"true"},
+		"data[policy]":               {"followed"},
+	}, http.StatusOK)
+
+	suite.NotEmpty(subscription.ID)
+	suite.Equal("https://push.example.org/some-client", subscription.Endpoint)
+	suite.Equal(suite.testInstances["localhost:8080"].VAPIDPublicKey, subscription.ServerKey)
+	suite.Equal(apimodel.WebPushSubscriptionAlerts{
+		Follow:  true,
+		Mention: true,
+	}, subscription.Alerts)
+	suite.Equal(apimodel.WebPushNotificationPolicyFollowed, subscription.Policy)
+
+	// Should now be able to get the same subscription.
+	got := suite.request(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, "", nil, http.StatusOK)
+	suite.Equal(subscription, got)
+}
+
+func (suite *SubscriptionTestSuite) TestCreateUpdateDeleteJSON() {
+	p256dh, auth := suite.clientKeys()
+
+	created := suite.request(
+		suite.pushModule.PushSubscriptionPOSTHandler,
+		http.MethodPost,
+		"application/json",
+		bytes.NewReader([]byte(`{
+			"subscription": {
+				"endpoint": "https://push.example.org/some-client",
+				"keys": {"p256dh": "`+p256dh+`", "auth": "`+auth+`"}
+			},
+			"data": {"alerts": {"favourite": true, "reblog": true}}
+		}`)),
+		http.StatusOK,
+	)
+	suite.Equal(apimodel.WebPushSubscriptionAlerts{
+		Favourite: true,
+		Reblog:    true,
+	}, created.Alerts)
+	suite.Equal(apimodel.WebPushNotificationPolicyAll, created.Policy)
+
+	// Update replaces alerts + policy.
+	updated := suite.request(
+		suite.pushModule.PushSubscriptionPUTHandler,
+		http.MethodPut,
+		"application/json",
+		bytes.NewReader([]byte(`{"data": {"alerts": {"poll": true}, "policy": "none"}}`)),
+		http.StatusOK,
+	)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal(created.Endpoint, updated.Endpoint)
+	suite.Equal(apimodel.WebPushSubscriptionAlerts{
+		Poll: true,
+	}, updated.Alerts)
+	suite.Equal(apimodel.WebPushNotificationPolicyNone, updated.Policy)
+
+	// Delete it, then it's gone.
+	suite.request(suite.pushModule.PushSubscriptionDELETEHandler, http.MethodDelete, "", nil, http.StatusOK)
+	suite.request(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, "", nil, http.StatusNotFound)
+}
+
+func (suite *SubscriptionTestSuite) TestCreateReplacesExisting() {
+	p256dh, auth := suite.clientKeys()
+	fields := map[string][]string{
+		"subscription[endpoint]":     {"https://push.example.org/some-client"},
+		"subscription[keys][p256dh]": {p256dh},
+		"subscription[keys][auth]":   {auth},
+	}
+
+	first := suite.createForm(fields, http.StatusOK)
+
+	fields["subscription[endpoint]"] = []string{"https://push.example.org/another-client"}
+	second := suite.createForm(fields, http.StatusOK)
+	suite.NotEqual(first.ID, second.ID)
+
+	got := suite.request(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, "", nil, http.StatusOK)
+	suite.Equal(second, got)
+}
+
+func (suite *SubscriptionTestSuite) TestCreateInvalid() {
+	p256dh, auth := suite.clientKeys()
+
+	for _, fields := range []map[string][]string{
+		{
+			// Not https.
+			"subscription[endpoint]":     {"http://push.example.org/some-client"},
+			"subscription[keys][p256dh]": {p256dh},
+			"subscription[keys][auth]":   {auth},
+		},
+		{
+			// Not a valid public key.
+			"subscription[endpoint]":     {"https://push.example.org/some-client"},
+			"subscription[keys][p256dh]": {auth},
+			"subscription[keys][auth]":   {auth},
+		},
+		{
+			// Unknown policy.
+			"subscription[endpoint]":     {"https://push.example.org/some-client"},
+			"subscription[keys][p256dh]": {p256dh},
+			"subscription[keys][auth]":   {auth},
+			"data[policy]":               {"everyone"},
+		},
+	} {
+		suite.createForm(fields, http.StatusBadRequest)
+	}
+}
+
+func (suite *SubscriptionTestSuite) TestGetNone() {
+	suite.request(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, "", nil, http.StatusNotFound)
+}
+
+func (suite *SubscriptionTestSuite) TestUpdateNone() {
+	suite.request(
+		suite.pushModule.PushSubscriptionPUTHandler,
+		http.MethodPut,
+		"application/json",
+		bytes.NewReader([]byte(`{"data": {"policy": "all"}}`)),
+		http.StatusNotFound,
+	)
+}
+
+func TestSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, &SubscriptionTestSuite{})
+}
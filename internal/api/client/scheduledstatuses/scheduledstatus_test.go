@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ScheduledStatusTestSuite struct {
+	ScheduledStatusesTestSuite
+}
+
+// putScheduled inserts a scheduled status for
+// the given account at the given time in the db.
+func (suite *ScheduledStatusTestSuite) putScheduled(accountKey string, text string, at time.Time) *gtsmodel.ScheduledStatus {
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     suite.testAccounts[accountKey].ID,
+		ScheduledAt:   at.UTC().Truncate(time.Second),
+		Text:          text,
+		Visibility:    gtsmodel.VisibilityPublic,
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+
+	if err := suite.db.PutScheduledStatus(context.Background(), scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return scheduled
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusesGet() {
+	now := time.Now()
+	first := suite.putScheduled("local_account_1", "first", now.Add(time.Hour))
+	second := suite.putScheduled("local_account_1", "second", now.Add(2*time.Hour))
+	suite.putScheduled("local_account_2", "not mine", now.Add(time.Hour))
+
+	b := suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusesGETHandler,
+		http.MethodGet,
+		scheduledstatuses.BasePath,
+		nil,
+		nil,
+		http.StatusOK,
+	)
+
+	apiScheduled := []*apimodel.ScheduledStatus{}
+	if err := json.Unmarshal(b, &apiScheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only our own, newest first.
+	if !suite.Len(apiScheduled, 2) {
+		suite.FailNow("", string(b))
+	}
+	suite.Equal(second.ID, apiScheduled[0].ID)
+	suite.Equal("second", apiScheduled[0].Params.Text)
+	suite.Equal(first.ID, apiScheduled[1].ID)
+	suite.Equal("first", apiScheduled[1].Params.Text)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusGet() {
+	scheduled := suite.putScheduled("local_account_1", "hello there", time.Now().Add(time.Hour))
+
+	b := suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusGETHandler,
+		http.MethodGet,
+		scheduledstatuses.BasePath+"/"+scheduled.ID,
+		gin.Params{{Key: scheduledstatuses.IDKey, Value: scheduled.ID}},
+		nil,
+		http.StatusOK,
+	)
+
+	apiScheduled := &apimodel.ScheduledStatus{}
+	if err := json.Unmarshal(b, apiScheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(scheduled.ID, apiScheduled.ID)
+	suite.Equal(util.FormatISO8601(scheduled.ScheduledAt), apiScheduled.ScheduledAt)
+	suite.Equal("hello there", apiScheduled.Params.Text)
+	suite.Equal(apimodel.VisibilityPublic, apiScheduled.Params.Visibility)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusGetOtherAccount() {
+	scheduled := suite.putScheduled("local_account_2", "not mine", time.Now().Add(time.Hour))
+
+	suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusGETHandler,
+		http.MethodGet,
+		scheduledstatuses.BasePath+"/"+scheduled.ID,
+		gin.Params{{Key: scheduledstatuses.IDKey, Value: scheduled.ID}},
+		nil,
+		http.StatusNotFound,
+	)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusUpdate() {
+	scheduled := suite.putScheduled("local_account_1", "move me", time.Now().Add(time.Hour))
+	newAt := time.Now().Add(3 * time.Hour).UTC().Truncate(time.Second)
+
+	b := suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusPUTHandler,
+		http.MethodPut,
+		scheduledstatuses.BasePath+"/"+scheduled.ID,
+		gin.Params{{Key: scheduledstatuses.IDKey, Value: scheduled.ID}},
+		map[string][]string{"scheduled_at": {newAt.Format(time.RFC3339)}},
+		http.StatusOK,
+	)
+
+	apiScheduled := &apimodel.ScheduledStatus{}
+	if err := json.Unmarshal(b, apiScheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(scheduled.ID, apiScheduled.ID)
+
+	dbScheduled, err := suite.db.GetScheduledStatusByID(context.Background(), scheduled.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbScheduled.ScheduledAt.Equal(newAt))
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusUpdateTooSoon() {
+	scheduled := suite.putScheduled("local_account_1", "move me", time.Now().Add(time.Hour))
+
+	b := suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusPUTHandler,
+		http.MethodPut,
+		scheduledstatuses.BasePath+"/"+scheduled.ID,
+		gin.Params{{Key: scheduledstatuses.IDKey, Value: scheduled.ID}},
+		map[string][]string{"scheduled_at": {time.Now().Add(time.Minute).Format(time.RFC3339)}},
+		http.StatusUnprocessableEntity,
+	)
+	suite.Equal(`{"error":"Unprocessable Entity: scheduled_at must be at least 5m0s in the future"}`, string(b))
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusDelete() {
+	scheduled := suite.putScheduled("local_account_1", "delete me", time.Now().Add(time.Hour))
+
+	suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusDELETEHandler,
+		http.MethodDelete,
+		scheduledstatuses.BasePath+"/"+scheduled.ID,
+		gin.Params{{Key: scheduledstatuses.IDKey, Value: scheduled.ID}},
+		nil,
+		http.StatusOK,
+	)
+
+	_, err := suite.db.GetScheduledStatusByID(context.Background(), scheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusDeleteOtherAccount() {
+	scheduled := suite.putScheduled("local_account_2", "not mine", time.Now().Add(time.Hour))
+
+	suite.request(
+		suite.scheduledStatusesModule.ScheduledStatusDELETEHandler,
+		http.MethodDelete,
+		scheduledstatuses.BasePath+"/"+scheduled.ID,
+		gin.Params{{Key: scheduledstatuses.IDKey, Value: scheduled.ID}},
+		nil,
+		http.StatusNotFound,
+	)
+
+	// Should still be there.
+	_, err := suite.db.GetScheduledStatusByID(context.Background(), scheduled.ID)
+	suite.NoError(err)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
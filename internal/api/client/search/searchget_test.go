@@ -40,6 +40,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -146,6 +147,46 @@ func (suite *SearchGetTestSuite) getSearch(
 	return searchResult, nil
 }
 
+// searchStatuses searches for statuses as local_account_1
+// using the given query, returning the IDs of the results.
+func (suite *SearchGetTestSuite) searchStatuses(query string) []string {
+	var (
+		requestingAccount = suite.testAccounts["local_account_1"]
+		token             = suite.testTokens["local_account_1"]
+		user              = suite.testUsers["local_account_1"]
+		queryType         = "statuses"
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		nil,
+		nil,
+		nil,
+		nil,
+		query,
+		&queryType,
+		nil,
+		nil,
+		http.StatusOK,
+		"")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(searchResult.Accounts)
+	suite.Empty(searchResult.Hashtags)
+
+	statusIDs := make([]string, 0, len(searchResult.Statuses))
+	for _, status := range searchResult.Statuses {
+		statusIDs = append(statusIDs, status.ID)
+	}
+
+	return statusIDs
+}
+
 func (suite *SearchGetTestSuite) bodgeLocalInstance(domain string) {
 	// Set new host.
 	config.SetHost(domain)
@@ -1731,6 +1772,84 @@ func (suite *SearchGetTestSuite) TestSearchBlockedAccountURI() {
 	suite.NotNil(gotAccount)
 }
 
+func (suite *SearchGetTestSuite) TestSearchStatusesRanked() {
+	statusIDs := suite.searchStatuses("hi")
+	if !suite.Len(statusIDs, 7) {
+		suite.FailNow("", "unexpected results %v", statusIDs)
+	}
+
+	// Statuses containing the whole
+	// word "hi" should come first.
+	suite.ElementsMatch([]string{
+		suite.testStatuses["admin_account_status_3"].ID,
+		suite.testStatuses["local_account_1_status_5"].ID,
+		suite.testStatuses["local_account_2_status_5"].ID,
+	}, statusIDs[:3])
+
+	// Followed by statuses that only contain
+	// "hi" as part of other words ("this",
+	// "think" etc), newest first.
+	suite.Equal([]string{
+		suite.testStatuses["local_account_1_status_7"].ID,
+		suite.testStatuses["local_account_1_status_6"].ID,
+		suite.testStatuses["local_account_1_status_3"].ID,
+		suite.testStatuses["local_account_1_status_2"].ID,
+	}, statusIDs[3:])
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesOperators() {
+	// Own statuses with media.
+	suite.Equal([]string{
+		suite.testStatuses["local_account_1_status_4"].ID,
+	}, suite.searchStatuses("from:me has:media"))
+
+	// Statuses from admin account. Only the status in reply to
+	// local_account_1 is visible, as admin is not indexable.
+	suite.Equal([]string{
+		suite.testStatuses["admin_account_status_3"].ID,
+	}, suite.searchStatuses("from:@admin"))
+
+	// Statuses with text before the start of 2022.
+	statusIDs := suite.searchStatuses("hi before:2021-12-31")
+	if suite.Len(statusIDs, 4) {
+		suite.ElementsMatch([]string{
+			suite.testStatuses["admin_account_status_3"].ID,
+			suite.testStatuses["local_account_2_status_5"].ID,
+		}, statusIDs[:2])
+	}
+
+	// Statuses with text after the start of 2022.
+	statusIDs = suite.searchStatuses("hi after:2021-12-31")
+	suite.Equal([]string{
+		suite.testStatuses["local_account_1_status_5"].ID,
+		suite.testStatuses["local_account_1_status_7"].ID,
+		suite.testStatuses["local_account_1_status_6"].ID,
+	}, statusIDs)
+
+	// Quoted operators are just text.
+	suite.Empty(suite.searchStatuses(`"from:me"`))
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesIndexable() {
+	// Public statuses of a non-indexable
+	// account should not be searchable.
+	suite.Empty(suite.searchStatuses("turtles"))
+
+	// Opt the account in to search.
+	indexedAccount := new(gtsmodel.Account)
+	*indexedAccount = *suite.testAccounts["local_account_2"]
+	indexedAccount.Indexable = util.Ptr(true)
+	if err := suite.db.UpdateAccount(context.Background(), indexedAccount, "indexable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the public status should be found,
+	// not the followers-only one about a turtle.
+	suite.Equal([]string{
+		suite.testStatuses["local_account_2_status_1"].ID,
+	}, suite.searchStatuses("turtles"))
+}
+
 func TestSearchGetTestSuite(t *testing.T) {
 	suite.Run(t, &SearchGetTestSuite{})
 }
@@ -64,6 +64,18 @@ const (
 
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
+
+	// PendingRepliesPath is for seeing replies awaiting the requester's approval
+	PendingRepliesPath = BasePath + "/pending_replies"
+	// ApprovePath is for approving a pending reply
+	ApprovePath = BasePathWithID + "/approve"
+	// RejectPath is for rejecting a pending reply
+	RejectPath = BasePathWithID + "/reject"
+
+	// HistoryPath is used for fetching the edit history of a status
+	HistoryPath = BasePathWithID + "/history"
+	// SourcePath is used for fetching the plain-text source of a status, for editing
+	SourcePath = BasePathWithID + "/source"
 )
 
 type Module struct {
@@ -77,11 +89,16 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	// create / get / delete status
+	// create / get / edit / delete status
 	attachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.StatusGETHandler)
+	attachHandler(http.MethodPut, BasePathWithID, m.StatusEditPUTHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
 
+	// edit history / source
+	attachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
+	attachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
+
 	// fave stuff
 	attachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
 	attachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
@@ -104,4 +121,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// context / status thread
 	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+
+	// pending reply stuff
+	attachHandler(http.MethodGet, PendingRepliesPath, m.StatusPendingRepliesGETHandler)
+	attachHandler(http.MethodPost, ApprovePath, m.StatusApprovePOSTHandler)
+	attachHandler(http.MethodPost, RejectPath, m.StatusRejectPOSTHandler)
 }
@@ -111,6 +111,14 @@ import (
 //		type: string
 //		in: formData
 //	-
+//		name: quote_id
+//		x-go-name: QuoteID
+//		description: |-
+//			ID of the status being quoted, if status is a quote.
+//			The quoted status must be public or unlisted, and must not be marked as not quotable by its author.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
@@ -143,8 +151,6 @@ import (
 //			ISO 8601 Datetime at which to schedule a status.
 //			Providing this parameter will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//
-//			This feature isn't implemented yet.
 //		type: string
 //		in: formData
 //	-
@@ -186,6 +192,49 @@ import (
 //		description: This status can be liked/faved.
 //		in: formData
 //		type: boolean
+//	-
+//		name: quotable
+//		x-go-name: Quotable
+//		description: This status can be quoted by other accounts. Only applies to public and unlisted statuses.
+//		in: formData
+//		type: boolean
+//	-
+//		name: interaction_policy[can_reply]
+//		x-go-name: InteractionPolicyCanReply
+//		description: |-
+//			Who can reply to this status. Takes precedence over replyable.
+//			Replies from accounts that aren't permitted are held for approval by the status author.
+//		in: formData
+//		type: string
+//		enum:
+//			- everyone
+//			- followers
+//			- mentioned
+//			- nobody
+//	-
+//		name: interaction_policy[can_boost]
+//		x-go-name: InteractionPolicyCanBoost
+//		description: |-
+//			Who can boost this status. Takes precedence over boostable.
+//			Only applies to public and unlisted statuses.
+//		in: formData
+//		type: string
+//		enum:
+//			- everyone
+//			- followers
+//			- mentioned
+//			- nobody
+//	-
+//		name: interaction_policy[can_like]
+//		x-go-name: InteractionPolicyCanLike
+//		description: Who can like/fave this status. Takes precedence over likeable.
+//		in: formData
+//		type: string
+//		enum:
+//			- everyone
+//			- followers
+//			- mentioned
+//			- nobody
 //
 //	produces:
 //	- application/json
@@ -196,7 +245,9 @@ import (
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: >-
+//				The newly created status.
+//				If scheduled_at was provided, the newly scheduled status is returned instead.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -212,9 +263,12 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
-	authed, err := oauth.Authed(c, true, true, true, true)
-	if err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		oauth.ScopeWriteStatuses,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
@@ -248,6 +302,24 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status should be posted later,
+		// schedule it instead of creating.
+		apiScheduledStatus, errWithCode := m.processor.Status().ScheduledStatusCreate(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiScheduledStatus)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
@@ -291,7 +363,7 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 	}
 
 	if form.Poll != nil {
-		if err := validateNormalizeCreatePoll(form); err != nil {
+		if err := validateNormalizeCreatePoll(form.Poll); err != nil {
 			return err
 		}
 	}
@@ -304,20 +376,50 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 		form.Language = language
 	}
 
+	if form.InteractionPolicy != nil {
+		if err := validateInteractionPolicy(form.InteractionPolicy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateInteractionPolicy(policy *apimodel.InteractionPolicyRequest) error {
+	for _, v := range []struct {
+		name  string
+		value apimodel.PolicyValue
+	}{
+		{"can_reply", policy.CanReply},
+		{"can_boost", policy.CanBoost},
+		{"can_like", policy.CanLike},
+	} {
+		switch v.value {
+		case "",
+			apimodel.PolicyValueEveryone,
+			apimodel.PolicyValueFollowers,
+			apimodel.PolicyValueMentioned,
+			apimodel.PolicyValueNobody:
+			// Valid (or unset).
+		default:
+			return fmt.Errorf("interaction_policy[%s] value %s not recognised, valid values are everyone, followers, mentioned, nobody", v.name, v.value)
+		}
+	}
+
 	return nil
 }
 
-func validateNormalizeCreatePoll(form *apimodel.AdvancedStatusCreateForm) error {
+func validateNormalizeCreatePoll(poll *apimodel.PollRequest) error {
 	maxPollOptions := config.GetStatusesPollMaxOptions()
 	maxPollChars := config.GetStatusesPollOptionMaxChars()
 
 	// Normalize poll expiry if necessary.
 	// If we parsed this as JSON, expires_in
 	// may be either a float64 or a string.
-	if ei := form.Poll.ExpiresInI; ei != nil {
+	if ei := poll.ExpiresInI; ei != nil {
 		switch e := ei.(type) {
 		case float64:
-			form.Poll.ExpiresIn = int(e)
+			poll.ExpiresIn = int(e)
 
 		case string:
 			expiresIn, err := strconv.Atoi(e)
@@ -325,22 +427,22 @@ func validateNormalizeCreatePoll(form *apimodel.AdvancedStatusCreateForm) error
 				return fmt.Errorf("could not parse expires_in value %s as integer: %w", e, err)
 			}
 
-			form.Poll.ExpiresIn = expiresIn
+			poll.ExpiresIn = expiresIn
 
 		default:
 			return fmt.Errorf("could not parse expires_in type %T as integer", ei)
 		}
 	}
 
-	if len(form.Poll.Options) == 0 {
+	if len(poll.Options) == 0 {
 		return errors.New("poll with no options")
 	}
 
-	if len(form.Poll.Options) > maxPollOptions {
-		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
+	if len(poll.Options) > maxPollOptions {
+		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(poll.Options), maxPollOptions)
 	}
 
-	for _, p := range form.Poll.Options {
+	for _, p := range poll.Options {
 		if length := len([]rune(p)); length > maxPollChars {
 			return fmt.Errorf("poll option too long, %d characters provided but limit is %d", length, maxPollChars)
 		}
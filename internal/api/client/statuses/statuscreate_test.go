@@ -28,6 +28,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
@@ -503,6 +504,77 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
 	})
 }
 
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this is a status from the future"},
+		"visibility":   {string(apimodel.VisibilityUnlisted)},
+		"scheduled_at": {scheduledAt.Format(time.RFC3339)},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// We should get a scheduled status back, not a status.
+	scheduledReply := &apimodel.ScheduledStatus{}
+	if err := json.Unmarshal(b, scheduledReply); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(scheduledReply.ID)
+	suite.Equal("this is a status from the future", scheduledReply.Params.Text)
+	suite.Equal(apimodel.VisibilityUnlisted, scheduledReply.Params.Visibility)
+
+	// It should be stored in the db for posting later.
+	dbScheduled, err := suite.db.GetScheduledStatusByID(context.Background(), scheduledReply.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbScheduled.ScheduledAt.Equal(scheduledAt))
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatusTooSoon() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this is a status from the very near future"},
+		"scheduled_at": {time.Now().Add(time.Minute).Format(time.RFC3339)},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusUnprocessableEntity, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Unprocessable Entity: scheduled_at must be at least 5m0s in the future"}`, string(b))
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
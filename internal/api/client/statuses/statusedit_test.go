@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) editStatus(
+	accountKey string,
+	statusID string,
+	form url.Values,
+) (*apimodel.Status, int) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Request = httptest.NewRequest(http.MethodPut, fmt.Sprintf("http://localhost:8080%s", strings.Replace(statuses.BasePathWithID, ":id", statusID, 1)), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = form
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: statusID,
+		},
+	}
+
+	suite.statusModule.StatusEditPUTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	apiStatus := &apimodel.Status{}
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return apiStatus, recorder.Code
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status":       {"hello everyone! (edited)"},
+		"spoiler_text": {"still an introduction post"},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("<p>hello everyone! (edited)</p>", apiStatus.Content)
+	suite.Equal("still an introduction post", apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+	suite.NotNil(apiStatus.EditedAt)
+
+	// The status edit should be stored in the database.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbStatus.EditedAt.IsZero())
+	suite.Equal("hello everyone! (edited)", dbStatus.Text)
+
+	// The previous version should be stored in the status history.
+	edits, err := suite.db.GetStatusEditsByStatusID(context.Background(), targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(edits, 1)
+	suite.Equal(targetStatus.Content, edits[0].Content)
+	suite.Equal(targetStatus.ContentWarning, edits[0].ContentWarning)
+	suite.Equal(targetStatus.CreatedAt, edits[0].CreatedAt)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusRemoveMedia() {
+	var (
+		ctx          = context.Background()
+		targetStatus = suite.testStatuses["local_account_1_status_4"]
+		keptMedia    = suite.testAttachments["local_account_1_status_4_attachment_1"]
+		removedMedia = suite.testAttachments["local_account_1_status_4_attachment_2"]
+	)
+
+	apiStatus, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status":      {"here's a little gif of trent.... (cow removed)"},
+		"media_ids[]": {keptMedia.ID},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	// Prune unused media; the media removed
+	// by the edit is still used by the history.
+	if _, err := cleaner.New(&suite.state).Media().PruneUnused(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := suite.db.GetAttachmentByID(ctx, removedMedia.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	edits, err := suite.db.GetStatusEditsByStatusID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(edits, 1)
+	suite.Len(edits[0].Attachments, 2)
+}
+
+func (suite *StatusEditTestSuite) TestEditSomeoneElsesStatus() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	_, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status": {"this isn't mine to edit"},
+	})
+	suite.Equal(http.StatusForbidden, code)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusEmpty() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	_, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{})
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
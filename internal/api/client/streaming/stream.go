@@ -19,6 +19,7 @@ package streaming
 
 import (
 	"context"
+	"fmt"
 	"slices"
 	"time"
 
@@ -99,7 +100,7 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- read:streaming
+//		- read:statuses
 //
 //	responses:
 //		'101':
@@ -151,6 +152,7 @@ import (
 func (m *Module) StreamGETHandler(c *gin.Context) {
 	var (
 		account     *gtsmodel.Account
+		scopes      string
 		errWithCode gtserror.WithCode
 	)
 
@@ -164,7 +166,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	if token != "" {
 
 		// Token was provided, use it to authorize stream.
-		account, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
+		account, scopes, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
 		if errWithCode != nil {
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
@@ -174,15 +176,30 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 
 		// No explicit token was provided:
 		// try regular oauth as a last resort.
-		authed, err := oauth.Authed(c, true, true, true, true)
-		if err != nil {
-			errWithCode := gtserror.NewErrorUnauthorized(err, err.Error())
+		authed, errWithCode := apiutil.TokenAuth(c,
+			true, true, true, true,
+			"", // Scopes checked below.
+		)
+		if errWithCode != nil {
 			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
 		}
 
 		// Set the auth'ed account.
 		account = authed.Account
+		scopes = authed.Token.GetScope()
+	}
+
+	// Token must be able to read at least
+	// one kind of stream to connect at all.
+	if !oauth.ScopesPermit(scopes, oauth.ScopeReadStatuses) &&
+		!oauth.ScopesPermit(scopes, oauth.ScopeReadNotifications) {
+		err := fmt.Errorf(
+			"token scopes %q do not permit %q or %q",
+			scopes, oauth.ScopeReadStatuses, oauth.ScopeReadNotifications,
+		)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
 	}
 
 	if account.IsMoving() {
@@ -205,6 +222,15 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		streamType += ":" + tag
 	}
 
+	if streamType != "" {
+		// Check token can read initial stream type.
+		if wanted := streamScope(streamType); !oauth.ScopesPermit(scopes, wanted) {
+			err := fmt.Errorf("token scopes %q do not permit %q", scopes, wanted)
+			apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	// Open a stream with the processor; this lets processor
 	// functions pass messages into a channel, which we can
 	// then read from and put into a websockets connection.
@@ -241,7 +267,18 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// This prevents the upgrade handler from holding open any
 	// throttle / rate-limit request tokens which could become
 	// problematic on instances with multiple users.
-	go m.handleWSConn(&l, wsConn, stream)
+	go m.handleWSConn(&l, wsConn, stream, scopes)
+}
+
+// streamScope returns the scope that a token needs in
+// order to subscribe to the given stream type. As with
+// Mastodon, this is read:notifications for notifications,
+// and read:statuses for everything else.
+func streamScope(streamType string) oauth.Scope {
+	if streamType == streampkg.TimelineNotifications {
+		return oauth.ScopeReadNotifications
+	}
+	return oauth.ScopeReadStatuses
 }
 
 // handleWSConn handles a two-way websocket streaming connection.
@@ -249,7 +286,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 // into the connection. If any errors are encountered while reading
 // or writing (including expected errors like clients leaving), the
 // connection will be closed.
-func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream) {
+func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream, scopes string) {
 	l.Info("opened websocket connection")
 
 	// Create new async context with cancel.
@@ -259,7 +296,7 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 		defer cncl()
 
 		// Read messages from websocket to server.
-		m.readFromWSConn(ctx, wsConn, stream, l)
+		m.readFromWSConn(ctx, wsConn, stream, scopes, l)
 	}()
 
 	go func() {
@@ -288,6 +325,8 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 // readFromWSConn reads control messages coming in from the given
 // websockets connection, and modifies the subscription StreamTypes
 // of the given stream accordingly after acquiring a lock on it.
+// Subscriptions to stream types not permitted by the given token
+// scopes are ignored.
 //
 // This is a blocking function; will return only on read error or
 // if the given context is canceled.
@@ -295,6 +334,7 @@ func (m *Module) readFromWSConn(
 	ctx context.Context,
 	wsConn *websocket.Conn,
 	stream *streampkg.Stream,
+	scopes string,
 	l *log.Entry,
 ) {
 
@@ -342,6 +382,10 @@ func (m *Module) readFromWSConn(
 
 		switch msg.Type {
 		case "subscribe":
+			if wanted := streamScope(msg.Stream); !oauth.ScopesPermit(scopes, wanted) {
+				l.Warnf("token scopes %q do not permit %q: %v", scopes, wanted, msg)
+				continue
+			}
 			stream.Subscribe(msg.Stream)
 		case "unsubscribe":
 			stream.Unsubscribe(msg.Stream)
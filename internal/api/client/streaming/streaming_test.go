@@ -19,6 +19,7 @@ package streaming_test
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -37,6 +38,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -245,6 +247,66 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	}
 }
 
+// scopedToken stores and returns a copy of
+// local_account_1's token with the given scope.
+func (suite *StreamingTestSuite) scopedToken(scope string) *gtsmodel.Token {
+	token := new(gtsmodel.Token)
+	*token = *suite.testTokens["local_account_1"]
+	token.ID = id.NewULID()
+	token.Access = id.NewULID()
+	token.Scope = scope
+
+	if err := suite.db.PutToken(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
+
+// openStream requests a websocket stream of the given
+// type using the given token, returning the status code.
+func (suite *StreamingTestSuite) openStream(token *gtsmodel.Token, streamType string) int {
+	recorder := CreateTestResponseRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/%s?stream=%s", streaming.BasePath, streamType), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Header.Set(streaming.AccessTokenHeader, token.Access)
+	ctx.Request.Header.Set("Connection", "upgrade")
+	ctx.Request.Header.Set("Upgrade", "websocket")
+	ctx.Request.Header.Set("Sec-Websocket-Version", "13")
+	key := [16]byte{'h', 'e', 'l', 'l', 'o', ' ', 'w', 'o', 'r', 'l', 'd'}
+	ctx.Request.Header.Set("Sec-Websocket-Key", base64.StdEncoding.EncodeToString(key[:]))
+
+	suite.streamingModule.StreamGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	return recorder.Code
+}
+
+func (suite *StreamingTestSuite) TestStreamScopes() {
+	for _, test := range []struct {
+		scope      string
+		streamType string
+		expect     int
+	}{
+		{scope: "read", streamType: "user", expect: http.StatusOK},
+		{scope: "read", streamType: "user:notification", expect: http.StatusOK},
+		{scope: "read:statuses", streamType: "public", expect: http.StatusOK},
+		{scope: "read:statuses", streamType: "user:notification", expect: http.StatusForbidden},
+		{scope: "read:notifications", streamType: "user:notification", expect: http.StatusOK},
+		{scope: "read:notifications", streamType: "user", expect: http.StatusForbidden},
+		{scope: "user admin", streamType: "user", expect: http.StatusOK},
+		{scope: "write", streamType: "user", expect: http.StatusForbidden},
+		{scope: "read:accounts", streamType: "", expect: http.StatusForbidden},
+	} {
+		token := suite.scopedToken(test.scope)
+		code := suite.openStream(token, test.streamType)
+		suite.Equal(test.expect, code, "scope %q stream %q", test.scope, test.streamType)
+	}
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
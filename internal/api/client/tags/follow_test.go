@@ -0,0 +1,176 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *TagsTestSuite) tagAction(
+	method string,
+	path string,
+	handler gin.HandlerFunc,
+	tagName string,
+	expectedHTTPStatus int,
+) (*apimodel.Tag, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// create the request
+	requestPath := strings.Replace(path, ":"+apiutil.TagNameKey, tagName, 1)
+	ctx.Request = httptest.NewRequest(method, config.GetProtocol()+"://"+config.GetHost()+"/api/"+requestPath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(apiutil.TagNameKey, tagName)
+
+	// trigger the handler
+	handler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		return nil, gtserror.Newf("expected %d got %d: %s", expectedHTTPStatus, resultCode, string(b))
+	}
+
+	if expectedHTTPStatus != http.StatusOK {
+		return nil, nil
+	}
+
+	resp := &apimodel.Tag{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *TagsTestSuite) getTag(tagName string, expectedHTTPStatus int) (*apimodel.Tag, error) {
+	return suite.tagAction(http.MethodGet, tags.TagPath, suite.tagsModule.TagGETHandler, tagName, expectedHTTPStatus)
+}
+
+func (suite *TagsTestSuite) followTag(tagName string, expectedHTTPStatus int) (*apimodel.Tag, error) {
+	return suite.tagAction(http.MethodPost, tags.FollowPath, suite.tagsModule.FollowTagPOSTHandler, tagName, expectedHTTPStatus)
+}
+
+func (suite *TagsTestSuite) unfollowTag(tagName string, expectedHTTPStatus int) (*apimodel.Tag, error) {
+	return suite.tagAction(http.MethodPost, tags.UnfollowPath, suite.tagsModule.UnfollowTagPOSTHandler, tagName, expectedHTTPStatus)
+}
+
+func (suite *TagsTestSuite) TestFollowUnfollowExistingTag() {
+	tagName := suite.testTags["welcome"].Name
+
+	// Not following yet.
+	tag, err := suite.getTag(tagName, http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(tagName, tag.Name)
+	if suite.NotNil(tag.Following) {
+		suite.False(*tag.Following)
+	}
+
+	// Follow it, twice, since it's idempotent.
+	for i := 0; i < 2; i++ {
+		tag, err = suite.followTag(tagName, http.StatusOK)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if suite.NotNil(tag.Following) {
+			suite.True(*tag.Following)
+		}
+	}
+
+	// Should be shown as followed now.
+	tag, err = suite.getTag(tagName, http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.NotNil(tag.Following) {
+		suite.True(*tag.Following)
+	}
+
+	// Unfollow it.
+	tag, err = suite.unfollowTag(tagName, http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.NotNil(tag.Following) {
+		suite.False(*tag.Following)
+	}
+}
+
+func (suite *TagsTestSuite) TestFollowNewTag() {
+	// Nobody has used this tag yet.
+	tagName := "NewTagWhoDis"
+
+	tag, err := suite.getTag(tagName, http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("newtagwhodis", tag.Name)
+	if suite.NotNil(tag.Following) {
+		suite.False(*tag.Following)
+	}
+
+	// Following it should create it.
+	tag, err = suite.followTag(tagName, http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.NotNil(tag.Following) {
+		suite.True(*tag.Following)
+	}
+
+	dbTag, err := suite.db.GetTagByName(context.Background(), tagName)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("newtagwhodis", dbTag.Name)
+}
+
+func (suite *TagsTestSuite) TestFollowInvalidTag() {
+	_, err := suite.followTag("not a tag!", http.StatusBadRequest)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
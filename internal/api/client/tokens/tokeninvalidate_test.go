@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type TokenInvalidateTestSuite struct {
+	TokensTestSuite
+}
+
+func (suite *TokenInvalidateTestSuite) TestTokenInvalidate() {
+	token := suite.testTokens["local_account_1"]
+	b := suite.request(
+		suite.tokensModule.TokenInvalidatePOSTHandler,
+		http.MethodPost,
+		tokens.BasePath+"/"+token.ID+"/invalidate",
+		gin.Params{{Key: tokens.IDKey, Value: token.ID}},
+		nil,
+		http.StatusOK,
+	)
+
+	tokenInfo := &apimodel.TokenInfo{}
+	if err := json.Unmarshal(b, tokenInfo); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(token.ID, tokenInfo.ID)
+
+	// Token should no longer be usable.
+	_, err := suite.db.GetTokenByAccess(context.Background(), token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TokenInvalidateTestSuite) TestTokenInvalidateOtherUser() {
+	token := suite.testTokens["local_account_2"]
+	suite.request(
+		suite.tokensModule.TokenInvalidatePOSTHandler,
+		http.MethodPost,
+		tokens.BasePath+"/"+token.ID+"/invalidate",
+		gin.Params{{Key: tokens.IDKey, Value: token.ID}},
+		nil,
+		http.StatusNotFound,
+	)
+
+	// Token should still be there.
+	_, err := suite.db.GetTokenByAccess(context.Background(), token.Access)
+	suite.NoError(err)
+}
+
+func (suite *TokenInvalidateTestSuite) TestTokensInvalidateForApplication() {
+	app := suite.testApplications["application_1"]
+	b := suite.request(
+		suite.tokensModule.TokensInvalidatePOSTHandler,
+		http.MethodPost,
+		tokens.InvalidatePath,
+		nil,
+		url.Values{"application_id": {app.ID}},
+		http.StatusOK,
+	)
+
+	tokenInfos := []*apimodel.TokenInfo{}
+	if err := json.Unmarshal(b, &tokenInfos); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(tokenInfos, 1)
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokenInfos[0].ID)
+}
+
+func (suite *TokenInvalidateTestSuite) TestTokensInvalidateNoApplication() {
+	suite.request(
+		suite.tokensModule.TokensInvalidatePOSTHandler,
+		http.MethodPost,
+		tokens.InvalidatePath,
+		nil,
+		url.Values{},
+		http.StatusBadRequest,
+	)
+}
+
+func TestTokenInvalidateTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenInvalidateTestSuite))
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type TokensInfoGetTestSuite struct {
+	TokensTestSuite
+}
+
+func (suite *TokensInfoGetTestSuite) TestTokensInfoGet() {
+	b := suite.request(
+		suite.tokensModule.TokensInfoGETHandler,
+		http.MethodGet,
+		tokens.BasePath,
+		nil,
+		nil,
+		http.StatusOK,
+	)
+
+	tokenInfos := []*apimodel.TokenInfo{}
+	if err := json.Unmarshal(b, &tokenInfos); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The raw token should never be returned.
+	suite.NotContains(string(b), suite.testTokens["local_account_1"].Access)
+
+	suite.Len(tokenInfos, 1)
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokenInfos[0].ID)
+	suite.Equal("read write follow push", tokenInfos[0].Scope)
+	suite.Equal(suite.testApplications["application_1"].ID, tokenInfos[0].Application.ID)
+	suite.Equal("really cool gts application", tokenInfos[0].Application.Name)
+	suite.Empty(tokenInfos[0].LastUsed)
+}
+
+func (suite *TokensInfoGetTestSuite) TestTokenInfoGetOtherUser() {
+	id := suite.testTokens["local_account_2"].ID
+	suite.request(
+		suite.tokensModule.TokenInfoGETHandler,
+		http.MethodGet,
+		tokens.BasePath+"/"+id,
+		gin.Params{{Key: tokens.IDKey, Value: id}},
+		nil,
+		http.StatusNotFound,
+	)
+}
+
+func TestTokensInfoGetTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensInfoGetTestSuite))
+}
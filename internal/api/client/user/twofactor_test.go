@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) newContext(method string, path string, form url.Values) (*gin.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(method, fmt.Sprintf("http://localhost:8080%s", path), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = form
+	return ctx, recorder
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorSetupEnable() {
+	// Starts out disabled.
+	ctx, recorder := suite.newContext(http.MethodGet, user.TwoFactorPath, nil)
+	suite.userModule.TwoFactorGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`{"enabled":false,"backup_codes_remaining":0}`, recorder.Body.String())
+
+	// Get a secret.
+	ctx, recorder = suite.newContext(http.MethodPost, user.TwoFactorSetupPath, nil)
+	suite.userModule.TwoFactorSetupPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	setup := &apimodel.TwoFactorSetup{}
+	if err := json.NewDecoder(recorder.Body).Decode(setup); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Confirm it.
+	ctx, recorder = suite.newContext(http.MethodPost, user.TwoFactorEnablePath, url.Values{"code": {code}})
+	suite.userModule.TwoFactorEnablePOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	backups := &apimodel.TwoFactorBackupCodes{}
+	if err := json.NewDecoder(recorder.Body).Decode(backups); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(backups.BackupCodes, 8)
+
+	// Now enabled.
+	ctx, recorder = suite.newContext(http.MethodGet, user.TwoFactorPath, nil)
+	suite.userModule.TwoFactorGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	status := &apimodel.TwoFactorStatus{}
+	if err := json.NewDecoder(recorder.Body).Decode(status); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(status.Enabled)
+	suite.Equal(8, status.BackupCodesRemaining)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableMissingCode() {
+	ctx, recorder := suite.newContext(http.MethodPost, user.TwoFactorEnablePath, url.Values{})
+	suite.userModule.TwoFactorEnablePOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"error":"Bad Request: two-factor enable request missing field code"}`, string(b))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
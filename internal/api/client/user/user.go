@@ -29,6 +29,16 @@ const (
 	BasePath = "/v1/user"
 	// PasswordChangePath is the path for POSTing a password change request.
 	PasswordChangePath = BasePath + "/password_change"
+	// TwoFactorPath is the path for GETting the two-factor authentication status of the user.
+	TwoFactorPath = BasePath + "/2fa"
+	// TwoFactorSetupPath is the path for POSTing a request for a new TOTP secret.
+	TwoFactorSetupPath = TwoFactorPath + "/setup"
+	// TwoFactorEnablePath is the path for POSTing a code to confirm a new TOTP secret.
+	TwoFactorEnablePath = TwoFactorPath + "/enable"
+	// TwoFactorDisablePath is the path for POSTing a request to disable two-factor authentication.
+	TwoFactorDisablePath = TwoFactorPath + "/disable"
+	// TwoFactorBackupCodesPath is the path for POSTing a request for new backup codes.
+	TwoFactorBackupCodesPath = TwoFactorPath + "/backup_codes"
 )
 
 type Module struct {
@@ -43,4 +53,9 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodGet, TwoFactorPath, m.TwoFactorGETHandler)
+	attachHandler(http.MethodPost, TwoFactorSetupPath, m.TwoFactorSetupPOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorEnablePath, m.TwoFactorEnablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorDisablePath, m.TwoFactorDisablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorBackupCodesPath, m.TwoFactorBackupCodesPOSTHandler)
 }
@@ -29,7 +29,7 @@ const (
 	AccountIDKey = "account_id"
 	// MediaTypeKey is the url key for media type (usually something like attachment or header etc)
 	MediaTypeKey = "media_type"
-	// MediaSizeKey is the url key for the desired media size--original/small/static
+	// MediaSizeKey is the url key for the desired media size--original/medium/small/static
 	MediaSizeKey = "media_size"
 	// FileNameKey is the actual filename being sought. Will usually be a UUID then something like .jpeg
 	FileNameKey = "file_name"
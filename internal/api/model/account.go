@@ -99,6 +99,10 @@ type Account struct {
 	// Account has opted to hide their followers/following collections.
 	// Key/value omitted if false.
 	HideCollections bool `json:"hide_collections,omitempty"`
+	// Account has opted into having their public statuses
+	// included in full-text search results for other users.
+	// Key/value omitted if false.
+	Indexable bool `json:"indexable,omitempty"`
 	// Role of the account on this instance.
 	// Key/value omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
@@ -150,6 +154,8 @@ type AccountCreateRequest struct {
 type UpdateCredentialsRequest struct {
 	// Account should be made discoverable and shown in the profile directory (if enabled).
 	Discoverable *bool `form:"discoverable" json:"discoverable"`
+	// Account's public statuses may be included in full-text search results for other users.
+	Indexable *bool `form:"indexable" json:"indexable"`
 	// Account is flagged as a bot.
 	Bot *bool `form:"bot" json:"bot"`
 	// The display name to use for the account.
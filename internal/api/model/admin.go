@@ -211,6 +211,52 @@ type AdminInstanceRule struct {
 	Text      string `json:"text"`       // text content of the rule
 }
 
+// AdminInstance models the admin view of a remote
+// instance, including its delivery availability.
+//
+// swagger:model adminInstance
+type AdminInstance struct {
+	// The ID of the instance.
+	// example: 01GEM7SFDZ7GZNRXFVZ3X4E4N1
+	ID string `json:"id"`
+	// The domain of the instance.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Base URI of the instance.
+	// example: https://example.org
+	URI string `json:"uri"`
+	// Title of the instance, as given by the instance itself.
+	// example: Example Instance
+	Title string `json:"title,omitempty"`
+	// Version of the software used by the instance, if known.
+	// example: 4.2.8
+	Version string `json:"version,omitempty"`
+	// Time when the instance was first seen (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time when the instance was suspended (ISO 8601 Datetime), if it is.
+	// example: 2021-07-30T09:20:25+00:00
+	SuspendedAt string `json:"suspended_at,omitempty"`
+	// True if the instance is currently marked unavailable due to sustained delivery failures.
+	// Deliveries to and dereferencing from unavailable instances are skipped until the instance is reachable again.
+	// example: false
+	Unavailable bool `json:"unavailable"`
+	// Time when the instance was marked unavailable (ISO 8601 Datetime), if it is.
+	// example: 2021-07-30T09:20:25+00:00
+	UnavailableAt string `json:"unavailable_at,omitempty"`
+	// Time of the most recent successful delivery to the instance (ISO 8601 Datetime), if any.
+	// This is updated at most once an hour while deliveries are succeeding.
+	// example: 2021-07-30T09:20:25+00:00
+	DeliveredAt string `json:"delivered_at,omitempty"`
+	// Time of the most recent failed delivery to the instance (ISO 8601 Datetime), if any.
+	// example: 2021-07-30T09:20:25+00:00
+	DeliveryFailedAt string `json:"delivery_failed_at,omitempty"`
+	// Time of the first failed delivery to the instance since the last successful one (ISO 8601 Datetime).
+	// Not set if the most recent delivery succeeded.
+	// example: 2021-07-30T09:20:25+00:00
+	DeliveryFailingSince string `json:"delivery_failing_since,omitempty"`
+}
+
 // DebugAPUrlResponse provides detailed debug
 // information for an AP URL dereference request.
 //
@@ -229,3 +275,38 @@ type DebugAPUrlResponse struct {
 	// may be an error, may be both!
 	ResponseBody string `json:"response_body"`
 }
+
+// AdminRelay models an ActivityPub relay
+// subscribed to by this instance.
+//
+// swagger:model adminRelay
+type AdminRelay struct {
+	// The ID of the relay.
+	// example: 01GEM7SFDZ7GZNRXFVZ3X4E4N1
+	ID string `json:"id"`
+	// URL of the relay's inbox.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// State of the subscription to the relay.
+	// enum:
+	//	- pending
+	//	- accepted
+	//	- rejected
+	// example: accepted
+	State string `json:"state"`
+	// URI of the relay's actor, set once the relay has accepted the subscription.
+	// example: https://relay.example.org/actor
+	ActorURI string `json:"actor_uri,omitempty"`
+	// Time when the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// AdminRelayCreateRequest models a request
+// to subscribe to an ActivityPub relay.
+//
+// swagger:ignore
+type AdminRelayCreateRequest struct {
+	// URL of the relay's inbox.
+	InboxURL string `form:"inbox_url" json:"inbox_url"`
+}
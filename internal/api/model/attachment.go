@@ -146,6 +146,10 @@ type MediaDimensions struct {
 	// Bitrate of the media in bits per second.
 	// example: 1000000
 	Bitrate int `json:"bitrate,omitempty"`
+	// Number of audio channels.
+	// Only set for audio.
+	// example: 2
+	Channels int `json:"channels,omitempty"`
 	// Size of the media, in the format `[width]x[height]`.
 	// Not set for audio.
 	// example: 1920x1080
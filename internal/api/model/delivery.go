@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "encoding/json"
+
+// Delivery represents a queued delivery of an outgoing
+// ActivityPub activity to one remote inbox.
+//
+// swagger:model delivery
+type Delivery struct {
+	// The ID of the delivery.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Time at which the delivery was queued (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// URI of the remote inbox to deliver to.
+	// example: https://example.org/users/someone/inbox
+	// readonly: true
+	InboxURI string `json:"inbox_uri"`
+	// Status of the delivery (pending, failed).
+	// Pending deliveries will be retried; failed deliveries have been given up on.
+	// example: pending
+	// readonly: true
+	Status string `json:"status"`
+	// Number of delivery attempts made so far.
+	// example: 3
+	// readonly: true
+	Attempts int `json:"attempts"`
+	// Time at which the next delivery attempt is due (ISO 8601 Datetime).
+	// Not set if the delivery has failed.
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	// Time of the most recent delivery attempt (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	LastAttemptAt string `json:"last_attempt_at,omitempty"`
+	// Error returned by the most recent delivery attempt, if any.
+	// example: POST request to https://example.org/users/someone/inbox failed: 503 Service Unavailable
+	// readonly: true
+	LastError string `json:"last_error,omitempty"`
+	// Time at which the delivery was given up on (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	FailedAt string `json:"failed_at,omitempty"`
+	// The ActivityPub activity to be delivered.
+	// readonly: true
+	Activity json.RawMessage `json:"activity"`
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainPermissionSubscription represents an auto-refreshing subscription to a list of domain permissions (allows, blocks).
+//
+// swagger:model domainPermissionSubscription
+type DomainPermissionSubscription struct {
+	// The ID of the domain permission subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	// example: 100
+	Priority uint8 `json:"priority"`
+	// Moderator-set title for this list.
+	// example: Some List Of Domains
+	Title string `json:"title"`
+	// The type of domain permission subscription (allow, block).
+	// example: block
+	PermissionType string `json:"permission_type"`
+	// Adopt orphaned domain permissions present in this subscription's entries.
+	// example: false
+	AdoptOrphans bool `json:"adopt_orphans"`
+	// ID of the account that created this subscription.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+	// Time at which the subscription was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// URI to call in order to fetch the permissions list.
+	// example: https://www.example.org/blocklists/list1.csv
+	URI string `json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	// example: text/csv
+	ContentType string `json:"content_type"`
+	// (Optional) username to set for basic auth when doing a fetch of URI.
+	// example: admin123
+	FetchUsername string `json:"fetch_username,omitempty"`
+	// (Optional) password to set for basic auth when doing a fetch of URI.
+	// example: admin123
+	FetchPassword string `json:"fetch_password,omitempty"`
+	// Time of the most recent fetch attempt (successful or otherwise) (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time of the most recent successful fetch (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	SuccessfullyFetchedAt string `json:"successfully_fetched_at,omitempty"`
+	// If most recent fetch attempt failed, this field will contain an error message related to the fetch attempt.
+	// example: Oopsie doopsie, we made a fucky wucky.
+	// readonly: true
+	Error string `json:"error,omitempty"`
+	// Count of domain permission entries on this instance currently created by this subscription.
+	// example: 53
+	// readonly: true
+	Count uint64 `json:"count"`
+}
+
+// DomainPermissionSubscriptionRequest represents a request to create or update a domain permission subscription.
+//
+// swagger:ignore
+type DomainPermissionSubscriptionRequest struct {
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Priority *int `form:"priority" json:"priority"`
+	// Moderator-set title for this list.
+	Title *string `form:"title" json:"title"`
+	// Type of domain permission to create from entries in this list (allow, block).
+	// Can only be set on creation.
+	PermissionType *string `form:"permission_type" json:"permission_type"`
+	// Adopt orphaned domain permissions present in this subscription's entries.
+	AdoptOrphans *bool `form:"adopt_orphans" json:"adopt_orphans"`
+	// URI to call in order to fetch the permissions list.
+	URI *string `form:"uri" json:"uri"`
+	// MIME content type to use when parsing the permissions list (text/csv, application/json, text/plain).
+	ContentType *string `form:"content_type" json:"content_type"`
+	// (Optional) username to set for basic auth when doing a fetch of URI.
+	FetchUsername *string `form:"fetch_username" json:"fetch_username"`
+	// (Optional) password to set for basic auth when doing a fetch of URI.
+	FetchPassword *string `form:"fetch_password" json:"fetch_password"`
+}
+
+// DomainPermissionSubscriptionPreview represents the
+// changes that would result from processing a domain
+// permission subscription, without actually making them.
+//
+// swagger:model domainPermissionSubscriptionPreview
+type DomainPermissionSubscriptionPreview struct {
+	// Domain permissions that would be newly created by this subscription.
+	Create []*DomainPermission `json:"create"`
+	// Existing domain permissions that would be adopted by this subscription.
+	Adopt []*DomainPermission `json:"adopt"`
+	// Existing domain permissions that would be removed by this subscription,
+	// as they are no longer present in the subscribed list.
+	Remove []*DomainPermission `json:"remove"`
+}
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// PolicyValue represents a group of accounts
+// permitted to interact with a status.
+//
+// swagger:enum interactionPolicyValue
+// swagger:type string
+type PolicyValue string
+
+const (
+	// PolicyValueEveryone permits anyone who can see the status.
+	PolicyValueEveryone PolicyValue = "everyone"
+	// PolicyValueFollowers permits followers of the status author, and accounts mentioned in the status.
+	PolicyValueFollowers PolicyValue = "followers"
+	// PolicyValueMentioned permits only accounts mentioned in the status.
+	PolicyValueMentioned PolicyValue = "mentioned"
+	// PolicyValueNobody permits nobody but the status author.
+	PolicyValueNobody PolicyValue = "nobody"
+)
+
+// InteractionPolicy describes who is permitted
+// to reply to, boost, or like/fave a status.
+//
+// swagger:model interactionPolicy
+type InteractionPolicy struct {
+	// Who can reply to the status.
+	// Replies from anyone else must be approved by the status author.
+	CanReply PolicyValue `json:"can_reply"`
+	// Who can boost the status.
+	CanBoost PolicyValue `json:"can_boost"`
+	// Who can like/fave the status.
+	CanLike PolicyValue `json:"can_like"`
+}
+
+// InteractionPolicyRequest models an interaction
+// policy submitted when creating a status.
+//
+// swagger:ignore
+type InteractionPolicyRequest struct {
+	// Who can reply to the status.
+	CanReply PolicyValue `form:"interaction_policy[can_reply]" json:"can_reply" xml:"can_reply"`
+	// Who can boost the status.
+	CanBoost PolicyValue `form:"interaction_policy[can_boost]" json:"can_boost" xml:"can_boost"`
+	// Who can like/fave the status.
+	CanLike PolicyValue `form:"interaction_policy[can_like]" json:"can_like" xml:"can_like"`
+}
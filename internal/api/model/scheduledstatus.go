@@ -18,21 +18,79 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// ID of the scheduled status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Time at which the status will be published (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt string `json:"scheduled_at"`
+	// Parameters that will be used to create the status.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached to the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model statusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	// Text content of the status.
+	Text string `json:"text"`
+	// Poll to be attached to the status.
+	// nullable: true
+	Poll *StatusParamsPoll `json:"poll"`
+	// ID of the status being replied to, if status is a reply.
+	// nullable: true
+	InReplyToID *string `json:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote.
+	QuoteID *string `json:"quote_id,omitempty"`
+	// Array of Attachment ids to be attached as media.
+	// nullable: true
+	MediaIDs []string `json:"media_ids"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `json:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text"`
+	// Visibility of the status.
+	Visibility Visibility `json:"visibility"`
+	// Time at which the status will be published (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt string `json:"scheduled_at"`
+	// ISO 639 language code for the status.
+	// nullable: true
+	Language *string `json:"language"`
+	// Content type to use when parsing the status.
+	// nullable: true
+	ContentType *StatusContentType `json:"content_type"`
+	// ID of the application used to schedule the status.
+	ApplicationID string `json:"application_id"`
+	// Who will be permitted to interact with the status.
+	// Omitted if everyone will be permitted to reply, boost and like.
+	InteractionPolicy *InteractionPolicy `json:"interaction_policy,omitempty"`
+}
+
+// StatusParamsPoll represents the poll parameters for a scheduled status.
+//
+// swagger:model statusParamsPoll
+type StatusParamsPoll struct {
+	// Array of possible poll answers.
+	Options []string `json:"options"`
+	// Duration the poll should be open, in seconds.
+	ExpiresIn int `json:"expires_in"`
+	// Allow multiple choices on this poll.
+	Multiple bool `json:"multiple"`
+	// Hide vote counts until the poll ends.
+	HideTotals bool `json:"hide_totals"`
+}
+
+// ScheduledStatusUpdateRequest models a request to update a scheduled status.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which the status will be published.
+	// Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
 }
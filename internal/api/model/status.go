@@ -29,6 +29,11 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited (ISO 8601 Datetime).
+	// Will be null if the status has never been edited.
+	// example: 2021-07-30T09:20:25+00:00
+	// nullable: true
+	EditedAt *string `json:"edited_at"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	// nullable: true
@@ -78,6 +83,16 @@ type Status struct {
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// The status that this status quotes, if any, and if
+	// visible to the viewer. Not set for quotes of quotes.
+	Quote *StatusQuoted `json:"quote,omitempty"`
+	// Title of this status, if it's an article, page, or event
+	// from a remote instance. The title is also prepended to
+	// the status content, for clients that don't show it.
+	// example: My First Blog Post
+	Title string `json:"title,omitempty"`
+	// Details of the event described by this status, if it's an event.
+	Event *StatusEvent `json:"event,omitempty"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
@@ -100,6 +115,11 @@ type Status struct {
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
 	Text string `json:"text,omitempty"`
+	// A list of filters that matched this status and why they matched, if there are any such filters.
+	Filtered []FilterResult `json:"filtered,omitempty"`
+	// Who is permitted to interact with this status.
+	// Omitted if everyone is permitted to reply, boost and like.
+	InteractionPolicy *InteractionPolicy `json:"interaction_policy,omitempty"`
 
 	// Additional fields not exposed via JSON
 	// (used only internally for templating etc).
@@ -161,6 +181,30 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuoted represents a quoted status.
+//
+// swagger:model statusQuoted
+type StatusQuoted struct {
+	*Status
+}
+
+// StatusEvent represents the details
+// of an event described by a status.
+//
+// swagger:model statusEvent
+type StatusEvent struct {
+	// When the event starts (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	StartTime string `json:"start_time"`
+	// When the event ends (ISO 8601 Datetime), if known.
+	// example: 2021-07-30T11:20:25+00:00
+	// nullable: true
+	EndTime *string `json:"end_time"`
+	// Name of the place where the event takes place, if known.
+	// example: Community Center, Main Street
+	Location string `json:"location,omitempty"`
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -176,6 +220,8 @@ type StatusCreateRequest struct {
 	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote.
+	QuoteID string `form:"quote_id" json:"quote_id" xml:"quote_id"`
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
@@ -193,6 +239,30 @@ type StatusCreateRequest struct {
 	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
 }
 
+// StatusEditRequest models status edit parameters.
+//
+// swagger:ignore
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	// Attaching a poll is optional while status is provided.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Array of Attachment ids to be attached as media.
+	// If provided, status becomes optional, and poll cannot be used.
+	MediaIDs []string `form:"media_ids[]" json:"media_ids" xml:"media_ids"`
+	// Poll to include with this status.
+	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// ISO 639 language code for this status.
+	Language string `form:"language" json:"language" xml:"language"`
+	// Content type to use when parsing this status.
+	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
+}
+
 // Visibility models the visibility of a status.
 //
 // swagger:enum statusVisibility
@@ -234,6 +304,11 @@ type AdvancedVisibilityFlagsForm struct {
 	Replyable *bool `form:"replyable" json:"replyable" xml:"replyable"`
 	// This status can be liked/faved.
 	Likeable *bool `form:"likeable" json:"likeable" xml:"likeable"`
+	// This status can be quoted by other accounts.
+	Quotable *bool `form:"quotable" json:"quotable" xml:"quotable"`
+	// Who is permitted to interact with this status.
+	// Takes precedence over boostable, replyable and likeable.
+	InteractionPolicy *InteractionPolicyRequest `form:"interaction_policy" json:"interaction_policy" xml:"interaction_policy"`
 }
 
 // StatusContentType is the content type with which to parse the submitted status.
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// StatusEdit models one historical revision of a status.
+//
+// swagger:model statusEdit
+type StatusEdit struct {
+	// The content of this status at this revision.
+	// Should be HTML, but might also be plaintext in some cases.
+	// example: <p>Hey this is a status!</p>
+	Content string `json:"content"`
+	// Subject, summary, or content warning for the status at this revision.
+	// example: warning nsfw
+	SpoilerText string `json:"spoiler_text"`
+	// Status marked sensitive at this revision.
+	// example: false
+	Sensitive bool `json:"sensitive"`
+	// The date when this revision was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The account that authored this status.
+	Account *Account `json:"account"`
+	// The poll attached to the status at this revision.
+	// Note that edits changing the poll options will be collapsed together into one edit, since this action resets the poll.
+	// nullable: true
+	Poll *StatusEditPoll `json:"poll"`
+	// Media that is attached to this status.
+	MediaAttachments []*Attachment `json:"media_attachments"`
+	// Custom emoji to be used when rendering status content.
+	Emojis []Emoji `json:"emojis"`
+}
+
+// StatusEditPoll models a poll at the time
+// of a specific historical revision of a status.
+//
+// swagger:model statusEditPoll
+type StatusEditPoll struct {
+	// Options available for this poll at this revision.
+	Options []StatusEditPollOption `json:"options"`
+}
+
+// StatusEditPollOption models one option of a
+// poll at the time of a historical status revision.
+//
+// swagger:model statusEditPollOption
+type StatusEditPollOption struct {
+	// The text value of the poll option at this revision.
+	Title string `json:"title"`
+}
+
+// StatusSource represents the source text of a
+// status as submitted to the API when it was created.
+//
+// swagger:model statusSource
+type StatusSource struct {
+	// ID of the status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Plain-text source of a status.
+	Text string `json:"text"`
+	// Plain-text version of spoiler text.
+	SpoilerText string `json:"spoiler_text"`
+}
@@ -34,3 +34,70 @@ type PasswordChangeRequest struct {
 	// required: true
 	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
 }
+
+// TwoFactorStatus models the second factor
+// authentication status of the requesting user.
+//
+// swagger:model twoFactorStatus
+type TwoFactorStatus struct {
+	// Two-factor authentication is enabled for this user,
+	// and a code is required when signing in.
+	Enabled bool `json:"enabled"`
+	// Time when two-factor authentication was enabled (ISO 8601 Datetime).
+	// Omitted if not enabled.
+	EnabledAt string `json:"enabled_at,omitempty"`
+	// Number of unused backup codes remaining.
+	BackupCodesRemaining int `json:"backup_codes_remaining"`
+}
+
+// TwoFactorSetup models a new TOTP secret,
+// which must be confirmed with a valid code
+// before two-factor authentication is enabled.
+//
+// swagger:model twoFactorSetup
+type TwoFactorSetup struct {
+	// Base32-encoded TOTP secret, for manual
+	// entry into an authenticator app.
+	Secret string `json:"secret"`
+	// otpauth:// URI containing the secret.
+	URI string `json:"uri"`
+	// PNG image of a QR code encoding the
+	// otpauth:// URI, as a base64 data URI.
+	QRCode string `json:"qr_code"`
+}
+
+// TwoFactorEnableRequest models a request to
+// confirm enrolment of a new TOTP secret.
+//
+// swagger:parameters userTwoFactorEnable
+type TwoFactorEnableRequest struct {
+	// Current code from the user's authenticator app.
+	//
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" validation:"required"`
+}
+
+// TwoFactorDisableRequest models a request to
+// disable two-factor authentication, or to
+// regenerate backup codes.
+//
+// swagger:parameters userTwoFactorDisable userTwoFactorBackupCodes
+type TwoFactorDisableRequest struct {
+	// User's current password.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
+
+// TwoFactorBackupCodes models a newly generated
+// set of one-time backup codes. These are only
+// ever shown to the user once.
+//
+// swagger:model twoFactorBackupCodes
+type TwoFactorBackupCodes struct {
+	// One-time codes which can each be used
+	// once in place of a TOTP code when signing in.
+	BackupCodes []string `json:"backup_codes"`
+}
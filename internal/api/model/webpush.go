@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// WebPushSubscription represents a subscription to a Web Push server.
+//
+// swagger:model webPushSubscription
+type WebPushSubscription struct {
+	// The id of the push subscription in the database.
+	ID string `json:"id"`
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint"`
+	// The streaming server's VAPID public key.
+	ServerKey string `json:"server_key"`
+	// Which alerts should be delivered to the endpoint.
+	Alerts WebPushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should generate notifications for the endpoint.
+	// 	all = Receive notifications from anyone
+	// 	followed = Receive notifications from accounts you follow
+	// 	follower = Receive notifications from accounts that follow you
+	// 	none = Receive no notifications
+	Policy WebPushNotificationPolicy `json:"policy"`
+}
+
+// WebPushSubscriptionAlerts represents the specific
+// alerts that a Web Push subscription will receive.
+//
+// swagger:model webPushSubscriptionAlerts
+type WebPushSubscriptionAlerts struct {
+	// Receive a push notification when someone has followed you?
+	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
+	// Receive a push notification when a status you created has been favourited by someone else?
+	Favourite bool `json:"favourite"`
+	// Receive a push notification when someone else has mentioned you in a status?
+	Mention bool `json:"mention"`
+	// Receive a push notification when a status you created has been boosted by someone else?
+	Reblog bool `json:"reblog"`
+	// Receive a push notification when a poll you voted in or created has ended?
+	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+}
+
+// WebPushNotificationPolicy names sets of accounts
+// that can generate notifications for a Web Push subscription.
+type WebPushNotificationPolicy string
+
+const (
+	WebPushNotificationPolicyAll      WebPushNotificationPolicy = "all"
+	WebPushNotificationPolicyFollowed WebPushNotificationPolicy = "followed"
+	WebPushNotificationPolicyFollower WebPushNotificationPolicy = "follower"
+	WebPushNotificationPolicyNone     WebPushNotificationPolicy = "none"
+)
+
+// WebPushSubscriptionCreateRequest models a request to create a Web Push subscription.
+// This has two sets of fields to support a goofy nested map structure in both form data and JSON bodies.
+//
+// swagger:ignore
+type WebPushSubscriptionCreateRequest struct {
+	Subscription             *WebPushSubscriptionRequestSubscription `json:"subscription"`
+	FormSubscriptionEndpoint string                                  `form:"subscription[endpoint]"`
+	FormSubscriptionAuth     string                                  `form:"subscription[keys][auth]"`
+	FormSubscriptionP256dh   string                                  `form:"subscription[keys][p256dh]"`
+
+	// Alert and policy data for the subscription.
+	WebPushSubscriptionUpdateRequest
+}
+
+// WebPushSubscriptionRequestSubscription contains
+// the push endpoint and client keys of a subscription.
+type WebPushSubscriptionRequestSubscription struct {
+	// The endpoint URL that is called when a notification event occurs.
+	Endpoint string `json:"endpoint"`
+	// Client keys used for encrypting notifications.
+	Keys WebPushSubscriptionRequestKeys `json:"keys"`
+}
+
+// WebPushSubscriptionRequestKeys contains the client
+// keys used for encrypting a subscription's notifications.
+type WebPushSubscriptionRequestKeys struct {
+	// Base64-encoded auth secret, 16 bytes of random data.
+	Auth string `json:"auth"`
+	// Base64-encoded public key of an ECDH keypair using the P-256 elliptic curve.
+	P256dh string `json:"p256dh"`
+}
+
+// GetSubscription should be used instead of Subscription or the FormSubscription* fields.
+func (r *WebPushSubscriptionCreateRequest) GetSubscription() WebPushSubscriptionRequestSubscription {
+	if r.Subscription != nil {
+		return *r.Subscription
+	}
+	return WebPushSubscriptionRequestSubscription{
+		Endpoint: r.FormSubscriptionEndpoint,
+		Keys: WebPushSubscriptionRequestKeys{
+			Auth:   r.FormSubscriptionAuth,
+			P256dh: r.FormSubscriptionP256dh,
+		},
+	}
+}
+
+// WebPushSubscriptionUpdateRequest models a request to update a Web Push subscription's alerts and policy.
+// This has two sets of fields to support a goofy nested map structure in both form data and JSON bodies.
+//
+// swagger:ignore
+type WebPushSubscriptionUpdateRequest struct {
+	Data                        *WebPushSubscriptionRequestData `json:"data"`
+	FormDataAlertsFollow        bool                            `form:"data[alerts][follow]"`
+	FormDataAlertsFollowRequest bool                            `form:"data[alerts][follow_request]"`
+	FormDataAlertsFavourite     bool                            `form:"data[alerts][favourite]"`
+	FormDataAlertsMention       bool                            `form:"data[alerts][mention]"`
+	FormDataAlertsReblog        bool                            `form:"data[alerts][reblog]"`
+	FormDataAlertsPoll          bool                            `form:"data[alerts][poll]"`
+	FormDataAlertsStatus        bool                            `form:"data[alerts][status]"`
+	FormDataPolicy              WebPushNotificationPolicy       `form:"data[policy]"`
+}
+
+// WebPushSubscriptionRequestData contains
+// the alerts and policy of a subscription.
+type WebPushSubscriptionRequestData struct {
+	// Which alerts should be delivered to the endpoint.
+	Alerts WebPushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should generate notifications for the endpoint.
+	Policy WebPushNotificationPolicy `json:"policy"`
+}
+
+// GetData should be used instead of Data or the FormData* fields.
+func (r *WebPushSubscriptionUpdateRequest) GetData() WebPushSubscriptionRequestData {
+	if r.Data != nil {
+		return *r.Data
+	}
+	return WebPushSubscriptionRequestData{
+		Alerts: WebPushSubscriptionAlerts{
+			Follow:        r.FormDataAlertsFollow,
+			FollowRequest: r.FormDataAlertsFollowRequest,
+			Favourite:     r.FormDataAlertsFavourite,
+			Mention:       r.FormDataAlertsMention,
+			Reblog:        r.FormDataAlertsReblog,
+			Poll:          r.FormDataAlertsPoll,
+			Status:        r.FormDataAlertsStatus,
+		},
+		Policy: r.FormDataPolicy,
+	}
+}
+
+// WebPushNotification is the payload of a Web Push
+// notification, as decrypted by the receiving client.
+//
+// swagger:ignore
+type WebPushNotification struct {
+	// Access token of the subscription, which clients can use to fetch further details.
+	AccessToken string `json:"access_token"`
+	// Locale that the notification text was written in.
+	PreferredLocale string `json:"preferred_locale"`
+	// ID of the notification that was pushed.
+	NotificationID string `json:"notification_id"`
+	// Type of the notification that was pushed.
+	NotificationType string `json:"notification_type"`
+	// URL of an icon (the origin account's avatar) to display with the notification.
+	Icon string `json:"icon"`
+	// Title of the notification.
+	Title string `json:"title"`
+	// Plaintext body of the notification.
+	Body string `json:"body"`
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TokenAuth is a convenience wrapper around oauth.Authed for client API
+// handlers, which additionally checks that the token used to authenticate
+// the request (if any) has been granted the given scope.
+//
+// If the requirements passed to oauth.Authed are not met, a 401 will be
+// returned. If a token is present but lacks the required scope, a 403
+// will be returned, with a message saying which scope was missing.
+//
+// An empty scope means that no particular scope is required.
+func TokenAuth(
+	c *gin.Context,
+	requireToken bool,
+	requireApp bool,
+	requireUser bool,
+	requireAccount bool,
+	scope oauth.Scope,
+) (*oauth.Auth, gtserror.WithCode) {
+	authed, err := oauth.Authed(c,
+		requireToken,
+		requireApp,
+		requireUser,
+		requireAccount,
+	)
+	if err != nil {
+		return nil, gtserror.NewErrorUnauthorized(err, err.Error())
+	}
+
+	if scope == "" || authed.Token == nil {
+		// Nothing to check.
+		return authed, nil
+	}
+
+	if !oauth.ScopesPermit(authed.Token.GetScope(), scope) {
+		err := fmt.Errorf(
+			"token scopes %q do not permit %q",
+			authed.Token.GetScope(), scope,
+		)
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return authed, nil
+}
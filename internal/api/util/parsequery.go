@@ -68,6 +68,15 @@ const (
 
 	DomainPermissionExportKey = "export"
 	DomainPermissionImportKey = "import"
+
+	/* Domain permission subscription keys */
+
+	DomainPermissionSubscriptionRemoveChildrenKey = "remove_children"
+
+	/* Admin instance keys */
+
+	InstanceDomainKey      = "domain"
+	InstanceUnavailableKey = "unavailable"
 )
 
 /*
@@ -119,6 +128,14 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseDomainPermissionSubscriptionRemoveChildren(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, DomainPermissionSubscriptionRemoveChildrenKey)
+}
+
+func ParseInstanceUnavailable(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, InstanceUnavailableKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
@@ -81,12 +81,14 @@ func (c *Caches) Init() {
 	c.initPoll()
 	c.initPollVote()
 	c.initPollVoteIDs()
+	c.initRelays()
 	c.initReport()
 	c.initStatus()
 	c.initStatusFave()
 	c.initTag()
 	c.initThreadMute()
 	c.initStatusFaveIDs()
+	c.initToken()
 	c.initTombstone()
 	c.initUser()
 	c.initWebfinger()
@@ -146,6 +148,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.StatusFave.Trim(threshold)
 	c.GTS.Tag.Trim(threshold)
 	c.GTS.ThreadMute.Trim(threshold)
+	c.GTS.Token.Trim(threshold)
 	c.GTS.Tombstone.Trim(threshold)
 	c.GTS.User.Trim(threshold)
 	c.Visibility.Trim(threshold)
@@ -135,6 +135,11 @@ type GTSCaches struct {
 	// PollVoteIDs provides access to the poll vote IDs list database cache.
 	PollVoteIDs SliceCache[string]
 
+	// Relays provides access to the list of all gtsmodel Relay database cache.
+	// Relays are few, and checked on every Announce + new public status, so
+	// they're cached all together as one list under a single key.
+	Relays SliceCache[*gtsmodel.Relay]
+
 	// Report provides access to the gtsmodel Report database cache.
 	Report StructCache[*gtsmodel.Report]
 
@@ -150,6 +155,9 @@ type GTSCaches struct {
 	// Tag provides access to the gtsmodel Tag database cache.
 	Tag StructCache[*gtsmodel.Tag]
 
+	// Token provides access to the gtsmodel Token database cache.
+	Token StructCache[*gtsmodel.Token]
+
 	// Tombstone provides access to the gtsmodel Tombstone database cache.
 	Tombstone StructCache[*gtsmodel.Tombstone]
 
@@ -978,6 +986,12 @@ func (c *Caches) initPollVoteIDs() {
 	c.GTS.PollVoteIDs.Init(0, cap)
 }
 
+func (c *Caches) initRelays() {
+	// Only ever need 1 entry,
+	// the list of all relays.
+	c.GTS.Relays.Init(0, 1)
+}
+
 func (c *Caches) initReport() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1034,6 +1048,8 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.QuoteOf = nil
+		s2.QuoteOfAccount = nil
 		s2.Poll = nil
 		s2.Attachments = nil
 		s2.Tags = nil
@@ -1160,6 +1176,35 @@ func (c *Caches) initThreadMute() {
 	})
 }
 
+func (c *Caches) initToken() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofToken(), // model in-mem size.
+		config.GetCacheTokenMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(t1 *gtsmodel.Token) *gtsmodel.Token {
+		t2 := new(gtsmodel.Token)
+		*t2 = *t1
+		return t2
+	}
+
+	c.GTS.Token.Init(structr.CacheConfig[*gtsmodel.Token]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "Code"},
+			{Fields: "Access"},
+			{Fields: "Refresh"},
+			{Fields: "ClientID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initTombstone() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
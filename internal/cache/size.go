@@ -198,6 +198,7 @@ func totalOfRatios() float64 {
 		config.GetCacheStatusFaveIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
 		config.GetCacheThreadMuteMemRatio() +
+		config.GetCacheTokenMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
 		config.GetCacheUserMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
@@ -220,6 +221,7 @@ func sizeofAccount() uintptr {
 		Bot:                     func() *bool { ok := true; return &ok }(),
 		Locked:                  func() *bool { ok := true; return &ok }(),
 		Discoverable:            func() *bool { ok := false; return &ok }(),
+		Indexable:               func() *bool { ok := false; return &ok }(),
 		URI:                     exampleURI,
 		URL:                     exampleURI,
 		InboxURI:                exampleURI,
@@ -566,6 +568,8 @@ func sizeofStatus() uintptr {
 		Boostable:                func() *bool { ok := true; return &ok }(),
 		Replyable:                func() *bool { ok := true; return &ok }(),
 		Likeable:                 func() *bool { ok := true; return &ok }(),
+		Quotable:                 func() *bool { ok := true; return &ok }(),
+		PendingApproval:          func() *bool { ok := false; return &ok }(),
 		ActivityStreamsType:      ap.ObjectNote,
 	}))
 }
@@ -602,6 +606,22 @@ func sizeOfThreadMute() uintptr {
 	}))
 }
 
+func sizeofToken() uintptr {
+	return uintptr(size.Of(&gtsmodel.Token{
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		ClientID:        exampleID,
+		UserID:          exampleID,
+		RedirectURI:     exampleURI,
+		Scope:           "read write push",
+		Access:          exampleID,
+		AccessCreateAt:  exampleTime,
+		AccessExpiresAt: exampleTime,
+		LastUsed:        exampleTime,
+	}))
+}
+
 func sizeofTombstone() uintptr {
 	return uintptr(size.Of(&gtsmodel.Tombstone{
 		ID:        exampleID,
@@ -20,6 +20,8 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -303,6 +305,15 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			return true, nil
 		}
 
+		// Files of media that's done processing should all be
+		// known to it; anything else (e.g. a transcoded video
+		// left by an interrupted transcode) is no longer used.
+		if media.Processing == gtsmodel.ProcessingStatusProcessed &&
+			!slices.Contains(mediaFiles(media), strings.TrimPrefix(path, "/")) {
+			l.Debug("file not in use by media")
+			return true, nil
+		}
+
 	case media.TypeEmoji:
 		// Generate static URL for this emoji to lookup.
 		staticURL := uris.URIForAttachment(
@@ -374,6 +385,15 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 				return false, nil
 			}
 		}
+
+		// Check whether attached to a previous version of status.
+		inHistory, err := m.inStatusHistory(ctx, status, media)
+		if err != nil {
+			return false, err
+		} else if inHistory {
+			l.Debug("skipping as attached to status edit")
+			return false, nil
+		}
 	}
 
 	// Media totally unused, delete it.
@@ -422,10 +442,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		_, err := m.removeFiles(ctx, mediaFiles(media)...)
 		return true, err
 
 	default:
@@ -532,17 +549,38 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) inStatusHistory(ctx context.Context, status *gtsmodel.Status, media *gtsmodel.MediaAttachment) (bool, error) {
+	if status.EditedAt.IsZero() {
+		// never edited.
+		return false, nil
+	}
+
+	// Load the previous versions of this status.
+	edits, err := m.state.DB.GetStatusEditsByStatusID(
+		gtscontext.SetBarebones(ctx),
+		status.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error fetching edits for status %s: %w", status.ID, err)
+	}
+
+	for _, edit := range edits {
+		if slices.Contains(edit.AttachmentIDs, media.ID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Remove media and renditions.
+	_, err := m.removeFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -563,11 +601,8 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 		return nil
 	}
 
-	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	// Remove media and renditions.
+	_, err := m.removeFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -580,3 +615,13 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 
 	return nil
 }
+
+// mediaFiles returns the storage paths of the original
+// file, thumbnail and any medium rendition of media.
+func mediaFiles(media *gtsmodel.MediaAttachment) []string {
+	files := []string{media.File.Path, media.Thumbnail.Path}
+	if media.Medium.Path != "" {
+		files = append(files, media.Medium.Path)
+	}
+	return files
+}
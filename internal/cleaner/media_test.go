@@ -426,3 +426,30 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(3, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestPruneOrphanedTranscodeLeftover() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_2"]
+
+	// Pretend a transcode of this video was interrupted
+	// after storing the result, but before the attachment
+	// could be updated to point at it.
+	leftoverPath := testAttachment.AccountID + "/attachment/transcoded/" + testAttachment.ID + ".mp4"
+	if _, err := suite.storage.Put(ctx, leftoverPath, []byte("not really a video")); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := suite.cleaner.Media().PruneOrphaned(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Leftover should be gone...
+	_, err := suite.storage.Get(ctx, leftoverPath)
+	suite.ErrorIs(err, storage.ErrNotFound)
+
+	// ...but the files in use kept.
+	_, err = suite.storage.Get(ctx, testAttachment.File.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, testAttachment.Thumbnail.Path)
+	suite.NoError(err)
+}
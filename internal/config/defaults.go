@@ -66,21 +66,26 @@ var Defaults = Configuration{
 	InstanceDeliverToSharedInboxes: true,
 	InstanceLanguages:              make(language.Languages, 0),
 
+	InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm,
+	InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
+
 	AccountsRegistrationOpen: true,
 	AccountsApprovalRequired: true,
 	AccountsReasonRequired:   true,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
-	MediaImageMaxSize:        10 * bytesize.MiB,
-	MediaVideoMaxSize:        40 * bytesize.MiB,
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 1500,
-	MediaRemoteCacheDays:     7,
-	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
-	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
-	MediaCleanupFrom:         "00:00",        // Midnight.
-	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaImageMaxSize:               10 * bytesize.MiB,
+	MediaVideoMaxSize:               40 * bytesize.MiB,
+	MediaVideoTranscode:             false,
+	MediaVideoTranscodeMaxDimension: 1920,
+	MediaDescriptionMinChars:        0,
+	MediaDescriptionMaxChars:        1500,
+	MediaRemoteCacheDays:            7,
+	MediaEmojiLocalMaxSize:          50 * bytesize.KiB,
+	MediaEmojiRemoteMaxSize:         100 * bytesize.KiB,
+	MediaCleanupFrom:                "00:00",        // Midnight.
+	MediaCleanupEvery:               24 * time.Hour, // 1/day.
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
@@ -134,6 +139,7 @@ var Defaults = Configuration{
 	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
 	AdvancedThrottlingRetryAfter: time.Second * 30,
 	AdvancedSenderMultiplier:     2, // 2 senders per CPU
+	AdvancedPersistWorkerQueues:  false,
 	AdvancedCSPExtraURIs:         []string{},
 	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
 
@@ -191,6 +197,7 @@ var Defaults = Configuration{
 		StatusFaveIDsMemRatio:    3,
 		TagMemRatio:              2,
 		ThreadMuteMemRatio:       0.2,
+		TokenMemRatio:            0.75,
 		TombstoneMemRatio:        0.5,
 		UserMemRatio:             0.25,
 		WebfingerMemRatio:        0.1,
@@ -975,6 +975,62 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceSubscriptionsProcessFrom safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessFrom = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessFromFlag returns the flag name for the 'InstanceSubscriptionsProcessFrom' field
+func InstanceSubscriptionsProcessFromFlag() string { return "instance-subscriptions-process-from" }
+
+// GetInstanceSubscriptionsProcessFrom safely fetches the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func GetInstanceSubscriptionsProcessFrom() string {
+	return global.GetInstanceSubscriptionsProcessFrom()
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func SetInstanceSubscriptionsProcessFrom(v string) { global.SetInstanceSubscriptionsProcessFrom(v) }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessEvery = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessEveryFlag returns the flag name for the 'InstanceSubscriptionsProcessEvery' field
+func InstanceSubscriptionsProcessEveryFlag() string { return "instance-subscriptions-process-every" }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func GetInstanceSubscriptionsProcessEvery() time.Duration {
+	return global.GetInstanceSubscriptionsProcessEvery()
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	global.SetInstanceSubscriptionsProcessEvery(v)
+}
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1150,6 +1206,56 @@ func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize()
 // SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
 func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
 
+// GetMediaVideoTranscode safely fetches the Configuration value for state's 'MediaVideoTranscode' field
+func (st *ConfigState) GetMediaVideoTranscode() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaVideoTranscode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaVideoTranscode safely sets the Configuration value for state's 'MediaVideoTranscode' field
+func (st *ConfigState) SetMediaVideoTranscode(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaVideoTranscode = v
+	st.reloadToViper()
+}
+
+// MediaVideoTranscodeFlag returns the flag name for the 'MediaVideoTranscode' field
+func MediaVideoTranscodeFlag() string { return "media-video-transcode" }
+
+// GetMediaVideoTranscode safely fetches the value for global configuration 'MediaVideoTranscode' field
+func GetMediaVideoTranscode() bool { return global.GetMediaVideoTranscode() }
+
+// SetMediaVideoTranscode safely sets the value for global configuration 'MediaVideoTranscode' field
+func SetMediaVideoTranscode(v bool) { global.SetMediaVideoTranscode(v) }
+
+// GetMediaVideoTranscodeMaxDimension safely fetches the Configuration value for state's 'MediaVideoTranscodeMaxDimension' field
+func (st *ConfigState) GetMediaVideoTranscodeMaxDimension() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaVideoTranscodeMaxDimension
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaVideoTranscodeMaxDimension safely sets the Configuration value for state's 'MediaVideoTranscodeMaxDimension' field
+func (st *ConfigState) SetMediaVideoTranscodeMaxDimension(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaVideoTranscodeMaxDimension = v
+	st.reloadToViper()
+}
+
+// MediaVideoTranscodeMaxDimensionFlag returns the flag name for the 'MediaVideoTranscodeMaxDimension' field
+func MediaVideoTranscodeMaxDimensionFlag() string { return "media-video-transcode-max-dimension" }
+
+// GetMediaVideoTranscodeMaxDimension safely fetches the value for global configuration 'MediaVideoTranscodeMaxDimension' field
+func GetMediaVideoTranscodeMaxDimension() int { return global.GetMediaVideoTranscodeMaxDimension() }
+
+// SetMediaVideoTranscodeMaxDimension safely sets the value for global configuration 'MediaVideoTranscodeMaxDimension' field
+func SetMediaVideoTranscodeMaxDimension(v int) { global.SetMediaVideoTranscodeMaxDimension(v) }
+
 // GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
 func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
 	st.mutex.RLock()
@@ -2600,6 +2706,31 @@ func GetAdvancedSenderMultiplier() int { return global.GetAdvancedSenderMultipli
 // SetAdvancedSenderMultiplier safely sets the value for global configuration 'AdvancedSenderMultiplier' field
 func SetAdvancedSenderMultiplier(v int) { global.SetAdvancedSenderMultiplier(v) }
 
+// GetAdvancedPersistWorkerQueues safely fetches the Configuration value for state's 'AdvancedPersistWorkerQueues' field
+func (st *ConfigState) GetAdvancedPersistWorkerQueues() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdvancedPersistWorkerQueues
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedPersistWorkerQueues safely sets the Configuration value for state's 'AdvancedPersistWorkerQueues' field
+func (st *ConfigState) SetAdvancedPersistWorkerQueues(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedPersistWorkerQueues = v
+	st.reloadToViper()
+}
+
+// AdvancedPersistWorkerQueuesFlag returns the flag name for the 'AdvancedPersistWorkerQueues' field
+func AdvancedPersistWorkerQueuesFlag() string { return "advanced-persist-worker-queues" }
+
+// GetAdvancedPersistWorkerQueues safely fetches the value for global configuration 'AdvancedPersistWorkerQueues' field
+func GetAdvancedPersistWorkerQueues() bool { return global.GetAdvancedPersistWorkerQueues() }
+
+// SetAdvancedPersistWorkerQueues safely sets the value for global configuration 'AdvancedPersistWorkerQueues' field
+func SetAdvancedPersistWorkerQueues(v bool) { global.SetAdvancedPersistWorkerQueues(v) }
+
 // GetAdvancedCSPExtraURIs safely fetches the Configuration value for state's 'AdvancedCSPExtraURIs' field
 func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
 	st.mutex.RLock()
@@ -3625,6 +3756,31 @@ func GetCacheThreadMuteMemRatio() float64 { return global.GetCacheThreadMuteMemR
 // SetCacheThreadMuteMemRatio safely sets the value for global configuration 'Cache.ThreadMuteMemRatio' field
 func SetCacheThreadMuteMemRatio(v float64) { global.SetCacheThreadMuteMemRatio(v) }
 
+// GetCacheTokenMemRatio safely fetches the Configuration value for state's 'Cache.TokenMemRatio' field
+func (st *ConfigState) GetCacheTokenMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.TokenMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheTokenMemRatio safely sets the Configuration value for state's 'Cache.TokenMemRatio' field
+func (st *ConfigState) SetCacheTokenMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.TokenMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheTokenMemRatioFlag returns the flag name for the 'Cache.TokenMemRatio' field
+func CacheTokenMemRatioFlag() string { return "cache-token-mem-ratio" }
+
+// GetCacheTokenMemRatio safely fetches the value for global configuration 'Cache.TokenMemRatio' field
+func GetCacheTokenMemRatio() float64 { return global.GetCacheTokenMemRatio() }
+
+// SetCacheTokenMemRatio safely sets the value for global configuration 'Cache.TokenMemRatio' field
+func SetCacheTokenMemRatio(v float64) { global.SetCacheTokenMemRatio(v) }
+
 // GetCacheTombstoneMemRatio safely fetches the Configuration value for state's 'Cache.TombstoneMemRatio' field
 func (st *ConfigState) GetCacheTombstoneMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -19,8 +19,10 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type Application interface {
@@ -35,4 +37,43 @@ type Application interface {
 
 	// DeleteApplicationByClientID deletes the application with corresponding client_id value from the database.
 	DeleteApplicationByClientID(ctx context.Context, clientID string) error
+
+	// GetExpiredTokenIDs fetches the IDs of all client oauth tokens with a code, access
+	// or refresh expiry time before now. This bypasses the cache, so as not to fill it up.
+	GetExpiredTokenIDs(ctx context.Context, now time.Time) ([]string, error)
+
+	// GetTokenByID fetches the client oauth token from database with ID.
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error)
+
+	// GetTokenByCode fetches the client oauth token from database with code.
+	GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error)
+
+	// GetTokenByAccess fetches the client oauth token from database with access code.
+	GetTokenByAccess(ctx context.Context, access string) (*gtsmodel.Token, error)
+
+	// GetTokenByRefresh fetches the client oauth token from database with refresh code.
+	GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error)
+
+	// GetAccessTokensByUserID fetches a page of the access tokens
+	// (ie., not yet-to-be-exchanged codes) belonging to the given user.
+	GetAccessTokensByUserID(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error)
+
+	// PutToken puts given client oauth token in the database.
+	PutToken(ctx context.Context, token *gtsmodel.Token) error
+
+	// UpdateToken updates the given client oauth token in the database.
+	// If columns is empty, all columns will be updated.
+	UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error
+
+	// DeleteTokenByID deletes client oauth token from database with ID.
+	DeleteTokenByID(ctx context.Context, id string) error
+
+	// DeleteTokenByCode deletes client oauth token from database with code.
+	DeleteTokenByCode(ctx context.Context, code string) error
+
+	// DeleteTokenByAccess deletes client oauth token from database with access code.
+	DeleteTokenByAccess(ctx context.Context, access string) error
+
+	// DeleteTokenByRefresh deletes client oauth token from database with refresh code.
+	DeleteTokenByRefresh(ctx context.Context, refresh string) error
 }
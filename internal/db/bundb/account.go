@@ -369,8 +369,12 @@ func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) e
 			}
 
 			// insert the account
-			_, err := tx.NewInsert().Model(account).Exec(ctx)
-			return err
+			if _, err := tx.NewInsert().Model(account).Exec(ctx); err != nil {
+				return err
+			}
+
+			// add it to the search index
+			return putAccountSearch(ctx, tx, account)
 		})
 	})
 }
@@ -410,12 +414,20 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 			}
 
 			// update the account
-			_, err := tx.NewUpdate().
+			if _, err := tx.NewUpdate().
 				Model(account).
 				Where("? = ?", bun.Ident("account.id"), account.ID).
 				Column(columns...).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if needsReindex(columns, accountSearchColumns) {
+				// reindex searchable text
+				return putAccountSearch(ctx, tx, account)
+			}
+
+			return nil
 		})
 	})
 }
@@ -444,6 +456,11 @@ func (a *accountDB) DeleteAccount(ctx context.Context, id string) error {
 			return err
 		}
 
+		// remove from the search index
+		if err := deleteAccountSearch(ctx, tx, id); err != nil {
+			return err
+		}
+
 		// delete the account
 		_, err := tx.
 			NewDelete().
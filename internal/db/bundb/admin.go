@@ -19,8 +19,10 @@ package bundb
 
 import (
 	"context"
+	"crypto/ecdh"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/mail"
@@ -303,7 +305,7 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) error {
 	}
 	if exists {
 		log.Infof(ctx, "instance entry already exists")
-		return nil
+		return a.ensureInstanceVAPIDKeyPair(ctx, host)
 	}
 
 	iID, err := id.NewRandomULID()
@@ -311,11 +313,19 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) error {
 		return err
 	}
 
+	vapidPublicKey, vapidPrivateKey, err := newVAPIDKeyPair()
+	if err != nil {
+		log.Errorf(ctx, "error creating new vapid key pair: %s", err)
+		return err
+	}
+
 	i := &gtsmodel.Instance{
-		ID:     iID,
-		Domain: host,
-		Title:  host,
-		URI:    fmt.Sprintf("%s://%s", protocol, host),
+		ID:              iID,
+		Domain:          host,
+		Title:           host,
+		URI:             fmt.Sprintf("%s://%s", protocol, host),
+		VAPIDPublicKey:  vapidPublicKey,
+		VAPIDPrivateKey: vapidPrivateKey,
 	}
 
 	insertQ := a.db.
@@ -331,6 +341,52 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) error {
 	return nil
 }
 
+// ensureInstanceVAPIDKeyPair generates and stores a VAPID key
+// pair for the instance entry with the given domain, if it
+// doesn't have one yet (eg., it was created before Web Push).
+func (a *adminDB) ensureInstanceVAPIDKeyPair(ctx context.Context, host string) error {
+	instance, err := a.state.DB.GetInstance(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	if instance.VAPIDPublicKey != "" && instance.VAPIDPrivateKey != "" {
+		// Already set,
+		// nothing to do.
+		return nil
+	}
+
+	instance.VAPIDPublicKey, instance.VAPIDPrivateKey, err = newVAPIDKeyPair()
+	if err != nil {
+		log.Errorf(ctx, "error creating new vapid key pair: %s", err)
+		return err
+	}
+
+	if err := a.state.DB.UpdateInstance(ctx, instance,
+		"vapid_public_key",
+		"vapid_private_key",
+	); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "created vapid key pair for instance %s", host)
+	return nil
+}
+
+// newVAPIDKeyPair generates a new P-256 key pair for signing Web Push
+// requests, returning the base64url-encoded uncompressed public key
+// point and private key scalar, as expected by Web Push clients.
+func newVAPIDKeyPair() (string, string, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey := base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	privateKey := base64.RawURLEncoding.EncodeToString(key.Bytes())
+	return publicKey, privateKey, nil
+}
+
 /*
 	ACTION FUNCS
 */
@@ -19,8 +19,11 @@ package bundb
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
@@ -95,3 +98,209 @@ func (a *applicationDB) DeleteApplicationByClientID(ctx context.Context, clientI
 
 	return nil
 }
+
+func (a *applicationDB) GetExpiredTokenIDs(ctx context.Context, now time.Time) ([]string, error) {
+	var tokenIDs []string
+
+	// Select IDs of tokens with any expiry time
+	// passed. Expiry times are null for never.
+	if err := a.db.NewSelect().
+		Table("tokens").
+		Column("id").
+		WhereOr("? < ?", bun.Ident("code_expires_at"), now).
+		WhereOr("? < ?", bun.Ident("access_expires_at"), now).
+		WhereOr("? < ?", bun.Ident("refresh_expires_at"), now).
+		Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	return tokenIDs, nil
+}
+
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"ID",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("id"), id).Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (a *applicationDB) GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"Code",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("code"), code).Scan(ctx)
+		},
+		code,
+	)
+}
+
+func (a *applicationDB) GetTokenByAccess(ctx context.Context, access string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"Access",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("access"), access).Scan(ctx)
+		},
+		access,
+	)
+}
+
+func (a *applicationDB) GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"Refresh",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("refresh"), refresh).Scan(ctx)
+		},
+		refresh,
+	)
+}
+
+func (a *applicationDB) getTokenBy(lookup string, dbQuery func(*gtsmodel.Token) error, keyParts ...any) (*gtsmodel.Token, error) {
+	return a.state.Caches.GTS.Token.LoadOne(lookup, func() (*gtsmodel.Token, error) {
+		var token gtsmodel.Token
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&token); err != nil {
+			return nil, err
+		}
+
+		return &token, nil
+	}, keyParts...)
+}
+
+func (a *applicationDB) getTokensByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Token, error) {
+	tokens := make([]*gtsmodel.Token, 0, len(ids))
+
+	for _, id := range ids {
+		token, err := a.GetTokenByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (a *applicationDB) GetAccessTokensByUserID(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		tokenIDs = make([]string, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("tokens"), bun.Ident("token")).
+		Column("token.id").
+		Where("? = ?", bun.Ident("token.user_id"), userID).
+		Where("? != ''", bun.Ident("token.access"))
+
+	if maxID != "" {
+		// Return only items *OLDER* than the given max ID.
+		q = q.Where("? < ?", bun.Ident("token.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only items *NEWER* than the given min ID.
+		q = q.Where("? > ?", bun.Ident("token.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("token.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("token.id"))
+	}
+
+	if err := q.Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	if len(tokenIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(tokenIDs)
+	}
+
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) PutToken(ctx context.Context, token *gtsmodel.Token) error {
+	return a.state.Caches.GTS.Token.Store(token, func() error {
+		_, err := a.db.NewInsert().Model(token).Exec(ctx)
+		return err
+	})
+}
+
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	// Update the token's last-updated
+	token.UpdatedAt = time.Now()
+
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included
+		columns = append(columns, "updated_at")
+	}
+
+	return a.state.Caches.GTS.Token.Store(token, func() error {
+		_, err := a.db.
+			NewUpdate().
+			Model(token).
+			Where("? = ?", bun.Ident("token.id"), token.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
+	return a.deleteTokenBy(ctx, "ID", "id", id)
+}
+
+func (a *applicationDB) DeleteTokenByCode(ctx context.Context, code string) error {
+	return a.deleteTokenBy(ctx, "Code", "code", code)
+}
+
+func (a *applicationDB) DeleteTokenByAccess(ctx context.Context, access string) error {
+	return a.deleteTokenBy(ctx, "Access", "access", access)
+}
+
+func (a *applicationDB) DeleteTokenByRefresh(ctx context.Context, refresh string) error {
+	return a.deleteTokenBy(ctx, "Refresh", "refresh", refresh)
+}
+
+// deleteTokenBy deletes the token with the given column value
+// from the database, then invalidates it from the cache. As the
+// oauth token store and the token checking middleware both read
+// through the cache, this ensures deletion takes effect immediately.
+func (a *applicationDB) deleteTokenBy(ctx context.Context, lookup string, column string, key string) error {
+	if _, err := a.db.NewDelete().
+		Table("tokens").
+		Where("? = ?", bun.Ident(column), key).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear token from the cache.
+	a.state.Caches.GTS.Token.Invalidate(lookup, key)
+
+	return nil
+}
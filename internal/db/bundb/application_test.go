@@ -123,6 +123,195 @@ func (suite *ApplicationTestSuite) TestDeleteApplicationBy() {
 	}
 }
 
+func (suite *ApplicationTestSuite) TestGetTokenBy() {
+	t := suite.T()
+
+	// Create a new context for this test.
+	ctx, cncl := context.WithCancel(context.Background())
+	defer cncl()
+
+	// Sentinel error to mark avoiding a test case.
+	sentinelErr := errors.New("sentinel")
+
+	// isEqual checks if 2 token models are equal.
+	isEqual := func(t1, t2 gtsmodel.Token) bool {
+		// Clear database-set fields.
+		t1.CreatedAt = time.Time{}
+		t2.CreatedAt = time.Time{}
+		t1.UpdatedAt = time.Time{}
+		t2.UpdatedAt = time.Time{}
+
+		return reflect.DeepEqual(t1, t2)
+	}
+
+	for _, token := range suite.testTokens {
+		for lookup, dbfunc := range map[string]func() (*gtsmodel.Token, error){
+			"id": func() (*gtsmodel.Token, error) {
+				return suite.db.GetTokenByID(ctx, token.ID)
+			},
+
+			"code": func() (*gtsmodel.Token, error) {
+				if token.Code == "" {
+					return nil, sentinelErr
+				}
+				return suite.db.GetTokenByCode(ctx, token.Code)
+			},
+
+			"access": func() (*gtsmodel.Token, error) {
+				if token.Access == "" {
+					return nil, sentinelErr
+				}
+				return suite.db.GetTokenByAccess(ctx, token.Access)
+			},
+
+			"refresh": func() (*gtsmodel.Token, error) {
+				if token.Refresh == "" {
+					return nil, sentinelErr
+				}
+				return suite.db.GetTokenByRefresh(ctx, token.Refresh)
+			},
+		} {
+			// Clear database caches.
+			suite.state.Caches.Init()
+
+			t.Logf("checking database lookup %q", lookup)
+
+			// Perform database function.
+			checkToken, err := dbfunc()
+			if err != nil {
+				if err == sentinelErr {
+					continue
+				}
+
+				t.Errorf("error encountered for database lookup %q: %v", lookup, err)
+				continue
+			}
+
+			// Check received token data.
+			if !isEqual(*checkToken, *token) {
+				t.Errorf("token does not contain expected data: %+v", checkToken)
+				continue
+			}
+		}
+	}
+}
+
+func (suite *ApplicationTestSuite) TestGetAccessTokensByUserID() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	// Only the access token should be returned, not
+	// the authorization code or the app's own token.
+	tokens, err := suite.db.GetAccessTokensByUserID(ctx, token.UserID, nil)
+	suite.NoError(err)
+	suite.Len(tokens, 1)
+	suite.Equal(token.ID, tokens[0].ID)
+}
+
+func (suite *ApplicationTestSuite) TestUpdateTokenLastUsed() {
+	ctx := context.Background()
+	testToken := suite.testTokens["local_account_1"]
+
+	// Load token into the cache before updating.
+	token, err := suite.db.GetTokenByAccess(ctx, testToken.Access)
+	suite.NoError(err)
+	suite.Zero(token.LastUsed)
+
+	lastUsed := time.Now().Truncate(time.Second)
+	token.LastUsed = lastUsed
+	err = suite.db.UpdateToken(ctx, token, "last_used")
+	suite.NoError(err)
+
+	// Both cached and database versions should be updated.
+	token, err = suite.db.GetTokenByAccess(ctx, testToken.Access)
+	suite.NoError(err)
+	suite.True(lastUsed.Equal(token.LastUsed))
+
+	suite.state.Caches.Init()
+	token, err = suite.db.GetTokenByID(ctx, testToken.ID)
+	suite.NoError(err)
+	suite.True(lastUsed.Equal(token.LastUsed))
+}
+
+func (suite *ApplicationTestSuite) TestGetExpiredTokenIDs() {
+	ctx := context.Background()
+
+	// No test tokens have expired yet.
+	tokenIDs, err := suite.db.GetExpiredTokenIDs(ctx, time.Now())
+	suite.NoError(err)
+	suite.Empty(tokenIDs)
+
+	// Expire the access token of one.
+	token := &gtsmodel.Token{}
+	*token = *suite.testTokens["local_account_1"]
+	token.AccessExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.db.UpdateToken(ctx, token, "access_expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tokenIDs, err = suite.db.GetExpiredTokenIDs(ctx, time.Now())
+	suite.NoError(err)
+	suite.Equal([]string{token.ID}, tokenIDs)
+}
+
+func (suite *ApplicationTestSuite) TestDeleteTokensBy() {
+	t := suite.T()
+
+	// Create a new context for this test.
+	ctx, cncl := context.WithCancel(context.Background())
+	defer cncl()
+
+	for _, token := range suite.testTokens {
+		for lookup, dbfunc := range map[string]func() error{
+			"id": func() error {
+				return suite.db.DeleteTokenByID(ctx, token.ID)
+			},
+
+			"code": func() error {
+				return suite.db.DeleteTokenByCode(ctx, token.Code)
+			},
+
+			"access": func() error {
+				return suite.db.DeleteTokenByAccess(ctx, token.Access)
+			},
+
+			"refresh": func() error {
+				return suite.db.DeleteTokenByRefresh(ctx, token.Refresh)
+			},
+		} {
+			// Skip lookups the token can't be found by.
+			if (lookup == "code" && token.Code == "") ||
+				(lookup == "access" && token.Access == "") ||
+				(lookup == "refresh" && token.Refresh == "") {
+				continue
+			}
+
+			// Reset tokens and load into cache.
+			suite.NoError(suite.db.DeleteTokenByID(ctx, token.ID))
+			suite.NoError(suite.db.PutToken(ctx, token))
+			if _, err := suite.db.GetTokenByID(ctx, token.ID); err != nil {
+				t.Errorf("error loading token %q: %v", lookup, err)
+				continue
+			}
+
+			t.Logf("checking database lookup %q", lookup)
+
+			// Perform database function.
+			err := dbfunc()
+			if err != nil {
+				t.Errorf("error encountered for database lookup %q: %v", lookup, err)
+				continue
+			}
+
+			// Ensure this token has been deleted and cache cleared.
+			if _, err := suite.db.GetTokenByID(ctx, token.ID); err != db.ErrNoEntries {
+				t.Errorf("token does not appear to have been deleted %q: %v", lookup, err)
+				continue
+			}
+		}
+	}
+}
+
 func TestApplicationTestSuite(t *testing.T) {
 	suite.Run(t, new(ApplicationTestSuite))
 }
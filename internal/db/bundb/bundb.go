@@ -58,6 +58,8 @@ type DBService struct {
 	db.Admin
 	db.Application
 	db.Basic
+	db.Conversation
+	db.Delivery
 	db.Domain
 	db.Emoji
 	db.HeaderFilter
@@ -71,18 +73,24 @@ type DBService struct {
 	db.Notification
 	db.Poll
 	db.Relationship
+	db.Relay
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.Status
 	db.StatusBookmark
+	db.StatusEdit
 	db.StatusFave
+	db.StorageBlob
 	db.Tag
 	db.Thread
 	db.Timeline
 	db.User
 	db.Tombstone
+	db.WebPush
+	db.WorkerTask
 	db *bun.DB
 }
 
@@ -189,6 +197,14 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		Conversation: &conversationDB{
+			db:    db,
+			state: state,
+		},
+		Delivery: &deliveryDB{
+			db:    db,
+			state: state,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
@@ -241,6 +257,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Report: &reportDB{
 			db:    db,
 			state: state,
@@ -249,6 +269,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -264,10 +288,17 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusEdit: &statusEditDB{
+			db:    db,
+			state: state,
+		},
 		StatusFave: &statusFaveDB{
 			db:    db,
 			state: state,
 		},
+		StorageBlob: &storageBlobDB{
+			db: db,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -288,6 +319,13 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
+		WorkerTask: &workerTaskDB{
+			db: db,
+		},
 		db: db,
 	}
 
@@ -0,0 +1,365 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type conversationDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *conversationDB) GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, error) {
+	return c.getConversation(
+		ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("? = ?", bun.Ident("conversation.id"), id)
+		},
+	)
+}
+
+func (c *conversationDB) GetConversationByThreadAndAccountIDs(
+	ctx context.Context,
+	threadID string,
+	accountID string,
+	otherAccountIDs []string,
+) (*gtsmodel.Conversation, error) {
+	// Don't reorder the caller's slice.
+	otherAccountsKey := gtsmodel.ConversationOtherAccountsKey(slices.Clone(otherAccountIDs))
+
+	return c.getConversation(
+		ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("conversation.thread_id"), threadID).
+				Where("? = ?", bun.Ident("conversation.account_id"), accountID).
+				Where("? = ?", bun.Ident("conversation.other_accounts_key"), otherAccountsKey)
+		},
+	)
+}
+
+func (c *conversationDB) getConversation(
+	ctx context.Context,
+	query func(*bun.SelectQuery) *bun.SelectQuery,
+) (*gtsmodel.Conversation, error) {
+	var conversation gtsmodel.Conversation
+
+	q := c.db.
+		NewSelect().
+		Model(&conversation)
+
+	if err := query(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &conversation, nil
+	}
+
+	if err := c.PopulateConversation(ctx, &conversation); err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (c *conversationDB) GetConversationsByOwnerAccountID(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.Conversation, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		conversationIDs = make([]string, 0, limit)
+	)
+
+	// Conversations are paged by the
+	// ID of their latest status, so that
+	// the most recently active come first.
+	q := c.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+		Column("conversation.id").
+		Where("? = ?", bun.Ident("conversation.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only items *OLDER* than the given max ID.
+		q = q.Where("? < ?", bun.Ident("conversation.last_status_id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only items *NEWER* than the given min ID.
+		q = q.Where("? > ?", bun.Ident("conversation.last_status_id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("conversation.last_status_id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("conversation.last_status_id"))
+	}
+
+	if err := q.Scan(ctx, &conversationIDs); err != nil {
+		return nil, err
+	}
+
+	if len(conversationIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(conversationIDs)
+	}
+
+	conversations := make([]*gtsmodel.Conversation, 0, len(conversationIDs))
+	for _, id := range conversationIDs {
+		// Attempt to fetch conversation from DB.
+		conversation, err := c.GetConversationByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting conversation %q: %v", id, err)
+			continue
+		}
+
+		// Append conversation to return slice.
+		conversations = append(conversations, conversation)
+	}
+
+	return conversations, nil
+}
+
+func (c *conversationDB) PopulateConversation(ctx context.Context, conversation *gtsmodel.Conversation) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if conversation.Account == nil {
+		// Conversation owner is not set, fetch from database.
+		conversation.Account, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			conversation.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation owner account: %w", err)
+		}
+	}
+
+	if !conversation.OtherAccountsPopulated() {
+		// Conversation participants are out-of-date with IDs, repopulate.
+		conversation.OtherAccounts = make([]*gtsmodel.Account, 0, len(conversation.OtherAccountIDs))
+		for _, id := range conversation.OtherAccountIDs {
+			account, err := c.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				id,
+			)
+			if err != nil {
+				errs.Appendf("error populating conversation participant %s: %w", id, err)
+				continue
+			}
+			conversation.OtherAccounts = append(conversation.OtherAccounts, account)
+		}
+	}
+
+	if conversation.LastStatus == nil && conversation.LastStatusID != "" {
+		// Conversation last status is not set, fetch from database.
+		conversation.LastStatus, err = c.state.DB.GetStatusByID(
+			ctx,
+			conversation.LastStatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *conversationDB) UpsertConversation(ctx context.Context, conversation *gtsmodel.Conversation, columns ...string) error {
+	// If we're updating by column, ensure "updated_at" is included.
+	if len(columns) > 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	conversation.UpdatedAt = time.Now()
+	conversation.OtherAccountsKey = gtsmodel.ConversationOtherAccountsKey(conversation.OtherAccountIDs)
+
+	_, err := NewUpsert(c.db).
+		Model(conversation).
+		Constraint("id").
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) LinkConversationToStatus(ctx context.Context, conversationID string, statusID string) error {
+	conversationToStatus := &gtsmodel.ConversationToStatus{
+		ConversationID: conversationID,
+		StatusID:       statusID,
+	}
+
+	_, err := c.db.
+		NewInsert().
+		Model(conversationToStatus).
+		Ignore().
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) DeleteConversationByID(ctx context.Context, id string) error {
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return deleteConversations(ctx, tx, "id", id)
+	})
+}
+
+func (c *conversationDB) DeleteConversationsByOwnerAccountID(ctx context.Context, accountID string) error {
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return deleteConversations(ctx, tx, "account_id", accountID)
+	})
+}
+
+func (c *conversationDB) DeleteStatusFromConversations(ctx context.Context, statusID string) error {
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Remove the status from any
+		// conversations it belonged to.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status")).
+			Where("? = ?", bun.Ident("conversation_to_status.status_id"), statusID).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting conversation links: %w", err)
+		}
+
+		// Find conversations which had
+		// this as their latest status.
+		var conversationIDs []string
+		if err := tx.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+			Column("conversation.id").
+			Where("? = ?", bun.Ident("conversation.last_status_id"), statusID).
+			Scan(ctx, &conversationIDs); err != nil {
+			return gtserror.Newf("error finding affected conversations: %w", err)
+		}
+
+		for _, conversationID := range conversationIDs {
+			// Get the latest remaining
+			// status in the conversation.
+			var lastStatusID string
+			err := tx.
+				NewSelect().
+				TableExpr("? AS ?", bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status")).
+				Column("conversation_to_status.status_id").
+				Where("? = ?", bun.Ident("conversation_to_status.conversation_id"), conversationID).
+				OrderExpr("? DESC", bun.Ident("conversation_to_status.status_id")).
+				Limit(1).
+				Scan(ctx, &lastStatusID)
+
+			switch {
+			case errors.Is(err, db.ErrNoEntries):
+				// Nothing left in this
+				// conversation, delete it.
+				if err := deleteConversations(ctx, tx, "id", conversationID); err != nil {
+					return err
+				}
+
+			case err != nil:
+				// Real db error.
+				return gtserror.Newf("error finding last status of conversation %s: %w", conversationID, err)
+
+			default:
+				// Point conversation
+				// at new last status.
+				if _, err := tx.
+					NewUpdate().
+					TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+					Set("? = ?", bun.Ident("last_status_id"), lastStatusID).
+					Set("? = ?", bun.Ident("updated_at"), time.Now()).
+					Where("? = ?", bun.Ident("conversation.id"), conversationID).
+					Exec(ctx); err != nil {
+					return gtserror.Newf("error updating conversation %s: %w", conversationID, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// deleteConversations deletes conversations
+// where the given column matches value, along
+// with their links to statuses, using given tx.
+func deleteConversations(ctx context.Context, tx bun.Tx, column string, value string) error {
+	var conversationIDs []string
+
+	// Perform DELETE on conversations,
+	// returning the deleted conversation IDs.
+	if _, err := tx.
+		NewDelete().
+		Table("conversations").
+		Where("? = ?", bun.Ident(column), value).
+		Returning("id").
+		Exec(ctx, &conversationIDs); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting conversations: %w", err)
+	}
+
+	if len(conversationIDs) == 0 {
+		return nil
+	}
+
+	// Delete links to statuses
+	// for deleted conversations.
+	if _, err := tx.
+		NewDelete().
+		Table("conversation_to_statuses").
+		Where("? IN (?)", bun.Ident("conversation_id"), bun.In(conversationIDs)).
+		Exec(ctx); err != nil {
+		return gtserror.Newf("error deleting conversation links: %w", err)
+	}
+
+	return nil
+}
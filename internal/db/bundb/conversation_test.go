@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ConversationTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ConversationTestSuite) TestConversationLastStatusDeleted() {
+	var (
+		ctx          = context.Background()
+		account      = suite.testAccounts["local_account_1"]
+		otherAccount = suite.testAccounts["local_account_2"]
+		statuses     = []*gtsmodel.Status{
+			suite.testStatuses["local_account_1_status_1"],
+			suite.testStatuses["local_account_1_status_2"],
+		}
+	)
+
+	conversation := &gtsmodel.Conversation{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		OtherAccountIDs: []string{otherAccount.ID},
+		ThreadID:        statuses[0].ThreadID,
+		LastStatusID:    statuses[1].ID,
+		Read:            util.Ptr(false),
+	}
+
+	if err := suite.db.UpsertConversation(ctx, conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, status := range statuses {
+		if err := suite.db.LinkConversationToStatus(ctx, conversation.ID, status.ID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Conversation should be findable by its participants.
+	dbConversation, err := suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		conversation.ThreadID,
+		account.ID,
+		[]string{otherAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(conversation.ID, dbConversation.ID)
+	suite.True(dbConversation.OtherAccountsPopulated())
+
+	conversations, err := suite.db.GetConversationsByOwnerAccountID(ctx, account.ID, &paging.Page{Limit: 10})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(conversations, 1)
+
+	// Deleting the last status should point
+	// the conversation at the previous one.
+	if err := suite.db.DeleteStatusFromConversations(ctx, statuses[1].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbConversation, err = suite.db.GetConversationByID(ctx, conversation.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(statuses[0].ID, dbConversation.LastStatusID)
+
+	// Deleting the only remaining status
+	// should delete the conversation.
+	if err := suite.db.DeleteStatusFromConversations(ctx, statuses[0].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetConversationByID(ctx, conversation.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestConversationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTestSuite))
+}
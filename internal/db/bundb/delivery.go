@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type deliveryDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (d *deliveryDB) GetDeliveryByID(ctx context.Context, id string) (*gtsmodel.Delivery, error) {
+	delivery := new(gtsmodel.Delivery)
+
+	if err := d.db.
+		NewSelect().
+		Model(delivery).
+		Where("? = ?", bun.Ident("delivery.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (d *deliveryDB) GetDeliveries(
+	ctx context.Context,
+	status gtsmodel.DeliveryStatus,
+	inboxURI string,
+	page *paging.Page,
+) ([]*gtsmodel.Delivery, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		deliveries = make([]*gtsmodel.Delivery, 0, limit)
+	)
+
+	q := d.db.
+		NewSelect().
+		Model(&deliveries)
+
+	switch status {
+	case gtsmodel.DeliveryStatusPending:
+		// Return only deliveries not yet given up on.
+		q = q.Where("? IS NULL", bun.Ident("delivery.failed_at"))
+	case gtsmodel.DeliveryStatusFailed:
+		// Return only deliveries that were given up on.
+		q = q.Where("? IS NOT NULL", bun.Ident("delivery.failed_at"))
+	}
+
+	if inboxURI != "" {
+		// Return only deliveries to given inbox.
+		q = q.Where("? = ?", bun.Ident("delivery.inbox_uri"), inboxURI)
+	}
+
+	if maxID != "" {
+		// Return only items *OLDER* than the given max ID.
+		q = q.Where("? < ?", bun.Ident("delivery.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only items *NEWER* than the given min ID.
+		q = q.Where("? > ?", bun.Ident("delivery.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("delivery.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("delivery.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(deliveries)
+	}
+
+	return deliveries, nil
+}
+
+func (d *deliveryDB) GetDueDeliveryInboxURIs(ctx context.Context, now time.Time) ([]string, error) {
+	inboxURIs := []string{}
+
+	if err := d.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		ColumnExpr("DISTINCT ?", bun.Ident("delivery.inbox_uri")).
+		Where("? IS NULL", bun.Ident("delivery.failed_at")).
+		Where("? <= ?", bun.Ident("delivery.next_attempt_at"), now).
+		Scan(ctx, &inboxURIs); err != nil {
+		return nil, err
+	}
+
+	return inboxURIs, nil
+}
+
+func (d *deliveryDB) GetPendingDeliveriesForInbox(ctx context.Context, inboxURI string, limit int) ([]*gtsmodel.Delivery, error) {
+	deliveries := []*gtsmodel.Delivery{}
+
+	q := d.db.
+		NewSelect().
+		Model(&deliveries).
+		Where("? = ?", bun.Ident("delivery.inbox_uri"), inboxURI).
+		Where("? IS NULL", bun.Ident("delivery.failed_at")).
+		OrderExpr("? ASC", bun.Ident("delivery.id"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (d *deliveryDB) PutDeliveries(ctx context.Context, deliveries ...*gtsmodel.Delivery) error {
+	if len(deliveries) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	_, err := d.db.
+		NewInsert().
+		Model(&deliveries).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) UpdateDelivery(ctx context.Context, delivery *gtsmodel.Delivery, columns ...string) error {
+	// Update the delivery's last-updated
+	delivery.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.
+		NewUpdate().
+		Model(delivery).
+		Where("? = ?", bun.Ident("delivery.id"), delivery.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeleteDeliveryByID(ctx context.Context, id string) error {
+	_, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		Where("? = ?", bun.Ident("delivery.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type DeliveryTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DeliveryTestSuite) newDelivery(inboxURI string, nextAttemptAt time.Time) *gtsmodel.Delivery {
+	return &gtsmodel.Delivery{
+		ID:            id.NewULID(),
+		PubKeyID:      suite.testAccounts["local_account_1"].PublicKeyURI,
+		InboxURI:      inboxURI,
+		Data:          []byte(`{"type":"Create"}`),
+		NextAttemptAt: nextAttemptAt,
+	}
+}
+
+func (suite *DeliveryTestSuite) TestDeliveryQueue() {
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		inbox1 = "https://example.org/users/someone/inbox"
+		inbox2 = "https://example.org/users/someone_else/inbox"
+	)
+
+	// Queue two due deliveries to inbox1,
+	// and one not-yet-due delivery to inbox2.
+	d1 := suite.newDelivery(inbox1, now)
+	d2 := suite.newDelivery(inbox1, now)
+	d3 := suite.newDelivery(inbox2, now.Add(time.Hour))
+	if err := suite.state.DB.PutDeliveries(ctx, d1, d2, d3); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only inbox1 should be due.
+	inboxURIs, err := suite.state.DB.GetDueDeliveryInboxURIs(ctx, now.Add(time.Minute))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{inbox1}, inboxURIs)
+
+	// Pending deliveries for inbox1 come oldest first.
+	pending, err := suite.state.DB.GetPendingDeliveriesForInbox(ctx, inbox1, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(pending, 2) {
+		suite.Equal(d1.ID, pending[0].ID)
+		suite.Equal(d2.ID, pending[1].ID)
+		suite.Equal(d1.Data, pending[0].Data)
+	}
+
+	// Give up on d1.
+	d1.Attempts = 3
+	d1.FailedAt = now
+	d1.LastError = "oh no"
+	if err := suite.state.DB.UpdateDelivery(ctx, d1, "attempts", "failed_at", "last_error"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbDelivery, err := suite.state.DB.GetDeliveryByID(ctx, d1.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbDelivery.Failed())
+	suite.Equal(3, dbDelivery.Attempts)
+	suite.Equal("oh no", dbDelivery.LastError)
+
+	// Failed delivery is no longer pending.
+	pending, err = suite.state.DB.GetPendingDeliveriesForInbox(ctx, inbox1, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(pending, 1) {
+		suite.Equal(d2.ID, pending[0].ID)
+	}
+
+	// Check status filters, newest first.
+	for _, test := range []struct {
+		status   gtsmodel.DeliveryStatus
+		inboxURI string
+		expect   []string
+	}{
+		{gtsmodel.DeliveryStatusAny, "", []string{d3.ID, d2.ID, d1.ID}},
+		{gtsmodel.DeliveryStatusPending, "", []string{d3.ID, d2.ID}},
+		{gtsmodel.DeliveryStatusFailed, "", []string{d1.ID}},
+		{gtsmodel.DeliveryStatusPending, inbox1, []string{d2.ID}},
+	} {
+		deliveries, err := suite.state.DB.GetDeliveries(ctx,
+			test.status,
+			test.inboxURI,
+			&paging.Page{Limit: 10},
+		)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		ids := make([]string, 0, len(deliveries))
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		suite.Equal(test.expect, ids, test.status.String())
+	}
+
+	// Drop d2.
+	if err := suite.state.DB.DeleteDeliveryByID(ctx, d2.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pending, err = suite.state.DB.GetPendingDeliveriesForInbox(ctx, inbox1, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(pending)
+}
+
+func TestDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTestSuite))
+}
@@ -153,6 +153,11 @@ func (f *filterDB) populateFilter(ctx context.Context, filter *gtsmodel.Filter)
 }
 
 func (f *filterDB) PutFilter(ctx context.Context, filter *gtsmodel.Filter) error {
+	// Pre-compile filter keyword regular expressions.
+	if err := compileFilterKeywords(filter.Keywords); err != nil {
+		return err
+	}
+
 	// Update database.
 	if err := f.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		if _, err := tx.
@@ -210,6 +215,11 @@ func (f *filterDB) UpdateFilter(
 		filterStatus.UpdatedAt = updatedAt
 	}
 
+	// Pre-compile filter keyword regular expressions.
+	if err := compileFilterKeywords(filter.Keywords); err != nil {
+		return err
+	}
+
 	// If we're updating by column, ensure "updated_at" is included.
 	if len(filterColumns) > 0 {
 		filterColumns = append(filterColumns, "updated_at")
@@ -253,7 +263,7 @@ func (f *filterDB) UpdateFilter(
 			if _, err := tx.
 				NewDelete().
 				Model((*gtsmodel.FilterKeyword)(nil)).
-				Where("? = (?)", bun.Ident("id"), bun.In(deleteFilterKeywordIDs)).
+				Where("? IN (?)", bun.Ident("id"), bun.In(deleteFilterKeywordIDs)).
 				Exec(ctx); err != nil {
 				return err
 			}
@@ -263,7 +273,7 @@ func (f *filterDB) UpdateFilter(
 			if _, err := tx.
 				NewDelete().
 				Model((*gtsmodel.FilterStatus)(nil)).
-				Where("? = (?)", bun.Ident("id"), bun.In(deleteFilterStatusIDs)).
+				Where("? IN (?)", bun.Ident("id"), bun.In(deleteFilterStatusIDs)).
 				Exec(ctx); err != nil {
 				return err
 			}
@@ -39,7 +39,16 @@ func (f *filterDB) GetFilterKeywordByID(ctx context.Context, id string) (*gtsmod
 				Model(&filterKeyword).
 				Where("? = ?", bun.Ident("id"), id).
 				Scan(ctx)
-			return &filterKeyword, err
+			if err != nil {
+				return nil, err
+			}
+
+			// Pre-compile filter keyword regular expression.
+			if err := filterKeyword.Compile(); err != nil {
+				return nil, gtserror.Newf("error compiling filter keyword regex: %w", err)
+			}
+
+			return &filterKeyword, nil
 		},
 		id,
 	)
@@ -108,6 +117,12 @@ func (f *filterDB) getFilterKeywords(ctx context.Context, idColumn string, id st
 				Scan(ctx); err != nil {
 				return nil, err
 			}
+
+			// Pre-compile filter keyword regular expressions.
+			if err := compileFilterKeywords(uncachedFilterKeywords); err != nil {
+				return nil, err
+			}
+
 			return uncachedFilterKeywords, nil
 		},
 	)
@@ -142,6 +157,11 @@ func (f *filterDB) getFilterKeywords(ctx context.Context, idColumn string, id st
 }
 
 func (f *filterDB) PutFilterKeyword(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword) error {
+	// Pre-compile filter keyword regular expression.
+	if err := filterKeyword.Compile(); err != nil {
+		return gtserror.Newf("error compiling filter keyword regex: %w", err)
+	}
+
 	return f.state.Caches.GTS.FilterKeyword.Store(filterKeyword, func() error {
 		_, err := f.db.
 			NewInsert().
@@ -157,6 +177,11 @@ func (f *filterDB) UpdateFilterKeyword(ctx context.Context, filterKeyword *gtsmo
 		columns = append(columns, "updated_at")
 	}
 
+	// Pre-compile filter keyword regular expression.
+	if err := filterKeyword.Compile(); err != nil {
+		return gtserror.Newf("error compiling filter keyword regex: %w", err)
+	}
+
 	return f.state.Caches.GTS.FilterKeyword.Store(filterKeyword, func() error {
 		_, err := f.db.
 			NewUpdate().
@@ -181,3 +206,13 @@ func (f *filterDB) DeleteFilterKeywordByID(ctx context.Context, id string) error
 
 	return nil
 }
+
+// compileFilterKeywords pre-compiles the regular expression of each given filter keyword.
+func compileFilterKeywords(filterKeywords []*gtsmodel.FilterKeyword) error {
+	for _, filterKeyword := range filterKeywords {
+		if err := filterKeyword.Compile(); err != nil {
+			return gtserror.Newf("error compiling filter keyword %s regex: %w", filterKeyword.ID, err)
+		}
+	}
+	return nil
+}
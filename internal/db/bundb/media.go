@@ -111,14 +111,40 @@ func (m *mediaDB) UpdateAttachment(ctx context.Context, media *gtsmodel.MediaAtt
 		columns = append(columns, "updated_at")
 	}
 
-	return m.state.Caches.GTS.Media.Store(media, func() error {
+	if err := m.state.Caches.GTS.Media.Store(media, func() error {
 		_, err := m.db.NewUpdate().
 			Model(media).
 			Where("? = ?", bun.Ident("media_attachment.id"), media.ID).
 			Column(columns...).
 			Exec(ctx)
 		return err
-	})
+	}); err != nil {
+		return err
+	}
+
+	if media.StatusID == "" ||
+		!needsReindex(columns, []string{"description"}) {
+		// No indexed status
+		// text to update.
+		return nil
+	}
+
+	// Media descriptions are included in the status
+	// search index, so update them for the status.
+	status, err := m.state.DB.GetStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.StatusID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Status not stored yet,
+			// will be indexed on put.
+			return nil
+		}
+		return err
+	}
+
+	return updateStatusSearchMedia(ctx, m.db, status)
 }
 
 func (m *mediaDB) DeleteAttachment(ctx context.Context, id string) error {
@@ -273,6 +299,21 @@ func (m *mediaDB) GetRemoteAttachments(ctx context.Context, page *paging.Page) (
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
 
+func (m *mediaDB) GetAttachmentsByProcessingStatus(ctx context.Context, status gtsmodel.ProcessingStatus) ([]*gtsmodel.MediaAttachment, error) {
+	var attachmentIDs []string
+
+	if err := m.db.NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("processing = ?", status).
+		Order("id ASC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
 func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error) {
 	attachmentIDs := make([]string, 0, limit)
 
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type MediaTestSuite struct {
@@ -50,6 +51,27 @@ func (suite *MediaTestSuite) TestGetCachedAttachmentsOlderThan() {
 	suite.Len(attachments, 3)
 }
 
+func (suite *MediaTestSuite) TestGetAttachmentsByProcessingStatus() {
+	ctx := context.Background()
+
+	// No test attachments are left processing.
+	attachments, err := suite.db.GetAttachmentsByProcessingStatus(ctx, gtsmodel.ProcessingStatusProcessing)
+	suite.NoError(err)
+	suite.Empty(attachments)
+
+	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_2"]
+	testAttachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachments, err = suite.db.GetAttachmentsByProcessingStatus(ctx, gtsmodel.ProcessingStatusProcessing)
+	suite.NoError(err)
+	if suite.Len(attachments, 1) {
+		suite.Equal(testAttachment.ID, attachments[0].ID)
+	}
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
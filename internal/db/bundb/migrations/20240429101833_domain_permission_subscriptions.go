@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Domain permission subscriptions table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.DomainPermissionSubscription{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index subscriptions by permission type + priority,
+			// and existing domain permissions by subscription ID.
+			for _, index := range []struct {
+				table   string
+				name    string
+				columns []string
+			}{
+				{
+					table:   "domain_permission_subscriptions",
+					name:    "domain_permission_subscriptions_permission_type_priority_idx",
+					columns: []string{"permission_type", "priority"},
+				},
+				{
+					table:   "domain_blocks",
+					name:    "domain_blocks_subscription_id_idx",
+					columns: []string{"subscription_id"},
+				},
+				{
+					table:   "domain_allows",
+					name:    "domain_allows_subscription_id_idx",
+					columns: []string{"subscription_id"},
+				},
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table(index.table).
+					Index(index.name).
+					Column(index.columns...).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
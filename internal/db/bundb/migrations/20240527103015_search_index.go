@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// searchIndex describes a full-text search index
+// table, and the text columns it contains, in
+// descending order of search weight.
+type searchIndex struct {
+	table   string
+	idCol   string
+	textCol []string
+}
+
+var searchIndexes = []searchIndex{
+	{
+		table:   "account_search",
+		idCol:   "account_id",
+		textCol: []string{"username", "display_name", "note"},
+	},
+	{
+		table:   "status_search",
+		idCol:   "status_id",
+		textCol: []string{"content", "content_warning", "media_descriptions"},
+	},
+}
+
+// searchIndexSQLite returns statements to create the given search index on
+// SQLite. The plaintext is stored in a regular table, with an integer primary
+// key that's used as the rowid of an external content FTS5 table, which is
+// kept in sync with the plaintext table using triggers.
+//
+// See: https://www.sqlite.org/fts5.html#external_content_tables
+func searchIndexSQLite(idx searchIndex) []string {
+	var (
+		fts     = idx.table + "_fts"
+		cols    = `"` + strings.Join(idx.textCol, `", "`) + `"`
+		newCols = `new."` + strings.Join(idx.textCol, `", new."`) + `"`
+		oldCols = `old."` + strings.Join(idx.textCol, `", old."`) + `"`
+		colDefs = `"` + strings.Join(idx.textCol, `" TEXT, "`) + `" TEXT`
+	)
+
+	insert := fmt.Sprintf(`INSERT INTO "%s" ("rowid", %s) VALUES (new."id", %s);`, fts, cols, newCols)
+	del := fmt.Sprintf(`INSERT INTO "%s" ("%s", "rowid", %s) VALUES ('delete', old."id", %s);`, fts, fts, cols, oldCols)
+
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" ("id" INTEGER PRIMARY KEY, "%s" CHAR(26) NOT NULL UNIQUE, %s)`, idx.table, idx.idCol, colDefs),
+		fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS "%s" USING fts5(%s, content='%s', content_rowid='id', tokenize='unicode61 remove_diacritics 2')`, fts, cols, idx.table),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS "%s_ai" AFTER INSERT ON "%s" BEGIN %s END`, idx.table, idx.table, insert),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS "%s_ad" AFTER DELETE ON "%s" BEGIN %s END`, idx.table, idx.table, del),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS "%s_au" AFTER UPDATE ON "%s" BEGIN %s %s END`, idx.table, idx.table, del, insert),
+	}
+}
+
+// searchIndexPG returns statements to create the given search index on
+// Postgres. The plaintext is stored alongside a generated tsvector column,
+// with each text column given a successively lower weight, and a GIN index.
+func searchIndexPG(idx searchIndex) []string {
+	var (
+		weights = []string{"A", "B", "C", "D"}
+		colDefs = make([]string, len(idx.textCol))
+		vectors = make([]string, len(idx.textCol))
+	)
+
+	for i, col := range idx.textCol {
+		colDefs[i] = fmt.Sprintf(`"%s" TEXT`, col)
+		vectors[i] = fmt.Sprintf(`setweight(to_tsvector('simple', coalesce("%s", '')), '%s')`, col, weights[i])
+	}
+
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" ("%s" CHAR(26) NOT NULL PRIMARY KEY, %s, "document" TSVECTOR GENERATED ALWAYS AS (%s) STORED)`,
+			idx.table, idx.idCol, strings.Join(colDefs, ", "), strings.Join(vectors, " || ")),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_document_idx" ON "%s" USING GIN ("document")`, idx.table, idx.table),
+	}
+}
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add indexable column to accounts table.
+			if _, err := tx.ExecContext(ctx,
+				"ALTER TABLE ? ADD COLUMN ? BOOLEAN DEFAULT false",
+				bun.Ident("accounts"), bun.Ident("indexable"),
+			); err != nil {
+				e := err.Error()
+				if !(strings.Contains(e, "already exists") ||
+					strings.Contains(e, "duplicate column name") ||
+					strings.Contains(e, "SQLSTATE 42701")) {
+					return err
+				}
+			}
+
+			// Create full-text search index tables.
+			for _, idx := range searchIndexes {
+				var stmts []string
+
+				switch d := tx.Dialect().Name(); d {
+				case dialect.SQLite:
+					stmts = searchIndexSQLite(idx)
+				case dialect.PG:
+					stmts = searchIndexPG(idx)
+				default:
+					log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+				}
+
+				for _, stmt := range stmts {
+					if _, err := tx.ExecContext(ctx, stmt); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Existing statuses aren't indexed by this migration, as
+			// on larger instances that could take a very long time.
+			hasStatuses, err := tx.
+				NewSelect().
+				Table("statuses").
+				Limit(1).
+				Exists(ctx)
+			if err != nil {
+				return err
+			}
+
+			if hasStatuses {
+				log.Warn(ctx, "created full-text search index; existing accounts "+
+					"and statuses remain searchable but won't be ranked by relevance "+
+					"until you run 'gotosocial admin search reindex' while GoToSocial is stopped")
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, col := range []struct {
+				model  any
+				column string
+				typ    string
+			}{
+				{&gtsmodel.Status{}, "quote_of_id", "CHAR(26)"},
+				{&gtsmodel.Status{}, "quote_of_uri", "VARCHAR"},
+				{&gtsmodel.Status{}, "quote_of_account_id", "CHAR(26)"},
+				{&gtsmodel.Status{}, "quotable", "BOOLEAN NOT NULL DEFAULT true"},
+				{&gtsmodel.ScheduledStatus{}, "quote_id", "CHAR(26)"},
+				{&gtsmodel.ScheduledStatus{}, "quotable", "BOOLEAN"},
+			} {
+				// Add the new column, ignoring
+				// errors if it already exists.
+				if _, err := tx.
+					NewAddColumn().
+					Model(col.model).
+					ColumnExpr("? "+col.typ, bun.Ident(col.column)).
+					Exec(ctx); err != nil {
+					e := err.Error()
+					if !(strings.Contains(e, "already exists") ||
+						strings.Contains(e, "duplicate column name") ||
+						strings.Contains(e, "SQLSTATE 42701")) {
+						return err
+					}
+				}
+			}
+
+			// Index quotes so we can
+			// look up quotes of a status.
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Status{}).
+				Index("statuses_quote_of_id_idx").
+				Column("quote_of_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
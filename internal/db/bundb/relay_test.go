@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type RelayTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RelayTestSuite) TestPutGetUpdateDeleteRelay() {
+	ctx := context.Background()
+
+	relay := &gtsmodel.Relay{
+		ID:        id.NewULID(),
+		InboxURI:  "https://relay.example.org/inbox",
+		FollowURI: "http://localhost:8080/users/localhost:8080/follow/01HY5G2PXK0V9W4MS1X7SA4ZQ2",
+		State:     gtsmodel.RelayStatePending,
+	}
+
+	if err := suite.state.DB.PutRelay(ctx, relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Fetch by follow URI.
+	dbRelay, err := suite.state.DB.GetRelayByFollowURI(ctx, relay.FollowURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relay.ID, dbRelay.ID)
+	suite.Equal(gtsmodel.RelayStatePending, dbRelay.State)
+
+	// Relay should be in the cached list.
+	relays, err := suite.state.DB.GetRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(relays, 1) {
+		suite.False(relays[0].Accepted())
+	}
+
+	// Accept the relay; cached list should
+	// be invalidated and reflect the update.
+	relay.State = gtsmodel.RelayStateAccepted
+	relay.ActorURI = "https://relay.example.org/actor"
+	if err := suite.state.DB.UpdateRelay(ctx, relay, "state", "actor_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relays, err = suite.state.DB.GetRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(relays, 1) {
+		suite.True(relays[0].Accepted())
+		suite.Equal(relay.ActorURI, relays[0].ActorURI)
+	}
+
+	// Delete the relay.
+	if err := suite.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.state.DB.GetRelayByInboxURI(ctx, relay.InboxURI)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	relays, err = suite.state.DB.GetRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(relays)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
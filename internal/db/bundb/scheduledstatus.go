@@ -0,0 +1,263 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	var status gtsmodel.ScheduledStatus
+
+	if err := s.db.
+		NewSelect().
+		Model(&status).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &status, nil
+	}
+
+	if err := s.PopulateScheduledStatus(ctx, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesForAccount(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.ScheduledStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Column("scheduled_status.id").
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only items *OLDER* than the given max ID.
+		q = q.Where("? < ?", bun.Ident("scheduled_status.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only items *NEWER* than the given min ID.
+		q = q.Where("? > ?", bun.Ident("scheduled_status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("scheduled_status.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("scheduled_status.id"))
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.getScheduledStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *scheduledStatusDB) CountScheduledStatusesForAccount(
+	ctx context.Context,
+	accountID string,
+	scheduledFrom time.Time,
+	scheduledTo time.Time,
+) (int, error) {
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	if !scheduledFrom.IsZero() {
+		q = q.Where("? >= ?", bun.Ident("scheduled_status.scheduled_at"), scheduledFrom)
+	}
+
+	if !scheduledTo.IsZero() {
+		q = q.Where("? < ?", bun.Ident("scheduled_status.scheduled_at"), scheduledTo)
+	}
+
+	return q.Count(ctx)
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error) {
+	var statusIDs []string
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Column("scheduled_status.id").
+		OrderExpr("? ASC", bun.Ident("scheduled_status.scheduled_at")).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getScheduledStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *scheduledStatusDB) getScheduledStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.ScheduledStatus, error) {
+	statuses := make([]*gtsmodel.ScheduledStatus, 0, len(ids))
+
+	for _, id := range ids {
+		// Attempt to fetch scheduled status from DB.
+		status, err := s.GetScheduledStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting scheduled status %q: %v", id, err)
+			continue
+		}
+
+		// Append scheduled status to return slice.
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s *scheduledStatusDB) PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if status.Account == nil {
+		// Scheduled status author is not set, fetch from database.
+		status.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status author: %w", err)
+		}
+	}
+
+	if status.Application == nil {
+		// Scheduled status application is not set, fetch from database.
+		status.Application, err = s.state.DB.GetApplicationByID(
+			gtscontext.SetBarebones(ctx),
+			status.ApplicationID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating scheduled status application: %w", err)
+		}
+	}
+
+	if !status.AttachmentsPopulated() {
+		// Scheduled status attachments are out-of-date with IDs, repopulate.
+		status.MediaAttachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx, // these are already barebones
+			status.MediaIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status attachments: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	_, err := s.db.
+		NewInsert().
+		Model(status).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error {
+	status.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := s.db.
+		NewUpdate().
+		Model(status).
+		Column(columns...).
+		Where("? = ?", bun.Ident("scheduled_status.id"), status.ID).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
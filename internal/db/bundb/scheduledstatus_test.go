@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutGetDeleteScheduledStatuses() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	testApplication := suite.testApplications["application_1"]
+	now := time.Now()
+
+	// Put three scheduled statuses,
+	// an hour and a day apart.
+	for _, at := range []time.Time{
+		now.Add(time.Hour),
+		now.Add(2 * time.Hour),
+		now.Add(48 * time.Hour),
+	} {
+		if err := suite.db.PutScheduledStatus(ctx, &gtsmodel.ScheduledStatus{
+			ID:            id.NewULID(),
+			AccountID:     testAccount.ID,
+			ScheduledAt:   at,
+			Text:          "hello world",
+			Visibility:    gtsmodel.VisibilityPublic,
+			ApplicationID: testApplication.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	scheduled, err := suite.db.GetScheduledStatusesForAccount(ctx, testAccount.ID, &paging.Page{Limit: 2})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(scheduled, 2)
+	suite.Equal(testAccount.ID, scheduled[0].Account.ID)
+
+	all, err := suite.db.GetAllScheduledStatuses(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(all, 3)
+	suite.True(all[0].ScheduledAt.Before(all[1].ScheduledAt))
+
+	count, err := suite.db.CountScheduledStatusesForAccount(ctx, testAccount.ID, now, now.Add(24*time.Hour))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, count)
+
+	// Delete the scheduled statuses, there should be none left.
+	if err := suite.db.DeleteScheduledStatusesByAccountID(ctx, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountScheduledStatusesForAccount(ctx, testAccount.ID, time.Time{}, time.Time{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(count)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
@@ -20,7 +20,9 @@ package bundb
 import (
 	"context"
 	"strings"
+	"unicode"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -29,33 +31,28 @@ import (
 	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// Text searches for accounts and statuses are performed using
+// a full-text search index: an FTS5 virtual table on SQLite, or
+// a tsvector column with a GIN index on Postgres. Results of
+// these searches are ordered by relevance rather than by ID, so
+// the 'offset' parameter is used to page through them, while
+// maxID and minID only restrict the range of returned items.
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
+// Anything that the older LIKE-based search would have found
+// (accounts, and statuses by or in reply to the searcher, that
+// contain the query text anywhere) is still included, ranked
+// after index matches. This way nothing goes missing from search
+// results while the index is incomplete, eg., after upgrading
+// and before 'gotosocial admin search reindex' has been run.
 //
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Other searches (eg., for tags or by username prefix) are still
+// ordered by ID, and ignore 'offset' in favour of maxID / minID,
+// since for SQLite or Postgres LIKE queries each higher offset
+// requires calculating all of the previous offsets as well
+// *within the execution time of the query*. The exception is
+// status searches using only search operators (no text), which
+// honour 'offset' too, so callers can page through all status
+// searches in the same way.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
@@ -64,11 +61,14 @@ type searchDB struct {
 // Query example (SQLite):
 //
 //	SELECT "account"."id" FROM "accounts" AS "account"
+//	LEFT JOIN "account_search" ON "account_search"."account_id" = "account"."id"
+//	LEFT JOIN (SELECT "rowid" AS "id", bm25("account_search_fts", 3.0, 2.0, 1.0) AS "score" FROM "account_search_fts"
+//	WHERE ("account_search_fts" MATCH '{username display_name} : ("turtle"*)')) AS "account_match"
+//	ON "account_match"."id" = "account_search"."id"
 //	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
 //	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
-//	ORDER BY "account"."id" DESC LIMIT 10
+//	AND (("account_match"."id" IS NOT NULL) OR ((SELECT "account"."username" || COALESCE("account"."display_name", '') AS "account_text") LIKE '%turtle%' ESCAPE '\'))
+//	ORDER BY "account_match"."id" IS NULL, "account_match"."score", "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
 	accountID string,
@@ -88,6 +88,7 @@ func (s *searchDB) SearchForAccounts(
 	var (
 		accountIDs  = make([]string, 0, limit)
 		frontToBack = true
+		ranked      = false
 	)
 
 	q := s.db.
@@ -136,10 +137,19 @@ func (s *searchDB) SearchForAccounts(
 		q = whereStartsLike(q, bun.Ident("account.username"), query)
 	} else {
 		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
+		// Search the full-text index for
+		// words starting with query terms.
+		terms := searchTerms(query)
+		if len(terms) == 0 {
+			// Nothing
+			// to match.
+			return nil, nil
+		}
+
+		// Only search account notes
+		// for accounts being followed.
+		q = s.matchAccounts(q, terms, query, following)
+		ranked = true
 	}
 
 	if limit > 0 {
@@ -147,7 +157,13 @@ func (s *searchDB) SearchForAccounts(
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	if ranked {
+		// Already ordered by relevance, break
+		// ties by ID and page using offset.
+		q = q.
+			Order("account.id DESC").
+			Offset(offset)
+	} else if frontToBack {
 		// Page down.
 		q = q.Order("account.id DESC")
 	} else {
@@ -166,7 +182,7 @@ func (s *searchDB) SearchForAccounts(
 	// If we're paging up, we still want accounts
 	// to be sorted by ID desc, so reverse ids slice.
 	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
+	if !ranked && !frontToBack {
 		for l, r := 0, len(accountIDs)-1; l < r; l, r = l+1, r-1 {
 			accountIDs[l], accountIDs[r] = accountIDs[r], accountIDs[l]
 		}
@@ -198,6 +214,94 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
+// matchAccounts joins the given query on the account full-text
+// search index, selecting only accounts where each of the given
+// terms matches the start of words in the account's username or
+// display name (or note, if includeNote), ordered by relevance.
+// Accounts containing the whole query text anywhere in the same
+// fields are also selected, ordered after those matches.
+func (s *searchDB) matchAccounts(
+	q *bun.SelectQuery,
+	terms [][]string,
+	text string,
+	includeNote bool,
+) *bun.SelectQuery {
+	q = q.Join(
+		"LEFT JOIN ? ON ? = ?",
+		bun.Ident("account_search"),
+		bun.Ident("account_search.account_id"),
+		bun.Ident("account.id"),
+	)
+
+	// Also select accounts containing the
+	// text, in case they're not indexed yet.
+	like := func(q *bun.SelectQuery) *bun.SelectQuery {
+		return whereLike(q, s.accountText(includeNote), likeText(text))
+	}
+
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		match := ftsMatch(terms, true)
+		if !includeNote {
+			// Restrict match to username
+			// and display name columns.
+			match = "{username display_name} : (" + match + ")"
+		}
+
+		return q.
+			Join(
+				"LEFT JOIN (?) AS ? ON ? = ?",
+				s.ftsMatches("account_search_fts", match),
+				bun.Ident("account_match"),
+				bun.Ident("account_match.id"),
+				bun.Ident("account_search.id"),
+			).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NOT NULL", bun.Ident("account_match.id")).
+					WhereGroup(" OR ", like)
+			}).
+			OrderExpr("? IS NULL", bun.Ident("account_match.id")).
+			OrderExpr("?", bun.Ident("account_match.score"))
+
+	case dialect.PG:
+		// Username and display name
+		// are weighted A and B, note C.
+		weights := "ABC"
+		if !includeNote {
+			weights = "AB"
+		}
+
+		tsquery := tsQuery(terms, true, weights)
+		return q.
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? @@ to_tsquery('simple', ?)", bun.Ident("account_search.document"), tsquery).
+					WhereGroup(" OR ", like)
+			}).
+			OrderExpr("(? @@ to_tsquery('simple', ?)) IS TRUE DESC", bun.Ident("account_search.document"), tsquery).
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC NULLS LAST", bun.Ident("account_search.document"), tsquery)
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// ftsMatches returns a subquery that selects the rowid (as "id")
+// and bm25 score (as "score", lower is better) of rows in the
+// given SQLite FTS5 table matching the given MATCH expression,
+// with columns weighted 3, 2 and 1 in the order they're declared.
+func (s *searchDB) ftsMatches(table string, match string) *bun.SelectQuery {
+	return s.db.
+		NewSelect().
+		TableExpr("?", bun.Ident(table)).
+		ColumnExpr("? AS ?", bun.Ident("rowid"), bun.Ident("id")).
+		ColumnExpr("bm25(?, 3.0, 2.0, 1.0) AS ?", bun.Ident(table), bun.Ident("score")).
+		Where("? MATCH ?", bun.Ident(table), match)
+}
+
 // accountText returns a subquery that selects a concatenation
 // of account username and display name as "account_text". If
 // `following` is true, then account note will also be included
@@ -257,17 +361,24 @@ func (s *searchDB) accountText(following bool) *bun.SelectQuery {
 
 // Query example (SQLite):
 //
-//	SELECT "status"."id"
-//	FROM "statuses" AS "status"
+//	SELECT "status"."id" FROM "statuses" AS "status"
+//	LEFT JOIN "status_search" ON "status_search"."status_id" = "status"."id"
+//	LEFT JOIN (SELECT "rowid" AS "id", bm25("status_search_fts", 3.0, 2.0, 1.0) AS "score" FROM "status_search_fts"
+//	WHERE ("status_search_fts" MATCH '"hello"')) AS "status_match"
+//	ON "status_match"."id" = "status_search"."id"
 //	WHERE ("status"."boost_of_id" IS NULL)
-//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')
+//	OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')
+//	OR (("status"."visibility" = 'public') AND ("status"."account_id" IN (SELECT "account"."id" FROM "accounts" AS "account" WHERE ("account"."indexable" = TRUE)))))
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
-//	ORDER BY "status"."id" DESC LIMIT 10
+//	AND (("status_match"."id" IS NOT NULL)
+//	OR ((("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')))
+//	ORDER BY "status_match"."id" IS NULL, "status_match"."score", "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	accountID string,
-	query string,
+	query *db.StatusSearchQuery,
 	maxID string,
 	minID string,
 	limit int,
@@ -282,6 +393,8 @@ func (s *searchDB) SearchForStatuses(
 	var (
 		statusIDs   = make([]string, 0, limit)
 		frontToBack = true
+		terms       = searchTerms(query.Text)
+		ranked      = len(terms) > 0
 	)
 
 	q := s.db.
@@ -291,12 +404,18 @@ func (s *searchDB) SearchForStatuses(
 		Column("status.id").
 		// Ignore boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
-		// Select only statuses created by
-		// accountID or replying to accountID.
+		// Select only statuses created by accountID,
+		// replying to accountID, or public statuses
+		// by accounts that have opted into search.
 		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
 			return q.
 				Where("? = ?", bun.Ident("status.account_id"), accountID).
-				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID)
+				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID).
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+						Where("? IN (?)", bun.Ident("status.account_id"), s.indexableAccounts())
+				})
 		})
 
 	// Return only items with a LOWER id than maxID.
@@ -313,19 +432,46 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
+	if query.FromAccountID != "" {
+		// Select only statuses by given account.
+		q = q.Where("? = ?", bun.Ident("status.account_id"), query.FromAccountID)
+	}
+
+	if query.HasMedia {
+		// Select only statuses with attachments.
+		q = s.whereHasAttachments(q)
+	}
+
+	if !query.Before.IsZero() {
+		// Select only statuses created before given time.
+		q = q.Where("? < ?", bun.Ident("status.created_at"), query.Before)
+	}
+
+	if !query.After.IsZero() {
+		// Select only statuses created after given time.
+		q = q.Where("? > ?", bun.Ident("status.created_at"), query.After)
+	}
 
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+	if ranked {
+		// Search the full-text index
+		// for matches of query terms.
+		q = s.matchStatuses(q, terms, query.Text, accountID)
+	} else if text := likeText(query.Text); text != "" {
+		// No indexable terms in the
+		// text, so just look for it.
+		q = whereLike(q, s.statusText(), text)
+	}
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	if ranked {
+		// Already ordered by
+		// relevance, break ties.
+		q = q.Order("status.id DESC")
+	} else if frontToBack {
 		// Page down.
 		q = q.Order("status.id DESC")
 	} else {
@@ -333,6 +479,9 @@ func (s *searchDB) SearchForStatuses(
 		q = q.Order("status.id ASC")
 	}
 
+	// Skip results from previous pages.
+	q = q.Offset(offset)
+
 	if err := q.Scan(ctx, &statusIDs); err != nil {
 		return nil, err
 	}
@@ -344,7 +493,7 @@ func (s *searchDB) SearchForStatuses(
 	// If we're paging up, we still want statuses
 	// to be sorted by ID desc, so reverse ids slice.
 	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
+	if !ranked && !frontToBack {
 		for l, r := 0, len(statusIDs)-1; l < r; l, r = l+1, r-1 {
 			statusIDs[l], statusIDs[r] = statusIDs[r], statusIDs[l]
 		}
@@ -366,6 +515,107 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
+// indexableAccounts returns a subquery that selects only
+// IDs of accounts that have opted into full-text search.
+func (s *searchDB) indexableAccounts() *bun.SelectQuery {
+	return s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? = ?", bun.Ident("account.indexable"), true)
+}
+
+// whereHasAttachments selects only statuses
+// with at least one media attachment.
+func (s *searchDB) whereHasAttachments(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		switch d := s.db.Dialect().Name(); d {
+		case dialect.PG:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments"))
+		case dialect.SQLite:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != ''", bun.Ident("status.attachments")).
+				Where("? != 'null'", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments")).
+				Where("? != '[]'", bun.Ident("status.attachments"))
+		default:
+			log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+			return nil
+		}
+	})
+}
+
+// matchStatuses joins the given query on the status full-text
+// search index, selecting only statuses where each of the given
+// terms matches words in the status' content, content warning
+// or media descriptions, ordered by relevance. Statuses created
+// by or in reply to accountID that contain the whole query text
+// anywhere in their content or content warning are also selected,
+// ordered after those matches.
+func (s *searchDB) matchStatuses(
+	q *bun.SelectQuery,
+	terms [][]string,
+	text string,
+	accountID string,
+) *bun.SelectQuery {
+	q = q.Join(
+		"LEFT JOIN ? ON ? = ?",
+		bun.Ident("status_search"),
+		bun.Ident("status_search.status_id"),
+		bun.Ident("status.id"),
+	)
+
+	// Also select statuses of accountID containing
+	// the text, in case they're not indexed yet.
+	like := func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("status.account_id"), accountID).
+				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID)
+		})
+		return whereLike(q, s.statusText(), likeText(text))
+	}
+
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		match := ftsMatch(terms, false)
+		return q.
+			Join(
+				"LEFT JOIN (?) AS ? ON ? = ?",
+				s.ftsMatches("status_search_fts", match),
+				bun.Ident("status_match"),
+				bun.Ident("status_match.id"),
+				bun.Ident("status_search.id"),
+			).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NOT NULL", bun.Ident("status_match.id")).
+					WhereGroup(" OR ", like)
+			}).
+			OrderExpr("? IS NULL", bun.Ident("status_match.id")).
+			OrderExpr("?", bun.Ident("status_match.score"))
+
+	case dialect.PG:
+		tsquery := tsQuery(terms, false, "")
+		return q.
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? @@ to_tsquery('simple', ?)", bun.Ident("status_search.document"), tsquery).
+					WhereGroup(" OR ", like)
+			}).
+			OrderExpr("(? @@ to_tsquery('simple', ?)) IS TRUE DESC", bun.Ident("status_search.document"), tsquery).
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC NULLS LAST", bun.Ident("status_search.document"), tsquery)
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
 // statusText returns a subquery that selects a concatenation
 // of status content and content warning as "status_text".
 func (s *searchDB) statusText() *bun.SelectQuery {
@@ -394,6 +644,98 @@ func (s *searchDB) statusText() *bun.SelectQuery {
 	return statusText
 }
 
+// likeText returns the given query text with any
+// phrase quotes removed, for matching using LIKE.
+func likeText(query string) string {
+	return strings.TrimSpace(strings.ReplaceAll(query, `"`, ""))
+}
+
+// searchTerms splits the given query text into search terms,
+// each of which is a slice of one or more lowercase words to be
+// matched as a phrase. Sections of the text in double quotes
+// become one phrase, and all other words become a term of their
+// own. Words are split on anything that isn't a letter or number,
+// to (roughly) match how text is split up in the search index.
+func searchTerms(query string) [][]string {
+	var (
+		terms  [][]string
+		quoted bool
+	)
+
+	isSeparator := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}
+
+	for _, section := range strings.Split(query, `"`) {
+		words := strings.FieldsFunc(strings.ToLower(section), isSeparator)
+
+		switch {
+		case len(words) == 0:
+			// Nothing to add.
+		case quoted:
+			// Whole section is a phrase.
+			terms = append(terms, words)
+		default:
+			// Each word is its own term.
+			for _, word := range words {
+				terms = append(terms, []string{word})
+			}
+		}
+
+		// Quotes alternate.
+		quoted = !quoted
+	}
+
+	return terms
+}
+
+// ftsMatch returns an SQLite FTS5 MATCH expression that matches
+// all of the given search terms. If prefix is true, the last word
+// of each term may match the start of a longer word.
+//
+// See: https://www.sqlite.org/fts5.html#full_text_query_syntax
+func ftsMatch(terms [][]string, prefix bool) string {
+	phrases := make([]string, len(terms))
+	for i, words := range terms {
+		// Words contain only letters and numbers,
+		// so can be safely wrapped in double quotes.
+		phrases[i] = `"` + strings.Join(words, " ") + `"`
+		if prefix {
+			phrases[i] += "*"
+		}
+	}
+	return strings.Join(phrases, " AND ")
+}
+
+// tsQuery returns a Postgres tsquery string that matches all of
+// the given search terms. If prefix is true, the last word of each
+// term may match the start of a longer word. If weights is set,
+// words will only match text in columns with the given weights.
+//
+// See: https://www.postgresql.org/docs/current/datatype-textsearch.html#DATATYPE-TSQUERY
+func tsQuery(terms [][]string, prefix bool, weights string) string {
+	phrases := make([]string, len(terms))
+	for i, words := range terms {
+		lexemes := make([]string, len(words))
+		for j, word := range words {
+			// Words contain only letters and numbers,
+			// so need no quoting or escaping.
+			label := weights
+			if prefix && j == len(words)-1 {
+				label = "*" + label
+			}
+
+			if label != "" {
+				word += ":" + label
+			}
+
+			lexemes[j] = word
+		}
+		phrases[i] = strings.Join(lexemes, " <-> ")
+	}
+	return strings.Join(phrases, " & ")
+}
+
 // Query example (SQLite):
 //
 //	SELECT "tag"."id" FROM "tags" AS "tag"
@@ -23,6 +23,9 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type SearchTestSuite struct {
@@ -107,11 +110,106 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "hello"}, "", "", 10, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesIndexable() {
+	var (
+		ctx            = context.Background()
+		testAccount    = suite.testAccounts["local_account_1"]
+		indexedAccount = new(gtsmodel.Account)
+		query          = &db.StatusSearchQuery{Text: "turtles"}
+	)
+
+	// Public statuses of a non-indexable
+	// account should not be searchable.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, query, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Opt the account in to search.
+	*indexedAccount = *suite.testAccounts["local_account_2"]
+	indexedAccount.Indexable = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, indexedAccount, "indexable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now its public statuses can be found.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, query, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_2_status_1"].ID, statuses[0].ID)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesMediaDescription() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// "adorably" only appears in a media description.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "adorably"}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_1_status_4"].ID, statuses[0].ID)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesPhrase() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: `"little gif"`}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Same words in the wrong order.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: `"gif little"`}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOperators() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Statuses with media only.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{HasMedia: true}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Statuses from the admin account only. Only the
+	// status in reply to local_account_1 is visible,
+	// since admin account is not indexable.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{FromAccountID: suite.testAccounts["admin_account"].ID}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Statuses created after the start of 2022.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{After: testrig.TimeMustParse("2022-01-01T00:00:00Z")}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 3)
+
+	// Same again, but paged with an offset.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{After: testrig.TimeMustParse("2022-01-01T00:00:00Z")}, "", "", 10, 2)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Statuses created before the start of 2022, with text.
+	// Statuses where "hi" is a whole word come first, followed
+	// by statuses that only contain "hi" in other words.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{Text: "hi", Before: testrig.TimeMustParse("2022-01-01T00:00:00Z")}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 4) {
+		suite.ElementsMatch(
+			[]string{
+				suite.testStatuses["admin_account_status_3"].ID,
+				suite.testStatuses["local_account_2_status_5"].ID,
+			},
+			[]string{statuses[0].ID, statuses[1].ID},
+		)
+	}
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
@@ -0,0 +1,312 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+)
+
+// statusSearchEntry models one row of the "status_search" table,
+// which holds the plaintext of each status' searchable fields.
+//
+// The full-text index itself is kept up to date by the database:
+// on Postgres using a generated, weighted tsvector column, and on
+// SQLite using an external content FTS5 table kept in sync by
+// triggers. See the search index migration for details.
+type statusSearchEntry struct {
+	bun.BaseModel `bun:"table:status_search"`
+
+	StatusID          string `bun:"type:CHAR(26),nullzero,notnull"`
+	Content           string `bun:""`
+	ContentWarning    string `bun:""`
+	MediaDescriptions string `bun:""`
+}
+
+// accountSearchEntry models one row of the "account_search"
+// table, which holds the plaintext of each account's searchable
+// fields. See statusSearchEntry for more information.
+type accountSearchEntry struct {
+	bun.BaseModel `bun:"table:account_search"`
+
+	AccountID   string `bun:"type:CHAR(26),nullzero,notnull"`
+	Username    string `bun:""`
+	DisplayName string `bun:""`
+	Note        string `bun:""`
+}
+
+// statusSearchColumns are the status columns
+// which, when updated, require the status to
+// be reindexed in the full-text search index.
+var statusSearchColumns = []string{
+	"content",
+	"content_warning",
+	"attachments",
+}
+
+// accountSearchColumns are the account columns
+// which, when updated, require the account to
+// be reindexed in the full-text search index.
+var accountSearchColumns = []string{
+	"username",
+	"display_name",
+	"note",
+}
+
+// needsReindex returns whether an update of the given
+// columns (where none means all) touches any of the
+// given search columns.
+func needsReindex(columns []string, searchColumns []string) bool {
+	if len(columns) == 0 {
+		return true
+	}
+
+	for _, column := range columns {
+		if slices.Contains(searchColumns, column) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// putStatusSearch indexes the given status in the
+// full-text search index, replacing any existing entry.
+func putStatusSearch(ctx context.Context, tx bun.IDB, status *gtsmodel.Status) error {
+	if err := deleteStatusSearch(ctx, tx, status.ID); err != nil {
+		return err
+	}
+
+	if status.BoostOfID != "" {
+		// Boosts have no content
+		// of their own to index.
+		return nil
+	}
+
+	mediaDescriptions, err := getMediaDescriptions(ctx, tx, status.AttachmentIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.
+		NewInsert().
+		Model(&statusSearchEntry{
+			StatusID:          status.ID,
+			Content:           text.SanitizeToSearchText(status.Content),
+			ContentWarning:    text.SanitizeToSearchText(status.ContentWarning),
+			MediaDescriptions: mediaDescriptions,
+		}).
+		Exec(ctx)
+	return err
+}
+
+// updateStatusSearchMedia updates just the media descriptions
+// stored in the full-text search index for the given status.
+func updateStatusSearchMedia(ctx context.Context, tx bun.IDB, status *gtsmodel.Status) error {
+	mediaDescriptions, err := getMediaDescriptions(ctx, tx, status.AttachmentIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.
+		NewUpdate().
+		Table("status_search").
+		Set("? = ?", bun.Ident("media_descriptions"), mediaDescriptions).
+		Where("? = ?", bun.Ident("status_id"), status.ID).
+		Exec(ctx)
+	return err
+}
+
+// deleteStatusSearch removes the status with the
+// given ID from the full-text search index.
+func deleteStatusSearch(ctx context.Context, tx bun.IDB, statusID string) error {
+	_, err := tx.
+		NewDelete().
+		Table("status_search").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+// getMediaDescriptions returns the searchable text of
+// the descriptions of the given media attachments.
+func getMediaDescriptions(ctx context.Context, tx bun.IDB, attachmentIDs []string) (string, error) {
+	if len(attachmentIDs) == 0 {
+		return "", nil
+	}
+
+	var descriptions []string
+	if err := tx.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.description").
+		Where("? IN (?)", bun.Ident("media_attachment.id"), bun.In(attachmentIDs)).
+		Where("? IS NOT NULL", bun.Ident("media_attachment.description")).
+		Scan(ctx, &descriptions); err != nil {
+		return "", err
+	}
+
+	return text.SanitizeToSearchText(strings.Join(descriptions, " ")), nil
+}
+
+// putAccountSearch indexes the given account in the
+// full-text search index, replacing any existing entry.
+func putAccountSearch(ctx context.Context, tx bun.IDB, account *gtsmodel.Account) error {
+	if err := deleteAccountSearch(ctx, tx, account.ID); err != nil {
+		return err
+	}
+
+	_, err := tx.
+		NewInsert().
+		Model(&accountSearchEntry{
+			AccountID:   account.ID,
+			Username:    account.Username,
+			DisplayName: text.SanitizeToSearchText(account.DisplayName),
+			Note:        text.SanitizeToSearchText(account.Note),
+		}).
+		Exec(ctx)
+	return err
+}
+
+// deleteAccountSearch removes the account with the
+// given ID from the full-text search index.
+func deleteAccountSearch(ctx context.Context, tx bun.IDB, accountID string) error {
+	_, err := tx.
+		NewDelete().
+		Table("account_search").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+// searchIndexBatchSize is the number of accounts
+// or statuses reindexed per transaction when
+// rebuilding the full-text search index.
+const searchIndexBatchSize = 200
+
+func (s *searchDB) RebuildSearchIndex(ctx context.Context) error {
+	// Clear out the existing index.
+	for _, table := range []string{
+		"account_search",
+		"status_search",
+	} {
+		if _, err := s.db.
+			NewDelete().
+			Table(table).
+			Where("TRUE").
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	var (
+		accountsTotal int
+		maxAccountID  string
+	)
+
+	for {
+		var accounts []*gtsmodel.Account
+
+		// Select next batch of accounts, only
+		// including columns needed for the index.
+		if err := s.db.
+			NewSelect().
+			Model(&accounts).
+			Column("account.id", "account.username", "account.display_name", "account.note").
+			Where("? > ?", bun.Ident("account.id"), maxAccountID).
+			Order("account.id ASC").
+			Limit(searchIndexBatchSize).
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, account := range accounts {
+				if err := putAccountSearch(ctx, tx, account); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		accountsTotal += len(accounts)
+		maxAccountID = accounts[len(accounts)-1].ID
+	}
+
+	log.Infof(ctx, "indexed %d accounts", accountsTotal)
+
+	var (
+		statusesTotal int
+		maxStatusID   string
+	)
+
+	for {
+		var statuses []*gtsmodel.Status
+
+		// Select next batch of non-boost statuses,
+		// only including columns needed for the index.
+		if err := s.db.
+			NewSelect().
+			Model(&statuses).
+			Column("status.id", "status.content", "status.content_warning", "status.attachments").
+			Where("? > ?", bun.Ident("status.id"), maxStatusID).
+			Where("? IS NULL", bun.Ident("status.boost_of_id")).
+			Order("status.id ASC").
+			Limit(searchIndexBatchSize).
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, status := range statuses {
+				if err := putStatusSearch(ctx, tx, status); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		statusesTotal += len(statuses)
+		maxStatusID = statuses[len(statuses)-1].ID
+
+		if statusesTotal%(100*searchIndexBatchSize) == 0 {
+			log.Infof(ctx, "indexed %d statuses so far...", statusesTotal)
+		}
+	}
+
+	log.Infof(ctx, "indexed %d statuses", statusesTotal)
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -181,7 +182,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(11)
 	)
 
 	if status.Account == nil {
@@ -243,6 +244,32 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" {
+		if status.QuoteOf == nil {
+			// Quoted status is not set, fetch from database.
+			// The quoted status may since have been deleted,
+			// which is fine, it simply won't be populated.
+			status.QuoteOf, err = s.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteOfID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating quoted status: %w", err)
+			}
+		}
+
+		if status.QuoteOfAccount == nil {
+			// Quoted status author is not set, fetch from database.
+			status.QuoteOfAccount, err = s.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteOfAccountID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating quoted status author: %w", err)
+			}
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -383,9 +410,13 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 				}
 			}
 
-			// Finally, insert the status
-			_, err := tx.NewInsert().Model(status).Exec(ctx)
-			return err
+			// Insert the status.
+			if _, err := tx.NewInsert().Model(status).Exec(ctx); err != nil {
+				return err
+			}
+
+			// Finally, add it to the search index.
+			return putStatusSearch(ctx, tx, status)
 		})
 	})
 }
@@ -402,6 +433,36 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 		// as the cache does not attempt a mutex lock until AFTER hook.
 		//
 		return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if len(columns) == 0 || slices.Contains(columns, "emojis") {
+				// delete links between this status and any
+				// emojis it no longer uses (e.g. after an edit)
+				q := tx.
+					NewDelete().
+					TableExpr("? AS ?", bun.Ident("status_to_emojis"), bun.Ident("status_to_emoji")).
+					Where("? = ?", bun.Ident("status_to_emoji.status_id"), status.ID)
+				if len(status.EmojiIDs) > 0 {
+					q = q.Where("? NOT IN (?)", bun.Ident("status_to_emoji.emoji_id"), bun.In(status.EmojiIDs))
+				}
+				if _, err := q.Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			if len(columns) == 0 || slices.Contains(columns, "tags") {
+				// delete links between this status and any
+				// tags it no longer uses (e.g. after an edit)
+				q := tx.
+					NewDelete().
+					TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+					Where("? = ?", bun.Ident("status_to_tag.status_id"), status.ID)
+				if len(status.TagIDs) > 0 {
+					q = q.Where("? NOT IN (?)", bun.Ident("status_to_tag.tag_id"), bun.In(status.TagIDs))
+				}
+				if _, err := q.Exec(ctx); err != nil {
+					return err
+				}
+			}
+
 			// create links between this status and any emojis it uses
 			for _, i := range status.EmojiIDs {
 				if _, err := tx.
@@ -466,14 +527,22 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
-			// Finally, update the status
-			_, err := tx.
+			// Update the status.
+			if _, err := tx.
 				NewUpdate().
 				Model(status).
 				Column(columns...).
 				Where("? = ?", bun.Ident("status.id"), status.ID).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if needsReindex(columns, statusSearchColumns) {
+				// Finally, reindex searchable text.
+				return putStatusSearch(ctx, tx, status)
+			}
+
+			return nil
 		})
 	})
 }
@@ -526,6 +595,22 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// Remove the status
+		// from the search index.
+		if err := deleteStatusSearch(ctx, tx, id); err != nil {
+			return err
+		}
+
+		// Delete any historical
+		// edits of this status.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("status_edits"), bun.Ident("status_edit")).
+			Where("? = ?", bun.Ident("status_edit.status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
@@ -615,6 +700,65 @@ func (s *statusDB) CountStatusReplies(ctx context.Context, statusID string) (int
 	return len(statusIDs), err
 }
 
+func (s *statusDB) GetPendingReplies(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID).
+		Where("? = ?", bun.Ident("status.pending_approval"), true)
+
+	if maxID != "" {
+		// Return only items *OLDER* than the given max ID.
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only items *NEWER* than the given min ID.
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("status.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("status.id"))
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
 func (s *statusDB) getStatusReplyIDs(ctx context.Context, statusID string) ([]string, error) {
 	return s.state.Caches.GTS.InReplyToIDs.Load(statusID, func() ([]string, error) {
 		var statusIDs []string
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type statusEditDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *statusEditDB) GetStatusEditByID(ctx context.Context, id string) (*gtsmodel.StatusEdit, error) {
+	var edit gtsmodel.StatusEdit
+
+	if err := s.db.
+		NewSelect().
+		Model(&edit).
+		Where("? = ?", bun.Ident("status_edit.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if !gtscontext.Barebones(ctx) {
+		if err := s.PopulateStatusEdit(ctx, &edit); err != nil {
+			return nil, err
+		}
+	}
+
+	return &edit, nil
+}
+
+func (s *statusEditDB) GetStatusEditsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.StatusEdit, error) {
+	var edits []*gtsmodel.StatusEdit
+
+	if err := s.db.
+		NewSelect().
+		Model(&edits).
+		Where("? = ?", bun.Ident("status_edit.status_id"), statusID).
+		Order("status_edit.created_at ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		return edits, nil
+	}
+
+	// Populate the edits. Remove any that we can't populate from the return slice.
+	errs := gtserror.NewMultiError(len(edits))
+	edits = slices.DeleteFunc(edits, func(edit *gtsmodel.StatusEdit) bool {
+		if err := s.PopulateStatusEdit(ctx, edit); err != nil {
+			errs.Appendf("error populating status edit %s: %w", edit.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return edits, errs.Combine()
+}
+
+func (s *statusEditDB) PopulateStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) error {
+	var err error
+
+	if !edit.AttachmentsPopulated() {
+		// Edit attachments are out-of-date with IDs, repopulate.
+		//
+		// Note that attachments referenced by old versions
+		// of a status may since have been deleted (e.g. by
+		// an admin), in which case they'll simply be missing.
+		edit.Attachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx, // these are already barebones
+			edit.AttachmentIDs,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating status edit attachments: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *statusEditDB) PutStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) error {
+	_, err := s.db.
+		NewInsert().
+		Model(edit).
+		Exec(ctx)
+	return err
+}
+
+func (s *statusEditDB) DeleteStatusEditsByStatusID(ctx context.Context, statusID string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("status_edits"), bun.Ident("status_edit")).
+		Where("? = ?", bun.Ident("status_edit.status_id"), statusID).
+		Exec(ctx)
+	return err
+}
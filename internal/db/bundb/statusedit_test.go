@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusEditTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestPutGetStatusEdits() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["local_account_1_status_4"]
+
+	// Put two edits, newest first, to check ordering.
+	for _, createdAt := range []time.Time{
+		testStatus.CreatedAt.Add(time.Hour),
+		testStatus.CreatedAt,
+	} {
+		if err := suite.db.PutStatusEdit(ctx, &gtsmodel.StatusEdit{
+			ID:            id.NewULID(),
+			CreatedAt:     createdAt,
+			Content:       testStatus.Content,
+			Text:          testStatus.Text,
+			Language:      testStatus.Language,
+			Sensitive:     util.Ptr(false),
+			AttachmentIDs: testStatus.AttachmentIDs,
+			StatusID:      testStatus.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	edits, err := suite.db.GetStatusEditsByStatusID(ctx, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(edits, 2)
+	suite.True(edits[0].CreatedAt.Before(edits[1].CreatedAt))
+	for _, edit := range edits {
+		suite.True(edit.AttachmentsPopulated())
+	}
+
+	// Delete the edits, there should be none left.
+	if err := suite.db.DeleteStatusEditsByStatusID(ctx, testStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	edits, err = suite.db.GetStatusEditsByStatusID(ctx, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(edits)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
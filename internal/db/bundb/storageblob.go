@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type storageBlobDB struct {
+	db *bun.DB
+}
+
+func (s *storageBlobDB) GetStorageBlobRef(ctx context.Context, key string) (string, error) {
+	var hash string
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("storage_blob_refs"), bun.Ident("storage_blob_ref")).
+		Column("storage_blob_ref.hash").
+		Where("? = ?", bun.Ident("storage_blob_ref.key"), key).
+		Scan(ctx, &hash); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Not a blob ref.
+			return "", nil
+		}
+		return "", err
+	}
+
+	return hash, nil
+}
+
+func (s *storageBlobDB) GetStorageBlobRefKeys(ctx context.Context, after string, limit int) ([]string, error) {
+	keys := []string{}
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("storage_blob_refs"), bun.Ident("storage_blob_ref")).
+		Column("storage_blob_ref.key").
+		Where("? > ?", bun.Ident("storage_blob_ref.key"), after).
+		OrderExpr("? ASC", bun.Ident("storage_blob_ref.key")).
+		Limit(limit).
+		Scan(ctx, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *storageBlobDB) PutStorageBlobRef(ctx context.Context, key string, hash string, size int64) (int, error) {
+	var refs int
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Insert the new ref, this will
+		// fail if key is already in use.
+		if _, err := tx.
+			NewInsert().
+			Model(&gtsmodel.StorageBlobRef{
+				Key:  key,
+				Hash: hash,
+			}).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Increment refs of existing blob.
+		res, err := tx.
+			NewUpdate().
+			Table("storage_blobs").
+			Set("? = ? + 1", bun.Ident("refs"), bun.Ident("refs")).
+			Where("? = ?", bun.Ident("hash"), hash).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			// No such blob yet,
+			// insert a new one.
+			refs = 1
+			_, err := tx.
+				NewInsert().
+				Model(&gtsmodel.StorageBlob{
+					Hash: hash,
+					Size: size,
+					Refs: refs,
+				}).
+				Exec(ctx)
+			return err
+		}
+
+		// Fetch updated refs count.
+		return tx.
+			NewSelect().
+			Table("storage_blobs").
+			Column("refs").
+			Where("? = ?", bun.Ident("hash"), hash).
+			Scan(ctx, &refs)
+	})
+
+	return refs, err
+}
+
+func (s *storageBlobDB) DeleteStorageBlobRef(ctx context.Context, key string) (int, error) {
+	var refs int
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Get the hash of the referenced blob.
+		var hash string
+		if err := tx.
+			NewSelect().
+			Table("storage_blob_refs").
+			Column("hash").
+			Where("? = ?", bun.Ident("key"), key).
+			Scan(ctx, &hash); err != nil {
+			return err
+		}
+
+		// Delete the ref itself.
+		if _, err := tx.
+			NewDelete().
+			Table("storage_blob_refs").
+			Where("? = ?", bun.Ident("key"), key).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Decrement refs of the blob.
+		if _, err := tx.
+			NewUpdate().
+			Table("storage_blobs").
+			Set("? = ? - 1", bun.Ident("refs"), bun.Ident("refs")).
+			Where("? = ?", bun.Ident("hash"), hash).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Fetch updated refs count.
+		if err := tx.
+			NewSelect().
+			Table("storage_blobs").
+			Column("refs").
+			Where("? = ?", bun.Ident("hash"), hash).
+			Scan(ctx, &refs); err != nil {
+			return err
+		}
+
+		if refs > 0 {
+			// Still in use.
+			return nil
+		}
+
+		// Last ref gone, delete the blob.
+		_, err := tx.
+			NewDelete().
+			Table("storage_blobs").
+			Where("? = ?", bun.Ident("hash"), hash).
+			Exec(ctx)
+		return err
+	})
+
+	return refs, err
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageBlobTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StorageBlobTestSuite) TestStorageBlobRefs() {
+	ctx := context.Background()
+
+	const (
+		hash1 = "aaaa1111"
+		hash2 = "bbbb2222"
+	)
+
+	// Unknown keys aren't blob refs.
+	hash, err := suite.db.GetStorageBlobRef(ctx, "some/key.png")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(hash)
+
+	// Reference the same blob from two keys,
+	// and another blob from a third key.
+	for _, ref := range []struct {
+		key  string
+		hash string
+		refs int
+	}{
+		{key: "a/1.png", hash: hash1, refs: 1},
+		{key: "b/2.png", hash: hash1, refs: 2},
+		{key: "c/3.png", hash: hash2, refs: 1},
+	} {
+		refs, err := suite.db.PutStorageBlobRef(ctx, ref.key, ref.hash, 1024)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(ref.refs, refs)
+	}
+
+	// Keys can't be referenced twice.
+	_, err = suite.db.PutStorageBlobRef(ctx, "a/1.png", hash2, 1024)
+	suite.Error(err)
+
+	hash, err = suite.db.GetStorageBlobRef(ctx, "b/2.png")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(hash1, hash)
+
+	// Keys page in order.
+	keys, err := suite.db.GetStorageBlobRefKeys(ctx, "", 2)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{"a/1.png", "b/2.png"}, keys)
+
+	keys, err = suite.db.GetStorageBlobRefKeys(ctx, "b/2.png", 2)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{"c/3.png"}, keys)
+
+	// Deleting refs counts down.
+	refs, err := suite.db.DeleteStorageBlobRef(ctx, "a/1.png")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, refs)
+
+	refs, err = suite.db.DeleteStorageBlobRef(ctx, "b/2.png")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(0, refs)
+
+	// The unreferenced blob should be gone,
+	// so referencing it again starts afresh.
+	refs, err = suite.db.PutStorageBlobRef(ctx, "d/4.png", hash1, 1024)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, refs)
+}
+
+func TestStorageBlobTestSuite(t *testing.T) {
+	suite.Run(t, new(StorageBlobTestSuite))
+}
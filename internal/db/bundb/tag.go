@@ -19,9 +19,12 @@ package bundb
 
 import (
 	"context"
+	"slices"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -131,3 +134,152 @@ func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 
 	return nil
 }
+
+func (t *tagDB) GetFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		followedTags = make([]*gtsmodel.FollowedTag, 0, limit)
+	)
+
+	q := t.db.
+		NewSelect().
+		Model(&followedTags).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only items *OLDER* than the given max ID.
+		q = q.Where("? < ?", bun.Ident("followed_tag.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only items *NEWER* than the given min ID.
+		q = q.Where("? > ?", bun.Ident("followed_tag.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("followed_tag.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("followed_tag.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(followedTags) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(followedTags)
+	}
+
+	// Populate the followed tags' tags.
+	tagIDs := make([]string, len(followedTags))
+	for i, followedTag := range followedTags {
+		tagIDs[i] = followedTag.TagID
+	}
+
+	tags, err := t.GetTags(ctx, tagIDs)
+	if err != nil {
+		return nil, gtserror.Newf("error getting followed tags: %w", err)
+	}
+
+	tagsByID := make(map[string]*gtsmodel.Tag, len(tags))
+	for _, tag := range tags {
+		tagsByID[tag.ID] = tag
+	}
+
+	// Drop any followed tags whose
+	// tag could not be found.
+	followedTags = slices.DeleteFunc(followedTags, func(followedTag *gtsmodel.FollowedTag) bool {
+		followedTag.Tag = tagsByID[followedTag.TagID]
+		return followedTag.Tag == nil
+	})
+
+	return followedTags, nil
+}
+
+func (t *tagDB) GetFollowedTagIDs(ctx context.Context, accountID string) ([]string, error) {
+	var tagIDs []string
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Column("followed_tag.tag_id").
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Scan(ctx, &tagIDs); err != nil {
+		return nil, err
+	}
+
+	return tagIDs, nil
+}
+
+func (t *tagDB) IsAccountFollowingTag(ctx context.Context, accountID string, tagID string) (bool, error) {
+	return exists(ctx, t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Column("followed_tag.id").
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID),
+	)
+}
+
+func (t *tagDB) GetAccountIDsFollowingTagIDs(ctx context.Context, tagIDs []string) ([]string, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	var accountIDs []string
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		ColumnExpr("DISTINCT ?", bun.Ident("followed_tag.account_id")).
+		Where("? IN (?)", bun.Ident("followed_tag.tag_id"), bun.In(tagIDs)).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (t *tagDB) PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error {
+	_, err := t.db.
+		NewInsert().
+		Model(followedTag).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error {
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error {
+	_, err := t.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
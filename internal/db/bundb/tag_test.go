@@ -86,6 +86,68 @@ func (suite *TagTestSuite) TestPutTag() {
 	}
 }
 
+func (suite *TagTestSuite) TestFollowedTags() {
+	var (
+		ctx       = context.Background()
+		accountID = suite.testAccounts["local_account_1"].ID
+		testTag   = suite.testTags["welcome"]
+	)
+
+	// Account shouldn't follow the tag yet.
+	following, err := suite.db.IsAccountFollowingTag(ctx, accountID, testTag.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	// Follow the tag.
+	err = suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: accountID,
+		TagID:     testTag.ID,
+	})
+	suite.NoError(err)
+
+	// Following it again should fail.
+	err = suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: accountID,
+		TagID:     testTag.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	following, err = suite.db.IsAccountFollowingTag(ctx, accountID, testTag.ID)
+	suite.NoError(err)
+	suite.True(following)
+
+	// The followed tag should be gettable by account, populated.
+	followedTags, err := suite.db.GetFollowedTags(ctx, accountID, nil)
+	suite.NoError(err)
+	if suite.Len(followedTags, 1) {
+		suite.Equal(testTag.ID, followedTags[0].TagID)
+		suite.Equal(testTag.Name, followedTags[0].Tag.Name)
+	}
+
+	tagIDs, err := suite.db.GetFollowedTagIDs(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal([]string{testTag.ID}, tagIDs)
+
+	// And the account should show up as a follower of the tag.
+	accountIDs, err := suite.db.GetAccountIDsFollowingTagIDs(ctx, []string{testTag.ID})
+	suite.NoError(err)
+	suite.Equal([]string{accountID}, accountIDs)
+
+	// Unfollow the tag.
+	err = suite.db.DeleteFollowedTag(ctx, accountID, testTag.ID)
+	suite.NoError(err)
+
+	following, err = suite.db.IsAccountFollowingTag(ctx, accountID, testTag.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	followedTags, err = suite.db.GetFollowedTags(ctx, accountID, nil)
+	suite.NoError(err)
+	suite.Empty(followedTags)
+}
+
 func TestTagTestSuite(t *testing.T) {
 	suite.Run(t, new(TagTestSuite))
 }
@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type WorkerTaskTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WorkerTaskTestSuite) TestWorkerTasks() {
+	ctx := context.Background()
+
+	t1 := &gtsmodel.WorkerTask{
+		ID:         id.NewULID(),
+		WorkerType: gtsmodel.WorkerTypeClientAPI,
+		TaskData:   []byte(`{"ap_object_type":"Note"}`),
+	}
+	t2 := &gtsmodel.WorkerTask{
+		ID:         id.NewULID(),
+		WorkerType: gtsmodel.WorkerTypeFediAPI,
+		TaskData:   []byte(`{"ap_object_type":"Like"}`),
+	}
+	t3 := &gtsmodel.WorkerTask{
+		ID:         id.NewULID(),
+		WorkerType: gtsmodel.WorkerTypeFediAPI,
+		TaskData:   []byte(`{"ap_object_type":"Follow"}`),
+	}
+
+	if err := suite.db.PutWorkerTasks(ctx, t1, t2, t3); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tasks come back in the order queued.
+	tasks, err := suite.db.GetWorkerTasks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(tasks, 3) {
+		suite.Equal(t1.ID, tasks[0].ID)
+		suite.Equal(t2.ID, tasks[1].ID)
+		suite.Equal(t3.ID, tasks[2].ID)
+		suite.Equal(t1.TaskData, tasks[0].TaskData)
+	}
+
+	count, err := suite.db.CountWorkerTasks(ctx, gtsmodel.WorkerTypeFediAPI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, count)
+
+	if err := suite.db.DeleteWorkerTaskByID(ctx, t2.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountWorkerTasks(ctx, gtsmodel.WorkerTypeFediAPI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, count)
+}
+
+func TestWorkerTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTaskTestSuite))
+}
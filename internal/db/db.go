@@ -28,6 +28,8 @@ type DB interface {
 	Admin
 	Application
 	Basic
+	Conversation
+	Delivery
 	Domain
 	Emoji
 	HeaderFilter
@@ -41,16 +43,22 @@ type DB interface {
 	Notification
 	Poll
 	Relationship
+	Relay
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
+	StorageBlob
 	Tag
 	Thread
 	Timeline
 	User
 	Tombstone
+	WebPush
+	WorkerTask
 }
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// Delivery contains functions for getting and storing
+// queued deliveries of outgoing ActivityPub activities.
+type Delivery interface {
+	// GetDeliveryByID fetches the queued delivery with given ID.
+	GetDeliveryByID(ctx context.Context, id string) (*gtsmodel.Delivery, error)
+
+	// GetDeliveries fetches a page of queued deliveries with given status, optionally limited to given inbox URI.
+	GetDeliveries(ctx context.Context, status gtsmodel.DeliveryStatus, inboxURI string, page *paging.Page) ([]*gtsmodel.Delivery, error)
+
+	// GetDueDeliveryInboxURIs fetches the inbox URIs of all pending deliveries which are due to be attempted at given time.
+	GetDueDeliveryInboxURIs(ctx context.Context, now time.Time) ([]string, error)
+
+	// GetPendingDeliveriesForInbox fetches up to limit pending deliveries to the given inbox URI, oldest first.
+	GetPendingDeliveriesForInbox(ctx context.Context, inboxURI string, limit int) ([]*gtsmodel.Delivery, error)
+
+	// PutDeliveries inserts the given deliveries into the database.
+	PutDeliveries(ctx context.Context, deliveries ...*gtsmodel.Delivery) error
+
+	// UpdateDelivery updates the given delivery in the database, only on selected columns if provided (else, all).
+	UpdateDelivery(ctx context.Context, delivery *gtsmodel.Delivery, columns ...string) error
+
+	// DeleteDeliveryByID deletes the queued delivery with given ID from the database.
+	DeleteDeliveryByID(ctx context.Context, id string) error
+}
@@ -60,6 +60,38 @@ type Domain interface {
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	// UpdateDomainAllow updates the given instance-level domain allow, setting the provided columns (empty for all).
+	UpdateDomainAllow(ctx context.Context, allow *gtsmodel.DomainAllow, columns ...string) error
+
+	// UpdateDomainBlock updates the given instance-level domain block, setting the provided columns (empty for all).
+	UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) error
+
+	// GetDomainAllowsBySubscriptionID returns all instance-level domain allows created by the subscription with the given id.
+	GetDomainAllowsBySubscriptionID(ctx context.Context, subscriptionID string) ([]*gtsmodel.DomainAllow, error)
+
+	// GetDomainBlocksBySubscriptionID returns all instance-level domain blocks created by the subscription with the given id.
+	GetDomainBlocksBySubscriptionID(ctx context.Context, subscriptionID string) ([]*gtsmodel.DomainBlock, error)
+
+	/*
+		Domain permission subscription functions.
+	*/
+
+	// GetDomainPermissionSubscriptionByID returns the domain permission subscription with the given id, if it exists.
+	GetDomainPermissionSubscriptionByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionSubscription, error)
+
+	// GetDomainPermissionSubscriptionsByPriority returns all domain permission subscriptions of
+	// the given permission type, ordered by priority descending (highest priority first).
+	GetDomainPermissionSubscriptionsByPriority(ctx context.Context, permType gtsmodel.DomainPermissionType) ([]*gtsmodel.DomainPermissionSubscription, error)
+
+	// PutDomainPermissionSubscription inserts the given domain permission subscription into the database.
+	PutDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) error
+
+	// UpdateDomainPermissionSubscription updates the given domain permission subscription, setting the provided columns (empty for all).
+	UpdateDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, columns ...string) error
+
+	// DeleteDomainPermissionSubscription deletes the domain permission subscription with the given id.
+	DeleteDomainPermissionSubscription(ctx context.Context, id string) error
+
 	/*
 		Block/allow checking functions.
 	*/
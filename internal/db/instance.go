@@ -55,6 +55,9 @@ type Instance interface {
 	// GetInstancePeers returns a slice of instances that the host instance knows about.
 	GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, error)
 
+	// GetUnavailableInstances returns a slice of instances that have been marked unavailable due to sustained delivery failures.
+	GetUnavailableInstances(ctx context.Context) ([]*gtsmodel.Instance, error)
+
 	// GetInstanceModeratorAddresses returns a slice of email addresses belonging to active
 	// (as in, not suspended) moderators + admins on this instance.
 	GetInstanceModeratorAddresses(ctx context.Context) ([]string, error)
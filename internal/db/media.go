@@ -48,6 +48,9 @@ type Media interface {
 	// GetRemoteAttachments fetches media attachments with a non-empty domain, up to a given max ID, and at most limit.
 	GetRemoteAttachments(ctx context.Context, page *paging.Page) ([]*gtsmodel.MediaAttachment, error)
 
+	// GetAttachmentsByProcessingStatus fetches all media attachments with the given processing status.
+	GetAttachmentsByProcessingStatus(ctx context.Context, status gtsmodel.ProcessingStatus) ([]*gtsmodel.MediaAttachment, error)
+
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
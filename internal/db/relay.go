@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Relay contains functions for getting and storing
+// subscriptions of this instance to ActivityPub relays.
+type Relay interface {
+	// GetRelayByID gets the relay subscription with given ID.
+	GetRelayByID(ctx context.Context, id string) (*gtsmodel.Relay, error)
+
+	// GetRelayByInboxURI gets the relay subscription with given relay inbox URI.
+	GetRelayByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Relay, error)
+
+	// GetRelayByFollowURI gets the relay subscription with given instance actor Follow URI.
+	GetRelayByFollowURI(ctx context.Context, followURI string) (*gtsmodel.Relay, error)
+
+	// GetRelays gets all relay subscriptions, in any state, oldest first.
+	GetRelays(ctx context.Context) ([]*gtsmodel.Relay, error)
+
+	// PutRelay puts the given relay subscription in the database.
+	PutRelay(ctx context.Context, relay *gtsmodel.Relay) error
+
+	// UpdateRelay updates the given relay subscription in the database, only on selected columns if provided (else, all).
+	UpdateRelay(ctx context.Context, relay *gtsmodel.Relay, columns ...string) error
+
+	// DeleteRelayByID deletes the relay subscription with given ID from the database.
+	DeleteRelayByID(ctx context.Context, id string) error
+}
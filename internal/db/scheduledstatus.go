@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// ScheduledStatus contains functions for getting and
+// storing statuses scheduled to be posted in future.
+type ScheduledStatus interface {
+	// GetScheduledStatusByID fetches the scheduled status with given ID from the database.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAccount fetches a page of scheduled statuses owned by the account with given ID.
+	GetScheduledStatusesForAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAccount counts the scheduled statuses owned by the account with given ID.
+	// If scheduledFrom and scheduledTo are non-zero, only statuses scheduled within that range are counted.
+	CountScheduledStatusesForAccount(ctx context.Context, accountID string, scheduledFrom time.Time, scheduledTo time.Time) (int, error)
+
+	// GetAllScheduledStatuses fetches all scheduled statuses from the database, oldest scheduled first.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// PopulateScheduledStatus ensures that all sub-models of a scheduled status are populated (e.g. account, attachments).
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus inserts the given scheduled status into the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status in the database, only on selected columns if provided (else, all).
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// DeleteScheduledStatusByID deletes the scheduled status with given ID from the database.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+
+	// DeleteScheduledStatusesByAccountID deletes all scheduled statuses owned by the account with given ID from the database.
+	DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error
+}
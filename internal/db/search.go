@@ -19,17 +19,50 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type Search interface {
-	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// SearchForAccounts uses the given query text to search for accounts, optionally limited to those that accountID follows.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by accountID, or in reply to accountID.
-	SearchForStatuses(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// SearchForStatuses uses the given query to search for statuses created by accountID, in reply to accountID,
+	// or public statuses created by accounts that have opted in to being indexed. Results are ordered by relevance.
+	SearchForStatuses(ctx context.Context, accountID string, query *StatusSearchQuery, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
+
+	// RebuildSearchIndex clears and repopulates the full-text search index for all accounts and statuses.
+	RebuildSearchIndex(ctx context.Context) error
+}
+
+// StatusSearchQuery models a full-text status
+// search query, after any search operators
+// have been parsed out of the query text.
+type StatusSearchQuery struct {
+	// Text to match against status content,
+	// content warning and media descriptions.
+	// Double-quoted sections are matched as
+	// phrases. May be empty if only operators
+	// were provided.
+	Text string
+
+	// FromAccountID, if set, limits results
+	// to statuses created by this account.
+	FromAccountID string
+
+	// HasMedia limits results to statuses
+	// with at least one media attachment.
+	HasMedia bool
+
+	// Before, if set, limits results to
+	// statuses created before this time.
+	Before time.Time
+
+	// After, if set, limits results to
+	// statuses created after this time.
+	After time.Time
 }
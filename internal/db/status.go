@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Status contains functions for getting statuses, creating statuses, and checking various other fields on statuses.
@@ -64,6 +65,9 @@ type Status interface {
 	// CountStatusReplies returns the number of stored *direct* (i.e. in_reply_to_id column) replies to this status ID.
 	CountStatusReplies(ctx context.Context, statusID string) (int, error)
 
+	// GetPendingReplies returns a page of replies to statuses by the given account ID which are pending its approval.
+	GetPendingReplies(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, error)
+
 	// GetStatusBoosts returns all statuses whose boost_of_id column refer to given status ID.
 	GetStatusBoosts(ctx context.Context, statusID string) ([]*gtsmodel.Status, error)
 
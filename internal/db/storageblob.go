@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import "context"
+
+// StorageBlob contains functions for reference counting content-addressed
+// blobs in storage. As these are used by the storage driver itself for
+// every lookup, missing entries are returned as zero values rather than
+// as ErrNoEntries.
+type StorageBlob interface {
+	// GetStorageBlobRef returns the hash of the blob referenced
+	// by the given storage key, or an empty string if none.
+	GetStorageBlobRef(ctx context.Context, key string) (string, error)
+
+	// GetStorageBlobRefKeys returns up to limit storage keys
+	// which reference blobs, in key order, after the given key.
+	GetStorageBlobRefKeys(ctx context.Context, after string, limit int) ([]string, error)
+
+	// PutStorageBlobRef references the blob with given hash and size
+	// from the given storage key, creating the blob if necessary, and
+	// returns the number of references the blob now has.
+	PutStorageBlobRef(ctx context.Context, key string, hash string, size int64) (int, error)
+
+	// DeleteStorageBlobRef removes the blob reference from the given storage
+	// key, returning the number of references the blob has left. Blobs with
+	// no references left are deleted, as should be their value in storage.
+	DeleteStorageBlobRef(ctx context.Context, key string) (int, error)
+}
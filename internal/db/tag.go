@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Tag contains functions for getting/creating tags in the database.
@@ -36,4 +37,28 @@ type Tag interface {
 
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
+
+	// GetFollowedTags returns a page of tags followed by the given account,
+	// as FollowedTag models populated with their tags, newest follows first.
+	GetFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error)
+
+	// GetFollowedTagIDs returns the IDs of all tags followed by the given account.
+	GetFollowedTagIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// IsAccountFollowingTag returns whether the given account follows the given tag.
+	IsAccountFollowingTag(ctx context.Context, accountID string, tagID string) (bool, error)
+
+	// GetAccountIDsFollowingTagIDs returns the IDs of all
+	// accounts following any of the given tags, deduplicated.
+	GetAccountIDsFollowingTagIDs(ctx context.Context, tagIDs []string) ([]string, error)
+
+	// PutFollowedTag stores a new followed tag. Returns
+	// db.ErrAlreadyExists if the account already follows the tag.
+	PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error
+
+	// DeleteFollowedTag deletes the follow of the given tag by the given account, if it exists.
+	DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error
+
+	// DeleteFollowedTagsByAccountID deletes all tag follows of the given account.
+	DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error
 }
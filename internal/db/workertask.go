@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WorkerTask contains functions for persisting
+// queued client API and federator worker messages.
+type WorkerTask interface {
+	// GetWorkerTasks returns all persisted worker
+	// tasks, in the order they were queued.
+	GetWorkerTasks(ctx context.Context) ([]*gtsmodel.WorkerTask, error)
+
+	// CountWorkerTasks returns the number of persisted
+	// worker tasks queued for the given worker type.
+	CountWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType) (int, error)
+
+	// PutWorkerTasks inserts the given worker tasks.
+	PutWorkerTasks(ctx context.Context, tasks ...*gtsmodel.WorkerTask) error
+
+	// DeleteWorkerTaskByID removes the worker task with given ID.
+	DeleteWorkerTaskByID(ctx context.Context, id string) error
+}
@@ -91,7 +91,8 @@ func accountFresh(
 
 // GetAccountByURI will attempt to fetch an accounts by its URI, first checking the database. In the case of a newly-met remote model, or a remote model
 // whose last_fetched date is beyond a certain interval, the account will be dereferenced. In the case of dereferencing, some low-priority account information
-// may be enqueued for asynchronous fetching, e.g. featured account statuses (pins). An ActivityPub object indicates the account was dereferenced.
+// may be enqueued for asynchronous fetching, e.g. featured account statuses (pins), or the latest outbox statuses of a newly-met account. An ActivityPub
+// object indicates the account was dereferenced.
 func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string, uri *url.URL) (*gtsmodel.Account, ap.Accountable, error) {
 	// Fetch and dereference account if necessary.
 	account, accountable, err := d.getAccountByURI(ctx,
@@ -105,6 +106,12 @@ func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string,
 	if accountable != nil {
 		// This account was updated, enqueue re-dereference featured posts.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+			// If this is a newly-met account, backfill its latest
+			// statuses (do this first, as featured deref adds pins).
+			if err := d.backfillNewAccount(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error backfilling account outbox: %v", err)
+			}
+
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
@@ -187,7 +194,8 @@ func (d *Dereferencer) getAccountByURI(ctx context.Context, requestUser string,
 
 // GetAccountByUsernameDomain will attempt to fetch an accounts by its username@domain, first checking the database. In the case of a newly-met remote model,
 // or a remote model whose last_fetched date is beyond a certain interval, the account will be dereferenced. In the case of dereferencing, some low-priority
-// account information may be enqueued for asynchronous fetching, e.g. featured account statuses (pins). An ActivityPub object indicates the account was dereferenced.
+// account information may be enqueued for asynchronous fetching, e.g. featured account statuses (pins), or the latest outbox statuses of a newly-met
+// account. An ActivityPub object indicates the account was dereferenced.
 func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUser string, username string, domain string) (*gtsmodel.Account, ap.Accountable, error) {
 	account, accountable, err := d.getAccountByUsernameDomain(
 		ctx,
@@ -202,6 +210,12 @@ func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUs
 	if accountable != nil {
 		// This account was updated, enqueue re-dereference featured posts.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+			// If this is a newly-met account, backfill its latest
+			// statuses (do this first, as featured deref adds pins).
+			if err := d.backfillNewAccount(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error backfilling account outbox: %v", err)
+			}
+
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
@@ -104,6 +104,7 @@ func (d *Dereferencer) EnrichAnnounce(
 	boost.Boostable = target.Boostable
 	boost.Replyable = target.Replyable
 	boost.Likeable = target.Likeable
+	boost.InteractionPolicy = target.InteractionPolicy
 
 	// Store the boost wrapper status in database.
 	switch err = d.state.DB.PutStatus(ctx, boost); {
@@ -83,9 +83,10 @@ func (d *Dereferencer) dereferenceCollectionPage(ctx context.Context, username s
 	return page, nil
 }
 
-// getAttachedStatusCollection is a small utility function to fetch the first page of an
-// attached activity streams collection from a provided statusable object, along with a URI.
-func getAttachedStatusCollectionPage(status ap.Statusable) (ap.CollectionPageIterator, string) { //nolint:gocritic
+// getStatusRepliesPage returns the first page of the given status' replies collection, along
+// with its URI string. Where the replies collection, or its first page, isn't embedded in the
+// status, it will be dereferenced from remote (so long as it's on the same host as the status).
+func (d *Dereferencer) getStatusRepliesPage(ctx context.Context, username string, status ap.Statusable) (ap.CollectionPageIterator, string) {
 	// Look for an attached status replies (as collection)
 	replies := status.GetActivityStreamsReplies()
 	if replies == nil {
@@ -102,8 +103,93 @@ func getAttachedStatusCollectionPage(status ap.Statusable) (ap.CollectionPageIte
 		return ap.WrapOrderedCollectionPage(page), getIDString(page)
 	}
 
-	log.Warnf(nil, "replies without collection page: %s", getIDString(status))
-	return nil, ""
+	// Get the status URI, so we can
+	// check the host of any replies.
+	statusURI := ap.GetJSONLDId(status)
+	if statusURI == nil {
+		return nil, ""
+	}
+
+	var collect ap.CollectionIterator
+
+	switch {
+	// Replies collection not embedded, dereference it.
+	case replies.IsIRI():
+		collectURI := replies.GetIRI()
+		if collectURI.Host != statusURI.Host {
+			log.Warnf(ctx, "replies on different host: %s", statusURI)
+			return nil, ""
+		}
+
+		var err error
+		collect, err = d.dereferenceCollection(ctx, username, collectURI)
+		if err != nil {
+			log.Errorf(ctx, "error dereferencing replies of %s: %v", statusURI, err)
+			return nil, ""
+		}
+
+	// Replies collection embedded, but without first page.
+	case replies.IsActivityStreamsCollection():
+		collect = ap.WrapCollection(replies.GetActivityStreamsCollection())
+	case replies.IsActivityStreamsOrderedCollection():
+		collect = ap.WrapOrderedCollection(replies.GetActivityStreamsOrderedCollection())
+
+	default:
+		return nil, ""
+	}
+
+	// Get first page of the replies, dereferencing if needed.
+	page, err := d.getFirstPage(ctx, username, statusURI, collect)
+	if err != nil {
+		log.Errorf(ctx, "error dereferencing replies page of %s: %v", statusURI, err)
+		return nil, ""
+	}
+
+	if page == nil {
+		log.Warnf(ctx, "replies without collection page: %s", statusURI)
+		return nil, ""
+	}
+
+	return page, getIDString(page)
+}
+
+// getFirstPage returns the first page of the given collection, either as
+// embedded in the collection or dereferenced from remote. Returns nil page if
+// the collection isn't paged, or if its first page lives on another host.
+func (d *Dereferencer) getFirstPage(
+	ctx context.Context,
+	requestUser string,
+	uri *url.URL,
+	collect ap.CollectionIterator,
+) (ap.CollectionPageIterator, error) {
+	withFirst, ok := collect.(ap.WithFirst)
+	if !ok {
+		return nil, nil
+	}
+
+	first := withFirst.GetActivityStreamsFirst()
+	if first == nil {
+		return nil, nil
+	}
+
+	if !first.IsIRI() {
+		// Page embedded in the collection,
+		// check it's actually a page type.
+		t := first.GetType()
+		if t == nil {
+			return nil, nil
+		}
+		return ap.ToCollectionPageIterator(t)
+	}
+
+	firstIRI := first.GetIRI()
+	if firstIRI.Host != uri.Host {
+		// Don't follow collection
+		// pages onto another host.
+		return nil, nil
+	}
+
+	return d.dereferenceCollectionPage(ctx, requestUser, firstIRI)
 }
 
 func getRepliesCollectionPage(replies vocab.ActivityStreamsRepliesProperty) vocab.ActivityStreamsCollectionPage {
@@ -92,6 +92,14 @@ type Dereferencer struct {
 
 	handshakes   map[string][]*url.URL
 	handshakesMu sync.Mutex
+
+	// times of last outbox backfill by account URI.
+	backfills   map[string]time.Time
+	backfillsMu sync.Mutex
+
+	// active threads by root status URI.
+	threads   map[string]*activeThread
+	threadsMu sync.Mutex
 }
 
 // NewDereferencer returns a Dereferencer initialized with the given parameters.
@@ -112,5 +120,7 @@ func NewDereferencer(
 		derefHeaders:        make(map[string]*media.ProcessingMedia),
 		derefEmojis:         make(map[string]*media.ProcessingEmoji),
 		handshakes:          make(map[string][]*url.URL),
+		backfills:           make(map[string]time.Time),
+		threads:             make(map[string]*activeThread),
 	}
 }
@@ -18,6 +18,10 @@
 package dereferencing_test
 
 import (
+	"bytes"
+	"io"
+	"net/http"
+
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -88,3 +92,40 @@ func (suite *DereferencerStandardTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
 	testrig.StopWorkers(&suite.state)
 }
+
+// newMockDereferencer returns a new dereferencer using a mock HTTP client
+// that serves the given JSON responses by URL, falling back to the standard
+// mock HTTP client otherwise, and a map of counts of requests made by URL.
+func (suite *DereferencerStandardTestSuite) newMockDereferencer(responses map[string]string) (dereferencing.Dereferencer, map[string]int) {
+	var (
+		base     = testrig.NewMockHTTPClient(nil, "../../../testrig/media")
+		requests = make(map[string]int)
+	)
+
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		url := req.URL.String()
+		requests[url]++
+
+		body, ok := responses[url]
+		if !ok {
+			return base.Do(req)
+		}
+
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+			ContentLength: int64(len(body)),
+			Header:        http.Header{"Content-Type": {"application/activity+json"}},
+			Request:       req,
+		}, nil
+	}, "")
+
+	converter := typeutils.NewConverter(&suite.state)
+	return dereferencing.NewDereferencer(
+		&suite.state,
+		converter,
+		testrig.NewTestTransportController(&suite.state, client),
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestMediaManager(&suite.state),
+	), requests
+}
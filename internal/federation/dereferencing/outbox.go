@@ -0,0 +1,404 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// maxBackfillItems is the maximum number of
+	// activities imported from a remote account's
+	// outbox when backfilling the account.
+	maxBackfillItems = 20
+
+	// maxBackfillPages is the maximum number of outbox
+	// pages walked when backfilling a remote account, so
+	// that outboxes containing mostly unusable (eg., non
+	// public) activities don't get paged through forever.
+	maxBackfillPages = 5
+
+	// backfillCooldown is the minimum duration
+	// between two backfills of the same account.
+	backfillCooldown = 24 * time.Hour
+)
+
+// BackfillAccount dereferences the given remote account's featured collection
+// (pins) and outbox, importing the account's latest public statuses and boosts,
+// so that the account's profile doesn't start out empty on this instance.
+//
+// Backfills of any one account are rate limited to once per backfillCooldown,
+// and will import no more than maxBackfillItems activities from the outbox.
+//
+// This does a fair few HTTP requests, so it should be called asynchronously.
+func (d *Dereferencer) BackfillAccount(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.IsLocal() || account.IsSuspended() {
+		// Nothing to
+		// backfill.
+		return nil
+	}
+
+	if !d.startBackfill(account.URI) {
+		// Backfilled recently.
+		return nil
+	}
+
+	if account.FeaturedCollectionURI != "" {
+		// Update pinned statuses first, these are
+		// shown at the top of the account's profile.
+		if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
+			log.Errorf(ctx, "error fetching account featured collection: %v", err)
+		}
+	}
+
+	return d.dereferenceAccountOutbox(ctx, requestUser, account)
+}
+
+// backfillNewAccount is a small form of BackfillAccount() that only backfills the
+// given account's outbox if we don't yet have any statuses stored by it, ie., when
+// the account is newly-met and would otherwise show an empty profile. The featured
+// collection is left to the caller, as it's also refreshed on every account update.
+func (d *Dereferencer) backfillNewAccount(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.IsLocal() || account.IsSuspended() {
+		// Nothing to
+		// backfill.
+		return nil
+	}
+
+	count, err := d.state.DB.CountAccountStatuses(ctx, account.ID)
+	if err != nil {
+		return gtserror.Newf("error counting account statuses: %w", err)
+	}
+
+	if count > 0 {
+		// Account has statuses
+		// stored already, skip.
+		return nil
+	}
+
+	if !d.startBackfill(account.URI) {
+		// Backfilled recently.
+		return nil
+	}
+
+	return d.dereferenceAccountOutbox(ctx, requestUser, account)
+}
+
+// startBackfill checks whether a backfill of the account with given
+// URI may go ahead, ie., it hasn't been backfilled within the cooldown
+// window. If so, the account is marked as backfilled at current time.
+func (d *Dereferencer) startBackfill(accountURI string) bool {
+	now := time.Now()
+
+	d.backfillsMu.Lock()
+	defer d.backfillsMu.Unlock()
+
+	if last, ok := d.backfills[accountURI]; ok &&
+		now.Sub(last) < backfillCooldown {
+		return false
+	}
+
+	// Drop any entries outside of the cooldown
+	// window, so the map doesn't grow forever.
+	for uri, last := range d.backfills {
+		if now.Sub(last) >= backfillCooldown {
+			delete(d.backfills, uri)
+		}
+	}
+
+	d.backfills[accountURI] = now
+	return true
+}
+
+// dereferenceAccountOutbox dereferences the given account's outbox, and pages
+// through it importing up to maxBackfillItems publicly addressed Create and
+// Announce activities by the account, ie., its latest statuses and boosts.
+func (d *Dereferencer) dereferenceAccountOutbox(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.OutboxURI == "" {
+		// No outbox
+		// to page.
+		return nil
+	}
+
+	uri, err := url.Parse(account.OutboxURI)
+	if err != nil {
+		return gtserror.Newf("invalid account outbox uri %q: %w", account.OutboxURI, err)
+	}
+
+	collect, err := d.dereferenceCollection(ctx, requestUser, uri)
+	if err != nil {
+		return err
+	}
+
+	// Some implementations include items
+	// directly in the outbox collection.
+	imported := d.backfillOutboxItems(ctx,
+		requestUser,
+		account,
+		collect,
+		maxBackfillItems,
+	)
+
+	// Get the first page of the outbox,
+	// which should contain latest items.
+	page, err := d.getFirstPage(ctx,
+		requestUser,
+		uri,
+		collect,
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; page != nil &&
+		i < maxBackfillPages &&
+		imported < maxBackfillItems; i++ {
+
+		// Import the items from this page.
+		imported += d.backfillOutboxItems(ctx,
+			requestUser,
+			account,
+			page,
+			maxBackfillItems-imported,
+		)
+
+		// Look for an IRI of the next (ie., older) page.
+		next := page.NextPage()
+		if next == nil || !next.IsIRI() {
+			break
+		}
+
+		nextIRI := next.GetIRI()
+		if nextIRI.Host != uri.Host {
+			// Don't follow outbox pages
+			// over onto another host.
+			break
+		}
+
+		// Dereference the next page of the outbox.
+		page, err = d.dereferenceCollectionPage(ctx,
+			requestUser,
+			nextIRI,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Debugf(ctx, "backfilled %d items from outbox %s", imported, uri)
+	return nil
+}
+
+// backfillOutboxItems imports up to limit usable activities from the given outbox
+// items iterator, returning the number of statuses and boosts that were imported.
+func (d *Dereferencer) backfillOutboxItems(
+	ctx context.Context,
+	requestUser string,
+	account *gtsmodel.Account,
+	items interface{ NextItem() ap.TypeOrIRI },
+	limit int,
+) int {
+	var imported int
+
+	for imported < limit {
+		// Get next outbox item.
+		item := items.NextItem()
+		if item == nil {
+			break
+		}
+
+		// We only handle activities embedded in the outbox
+		// (as all implementations we know of do). Otherwise
+		// we'd need an extra request per item to fetch them.
+		t := item.GetType()
+		if t == nil {
+			continue
+		}
+
+		activity, ok := ap.ToActivityable(t)
+		if !ok {
+			continue
+		}
+
+		if !isPublicAddressed(activity) {
+			// Only import statuses + boosts
+			// that are visible to anyone.
+			continue
+		}
+
+		// Ensure the activity is actually by this account,
+		// we don't want to trust outboxes that say otherwise.
+		actors := ap.GetActorIRIs(activity)
+		if len(actors) != 1 || actors[0].String() != account.URI {
+			continue
+		}
+
+		switch activity.GetTypeName() {
+		case ap.ActivityCreate:
+			ok = d.backfillCreate(ctx, requestUser, account, activity)
+		case ap.ActivityAnnounce:
+			ok = d.backfillAnnounce(ctx, requestUser, account, activity)
+		default:
+			ok = false
+		}
+
+		if ok {
+			imported++
+		}
+	}
+
+	return imported
+}
+
+// backfillCreate dereferences the status created by the given
+// outbox Create activity, returning whether it was imported.
+func (d *Dereferencer) backfillCreate(
+	ctx context.Context,
+	requestUser string,
+	account *gtsmodel.Account,
+	create ap.Activityable,
+) bool {
+	objIRIs := ap.GetObjectIRIs(create)
+	if len(objIRIs) != 1 {
+		return false
+	}
+
+	statusIRI := objIRIs[0]
+
+	accountURI, err := url.Parse(account.URI)
+	if err != nil {
+		return false
+	}
+
+	if statusIRI.Host != accountURI.Host {
+		// If this status doesn't share a host with
+		// its creator, we shouldn't trust it. Skip.
+		return false
+	}
+
+	// Search for status by URI. Note this may return an existing model
+	// we have stored with an error from attempted update, so check both.
+	status, _, _, err := d.getStatusByURI(ctx, requestUser, statusIRI)
+	if err != nil {
+		log.Errorf(ctx, "error getting status from outbox %s: %v", statusIRI, err)
+
+		if status == nil {
+			// This is only unactionable
+			// if no status was returned.
+			return false
+		}
+	}
+
+	if status.AccountURI != account.URI {
+		// Status was created by
+		// someone else, skip it.
+		return false
+	}
+
+	// Only count statuses toward the
+	// limit if they're publicly visible.
+	visible, err := d.visibility.StatusVisible(ctx, nil, status)
+	if err != nil {
+		log.Errorf(ctx, "error checking status visibility %s: %v", status.URI, err)
+		return false
+	}
+
+	return visible
+}
+
+// backfillAnnounce creates a boost wrapper status from the given
+// outbox Announce activity, returning whether it was imported.
+func (d *Dereferencer) backfillAnnounce(
+	ctx context.Context,
+	requestUser string,
+	account *gtsmodel.Account,
+	announce ap.Activityable,
+) bool {
+	announceIRI := ap.GetJSONLDId(announce)
+	if announceIRI == nil {
+		return false
+	}
+
+	accountURI, err := url.Parse(account.URI)
+	if err != nil {
+		return false
+	}
+
+	if announceIRI.Host != accountURI.Host {
+		// If this boost doesn't share a host with
+		// its creator, we shouldn't trust it. Skip.
+		return false
+	}
+
+	boost, isNew, err := d.converter.ASAnnounceToStatus(ctx, announce)
+	if err != nil {
+		log.Errorf(ctx, "error converting announce from outbox: %v", err)
+		return false
+	}
+
+	if !isNew {
+		// Already
+		// have it.
+		return true
+	}
+
+	// Dereference the boosted status
+	// and store the boost wrapper.
+	boost, err = d.EnrichAnnounce(ctx,
+		boost,
+		requestUser,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error enriching announce from outbox: %v", err)
+		return false
+	}
+
+	// Only count boosts toward the
+	// limit if they're publicly visible.
+	visible, err := d.visibility.StatusVisible(ctx, nil, boost)
+	if err != nil {
+		log.Errorf(ctx, "error checking boost visibility %s: %v", boost.URI, err)
+		return false
+	}
+
+	return visible
+}
+
+// isPublicAddressed returns whether given activity
+// is addressed to the public collection in to or cc.
+func isPublicAddressed(activity ap.Activityable) bool {
+	for _, iri := range ap.GetTo(activity) {
+		if pub.IsPublic(iri.String()) {
+			return true
+		}
+	}
+	for _, iri := range ap.GetCc(activity) {
+		if pub.IsPublic(iri.String()) {
+			return true
+		}
+	}
+	return false
+}
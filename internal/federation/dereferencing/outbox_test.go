@@ -0,0 +1,180 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type OutboxTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+const (
+	testOutboxURI          = "http://fossbros-anonymous.io/users/foss_satan/outbox"
+	testOutboxPage1        = "http://fossbros-anonymous.io/users/foss_satan/outbox?page=true"
+	testOutboxPage2        = "http://fossbros-anonymous.io/users/foss_satan/outbox?page=true&max_id=2"
+	testOutboxNote1        = "http://fossbros-anonymous.io/users/foss_satan/statuses/01J0Z6Q3N7A4V8J2C9K5R1B3TD"
+	testOutboxNote2        = "http://fossbros-anonymous.io/users/foss_satan/statuses/01J0Z6TFQ6S1A8N3R4DGMXB7HC"
+	testOutboxNote3        = "http://fossbros-anonymous.io/users/foss_satan/statuses/01J0Z6WS1KNXWYKRG1F0V5Q9PE"
+	testOutboxBoost        = "http://fossbros-anonymous.io/users/foss_satan/statuses/01J0Z6YZ8B7HDG8C8P6FMJ1V4N/activity"
+	testOutboxForeignBoost = "http://example.org/users/someone_else/statuses/01J0Z7B9X3GQ2M5V8K1N4R6T2W/activity"
+)
+
+// testOutboxResponses contains the JSON
+// served by the outbox test's mock client.
+var testOutboxResponses = map[string]string{
+	testOutboxURI: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testOutboxURI + `",
+  "type": "OrderedCollection",
+  "totalItems": 5,
+  "first": "` + testOutboxPage1 + `"
+}`,
+	testOutboxPage1: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testOutboxPage1 + `",
+  "type": "OrderedCollectionPage",
+  "partOf": "` + testOutboxURI + `",
+  "next": "` + testOutboxPage2 + `",
+  "orderedItems": [
+    {
+      "id": "` + testOutboxBoost + `",
+      "type": "Announce",
+      "actor": "http://fossbros-anonymous.io/users/foss_satan",
+      "published": "2024-06-03T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "cc": ["http://fossbros-anonymous.io/users/foss_satan/followers"],
+      "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
+    },
+    {
+      "id": "` + testOutboxNote2 + `/activity",
+      "type": "Create",
+      "actor": "http://fossbros-anonymous.io/users/foss_satan",
+      "published": "2024-06-02T10:00:00Z",
+      "to": ["http://fossbros-anonymous.io/users/foss_satan/followers"],
+      "object": "` + testOutboxNote2 + `"
+    },
+    {
+      "id": "` + testOutboxNote1 + `/activity",
+      "type": "Create",
+      "actor": "http://fossbros-anonymous.io/users/foss_satan",
+      "published": "2024-06-01T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "cc": ["http://fossbros-anonymous.io/users/foss_satan/followers"],
+      "object": "` + testOutboxNote1 + `"
+    }
+  ]
+}`,
+	testOutboxPage2: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testOutboxPage2 + `",
+  "type": "OrderedCollectionPage",
+  "partOf": "` + testOutboxURI + `",
+  "orderedItems": [
+    {
+      "id": "` + testOutboxForeignBoost + `",
+      "type": "Announce",
+      "actor": "http://fossbros-anonymous.io/users/foss_satan",
+      "published": "2024-05-31T11:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "cc": ["http://fossbros-anonymous.io/users/foss_satan/followers"],
+      "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
+    },
+    {
+      "id": "` + testOutboxNote3 + `/activity",
+      "type": "Create",
+      "actor": "http://fossbros-anonymous.io/users/foss_satan",
+      "published": "2024-05-31T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "cc": ["http://fossbros-anonymous.io/users/foss_satan/followers"],
+      "object": "` + testOutboxNote3 + `"
+    }
+  ]
+}`,
+	testOutboxNote1: testOutboxNote(testOutboxNote1, "2024-06-01T10:00:00Z", "https://www.w3.org/ns/activitystreams#Public"),
+	testOutboxNote3: testOutboxNote(testOutboxNote3, "2024-05-31T10:00:00Z", "https://www.w3.org/ns/activitystreams#Public"),
+}
+
+func testOutboxNote(uri string, published string, to string) string {
+	return `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + uri + `",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "published": "` + published + `",
+  "to": ["` + to + `"],
+  "content": "<p>hello from the outbox</p>",
+  "url": "` + uri + `"
+}`
+}
+
+func (suite *OutboxTestSuite) TestBackfillAccount() {
+	var (
+		ctx            = context.Background()
+		fetchingAcct   = suite.testAccounts["local_account_1"]
+		account        = suite.testAccounts["remote_account_1"]
+		d, requests    = suite.newMockDereferencer(testOutboxResponses)
+		boostedStatus  = "01F8MHAMCHF6Y650WCRSCP4WMY"
+		expectBackfill = []string{testOutboxNote1, testOutboxNote3}
+	)
+
+	err := d.BackfillAccount(ctx, fetchingAcct.Username, account)
+	suite.NoError(err)
+
+	// Public statuses from
+	// both pages were imported.
+	for _, uri := range expectBackfill {
+		status, err := suite.db.GetStatusByURI(ctx, uri)
+		if suite.NoError(err) {
+			suite.Equal(account.ID, status.AccountID)
+		}
+	}
+
+	// The followers-only status
+	// should not have been fetched.
+	_, err = suite.db.GetStatusByURI(ctx, testOutboxNote2)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Zero(requests[testOutboxNote2])
+
+	// The boost should have been imported.
+	boost, err := suite.db.GetStatusByURI(ctx, testOutboxBoost)
+	if suite.NoError(err) {
+		suite.Equal(account.ID, boost.AccountID)
+		suite.Equal(boostedStatus, boost.BoostOfID)
+	}
+
+	// The boost with an ID on another host
+	// than the account should not have been.
+	_, err = suite.db.GetStatusByURI(ctx, testOutboxForeignBoost)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Backfilling again straight away
+	// should be prevented by cooldown.
+	err = d.BackfillAccount(ctx, fetchingAcct.Username, account)
+	suite.NoError(err)
+	suite.Equal(1, requests[testOutboxURI])
+}
+
+func TestOutboxTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxTestSuite))
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// DereferenceStatusQuote ensures that the status quoted by the given
+// status (if any) is dereferenced, linking the two statuses together
+// if the quote is permitted by the quoted status' author. Unlike with
+// ancestors, quoted statuses of quoted statuses are not dereferenced.
+func (d *Dereferencer) DereferenceStatusQuote(ctx context.Context, requestUser string, status *gtsmodel.Status) error {
+	if status.QuoteOfURI == "" || status.QuoteOfID != "" {
+		// Not a quote, or
+		// already linked.
+		return nil
+	}
+
+	uri, err := url.Parse(status.QuoteOfURI)
+	if err != nil {
+		log.Warnf(ctx, "invalid quote uri %s: %v", status.QuoteOfURI, err)
+		return nil
+	}
+
+	// Fetch quoted status by URI, this handles case
+	// of existing (updating if necessary) or new status.
+	quoteOf, _, _, err := d.getStatusByURI(ctx, requestUser, uri)
+	if err != nil && quoteOf == nil {
+		return gtserror.Newf("error dereferencing quote %s: %w", status.QuoteOfURI, err)
+	}
+
+	// Ensure status is permitted to quote this.
+	permit, err := d.isPermittedQuote(ctx, status, quoteOf)
+	if err != nil {
+		return gtserror.Newf("error checking quote permissibility for status %s: %w", status.URI, err)
+	}
+
+	if !permit {
+		log.Infof(ctx, "not linking unpermitted quote of %s by %s", status.QuoteOfURI, status.URI)
+		return nil
+	}
+
+	// Link the statuses together.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfAccountID = quoteOf.AccountID
+	status.QuoteOfAccount = quoteOf.Account
+	if err := d.state.DB.UpdateStatus(ctx,
+		status,
+		"quote_of_id",
+		"quote_of_account_id",
+	); err != nil {
+		return gtserror.Newf("error updating status %s: %w", status.URI, err)
+	}
+
+	return nil
+}
+
+// isPermittedQuote returns whether the given status is permitted
+// to quote the given quoteOf status, checking the quoted status'
+// visibility, its author's quote policy, and (for local statuses)
+// whether the quoted status is visible to the quoting account.
+func (d *Dereferencer) isPermittedQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	quoteOf *gtsmodel.Status,
+) (bool, error) {
+	if !quoteOf.IsQuotable(status.AccountID) {
+		// Not public, or author
+		// doesn't want it quoted.
+		return false, nil
+	}
+
+	if !*quoteOf.Local {
+		// Nothing more
+		// we can check.
+		return true, nil
+	}
+
+	// Check visibility of quoted status to status author.
+	visible, err := d.visibility.StatusVisible(ctx,
+		status.Account,
+		quoteOf,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking quote visibility: %w", err)
+	}
+
+	return visible, nil
+}
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// repliesCooldown is the minimum duration
+	// between two backfills of the same thread.
+	repliesCooldown = 10 * time.Minute
+
+	// activeThreadWindow is the duration after the context
+	// of a thread was last requested, during which it's
+	// considered "active", and periodically re-crawled.
+	activeThreadWindow = 6 * time.Hour
+)
+
+// activeThread stores details of a
+// thread with recently backfilled replies.
+type activeThread struct {
+	// username of the last
+	// requester of the thread.
+	requestUser string
+
+	// last time thread
+	// was requested.
+	requestedAt time.Time
+
+	// last time thread was
+	// crawled for replies.
+	crawledAt time.Time
+}
+
+// BackfillReplies dereferences the remote reply tree of the thread that the given status
+// is in, from the top-most status of the thread down, to fetch any replies (and replies of
+// replies, etc) that this instance may be missing. Returns whether new replies were fetched.
+//
+// Backfills of any one thread are rate limited to once per repliesCooldown, and the thread
+// is marked as active, so that it's periodically re-crawled by RecrawlThreads() until the
+// thread hasn't been requested again for activeThreadWindow.
+//
+// This does a lot of HTTP requests, so it should be called asynchronously.
+func (d *Dereferencer) BackfillReplies(ctx context.Context, requestUser string, status *gtsmodel.Status) (bool, error) {
+	// Get the top of the thread.
+	root, err := d.getThreadRoot(ctx, status)
+	if err != nil {
+		return false, err
+	}
+
+	if root.IsLocal() {
+		// Replies to local statuses are
+		// delivered to us, nothing to do.
+		return false, nil
+	}
+
+	if !d.startBackfillReplies(root.URI, requestUser) {
+		// Backfilled recently.
+		return false, nil
+	}
+
+	return d.backfillReplies(ctx, requestUser, root)
+}
+
+// RecrawlThreads re-crawls the remote reply trees of any threads marked as active by
+// BackfillReplies(), that haven't been crawled for at least repliesCooldown. Threads that
+// haven't been requested within the activeThreadWindow are no longer considered active.
+func (d *Dereferencer) RecrawlThreads(ctx context.Context) {
+	type crawl struct {
+		uri         string
+		requestUser string
+	}
+
+	var (
+		now    = time.Now()
+		crawls []crawl
+	)
+
+	d.threadsMu.Lock()
+	for uri, thread := range d.threads {
+		if now.Sub(thread.requestedAt) >= activeThreadWindow {
+			// No longer active.
+			delete(d.threads, uri)
+			continue
+		}
+
+		if now.Sub(thread.crawledAt) < repliesCooldown {
+			// Crawled recently.
+			continue
+		}
+
+		thread.crawledAt = now
+		crawls = append(crawls, crawl{
+			uri:         uri,
+			requestUser: thread.requestUser,
+		})
+	}
+	d.threadsMu.Unlock()
+
+	for _, crawl := range crawls {
+		crawl := crawl // rescope
+
+		// Enqueue each re-crawl separately, so as not to
+		// hog the caller or any single worker for too long.
+		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+			root, err := d.state.DB.GetStatusByURI(ctx, crawl.uri)
+			if err != nil {
+				log.Errorf(ctx, "error getting thread root %s: %v", crawl.uri, err)
+				return
+			}
+
+			if _, err := d.backfillReplies(ctx, crawl.requestUser, root); err != nil {
+				log.Errorf(ctx, "error re-crawling thread %s: %v", crawl.uri, err)
+			}
+		})
+	}
+}
+
+// startBackfillReplies marks the thread with given root status URI as active, and checks
+// whether a backfill of it may go ahead, ie., it hasn't been crawled within the cooldown.
+// If so, the thread is marked as crawled at the current time.
+func (d *Dereferencer) startBackfillReplies(rootURI string, requestUser string) bool {
+	now := time.Now()
+
+	d.threadsMu.Lock()
+	defer d.threadsMu.Unlock()
+
+	thread, ok := d.threads[rootURI]
+	if !ok {
+		thread = new(activeThread)
+		d.threads[rootURI] = thread
+	}
+
+	// Thread requested just now.
+	thread.requestUser = requestUser
+	thread.requestedAt = now
+
+	if now.Sub(thread.crawledAt) < repliesCooldown {
+		return false
+	}
+
+	thread.crawledAt = now
+	return true
+}
+
+// getThreadRoot returns the top-most status
+// we have stored in the thread of given status.
+func (d *Dereferencer) getThreadRoot(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Status, error) {
+	parents, err := d.state.DB.GetStatusParents(ctx, status)
+	if err != nil {
+		return nil, gtserror.Newf("error getting status parents: %w", err)
+	}
+
+	if len(parents) == 0 {
+		// Status is
+		// the root.
+		return status, nil
+	}
+
+	// Parents are ordered
+	// from bottom to top.
+	return parents[len(parents)-1], nil
+}
+
+// backfillReplies re-fetches the given remote thread root status, and dereferences
+// its reply tree, re-fetching any stored replies that aren't Fresh along the way.
+func (d *Dereferencer) backfillReplies(ctx context.Context, requestUser string, root *gtsmodel.Status) (bool, error) {
+	uri, err := url.Parse(root.URI)
+	if err != nil {
+		return false, gtserror.Newf("invalid status uri %q: %w", root.URI, err)
+	}
+
+	// Re-fetch the root status, for its latest replies.
+	_, statusable, _, err := d.enrichStatusSafely(ctx,
+		requestUser,
+		uri,
+		root,
+		nil,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error enriching thread root: %w", err)
+	}
+
+	if statusable == nil {
+		// Lost a race to another
+		// enrich, nothing to do.
+		return false, nil
+	}
+
+	// Walk the reply tree down from the root.
+	fetched, err := d.dereferenceStatusDescendants(ctx,
+		requestUser,
+		uri,
+		statusable,
+		Fresh,
+	)
+
+	log.Debugf(ctx, "fetched %d new replies in thread %s", fetched, uri)
+	return (fetched > 0), err
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RepliesTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+const (
+	testRepliesRoot       = "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M"
+	testRepliesCollection = "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M/replies"
+	testRepliesPage       = "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M/replies?page=true"
+	testRepliesReply      = "http://fossbros-anonymous.io/users/foss_satan/statuses/01J10A0ZK3WPV4GJX2BK8N6C1R"
+)
+
+// testRepliesResponses contains the JSON
+// served by the replies test's mock client,
+// with the replies collection and its first
+// page NOT embedded in the root status.
+var testRepliesResponses = map[string]string{
+	testRepliesRoot: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testRepliesRoot + `",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "published": "2021-09-20T10:40:37Z",
+  "to": ["https://www.w3.org/ns/activitystreams#Public"],
+  "content": "dark souls status bot: \"thoughts of dog\"",
+  "url": "http://fossbros-anonymous.io/@foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+  "replies": "` + testRepliesCollection + `"
+}`,
+	testRepliesCollection: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testRepliesCollection + `",
+  "type": "Collection",
+  "first": "` + testRepliesPage + `"
+}`,
+	testRepliesPage: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testRepliesPage + `",
+  "type": "CollectionPage",
+  "partOf": "` + testRepliesCollection + `",
+  "items": ["` + testRepliesReply + `"]
+}`,
+	testRepliesReply: `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + testRepliesReply + `",
+  "type": "Note",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "inReplyTo": "` + testRepliesRoot + `",
+  "published": "2024-06-20T10:00:00Z",
+  "to": ["https://www.w3.org/ns/activitystreams#Public"],
+  "content": "replying to myself",
+  "url": "` + testRepliesReply + `"
+}`,
+}
+
+func (suite *RepliesTestSuite) TestBackfillReplies() {
+	var (
+		ctx          = context.Background()
+		fetchingAcct = suite.testAccounts["local_account_1"]
+		d, requests  = suite.newMockDereferencer(testRepliesResponses)
+	)
+
+	root, err := suite.db.GetStatusByURI(ctx, testRepliesRoot)
+	suite.NoError(err)
+
+	fetched, err := d.BackfillReplies(ctx, fetchingAcct.Username, root)
+	suite.NoError(err)
+	suite.True(fetched)
+
+	// The reply should now be stored, and
+	// should be threaded onto the root.
+	reply, err := suite.db.GetStatusByURI(ctx, testRepliesReply)
+	if suite.NoError(err) {
+		suite.Equal(root.ID, reply.InReplyToID)
+	}
+
+	// Backfilling again straight away
+	// should be prevented by cooldown.
+	fetched, err = d.BackfillReplies(ctx, fetchingAcct.Username, reply)
+	suite.NoError(err)
+	suite.False(fetched)
+	suite.Equal(1, requests[testRepliesCollection])
+}
+
+func TestRepliesTestSuite(t *testing.T) {
+	suite.Run(t, new(RepliesTestSuite))
+}
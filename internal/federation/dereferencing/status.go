@@ -270,6 +270,9 @@ func (d *Dereferencer) RefreshStatusAsync(
 			if err := d.DereferenceStatusAncestors(ctx, requestUser, latest); err != nil {
 				log.Error(ctx, err)
 			}
+			if err := d.DereferenceStatusQuote(ctx, requestUser, latest); err != nil {
+				log.Error(ctx, err)
+			}
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
@@ -500,8 +503,10 @@ func (d *Dereferencer) enrichStatus(
 
 	// Carry-over values and set fetch time.
 	latestStatus.UpdatedAt = status.UpdatedAt
+	latestStatus.EditedAt = status.EditedAt
 	latestStatus.FetchedAt = time.Now()
 	latestStatus.Local = status.Local
+	latestStatus.PendingApproval = status.PendingApproval
 
 	// Check if this is a permitted status we should accept.
 	permit, err := d.isPermittedStatus(ctx, status, latestStatus)
@@ -515,6 +520,24 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetNotPermitted(err)
 	}
 
+	if latestStatus.QuoteOf != nil {
+		// Check if the quote is permitted by the quoted status author.
+		permit, err := d.isPermittedQuote(ctx, latestStatus, latestStatus.QuoteOf)
+		if err != nil {
+			return nil, nil, gtserror.Newf("error checking quote permissibility for status %s: %w", uri, err)
+		}
+
+		if !permit {
+			// Keep the status, but don't link it to the
+			// quoted status; leave URI in place for later.
+			log.Infof(ctx, "not linking unpermitted quote of %s by %s", latestStatus.QuoteOfURI, uri)
+			latestStatus.QuoteOfID = ""
+			latestStatus.QuoteOf = nil
+			latestStatus.QuoteOfAccountID = ""
+			latestStatus.QuoteOfAccount = nil
+		}
+	}
+
 	// Ensure the status' mentions are populated, and pass in existing to check for changes.
 	if err := d.fetchStatusMentions(ctx, requestUser, status, latestStatus); err != nil {
 		return nil, nil, gtserror.Newf("error populating mentions for status %s: %w", uri, err)
@@ -553,6 +576,11 @@ func (d *Dereferencer) enrichStatus(
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
 	} else {
+		// Check for edits to the status, storing the previous version in its history.
+		if err := d.handleStatusEdit(ctx, status, latestStatus, apubStatus); err != nil {
+			log.Errorf(ctx, "error handling edit for status %s: %v", uri, err)
+		}
+
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
 			return nil, nil, gtserror.Newf("error updating database: %w", err)
@@ -621,27 +649,43 @@ func (d *Dereferencer) isPermittedStatus(
 		return onFail()
 	}
 
-	// Default to true
-	permitted = true
+	if !*status.InReplyTo.Local {
+		// Replies to remote statuses are
+		// up to the remote instance to allow.
+		return true, nil
+	}
 
-	if *status.InReplyTo.Local {
-		// Check visibility of inReplyTo to status author.
-		permitted, err = d.visibility.StatusVisible(ctx,
-			status.Account,
-			status.InReplyTo,
-		)
-		if err != nil {
-			return false, gtserror.Newf("error checking in-reply-to visibility: %w", err)
-		}
+	// Check visibility of inReplyTo to status author.
+	permitted, err = d.visibility.StatusVisible(ctx,
+		status.Account,
+		status.InReplyTo,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking in-reply-to visibility: %w", err)
 	}
 
-	if permitted &&
-		*status.InReplyTo.Replyable {
-		// Status is reply-able to.
-		return true, nil
+	if !permitted {
+		return onFail()
+	}
+
+	// Check the interaction policy of inReplyTo permits the reply.
+	permitted, err = d.visibility.StatusPolicyPermits(ctx,
+		status.Account,
+		status.InReplyTo,
+		status.InReplyTo.GetInteractionPolicy().CanReply,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking in-reply-to interaction policy: %w", err)
+	}
+
+	if !permitted && (existing == nil || existing.ID == "") {
+		// Rather than dropping a new reply that isn't permitted,
+		// hold it for approval by the author of inReplyTo.
+		log.Infof(ctx, "holding reply for approval: %s", status.URI)
+		status.PendingApproval = util.Ptr(true)
 	}
 
-	return onFail()
+	return true, nil
 }
 
 // populateMentionTarget tries to populate the given
@@ -1081,3 +1125,71 @@ func (d *Dereferencer) fetchStatusEmojis(ctx context.Context, requestUser string
 
 	return nil
 }
+
+// handleStatusEdit checks whether the content of the status
+// has been changed between existing and the latest version.
+// If so, the existing version is stored as a status edit,
+// and the latest version's edited time is set accordingly.
+func (d *Dereferencer) handleStatusEdit(
+	ctx context.Context,
+	existing *gtsmodel.Status,
+	status *gtsmodel.Status,
+	apubStatus ap.Statusable,
+) error {
+	var existingOptions, latestOptions []string
+	if existing.Poll != nil {
+		existingOptions = existing.Poll.Options
+	}
+	if status.Poll != nil {
+		latestOptions = status.Poll.Options
+	}
+
+	if existing.Content == status.Content &&
+		existing.ContentWarning == status.ContentWarning &&
+		util.PtrValueOr(existing.Sensitive, false) == util.PtrValueOr(status.Sensitive, false) &&
+		slices.Equal(existing.AttachmentIDs, status.AttachmentIDs) &&
+		slices.Equal(existingOptions, latestOptions) {
+		// Nothing changed.
+		return nil
+	}
+
+	// Take edit time from the 'updated' property if
+	// set, falling back to now. As with CreatedAt, we
+	// trust the remote's claim as long as it's sensible.
+	editedAt := ap.GetUpdated(apubStatus)
+	if editedAt.IsZero() || editedAt.After(time.Now()) {
+		editedAt = time.Now()
+	}
+
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      existing.EditedAt,
+		Content:        existing.Content,
+		ContentWarning: existing.ContentWarning,
+		Text:           existing.Text,
+		Language:       existing.Language,
+		Sensitive:      util.Ptr(util.PtrValueOr(existing.Sensitive, false)),
+		AttachmentIDs:  existing.AttachmentIDs,
+		PollOptions:    existingOptions,
+		StatusID:       existing.ID,
+	}
+
+	if edit.CreatedAt.IsZero() {
+		// Never edited before,
+		// so this is the original.
+		edit.CreatedAt = existing.CreatedAt
+	}
+
+	if existing.Poll != nil {
+		edit.PollVotes = existing.Poll.Votes
+	}
+
+	// Mark the latest version as edited.
+	status.EditedAt = editedAt
+
+	if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		return gtserror.Newf("error putting status edit in database: %w", err)
+	}
+
+	return nil
+}
@@ -55,6 +55,12 @@ func (d *Dereferencer) dereferenceThread(
 			log.Error(ctx, err)
 		}
 
+		// Quoted status (if any) is needed
+		// too, for display and visibility.
+		if err := d.DereferenceStatusQuote(ctx, requestUser, status); err != nil {
+			log.Error(ctx, err)
+		}
+
 		// Enqueue dereferencing remaining status thread, (children), asychronously .
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
@@ -67,6 +73,9 @@ func (d *Dereferencer) dereferenceThread(
 			if err := d.DereferenceStatusAncestors(ctx, requestUser, status); err != nil {
 				log.Error(ctx, err)
 			}
+			if err := d.DereferenceStatusQuote(ctx, requestUser, status); err != nil {
+				log.Error(ctx, err)
+			}
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
@@ -220,6 +229,20 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 
 // DereferenceStatusDescendents iterates downwards from the given status, using its replies, to ensure that as many children statuses as possible are dereferenced.
 func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, username string, statusIRI *url.URL, parent ap.Statusable) error {
+	_, err := d.dereferenceStatusDescendants(ctx, username, statusIRI, parent, nil)
+	return err
+}
+
+// dereferenceStatusDescendants is the package internal form of DereferenceStatusDescendants(). Children statuses
+// already stored are only re-fetched (to follow their own replies) when they're not fresh according to the given
+// window, falling back to default status freshness if nil. Returns the number of newly dereferenced statuses.
+func (d *Dereferencer) dereferenceStatusDescendants(
+	ctx context.Context,
+	username string,
+	statusIRI *url.URL,
+	parent ap.Statusable,
+	window *FreshnessWindow,
+) (int, error) {
 	statusIRIStr := statusIRI.String()
 
 	// Start log entry with fields
@@ -239,6 +262,10 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 	// pages for this thread to prevent recursion.
 	derefdPages := make(map[string]struct{}, 10)
 
+	// Number of new
+	// statuses fetched.
+	var fetched int
+
 	// frame represents a single stack frame when
 	// iteratively derefencing status descendants.
 	type frame struct {
@@ -265,7 +292,7 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 		stack = []*frame{
 			func() *frame {
 				// Start input frame is built from the first input.
-				page, pageURI := getAttachedStatusCollectionPage(parent)
+				page, pageURI := d.getStatusRepliesPage(ctx, username, parent)
 				if page == nil {
 					return nil
 				}
@@ -295,7 +322,7 @@ stackLoop:
 	for i := 0; i < maxIter; i++ {
 		// Pop next frame, nil means we are at end
 		if current = popStack(); current == nil {
-			return nil
+			return fetched, nil
 		}
 
 	pageLoop:
@@ -326,12 +353,33 @@ stackLoop:
 				//   - refetching recently fetched statuses (recursion!)
 				//   - remote domain is blocked (will return unretrievable)
 				//   - any http type error for a new status returns unretrievable
-				_, statusable, _, err := d.getStatusByURI(ctx, username, itemIRI)
+				status, statusable, isNew, err := d.getStatusByURI(ctx, username, itemIRI)
 				if err != nil {
 					l.Errorf("error dereferencing remote status %s: %v", itemIRI, err)
 					continue itemLoop
 				}
 
+				if isNew {
+					fetched++
+				}
+
+				if statusable == nil && window != nil &&
+					!statusFresh(status, window) {
+					// Status was fresh enough for getStatusByURI(),
+					// but not according to the freshness requested
+					// by caller, so re-fetch to check its replies.
+					_, statusable, _, err = d.enrichStatusSafely(ctx,
+						username,
+						itemIRI,
+						status,
+						nil,
+					)
+					if err != nil {
+						l.Errorf("error refreshing remote status %s: %v", itemIRI, err)
+						continue itemLoop
+					}
+				}
+
 				if statusable == nil {
 					// A nil statusable return from
 					// getStatusByURI() indicates a
@@ -341,8 +389,8 @@ stackLoop:
 					continue itemLoop
 				}
 
-				// Extract any attached collection + ID URI from status.
-				page, pageURI := getAttachedStatusCollectionPage(statusable)
+				// Extract any replies collection + ID URI from status.
+				page, pageURI := d.getStatusRepliesPage(ctx, username, statusable)
 				if page == nil {
 					continue itemLoop
 				}
@@ -393,7 +441,7 @@ stackLoop:
 		}
 	}
 
-	return gtserror.Newf("reached %d descendant iterations for %q", maxIter, statusIRIStr)
+	return fetched, gtserror.Newf("reached %d descendant iterations for %q", maxIter, statusIRIStr)
 }
 
 // updateStatusParent updates the given status' parent
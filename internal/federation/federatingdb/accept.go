@@ -66,6 +66,19 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				// Cast the vocab.Type object to known AS type.
 				asFollow := objType.(vocab.ActivityStreamsFollow)
 
+				// Check if this is a relay accepting
+				// the instance actor's Follow.
+				if ok, err := f.relayFollowResponse(ctx,
+					ap.GetJSONLDId(asFollow),
+					receivingAcct,
+					requestingAcct,
+					true,
+				); err != nil {
+					return err
+				} else if ok {
+					continue
+				}
+
 				// convert the follow to something we can understand
 				gtsFollow, err := f.converter.ASFollowToFollow(ctx, asFollow)
 				if err != nil {
@@ -110,6 +123,19 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				continue
 			}
 
+			// Check if this is a relay accepting
+			// the instance actor's Follow.
+			if ok, err := f.relayFollowResponse(ctx,
+				iri,
+				receivingAcct,
+				requestingAcct,
+				true,
+			); err != nil {
+				return err
+			} else if ok {
+				continue
+			}
+
 			// Serialize IRI.
 			iriStr := iri.String()
 
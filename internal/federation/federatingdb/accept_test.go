@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AcceptTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *AcceptTestSuite) TestAcceptRelayFollows() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relayAccount := suite.testAccounts["remote_account_1"]
+
+	// Subscribe to two relays on
+	// the same host, both pending.
+	relays := []*gtsmodel.Relay{
+		{
+			ID:        id.NewULID(),
+			InboxURI:  "http://fossbros-anonymous.io/inbox",
+			FollowURI: "http://localhost:8080/users/localhost:8080/follow/01J2D1R3TM0Z8XK1X2V4WS9G0N",
+			State:     gtsmodel.RelayStatePending,
+		},
+		{
+			ID:        id.NewULID(),
+			InboxURI:  "http://fossbros-anonymous.io/relay/inbox",
+			FollowURI: "http://localhost:8080/users/localhost:8080/follow/01J2D1S0F6KQ3NQ8TVY1AH5B7M",
+			State:     gtsmodel.RelayStatePending,
+		},
+	}
+
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	// Accept both Follows at once.
+	accept := streams.NewActivityStreamsAccept()
+	ap.SetJSONLDId(accept, testrig.URLMustParse("http://fossbros-anonymous.io/accepts/01J2D1TX5BMW3E7SQ2C6R3ZQ1D"))
+
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(testrig.URLMustParse(relayAccount.URI))
+	accept.SetActivityStreamsActor(actor)
+
+	object := streams.NewActivityStreamsObjectProperty()
+	for _, relay := range relays {
+		if err := suite.state.DB.PutRelay(context.Background(), relay); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		follow := streams.NewActivityStreamsFollow()
+		ap.SetJSONLDId(follow, testrig.URLMustParse(relay.FollowURI))
+		object.AppendActivityStreamsFollow(follow)
+	}
+	accept.SetActivityStreamsObject(object)
+
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Both relays should be accepted,
+	// not just the first one handled.
+	for _, relay := range relays {
+		dbRelay, err := suite.state.DB.GetRelayByFollowURI(context.Background(), relay.FollowURI)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(dbRelay.Accepted())
+		suite.Equal(relayAccount.URI, dbRelay.ActorURI)
+	}
+}
+
+func TestAcceptTestSuite(t *testing.T) {
+	suite.Run(t, &AcceptTestSuite{})
+}
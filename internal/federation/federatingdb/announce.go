@@ -25,6 +25,7 @@ import (
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
@@ -69,6 +70,18 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Check if this Announce is from a relay
+	// we're subscribed to, which we handle
+	// differently to a normal boost.
+	relay, err := f.getAcceptedRelay(ctx, requestingAcct.URI)
+	if err != nil {
+		return err
+	}
+
+	if relay != nil {
+		return f.relayAnnounce(ctx, announce, requestingAcct)
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
@@ -80,6 +93,33 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil
 	}
 
+	// If the boosted status is one of ours, make
+	// sure its interaction policy permits the boost.
+	boostOfURI, err := url.Parse(boost.BoostOfURI)
+	if err != nil {
+		return gtserror.Newf("error parsing boost target uri: %w", err)
+	}
+
+	if boostOfURI.Host == config.GetHost() {
+		boostOf, err := f.state.DB.GetStatusByURI(ctx, boost.BoostOfURI)
+		if err != nil {
+			return gtserror.Newf("error getting boost target %s: %w", boost.BoostOfURI, err)
+		}
+
+		boostable, err := f.visFilter.StatusBoostable(ctx, requestingAcct, boostOf)
+		if err != nil {
+			return gtserror.Newf("error checking boostability of %s: %w", boostOf.URI, err)
+		}
+
+		if !boostable {
+			log.Debugf(ctx,
+				"dropping boost of %s by %s: not permitted by interaction policy",
+				boostOf.URI, requestingAcct.URI,
+			)
+			return nil
+		}
+	}
+
 	// This is a new boost. Process side effects asynchronously.
 	f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
 		APObjectType:     ap.ActivityAnnounce,
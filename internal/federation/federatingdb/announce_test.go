@@ -18,6 +18,7 @@
 package federatingdb_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -97,6 +98,39 @@ func (suite *AnnounceTestSuite) TestAnnounceTwice() {
 	suite.Empty(suite.fromFederator)
 }
 
+func (suite *AnnounceTestSuite) TestRelayAnnounce() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relayAccount := suite.testAccounts["remote_account_1"]
+
+	// Subscribe to an accepted relay
+	// with remote_account_1 as actor.
+	relay := &gtsmodel.Relay{
+		ID:        id.NewULID(),
+		InboxURI:  "http://fossbros-anonymous.io/inbox",
+		FollowURI: "http://localhost:8080/users/localhost:8080/follow/01HY5G2PXK0V9W4MS1X7SA4ZQ2",
+		ActorURI:  relayAccount.URI,
+		State:     gtsmodel.RelayStateAccepted,
+	}
+	if err := suite.state.DB.PutRelay(context.Background(), relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(instanceAccount, relayAccount)
+	announce := suite.testActivities["announce_forwarded_1_zork"]
+
+	err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce))
+	suite.NoError(err)
+
+	// Announced post should be queued for dereferencing
+	// by the instance actor, rather than stored as a boost.
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Nil(msg.GTSModel)
+	suite.Equal(instanceAccount.ID, msg.ReceivingAccount.ID)
+	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIri.String())
+}
+
 func TestAnnounceTestSuite(t *testing.T) {
 	suite.Run(t, &AnnounceTestSuite{})
 }
@@ -467,6 +467,23 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		)
 	}
 
+	if fave.Status.IsLocal() {
+		// Make sure the faved status' interaction
+		// policy permits the requester to fave it.
+		likeable, err := f.visFilter.StatusLikeable(ctx, requestingAccount, fave.Status)
+		if err != nil {
+			return fmt.Errorf("activityLike: error checking likeability of %s: %w", fave.Status.URI, err)
+		}
+
+		if !likeable {
+			log.Debugf(ctx,
+				"dropping like of %s by %s: not permitted by interaction policy",
+				fave.Status.URI, requestingAccount.URI,
+			)
+			return nil
+		}
+	}
+
 	fave.ID = id.NewULID()
 
 	if err := f.state.DB.PutStatusFave(ctx, fave); err != nil {
@@ -54,6 +54,17 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 			// we have just the URI of whatever is being rejected, so we need to find out what it is
 			rejectedObjectIRI := obj.GetIRI()
 			if uris.IsFollowPath(rejectedObjectIRI) {
+				// Check if this is a relay rejecting
+				// the instance actor's Follow.
+				if ok, err := f.relayFollowResponse(ctx,
+					rejectedObjectIRI,
+					receivingAcct,
+					requestingAcct,
+					false,
+				); err != nil || ok {
+					return err
+				}
+
 				// REJECT FOLLOW
 				followReq, err := f.state.DB.GetFollowRequestByURI(ctx, rejectedObjectIRI.String())
 				if err != nil {
@@ -84,6 +95,17 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 				return errors.New("Reject: couldn't parse follow into vocab.ActivityStreamsFollow")
 			}
 
+			// Check if this is a relay rejecting
+			// the instance actor's Follow.
+			if ok, err := f.relayFollowResponse(ctx,
+				ap.GetJSONLDId(asFollow),
+				receivingAcct,
+				requestingAcct,
+				false,
+			); err != nil || ok {
+				return err
+			}
+
 			// convert the follow to something we can understand
 			gtsFollow, err := f.converter.ASFollowToFollow(ctx, asFollow)
 			if err != nil {
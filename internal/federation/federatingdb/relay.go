@@ -0,0 +1,185 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// relayFollowResponse handles an Accept or Reject (depending on
+// accepted) of the instance actor's Follow with given IRI, if
+// it was sent to a relay. Returns false if the Follow with
+// given IRI doesn't belong to a relay, so wasn't handled.
+func (f *federatingDB) relayFollowResponse(
+	ctx context.Context,
+	followIRI *url.URL,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+	accepted bool,
+) (bool, error) {
+	if followIRI == nil || !receivingAcct.IsInstance() {
+		// Relays are only ever
+		// followed by instance actor.
+		return false, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, followIRI.String())
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Not a relay Follow.
+			return false, nil
+		}
+		return false, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	// Make sure the account responding
+	// to the Follow is on the same host
+	// as the relay inbox it was sent to.
+	inboxURI, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return true, gtserror.Newf("error parsing relay inbox uri: %w", err)
+	}
+
+	actorURI, err := url.Parse(requestingAcct.URI)
+	if err != nil {
+		return true, gtserror.Newf("error parsing requesting account uri: %w", err)
+	}
+
+	if inboxURI.Host != actorURI.Host {
+		return true, gtserror.Newf(
+			"requesting account %s not on same host as relay inbox %s",
+			requestingAcct.URI, relay.InboxURI,
+		)
+	}
+
+	if accepted {
+		relay.State = gtsmodel.RelayStateAccepted
+	} else {
+		relay.State = gtsmodel.RelayStateRejected
+	}
+	relay.ActorURI = requestingAcct.URI
+
+	if err := f.state.DB.UpdateRelay(ctx, relay, "state", "actor_uri"); err != nil {
+		return true, gtserror.Newf("db error updating relay: %w", err)
+	}
+
+	log.Infof(ctx, "relay %s %s subscription", relay.InboxURI, relay.State)
+	return true, nil
+}
+
+// getAcceptedRelay returns the accepted relay
+// with the given actor URI, if there is one.
+func (f *federatingDB) getAcceptedRelay(ctx context.Context, actorURI string) (*gtsmodel.Relay, error) {
+	relays, err := f.state.DB.GetRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	for _, relay := range relays {
+		if relay.Accepted() && relay.ActorURI == actorURI {
+			return relay, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// relayAnnounce handles an Announce from an accepted relay,
+// which wraps public posts from other instances subscribed
+// to the relay. Rather than being treated as a boost by the
+// relay actor, each announced post is dereferenced + stored
+// by the instance actor, so that it appears in timelines.
+func (f *federatingDB) relayAnnounce(
+	ctx context.Context,
+	announce vocab.ActivityStreamsAnnounce,
+	requestingAcct *gtsmodel.Account,
+) error {
+	instanceAcct, err := f.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	for _, object := range ap.ExtractObjects(announce) {
+		var statusIRI *url.URL
+
+		switch {
+		case object.IsIRI():
+			// Just the post IRI.
+			statusIRI = object.GetIRI()
+
+		case object.GetType() != nil:
+			// Whole post, or an activity
+			// wrapping it. Either way, we
+			// don't trust the relay's copy
+			// so just take ID for deref.
+			t := object.GetType()
+			if statusable, ok := ap.ToStatusable(t); ok {
+				statusIRI = ap.GetJSONLDId(statusable)
+			} else if activity, ok := t.(ap.Activityable); ok &&
+				ap.IsActivityable(t.GetTypeName()) {
+				statusIRI = relayedStatusIRI(activity)
+			}
+		}
+
+		if statusIRI == nil {
+			log.Debugf(ctx, "ignoring unusable Announce object from relay %s", requestingAcct.URI)
+			continue
+		}
+
+		// Dereference + store the post asynchronously,
+		// as if it had been forwarded to the instance actor.
+		f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+			APObjectType:      ap.ObjectNote,
+			APActivityType:    ap.ActivityCreate,
+			APIri:             statusIRI,
+			RequestingAccount: requestingAcct,
+			ReceivingAccount:  instanceAcct,
+		})
+	}
+
+	return nil
+}
+
+// relayedStatusIRI returns the IRI of the
+// single post wrapped by the given activity.
+func relayedStatusIRI(activity ap.Activityable) *url.URL {
+	objects := ap.ExtractObjects(activity)
+	if len(objects) != 1 {
+		return nil
+	}
+
+	if objects[0].IsIRI() {
+		return objects[0].GetIRI()
+	}
+
+	if statusable, ok := ap.ToStatusable(objects[0].GetType()); ok {
+		return ap.GetJSONLDId(statusable)
+	}
+
+	return nil
+}
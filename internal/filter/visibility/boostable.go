@@ -24,7 +24,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
-// StatusBoostable checks if given status is boostable by requester, checking boolean status visibility to requester and ultimately the AP status visibility setting.
+// StatusBoostable checks if given status is boostable by requester, checking boolean status visibility to requester, the AP status visibility setting, and ultimately the status interaction policy.
 func (f *Filter) StatusBoostable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	if status.Visibility == gtsmodel.VisibilityDirect {
 		log.Trace(ctx, "direct statuses are not boostable")
@@ -53,10 +53,6 @@ func (f *Filter) StatusBoostable(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
-	if !*status.Boostable {
-		log.Trace(ctx, "status marked not boostable")
-		return false, nil
-	}
-
-	return true, nil
+	policy := status.GetInteractionPolicy()
+	return f.StatusPolicyPermits(ctx, requester, status, policy.CanBoost)
 }
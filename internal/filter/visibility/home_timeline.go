@@ -190,8 +190,20 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 	}
 
 	if follow == nil {
-		log.Trace(ctx, "ignoring status from unfollowed author")
-		return false, nil
+		// Owner doesn't follow author, but the
+		// status may still belong on the home
+		// timeline if it uses a followed tag.
+		followsTag, err := f.isFollowedTagStatus(ctx, owner, status)
+		if err != nil {
+			return false, err
+		}
+
+		if !followsTag {
+			log.Trace(ctx, "ignoring status from unfollowed author")
+			return false, nil
+		}
+
+		return true, nil
 	}
 
 	if status.BoostOfID != "" && !*follow.ShowReblogs {
@@ -277,3 +289,34 @@ func (f *Filter) isVisibleConversation(
 
 	return follow, false, nil
 }
+
+// isFollowedTagStatus returns whether the given status is
+// an original, public status using a tag that owner follows.
+func (f *Filter) isFollowedTagStatus(
+	ctx context.Context,
+	owner *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (bool, error) {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" {
+		// Only public originals are
+		// timelined by followed tags.
+		return false, nil
+	}
+
+	for _, tagID := range status.TagIDs {
+		following, err := f.state.DB.IsAccountFollowingTag(ctx,
+			owner.ID,
+			tagID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error checking tag follow %s->%s: %w", owner.ID, tagID, err)
+		}
+
+		if following {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
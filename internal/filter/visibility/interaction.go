@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// StatusReplyable checks if given status may be replied to by requester, checking status visibility to requester and ultimately the status interaction policy.
+func (f *Filter) StatusReplyable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	// Check whether status is visible to requesting account.
+	visible, err := f.StatusVisible(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if !visible {
+		log.Trace(ctx, "status not visible to requesting account")
+		return false, nil
+	}
+
+	policy := status.GetInteractionPolicy()
+	return f.StatusPolicyPermits(ctx, requester, status, policy.CanReply)
+}
+
+// StatusLikeable checks if given status may be liked/faved by requester, checking status visibility to requester and ultimately the status interaction policy.
+func (f *Filter) StatusLikeable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	// Check whether status is visible to requesting account.
+	visible, err := f.StatusVisible(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if !visible {
+		log.Trace(ctx, "status not visible to requesting account")
+		return false, nil
+	}
+
+	policy := status.GetInteractionPolicy()
+	return f.StatusPolicyPermits(ctx, requester, status, policy.CanLike)
+}
+
+// StatusPolicyPermits checks if requester falls within the group of accounts described by the given interaction policy value of status. This does NOT check status visibility.
+func (f *Filter) StatusPolicyPermits(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, value gtsmodel.PolicyValue) (bool, error) {
+	if requester.ID == status.AccountID {
+		// Status author can
+		// always interact.
+		return true, nil
+	}
+
+	switch value.OrEveryone() {
+	case gtsmodel.PolicyValueEveryone:
+		return true, nil
+
+	case gtsmodel.PolicyValueNobody:
+		log.Tracef(ctx, "status policy permits nobody")
+		return false, nil
+	}
+
+	if !status.MentionsPopulated() {
+		// Status needs its mentions populating, fetch these from database.
+		mentions, err := f.state.DB.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return false, gtserror.Newf("error populating status %s mentions: %w", status.ID, err)
+		}
+		status.Mentions = mentions
+	}
+
+	if status.MentionsAccount(requester.ID) {
+		// Mentioned accounts are permitted
+		// by both followers and mentioned.
+		return true, nil
+	}
+
+	if value == gtsmodel.PolicyValueMentioned {
+		log.Trace(ctx, "status policy permits only mentioned accounts")
+		return false, nil
+	}
+
+	// Check requester follows status author.
+	follows, err := f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		status.AccountID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, status.AccountID, err)
+	}
+
+	if !follows {
+		log.Trace(ctx, "status policy permits only followers")
+		return false, nil
+	}
+
+	return true, nil
+}
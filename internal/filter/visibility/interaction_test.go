@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusInteractionTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusInteractionTestSuite) policyPermits(
+	requester *gtsmodel.Account,
+	value gtsmodel.PolicyValue,
+) bool {
+	// Take a copy of a public status
+	// by admin, with no mentions.
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["admin_account_status_1"]
+	status.MentionIDs = nil
+	status.Mentions = nil
+
+	permits, err := suite.filter.StatusPolicyPermits(
+		context.Background(),
+		requester,
+		status,
+		value,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return permits
+}
+
+func (suite *StatusInteractionTestSuite) TestPolicyEveryone() {
+	suite.True(suite.policyPermits(suite.testAccounts["local_account_2"], ""))
+	suite.True(suite.policyPermits(suite.testAccounts["local_account_2"], gtsmodel.PolicyValueEveryone))
+}
+
+func (suite *StatusInteractionTestSuite) TestPolicyFollowers() {
+	// local_account_1 follows admin, local_account_2 doesn't.
+	suite.True(suite.policyPermits(suite.testAccounts["local_account_1"], gtsmodel.PolicyValueFollowers))
+	suite.False(suite.policyPermits(suite.testAccounts["local_account_2"], gtsmodel.PolicyValueFollowers))
+}
+
+func (suite *StatusInteractionTestSuite) TestPolicyMentioned() {
+	// Following doesn't help if not mentioned.
+	suite.False(suite.policyPermits(suite.testAccounts["local_account_1"], gtsmodel.PolicyValueMentioned))
+}
+
+func (suite *StatusInteractionTestSuite) TestPolicyNobody() {
+	// Only the author can interact.
+	suite.True(suite.policyPermits(suite.testAccounts["admin_account"], gtsmodel.PolicyValueNobody))
+	suite.False(suite.policyPermits(suite.testAccounts["local_account_1"], gtsmodel.PolicyValueNobody))
+}
+
+func TestStatusInteractionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusInteractionTestSuite))
+}
@@ -90,6 +90,14 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	if status.IsPendingApproval() {
+		// Replies pending approval are only visible to their
+		// author, and the author of the replied-to status.
+		return requester != nil &&
+			(requester.ID == status.AccountID ||
+				requester.ID == status.InReplyToAccountID), nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -62,6 +62,7 @@ type Account struct {
 	Bot                     *bool            `bun:",default:false"`                                              // Does this account identify itself as a bot?
 	Locked                  *bool            `bun:",default:true"`                                               // Does this account need an approval for new followers?
 	Discoverable            *bool            `bun:",default:false"`                                              // Should this account be shown in the instance's profile directory?
+	Indexable               *bool            `bun:",default:false"`                                              // Can this account's public statuses be found by others through full-text search?
 	URI                     string           `bun:",nullzero,notnull,unique"`                                    // ActivityPub URI for this account.
 	URL                     string           `bun:",nullzero,unique"`                                            // Web URL for this account's profile
 	InboxURI                string           `bun:",nullzero,unique"`                                            // Address of this account's ActivityPub inbox, for sending activity to
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Conversation represents direct-visibility statuses exchanged
+// within one thread between a local account and a fixed set
+// of other accounts, from the point of view of the local account.
+//
+// Each participating local account has their own Conversation,
+// so that read state etc. can be tracked per account.
+type Conversation struct {
+	ID               string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                         // id of this item in the database
+	CreatedAt        time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                      // when was item created
+	UpdatedAt        time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                      // when was item last updated
+	AccountID        string     `bun:"type:CHAR(26),unique:conversations_thread_id_account_id_other_accounts_key_uniq,nullzero,notnull"` // local account that owns this conversation
+	Account          *Account   `bun:"-"`                                                                                                // account corresponding to accountID
+	OtherAccountIDs  []string   `bun:"other_accounts,array"`                                                                             // ids of all other accounts participating in this conversation, sorted
+	OtherAccounts    []*Account `bun:"-"`                                                                                                // other accounts corresponding to otherAccountIDs
+	OtherAccountsKey string     `bun:",unique:conversations_thread_id_account_id_other_accounts_key_uniq,notnull"`                       // otherAccountIDs joined, for uniqueness and lookup; see ConversationOtherAccountsKey
+	ThreadID         string     `bun:"type:CHAR(26),unique:conversations_thread_id_account_id_other_accounts_key_uniq,nullzero,notnull"` // id of the thread this conversation takes place in
+	LastStatusID     string     `bun:"type:CHAR(26),nullzero,notnull"`                                                                   // id of the latest status in this conversation
+	LastStatus       *Status    `bun:"-"`                                                                                                // status corresponding to lastStatusID
+	Read             *bool      `bun:",default:false"`                                                                                   // has the owning account read the latest status?
+}
+
+// OtherAccountsPopulated returns whether other accounts are populated according to current OtherAccountIDs.
+func (c *Conversation) OtherAccountsPopulated() bool {
+	if len(c.OtherAccountIDs) != len(c.OtherAccounts) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range c.OtherAccountIDs {
+		if c.OtherAccounts[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+// ConversationOtherAccountsKey creates an OtherAccountsKey from a list of OtherAccountIDs.
+// The given slice will be sorted in place, so that the key doesn't depend on ordering.
+func ConversationOtherAccountsKey(otherAccountIDs []string) string {
+	slices.Sort(otherAccountIDs)
+	return strings.Join(otherAccountIDs, ",")
+}
+
+// ConversationToStatus is an intermediate struct to facilitate the
+// many2many relationship between a conversation and its statuses.
+type ConversationToStatus struct {
+	ConversationID string `bun:"type:CHAR(26),unique:conversation_to_statuses_conversation_id_status_id_uniq,nullzero,notnull"`
+	StatusID       string `bun:"type:CHAR(26),unique:conversation_to_statuses_conversation_id_status_id_uniq,nullzero,notnull"`
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Delivery represents a single queued delivery of an outgoing
+// ActivityPub activity to one remote inbox. Deliveries are
+// persisted so that they can be retried with backoff on failure,
+// and survive restarts of the instance.
+type Delivery struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	PubKeyID      string    `bun:",nullzero,notnull"`                                           // ID of the local public key to sign the delivery with.
+	InboxURI      string    `bun:",nullzero,notnull"`                                           // URI of the remote inbox to deliver to.
+	Data          []byte    `bun:",nullzero,notnull"`                                           // Serialized ActivityPub activity to deliver.
+	Attempts      int       `bun:",notnull,default:0"`                                          // Number of delivery attempts made so far.
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // Time at which the next delivery attempt is due.
+	LastAttemptAt time.Time `bun:"type:timestamptz,nullzero"`                                   // Time of the most recent delivery attempt, if any.
+	LastError     string    `bun:",nullzero"`                                                   // Error returned by the most recent failed delivery attempt, if any.
+	FailedAt      time.Time `bun:"type:timestamptz,nullzero"`                                   // Time at which delivery was given up on, if it was.
+}
+
+// Failed returns true if delivery has been given up on.
+func (d *Delivery) Failed() bool {
+	return !d.FailedAt.IsZero()
+}
+
+// DeliveryStatus can be used to
+// filter deliveries by their status.
+type DeliveryStatus int
+
+const (
+	DeliveryStatusUnknown DeliveryStatus = iota
+	DeliveryStatusAny                    // Deliveries of any status.
+	DeliveryStatusPending                // Deliveries still to be attempted.
+	DeliveryStatusFailed                 // Deliveries that have been given up on.
+)
+
+func (s DeliveryStatus) String() string {
+	switch s {
+	case DeliveryStatusAny:
+		return "any"
+	case DeliveryStatusPending:
+		return "pending"
+	case DeliveryStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+func NewDeliveryStatus(in string) DeliveryStatus {
+	switch in {
+	case "any":
+		return DeliveryStatusAny
+	case "pending":
+		return DeliveryStatusPending
+	case "failed":
+		return DeliveryStatusFailed
+	default:
+		return DeliveryStatusUnknown
+	}
+}
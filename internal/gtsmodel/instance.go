@@ -43,4 +43,18 @@ type Instance struct {
 	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
 	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
 	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
+	VAPIDPublicKey         string       `bun:",nullzero"`                                                   // Base64url-encoded P-256 public key used to identify this instance to Web Push services. Only set for the local instance.
+	VAPIDPrivateKey        string       `bun:",nullzero"`                                                   // Base64url-encoded P-256 private key used to sign Web Push requests. Only set for the local instance.
+	DeliveredAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // Time of the most recent successful delivery to this instance, if any.
+	DeliveryFailedAt       time.Time    `bun:"type:timestamptz,nullzero"`                                   // Time of the most recent failed delivery to this instance, if any.
+	DeliveryFailingSince   time.Time    `bun:"type:timestamptz,nullzero"`                                   // Time of the first failed delivery to this instance since the last successful one. Zero if the last delivery succeeded.
+	UnavailableAt          time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this instance marked unavailable due to sustained delivery failures, if at all?
+}
+
+// Unavailable returns true if this instance has been marked unavailable
+// due to sustained delivery failures, and hasn't since been probed
+// successfully. Deliveries to and dereferencing from unavailable
+// instances are skipped until it's reachable again.
+func (i *Instance) Unavailable() bool {
+	return !i.UnavailableAt.IsZero()
 }
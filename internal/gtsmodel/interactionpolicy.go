@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+// PolicyValue represents a group of accounts
+// permitted to perform a certain kind of
+// interaction (reply, boost, like) with a status.
+type PolicyValue string
+
+const (
+	PolicyValueEveryone  PolicyValue = "everyone"  // Anyone who can see the status.
+	PolicyValueFollowers PolicyValue = "followers" // Followers of the status author, and accounts mentioned in the status.
+	PolicyValueMentioned PolicyValue = "mentioned" // Only accounts mentioned in the status.
+	PolicyValueNobody    PolicyValue = "nobody"    // Nobody except the status author.
+)
+
+// InteractionPolicy describes which groups of
+// accounts are permitted to interact with a status.
+//
+// Empty values are equivalent to PolicyValueEveryone.
+type InteractionPolicy struct {
+	CanReply PolicyValue `bun:",nullzero"` // Who can reply to the status.
+	CanBoost PolicyValue `bun:",nullzero"` // Who can boost the status.
+	CanLike  PolicyValue `bun:",nullzero"` // Who can like/fave the status.
+}
+
+// IsZero returns whether no policy values are
+// set, ie., whether everyone may interact.
+func (p InteractionPolicy) IsZero() bool {
+	return p == InteractionPolicy{}
+}
+
+// OrEveryone returns the policy value,
+// or PolicyValueEveryone if it's not set.
+func (v PolicyValue) OrEveryone() PolicyValue {
+	if v == "" {
+		return PolicyValueEveryone
+	}
+	return v
+}
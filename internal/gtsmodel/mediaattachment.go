@@ -39,6 +39,7 @@ type MediaAttachment struct {
 	Processing        ProcessingStatus `bun:",notnull,default:2"`                                          // What is the processing status of this attachment
 	File              File             `bun:",embed:file_,notnull,nullzero"`                               // metadata for the whole file
 	Thumbnail         Thumbnail        `bun:",embed:thumbnail_,notnull,nullzero"`                          // small image thumbnail derived from a larger image, video, or audio file.
+	Medium            Medium           `bun:",embed:medium_,nullzero"`                                     // medium-sized image rendition derived from a larger image or video file.
 	Avatar            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
 	Header            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
 	Cached            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
@@ -62,6 +63,15 @@ type Thumbnail struct {
 	RemoteURL   string    `bun:",nullzero"`                                                   // What is the remote URL of the thumbnail (empty for local media)
 }
 
+// Medium refers to a medium-sized image rendition derived from a larger image or video
+// file, sitting between the small thumbnail and the original file. Not set for audio.
+type Medium struct {
+	Path        string `bun:",nullzero"` // Path of the file in storage.
+	ContentType string `bun:",nullzero"` // MIME content type of the file.
+	FileSize    int    `bun:",nullzero"` // File size in bytes
+	URL         string `bun:",nullzero"` // What is the URL of the rendition on the local server
+}
+
 // ProcessingStatus refers to how far along in the processing stage the attachment is.
 type ProcessingStatus int
 
@@ -106,9 +116,10 @@ type Original struct {
 	Height    int      // height in pixels
 	Size      int      // size in pixels (width * height)
 	Aspect    float32  // aspect ratio (width / height)
-	Duration  *float32 // video-specific: duration of the video in seconds
+	Duration  *float32 // video/audio-specific: duration of the video or audio in seconds
 	Framerate *float32 // video-specific: fps
-	Bitrate   *uint64  // video-specific: bitrate
+	Bitrate   *uint64  // video/audio-specific: bitrate
+	Channels  *int     // audio-specific: number of audio channels
 }
 
 // Focus describes the 'center' of the image for display purposes.
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Relay represents a subscription of this instance
+// to an ActivityPub relay, which rebroadcasts public
+// posts between all of the instances subscribed to it.
+type Relay struct {
+	ID        string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	InboxURI  string     `bun:",nullzero,notnull,unique"`                                    // URI of the relay's inbox, to which the instance actor's Follow and local public posts are sent.
+	FollowURI string     `bun:",nullzero,notnull,unique"`                                    // URI of the Follow sent from the instance actor to the relay.
+	ActorURI  string     `bun:",nullzero"`                                                   // URI of the relay's actor, set once the relay has accepted the Follow.
+	State     RelayState `bun:",nullzero,notnull"`                                           // State of the subscription to this relay.
+}
+
+// Accepted returns true if the relay has
+// accepted the Follow from the instance actor.
+func (r *Relay) Accepted() bool {
+	return r.State == RelayStateAccepted
+}
+
+// RelayState represents the state
+// of a subscription to a relay.
+type RelayState int16
+
+const (
+	RelayStateUnknown  RelayState = iota
+	RelayStatePending             // Follow sent, awaiting response from relay.
+	RelayStateAccepted            // Follow accepted by relay.
+	RelayStateRejected            // Follow rejected by relay.
+)
+
+func (s RelayState) String() string {
+	switch s {
+	case RelayStatePending:
+		return "pending"
+	case RelayStateAccepted:
+		return "accepted"
+	case RelayStateRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that a local account has
+// queued up to be posted at some point in the future. It holds
+// the parameters the status was submitted with, and will be
+// turned into a real Status when ScheduledAt is reached.
+type ScheduledStatus struct {
+	ID                string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string             `bun:"type:CHAR(26),nullzero,notnull"`                              // which account scheduled this status?
+	Account           *Account           `bun:"-"`                                                           // account corresponding to accountID
+	ScheduledAt       time.Time          `bun:"type:timestamptz,nullzero,notnull"`                           // when should the status be posted?
+	Text              string             `bun:""`                                                            // text of the status, without formatting
+	PollOptions       []string           `bun:",nullzero"`                                                   // options of the poll to attach to the status, if any
+	PollExpiresIn     int                `bun:",nullzero"`                                                   // duration in seconds the poll should be open for, from the time of posting
+	PollMultiple      *bool              `bun:",nullzero,notnull,default:false"`                             // poll allows multiple choices?
+	PollHideTotals    *bool              `bun:",nullzero,notnull,default:false"`                             // poll hides vote counts until it ends?
+	MediaIDs          []string           `bun:"attachments,array"`                                           // database IDs of media attachments to attach to the status
+	MediaAttachments  []*MediaAttachment `bun:"-"`                                                           // attachments corresponding to mediaIDs
+	Sensitive         *bool              `bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
+	SpoilerText       string             `bun:",nullzero"`                                                   // cw string for the status
+	Visibility        Visibility         `bun:",nullzero"`                                                   // visibility of the status; empty means account default
+	InReplyToID       string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status the status replies to
+	QuoteID           string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status the status quotes
+	Language          string             `bun:",nullzero"`                                                   // language of the status; empty means account default
+	ContentType       string             `bun:",nullzero"`                                                   // content type used to format the status text; empty means account default
+	Federated         *bool              `bun:",nullzero"`                                                   // the status will be federated beyond the local timeline(s)
+	Boostable         *bool              `bun:",nullzero"`                                                   // the status can be boosted/reblogged
+	Replyable         *bool              `bun:",nullzero"`                                                   // the status can be replied to
+	Likeable          *bool              `bun:",nullzero"`                                                   // the status can be liked/faved
+	Quotable          *bool              `bun:",nullzero"`                                                   // the status can be quoted
+	InteractionPolicy InteractionPolicy  `bun:"embed:policy_"`                                               // who can reply to, boost, or like the status
+	ApplicationID     string             `bun:"type:CHAR(26),nullzero,notnull"`                              // which application was used to schedule this status?
+	Application       *Application       `bun:"-"`                                                           // application corresponding to applicationID
+}
+
+// AttachmentsPopulated returns whether media attachments are populated according to current MediaIDs.
+func (s *ScheduledStatus) AttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if s.MediaAttachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -29,6 +29,7 @@ type Status struct {
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status content was last edited at this time.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
@@ -54,6 +55,11 @@ type Status struct {
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the boosted status
 	BoostOf                  *Status            `bun:"-"`                                                           // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `bun:"rel:belongs-to"`                                              // account that corresponds to boostOfAccountID
+	QuoteOfID                string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteOfURI               string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	QuoteOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the quoted status
+	QuoteOf                  *Status            `bun:"-"`                                                           // status that corresponds to quoteOfID
+	QuoteOfAccount           *Account           `bun:"-"`                                                           // account that corresponds to quoteOfAccountID
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
@@ -69,6 +75,14 @@ type Status struct {
 	Boostable                *bool              `bun:",notnull"`                                                    // This status can be boosted/reblogged
 	Replyable                *bool              `bun:",notnull"`                                                    // This status can be replied to
 	Likeable                 *bool              `bun:",notnull"`                                                    // This status can be liked/faved
+	Quotable                 *bool              `bun:",nullzero,notnull,default:true"`                              // This status can be quoted (if public or unlisted)
+	InteractionPolicy        InteractionPolicy  `bun:"embed:policy_"`                                               // Who is permitted to reply to, boost, or like this status.
+	PendingApproval          *bool              `bun:",nullzero,notnull,default:false"`                             // This status is a reply awaiting approval by the author of the replied-to status.
+	Title                    string             `bun:",nullzero"`                                                   // title of this status, if it's an Article, Page or Event
+	Abstract                 string             `bun:",nullzero"`                                                   // abstract / summary of this status, if it's an Article, Page or Event
+	StartTime                time.Time          `bun:"type:timestamptz,nullzero"`                                   // time at which this status starts, if it's an Event
+	EndTime                  time.Time          `bun:"type:timestamptz,nullzero"`                                   // time at which this status ends, if it's an Event
+	Location                 string             `bun:",nullzero"`                                                   // name of the place at which this status takes place, if it's an Event
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -193,6 +207,59 @@ func (s *Status) GetTagByName(name string) (*Tag, bool) {
 	return nil, false
 }
 
+// IsQuotable returns whether status may be quoted by the account with
+// the given ID, ie., whether it's public or unlisted and either authored
+// by the account, or its author hasn't opted out of it being quoted.
+func (s *Status) IsQuotable(accountID string) bool {
+	switch {
+	case s.BoostOfID != "":
+		// Boost wrappers
+		// can't be quoted.
+		return false
+	case s.Visibility != VisibilityPublic &&
+		s.Visibility != VisibilityUnlocked:
+		// Only public and unlisted statuses
+		// can be quoted, even by their author.
+		return false
+	case s.AccountID == accountID:
+		// Authors can always
+		// quote themselves.
+		return true
+	default:
+		return s.Quotable == nil || *s.Quotable
+	}
+}
+
+// GetInteractionPolicy returns the interaction policy of this
+// status, falling back to the Boostable, Replyable and Likeable
+// flags for statuses created without an interaction policy.
+func (s *Status) GetInteractionPolicy() InteractionPolicy {
+	policy := s.InteractionPolicy
+
+	if policy.CanReply == "" && s.Replyable != nil && !*s.Replyable {
+		policy.CanReply = PolicyValueNobody
+	}
+
+	if policy.CanBoost == "" && s.Boostable != nil && !*s.Boostable {
+		policy.CanBoost = PolicyValueNobody
+	}
+
+	if policy.CanLike == "" && s.Likeable != nil && !*s.Likeable {
+		policy.CanLike = PolicyValueNobody
+	}
+
+	policy.CanReply = policy.CanReply.OrEveryone()
+	policy.CanBoost = policy.CanBoost.OrEveryone()
+	policy.CanLike = policy.CanLike.OrEveryone()
+	return policy
+}
+
+// IsPendingApproval returns whether this status is a
+// reply awaiting approval by the replied-to author.
+func (s *Status) IsPendingApproval() bool {
+	return s.PendingApproval != nil && *s.PendingApproval
+}
+
 // MentionsAccount returns whether status mentions the given account ID.
 func (s *Status) MentionsAccount(accountID string) bool {
 	return slices.ContainsFunc(s.Mentions, func(m *Mention) bool {
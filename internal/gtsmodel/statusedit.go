@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusEdit represents a **historical** view of a Status
+// after a change has been made to its content: that is,
+// StatusEdit contains the content of the status as it was
+// *before* the edit was made, and CreatedAt is the time
+// at which that previous version of the status was created.
+type StatusEdit struct {
+	ID             string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt      time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when this version of the status was created
+	Content        string             `bun:""`                                                            // content of the status at this version
+	ContentWarning string             `bun:",nullzero"`                                                   // cw string of the status at this version
+	Text           string             `bun:""`                                                            // original text of the status at this version, without formatting
+	Language       string             `bun:",nullzero"`                                                   // language of the status at this version
+	Sensitive      *bool              `bun:",nullzero,notnull,default:false"`                             // whether the status was marked sensitive at this version
+	AttachmentIDs  []string           `bun:"attachments,array"`                                           // database IDs of media attachments on the status at this version
+	Attachments    []*MediaAttachment `bun:"-"`                                                           // attachments corresponding to attachmentIDs
+	PollOptions    []string           `bun:",nullzero"`                                                   // poll options of the status at this version, if any
+	PollVotes      []int              `bun:",nullzero"`                                                   // poll vote counts of the status at this version, if any
+	StatusID       string             `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the status this is a historical version of
+}
+
+// AttachmentsPopulated returns whether media attachments are populated according to current AttachmentIDs.
+func (e *StatusEdit) AttachmentsPopulated() bool {
+	if len(e.AttachmentIDs) != len(e.Attachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range e.AttachmentIDs {
+		if e.Attachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StorageBlob represents a single content-addressed value in storage,
+// keyed by the hash of its contents, which may be shared by any number
+// of storage keys (eg., the same image fetched for many statuses).
+type StorageBlob struct {
+	Hash      string    `bun:",pk,nullzero,notnull,unique"`                                 // Hex-encoded SHA-256 hash of the blob contents.
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Size      int64     `bun:",notnull"`                                                    // Size of the blob contents in bytes.
+	Refs      int       `bun:",notnull"`                                                    // Number of storage keys referencing this blob.
+}
+
+// StorageBlobRef maps a storage key (eg., the file path
+// of an attachment or emoji) to the blob holding its contents.
+type StorageBlobRef struct {
+	Key       string    `bun:",pk,nullzero,notnull,unique"`                                 // Storage key referencing the blob.
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Hash      string    `bun:",nullzero,notnull"`                                           // Hash of the referenced blob.
+}
@@ -29,3 +29,15 @@ type Tag struct {
 	Listable  *bool     `bun:",nullzero,notnull,default:true"`                              // Tagged statuses can be listed on this instance.
 	Href      string    `bun:"-"`                                                           // Href of the hashtag. Will only be set on freshly-extracted hashtags from remote AP messages. Not stored in the database.
 }
+
+// FollowedTag represents an account following a hashtag, so
+// that public statuses using that hashtag are put in the home
+// timeline of the account, as if they came from a followed account.
+type FollowedTag struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                   // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                // when was item created
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:followed_tags_account_id_tag_id_uniq"` // ID of the account following the tag.
+	Account   *Account  `bun:"-"`                                                                          // Account corresponding to AccountID.
+	TagID     string    `bun:"type:CHAR(26),nullzero,notnull,unique:followed_tags_account_id_tag_id_uniq"` // ID of the followed tag.
+	Tag       *Tag      `bun:"-"`                                                                          // Tag corresponding to TagID.
+}
@@ -56,6 +56,19 @@ type User struct {
 	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
 	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	TwoFactorSecret        string       `bun:",nullzero"`                                                   // Base32-encoded TOTP secret for this user's second factor. Set during enrolment, before TwoFactorEnabledAt.
+	TwoFactorBackups       []string     `bun:",nullzero,array"`                                             // Bcrypt hashes of the not-yet-used one-time backup codes for this user's second factor.
+	TwoFactorEnabledAt     time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user confirm enrolment of their second factor? Zero if 2FA is not enabled.
+	TwoFactorLastStep      int64        `bun:",nullzero"`                                                   // TOTP time step of the last code accepted for this user, so that codes can't be used twice.
+	TwoFactorFailures      int          `bun:",nullzero"`                                                   // Number of incorrect second factor codes entered in a row by this user, the last at TwoFactorFailedAt.
+	TwoFactorFailedAt      time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user last enter an incorrect second factor code?
+}
+
+// TwoFactorEnabled returns true if the user has
+// completed enrolment of a TOTP second factor,
+// and so must provide a code when signing in.
+func (u *User) TwoFactorEnabled() bool {
+	return !u.TwoFactorEnabledAt.IsZero()
 }
 
 // NewSignup models parameters for the creation
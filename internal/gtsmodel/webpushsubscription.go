@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents a client's Web Push subscription, through which
+// notifications for the owning account are delivered to a push service endpoint.
+// There can be at most one subscription per access token.
+type WebPushSubscription struct {
+	ID                string                               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string                               `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that owns this subscription.
+	TokenID           string                               `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the access token that created this subscription.
+	Endpoint          string                               `bun:",nullzero,notnull"`                                           // URL of the push service endpoint that notifications are POSTed to.
+	Auth              string                               `bun:",nullzero,notnull"`                                           // Base64-encoded authentication secret supplied by the client.
+	P256dh            string                               `bun:",nullzero,notnull"`                                           // Base64-encoded P-256 ECDH public key supplied by the client.
+	NotificationFlags WebPushSubscriptionNotificationFlags `bun:",notnull,default:0"`                                          // Which types of notification should be pushed.
+	Policy            WebPushNotificationPolicy            `bun:",nullzero,notnull,default:'all'"`                             // From which accounts should notifications be pushed.
+}
+
+// WebPushSubscriptionNotificationFlags is a
+// bitfield representation of a set of NotificationTypes.
+type WebPushSubscriptionNotificationFlags int64
+
+// webPushNotificationTypes defines the bit position of each
+// NotificationType in WebPushSubscriptionNotificationFlags.
+// Only ever append to this, as the positions are stored in the db!
+var webPushNotificationTypes = []NotificationType{
+	NotificationFollow,
+	NotificationFollowRequest,
+	NotificationMention,
+	NotificationReblog,
+	NotificationFave,
+	NotificationPoll,
+	NotificationStatus,
+}
+
+// Get returns whether the given NotificationType is set in the flags.
+func (f WebPushSubscriptionNotificationFlags) Get(notificationType NotificationType) bool {
+	for i, t := range webPushNotificationTypes {
+		if t == notificationType {
+			return f&(1<<i) != 0
+		}
+	}
+	return false
+}
+
+// Set sets or unsets the given NotificationType in the flags.
+func (f *WebPushSubscriptionNotificationFlags) Set(notificationType NotificationType, value bool) {
+	for i, t := range webPushNotificationTypes {
+		if t == notificationType {
+			if value {
+				*f |= 1 << i
+			} else {
+				*f &^= 1 << i
+			}
+			return
+		}
+	}
+}
+
+// WebPushNotificationPolicy describes from which
+// accounts a Web Push subscription receives notifications.
+type WebPushNotificationPolicy string
+
+const (
+	WebPushNotificationPolicyAll      WebPushNotificationPolicy = "all"      // Push notifications from anyone.
+	WebPushNotificationPolicyFollowed WebPushNotificationPolicy = "followed" // Push notifications from accounts the owner follows.
+	WebPushNotificationPolicyFollower WebPushNotificationPolicy = "follower" // Push notifications from accounts that follow the owner.
+	WebPushNotificationPolicyNone     WebPushNotificationPolicy = "none"     // Push no notifications.
+)
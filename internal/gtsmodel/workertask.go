@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WorkerTask represents a single message queued for processing
+// by one of the client API or federator worker pools, persisted
+// so that it can be replayed if the instance restarts or crashes
+// before the message has been processed.
+type WorkerTask struct {
+	ID         string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt  time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	WorkerType WorkerType `bun:",nullzero,notnull"`                                           // Type of worker pool this task is queued for.
+	TaskData   []byte     `bun:",nullzero,notnull"`                                           // Serialized worker message.
+}
+
+// WorkerType represents the
+// worker pool a task is for.
+type WorkerType int16
+
+const (
+	WorkerTypeUnknown   WorkerType = iota
+	WorkerTypeClientAPI            // Client API worker pool.
+	WorkerTypeFediAPI              // Federator worker pool.
+)
+
+func (t WorkerType) String() string {
+	switch t {
+	case WorkerTypeClientAPI:
+		return "client_api"
+	case WorkerTypeFediAPI:
+		return "fedi_api"
+	default:
+		return "unknown"
+	}
+}
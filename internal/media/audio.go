@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// audioWaveformSize is the
+	// size of rendered waveforms.
+	audioWaveformSize = "640x240"
+
+	// audioWaveformColor is the
+	// color of rendered waveforms.
+	audioWaveformColor = "#f7a85c"
+)
+
+type gtsAudio struct {
+	preview  *gtsImage // cover art or waveform
+	duration float32   // in seconds
+	bitrate  uint64
+	channels int
+}
+
+// decodeAudio decodes metadata from the given audio stream, along with a preview
+// image; either the embedded cover art, or a rendered waveform if there's none.
+func decodeAudio(ctx context.Context, r io.Reader) (*gtsAudio, error) {
+	tf, err := os.CreateTemp(os.TempDir(), "gts-audio")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file for audio processing: %w", err)
+	}
+
+	defer func() {
+		_ = tf.Close()
+		if err := os.Remove(tf.Name()); err != nil {
+			log.Errorf(ctx, "error removing temporary file %s: %v", tf.Name(), err)
+		}
+	}()
+
+	if _, err := io.Copy(tf, r); err != nil {
+		return nil, fmt.Errorf("writing audio for processing: %w", err)
+	}
+
+	audio, err := probeAudio(ctx, tf.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer embedded cover art (eg., album art or
+	// podcast artwork), falling back to a waveform.
+	audio.preview, err = extractCoverArt(ctx, tf.Name())
+	if err != nil {
+		log.Debugf(ctx, "no usable cover art, rendering waveform: %v", err)
+
+		audio.preview, err = renderWaveform(ctx, tf.Name())
+		if err != nil {
+			return nil, fmt.Errorf("rendering waveform: %w", err)
+		}
+	}
+
+	return audio, nil
+}
+
+// probeAudio uses ffprobe to read duration, bitrate and number
+// of channels from the first audio stream of the file at path.
+func probeAudio(ctx context.Context, filepath string) (*gtsAudio, error) {
+	out, err := ffprobe(ctx, filepath,
+		"-select_streams", "a:0",
+		"-show_entries", "stream=duration,bit_rate,channels:format=duration,bit_rate",
+		"-of", "json",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	probe := &struct {
+		Streams []struct {
+			Duration string `json:"duration"`
+			BitRate  string `json:"bit_rate"`
+			Channels int    `json:"channels"`
+		} `json:"streams"`
+		Format struct {
+			Duration string `json:"duration"`
+			BitRate  string `json:"bit_rate"`
+		} `json:"format"`
+	}{}
+	if err := json.Unmarshal(out, probe); err != nil {
+		return nil, fmt.Errorf("failed parsing metadata: %w", err)
+	}
+	if len(probe.Streams) == 0 {
+		return nil, fmt.Errorf("media container did not contain any audio streams")
+	}
+
+	s := probe.Streams[0]
+	audio := gtsAudio{channels: s.Channels}
+
+	// Not all containers have duration or bitrate set per stream
+	// (eg., ogg), fall back to container format values for these.
+	duration, bitrate := s.Duration, s.BitRate
+	if duration == "" || duration == "N/A" {
+		duration = probe.Format.Duration
+	}
+	if bitrate == "" || bitrate == "N/A" {
+		bitrate = probe.Format.BitRate
+	}
+
+	// duration
+	dur, err := strconv.ParseFloat(duration, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode audio duration with value %s", duration)
+	}
+	audio.duration = float32(dur)
+
+	// bitrate
+	br, err := strconv.ParseUint(bitrate, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode audio bitrate with value %s", bitrate)
+	}
+	audio.bitrate = br
+
+	return &audio, nil
+}
+
+// extractCoverArt uses ffmpeg to extract the
+// embedded cover art from file at path, if any.
+func extractCoverArt(ctx context.Context, filepath string) (*gtsImage, error) {
+	return ffmpegImage(ctx, filepath,
+		"-an",
+		"-map", "0:v:0",
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "mjpeg",
+		"pipe:1",
+	)
+}
+
+// renderWaveform uses ffmpeg to render an
+// image of the waveform of file at path.
+func renderWaveform(ctx context.Context, filepath string) (*gtsImage, error) {
+	return ffmpegImage(ctx, filepath,
+		"-filter_complex", "aformat=channel_layouts=mono,showwavespic=s="+audioWaveformSize+":colors="+audioWaveformColor,
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "png",
+		"pipe:1",
+	)
+}
@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"os"
+
+	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/matchers"
+	"github.com/h2non/filetype/matchers/isobmff"
+	"github.com/h2non/filetype/types"
+)
+
+func init() {
+	// Register matchers for image
+	// types that filetype doesn't
+	// (yet) recognize by itself.
+	filetype.AddMatcher(types.NewType(mimeAvif, mimeImageAvif), isAVIF)
+	filetype.AddMatcher(types.NewType(mimeHeic, mimeImageHeic), isHEIC)
+	filetype.AddMatcher(types.NewType(mimeJxl, mimeImageJxl), isJXL)
+}
+
+// isAVIF returns whether buf is the start of an AVIF image.
+func isAVIF(buf []byte) bool {
+	return isobmffBrand(buf, "avif", "avis")
+}
+
+// isHEIC returns whether buf is the start of a HEIC image that
+// filetype doesn't already match as HEIF (ie., one without a
+// "mif1" or "heic" major brand).
+func isHEIC(buf []byte) bool {
+	return isobmffBrand(buf, "heix", "hevc", "hevx", "heim", "heis")
+}
+
+// isobmffBrand returns whether buf is the start of an ISO base
+// media file with one of the given brands as its major brand,
+// or as a compatible brand of a generic "mif1" / "msf1" file.
+func isobmffBrand(buf []byte, brands ...string) bool {
+	if !isobmff.IsISOBMFF(buf) {
+		return false
+	}
+
+	major, _, compatible := isobmff.GetFtyp(buf)
+	for _, brand := range brands {
+		if major == brand {
+			return true
+		}
+	}
+
+	if major != "mif1" && major != "msf1" {
+		return false
+	}
+
+	for _, brand := range brands {
+		for _, c := range compatible {
+			if c == brand {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var (
+	// jxlCodestream is the signature
+	// of a bare JPEG XL codestream.
+	jxlCodestream = []byte{0xFF, 0x0A}
+
+	// jxlContainer is the signature of
+	// a JPEG XL image in a container.
+	jxlContainer = []byte{
+		0x00, 0x00, 0x00, 0x0C,
+		'J', 'X', 'L', ' ',
+		0x0D, 0x0A, 0x87, 0x0A,
+	}
+)
+
+// isJXL returns whether buf is the start of a JPEG XL image.
+func isJXL(buf []byte) bool {
+	return bytes.HasPrefix(buf, jxlCodestream) ||
+		bytes.HasPrefix(buf, jxlContainer)
+}
+
+// convertImage decodes an image in a format that most browsers (and Go)
+// can't decode, such as AVIF, HEIC or JPEG XL, using ffmpeg. It returns
+// a stream of the image re-encoded as JPEG, or as PNG if the image has
+// transparency, along with the type of the re-encoded image.
+//
+// As the image is re-encoded from pixel data, the returned stream
+// doesn't contain any of the metadata (EXIF, XMP, etc) of the original.
+func convertImage(ctx context.Context, r io.Reader) (io.Reader, types.Type, error) {
+	tf, err := os.CreateTemp(os.TempDir(), "gts-image")
+	if err != nil {
+		return nil, types.Unknown, fmt.Errorf("creating temporary file for image conversion: %w", err)
+	}
+	defer removeTemp(ctx, tf)
+
+	if _, err := io.Copy(tf, r); err != nil {
+		return nil, types.Unknown, fmt.Errorf("writing image for conversion: %w", err)
+	}
+
+	// Decode as png, which is lossless
+	// and keeps any alpha channel.
+	img, err := ffmpegImage(ctx, tf.Name(),
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "png",
+		"pipe:1",
+	)
+	if err != nil {
+		return nil, types.Unknown, err
+	}
+
+	if o, ok := img.image.(interface{ Opaque() bool }); ok && !o.Opaque() {
+		// Keep transparency.
+		return img.ToPNG(), matchers.TypePng, nil
+	}
+
+	return img.ToJPEG(&jpeg.Options{
+		// Stored as the original, so
+		// keep quality fairly high.
+		Quality: 90,
+	}), matchers.TypeJpeg, nil
+}
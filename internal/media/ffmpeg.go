@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"os/exec"
+	"time"
+)
+
+const (
+	// ffprobeTimeout is the max time
+	// allowed for probing media metadata.
+	ffprobeTimeout = 5 * time.Second
+
+	// ffmpegImageTimeout is the max time allowed for
+	// extracting or rendering an image with ffmpeg.
+	ffmpegImageTimeout = 30 * time.Second
+)
+
+// ffprobe runs ffprobe with given args on the input
+// file at path, returning what it wrote to stdout.
+func ffprobe(ctx context.Context, filepath string, args ...string) ([]byte, error) {
+	ctx, cncl := context.WithTimeout(ctx, ffprobeTimeout)
+	defer cncl()
+
+	args = append(append([]string{"-v", "error"}, args...), filepath)
+	cmd := exec.CommandContext(ctx, "ffprobe", args...)
+	out := bytes.NewBuffer(make([]byte, 0, 2048))
+	errOut := bytes.NewBuffer(make([]byte, 0, 512))
+	cmd.Stdout = out
+	cmd.Stderr = errOut
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("metadata probe subprocess failed: %w:\n%s", err, errOut)
+	}
+
+	return out.Bytes(), nil
+}
+
+// ffmpegImage runs ffmpeg with given output args on the input
+// file at path, decoding the image written by ffmpeg to stdout.
+func ffmpegImage(ctx context.Context, filepath string, args ...string) (*gtsImage, error) {
+	ctx, cncl := context.WithTimeout(ctx, ffmpegImageTimeout)
+	defer cncl()
+
+	args = append([]string{"-v", "error", "-i", filepath}, args...)
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	out := bytes.NewBuffer([]byte{})
+	errOut := bytes.NewBuffer([]byte{})
+	cmd.Stdout = out
+	cmd.Stderr = errOut
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg subprocess failed: %w:\n%s", err, errOut)
+	}
+
+	img, _, err := image.Decode(out)
+	if err != nil {
+		return nil, fmt.Errorf("decoding generated image: %w", err)
+	}
+
+	return &gtsImage{img}, nil
+}
@@ -103,23 +103,93 @@ func (m *gtsImage) AspectRatio() float32 {
 
 // Thumbnail returns a small sized copy of gtsImage{}, limited to 512x512 if not small enough.
 func (m *gtsImage) Thumbnail() *gtsImage {
-	const (
-		// max thumb
-		// dimensions.
-		maxWidth  = 512
-		maxHeight = 512
-	)
+	return m.fit(512, 512)
+}
 
-	// Check the receiving image is within max thumnail bounds.
+// Medium returns a medium sized copy of gtsImage{}, limited to 1280x1280 if not small enough.
+func (m *gtsImage) Medium() *gtsImage {
+	return m.fit(1280, 1280)
+}
+
+// fit returns a copy of gtsImage{}, resized to fit within maxWidth x maxHeight if not small enough.
+func (m *gtsImage) fit(maxWidth, maxHeight uint32) *gtsImage {
+	// Check the receiving image is within max bounds.
 	if m.Width() <= maxWidth && m.Height() <= maxHeight {
 		return &gtsImage{image: imaging.Clone(m.image)}
 	}
 
-	// Image is too large, needs to be resized to thumbnail max.
-	img := imaging.Fit(m.image, maxWidth, maxHeight, imaging.Linear)
+	// Image is too large, needs to be resized to max.
+	img := imaging.Fit(m.image, int(maxWidth), int(maxHeight), imaging.Linear)
 	return &gtsImage{image: img}
 }
 
+// CropAspect returns gtsImage{} cropped such that its aspect ratio lies within
+// 1:maxAspect and maxAspect:1, keeping the given focal point as close to the
+// center of the cropped image as possible. Focal point x and y are each between
+// -1 and 1, where x=-1 is the left edge and y=1 is the top edge of the image.
+// If no cropping is necessary, the receiving gtsImage{} is returned as-is.
+func (m *gtsImage) CropAspect(maxAspect float32, focusX, focusY float32) *gtsImage {
+	var (
+		width  = int(m.Width())
+		height = int(m.Height())
+		aspect = m.AspectRatio()
+	)
+
+	// Calculate the focal point in pixels,
+	// clamping to within image bounds.
+	x := int((clampFocus(focusX) + 1) / 2 * float32(width))
+	y := int((1 - clampFocus(focusY)) / 2 * float32(height))
+
+	var rect image.Rectangle
+
+	switch {
+	case aspect > maxAspect:
+		// Too wide, crop width around focus x.
+		w := int(float32(height) * maxAspect)
+		x0 := clampOffset(x-w/2, width-w)
+		rect = image.Rect(x0, 0, x0+w, height)
+
+	case aspect < 1/maxAspect:
+		// Too tall, crop height around focus y.
+		h := int(float32(width) * maxAspect)
+		y0 := clampOffset(y-h/2, height-h)
+		rect = image.Rect(0, y0, width, y0+h)
+
+	default:
+		// Within bounds.
+		return m
+	}
+
+	// Crop image relative to its own bounds origin.
+	rect = rect.Add(m.image.Bounds().Min)
+	img := imaging.Crop(m.image, rect)
+	return &gtsImage{image: img}
+}
+
+// clampFocus clamps focal point coordinate f to between -1 and 1.
+func clampFocus(f float32) float32 {
+	switch {
+	case f < -1:
+		return -1
+	case f > 1:
+		return 1
+	default:
+		return f
+	}
+}
+
+// clampOffset clamps crop offset i to between 0 and max.
+func clampOffset(i int, max int) int {
+	switch {
+	case i < 0:
+		return 0
+	case i > max:
+		return max
+	default:
+		return i
+	}
+}
+
 // Blurhash calculates the blurhash for the receiving image data.
 func (m *gtsImage) Blurhash() (string, error) {
 	// for generating blurhashes, it's more cost effective to
@@ -21,10 +21,12 @@ import (
 	"context"
 	"errors"
 	"io"
+	"slices"
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -39,7 +41,32 @@ var SupportedMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
 	mimeImageWebp,
+	mimeImageAvif,
+	mimeImageHeic,
+	mimeImageHeif,
+	mimeImageJxl,
 	mimeVideoMp4,
+	mimeAudioMpeg,
+	mimeAudioOgg,
+	mimeAudioFlac,
+	mimeAudioWav,
+	mimeAudioMp4,
+}
+
+// SupportedAttachmentMIMETypes returns the MIME types accepted
+// for media attachments, which includes more video types if
+// video transcoding is enabled.
+func SupportedAttachmentMIMETypes() []string {
+	if !config.GetMediaVideoTranscode() {
+		return SupportedMIMETypes
+	}
+
+	return append(slices.Clone(SupportedMIMETypes),
+		mimeVideoWebm,
+		mimeVideoQuicktime,
+		mimeVideoMatroska,
+		mimeVideoM4v,
+	)
 }
 
 var SupportedEmojiMIMETypes = []string{
@@ -21,6 +21,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"os"
 	"path"
@@ -29,10 +34,12 @@ import (
 
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type ManagerTestSuite struct {
@@ -435,6 +442,45 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestJpegWithMetadataProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image, which
+		// is test-jpeg.jpg with xmp (containing
+		// a location), iptc and comment segments
+		b, err := os.ReadFile("./test/test-jpeg-xmp.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// metadata segments should have been stripped,
+	// leaving us with the same file as test-jpeg.jpg
+	suite.Equal("image/jpeg", attachment.File.ContentType)
+	suite.Equal(269739, attachment.File.FileSize)
+
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	processedFullBytesExpected, err := os.ReadFile("./test/test-jpeg-processed.jpg")
+	suite.NoError(err)
+
+	suite.Equal(processedFullBytesExpected, processedFullBytes)
+	suite.NotContains(string(processedFullBytes), "GPSLatitude")
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	ctx := context.Background()
 
@@ -785,6 +831,74 @@ func (suite *ManagerTestSuite) TestBirdnestMp4ProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestWavProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test audio file
+		b, err := os.ReadFile("./test/test-wav.wav")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+	// fetch the attachment id from the processing media
+	attachmentID := processingMedia.AttachmentID()
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// make sure it's got the stuff set on it that we expect
+	// the attachment ID and accountID we expect
+	suite.Equal(attachmentID, attachment.ID)
+	suite.Equal(accountID, attachment.AccountID)
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+
+	// file meta should be correctly derived from the audio,
+	// with no dimensions set, and a waveform as thumbnail
+	suite.Zero(attachment.FileMeta.Original.Width)
+	suite.Zero(attachment.FileMeta.Original.Height)
+	suite.EqualValues(float32(1), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(128000, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(1, *attachment.FileMeta.Original.Channels)
+	suite.Nil(attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 192, Size: 98304, Aspect: 2.6666667,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/x-wav", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(16044, attachment.File.FileSize)
+	suite.NotEmpty(attachment.Blurhash)
+
+	// now make sure the attachment is in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+	suite.NoError(err)
+	suite.NotNil(dbAttachment)
+
+	// make sure the file is in storage unchanged
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	processedFullBytesExpected, err := os.ReadFile("./test/test-wav.wav")
+	suite.NoError(err)
+	suite.Equal(processedFullBytesExpected, processedFullBytes)
+
+	// and the waveform thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+
+	// audio should have no medium rendition
+	suite.Empty(attachment.Medium.Path)
+}
+
 func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
 	// try to load an 'mp4' that's actually an mkv in disguise
 
@@ -814,6 +928,163 @@ func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
 	suite.Equal(gtsmodel.FileTypeUnknown, attachment.Type)
 }
 
+func (suite *ManagerTestSuite) TestNotAnMp4ProcessTranscode() {
+	// load an 'mp4' with vp9 video, which
+	// browsers mostly can't play, with
+	// video transcoding enabled this time
+
+	config.SetMediaVideoTranscode(true)
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test video
+		b, err := os.ReadFile("./test/not-an.mp4")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+	attachmentID := processingMedia.AttachmentID()
+
+	// loading should return as soon as the original
+	// video is stored and has a poster frame thumbnail,
+	// without waiting for transcoding to finish
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Equal(gtsmodel.ProcessingStatusProcessing, attachment.Processing)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.NotEmpty(attachment.Blurhash)
+
+	// wait for transcoding to finish in the background
+	if !testrig.WaitFor(func() bool {
+		dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+		return err == nil && dbAttachment.Processing == gtsmodel.ProcessingStatusProcessed
+	}) {
+		suite.FailNow("timed out waiting for video transcode")
+	}
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+	suite.NoError(err)
+	suite.Equal("video/mp4", dbAttachment.File.ContentType)
+	suite.Equal(attachment.URL, dbAttachment.URL)
+	suite.NotEqual(attachment.File.Path, dbAttachment.File.Path)
+	suite.Equal(accountID+"/attachment/transcoded/"+attachmentID+".mp4", dbAttachment.File.Path)
+	suite.NotNil(dbAttachment.FileMeta.Original.Duration)
+	suite.NotNil(dbAttachment.FileMeta.Original.Bitrate)
+
+	// transcoded video in storage should now be h264
+	processedFullBytes, err := suite.storage.Get(ctx, dbAttachment.File.Path)
+	suite.NoError(err)
+	suite.EqualValues(dbAttachment.File.FileSize, len(processedFullBytes))
+	suite.True(bytes.Contains(processedFullBytes, []byte("avc1")))
+	suite.False(bytes.Contains(processedFullBytes, []byte("vp09")))
+
+	// original should have been removed
+	_, err = suite.storage.Get(ctx, attachment.File.Path)
+	suite.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (suite *ManagerTestSuite) TestTranscodePending() {
+	config.SetMediaVideoTranscode(true)
+	ctx := context.Background()
+
+	// Pretend transcoding of a test video
+	// was interrupted by a restart.
+	attachment := &gtsmodel.MediaAttachment{}
+	*attachment = *suite.testAttachments["local_account_1_status_4_attachment_2"]
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.manager.TranscodePending(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// It should be picked up and transcoded again.
+	if !testrig.WaitFor(func() bool {
+		dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+		return err == nil && dbAttachment.Processing == gtsmodel.ProcessingStatusProcessed
+	}) {
+		suite.FailNow("timed out waiting for video transcode")
+	}
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal("video/mp4", dbAttachment.File.ContentType)
+}
+
+func (suite *ManagerTestSuite) TestTranscodePendingLeftover() {
+	config.SetMediaVideoTranscode(true)
+	ctx := context.Background()
+
+	// Pretend transcoding of a test video was interrupted
+	// by a restart after the transcoded video was stored.
+	attachment := &gtsmodel.MediaAttachment{}
+	*attachment = *suite.testAttachments["local_account_1_status_4_attachment_2"]
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	leftoverPath := attachment.AccountID + "/attachment/original/" + attachment.ID + ".mp4"
+	if _, err := suite.storage.Put(ctx, leftoverPath, []byte("not really a video")); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.manager.TranscodePending(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+		return err == nil && dbAttachment.Processing == gtsmodel.ProcessingStatusProcessed
+	}) {
+		suite.FailNow("timed out waiting for video transcode")
+	}
+
+	// Leftover should have been replaced
+	// by the newly transcoded video.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(leftoverPath, dbAttachment.File.Path)
+
+	b, err := suite.storage.Get(ctx, leftoverPath)
+	suite.NoError(err)
+	suite.NotEqual("not really a video", string(b))
+}
+
+func (suite *ManagerTestSuite) TestTranscodePendingDisabled() {
+	config.SetMediaVideoTranscode(false)
+	ctx := context.Background()
+
+	attachment := &gtsmodel.MediaAttachment{}
+	*attachment = *suite.testAttachments["local_account_1_status_4_attachment_2"]
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.manager.TranscodePending(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// With transcoding disabled it should just be
+	// marked as processed, keeping the original.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+	suite.Equal(attachment.File.Path, dbAttachment.File.Path)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingNoContentLengthGiven() {
 	ctx := context.Background()
 
@@ -996,7 +1267,7 @@ func (suite *ManagerTestSuite) TestPngNoAlphaChannelProcessBlocking() {
 	}, attachment.FileMeta.Small)
 	suite.Equal("image/png", attachment.File.ContentType)
 	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
-	suite.Equal(17471, attachment.File.FileSize)
+	suite.Equal(16261, attachment.File.FileSize)
 	suite.Equal("LFQT7e.A%O%4?co$M}M{_1W9~TxV", attachment.Blurhash)
 
 	// now make sure the attachment is in the database
@@ -1067,7 +1338,7 @@ func (suite *ManagerTestSuite) TestPngAlphaChannelProcessBlocking() {
 	}, attachment.FileMeta.Small)
 	suite.Equal("image/png", attachment.File.ContentType)
 	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
-	suite.Equal(18904, attachment.File.FileSize)
+	suite.Equal(18324, attachment.File.FileSize)
 	suite.Equal("LFQT7e.A%O%4?co$M}M{_1W9~TxV", attachment.Blurhash)
 
 	// now make sure the attachment is in the database
@@ -1390,6 +1661,94 @@ func (suite *ManagerTestSuite) TestNoReportedSizeSmallMedia() {
 	suite.Equal(actualSize, attachment.File.FileSize)
 }
 
+func (suite *ManagerTestSuite) TestFocalPointRenditions() {
+	ctx := context.Background()
+
+	// generate a wide png, left half red and right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 800, 200))
+	draw.Draw(img, image.Rect(0, 0, 400, 200), image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(400, 0, 800, 200), image.NewUniform(color.RGBA{0, 0, 255, 255}), image.Point{}, draw.Src)
+
+	buf := &bytes.Buffer{}
+	suite.NoError(png.Encode(buf, img))
+	b := buf.Bytes()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with focus on the left edge
+	focusX := float32(-1)
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+		FocusX: &focusX,
+	})
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// original should be untouched, but the thumbnail
+	// should be cropped to 2:1 around the focal point
+	suite.EqualValues(gtsmodel.Original{
+		Width: 800, Height: 200, Size: 160000, Aspect: 4,
+	}, attachment.FileMeta.Original)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 400, Height: 200, Size: 80000, Aspect: 2,
+	}, attachment.FileMeta.Small)
+
+	// thumbnail should be all red
+	thumb := suite.decodeJPEG(ctx, attachment.Thumbnail.Path)
+	suite.Equal(image.Rect(0, 0, 400, 200), thumb.Bounds())
+	suite.True(isRed(thumb.At(390, 100)))
+
+	// medium rendition should be stored and red too
+	suite.Equal("image/jpeg", attachment.Medium.ContentType)
+	suite.NotZero(attachment.Medium.FileSize)
+	suite.NotEmpty(attachment.Medium.URL)
+	medium := suite.decodeJPEG(ctx, attachment.Medium.Path)
+	suite.Equal(image.Rect(0, 0, 400, 200), medium.Bounds())
+	suite.True(isRed(medium.At(390, 100)))
+
+	// now move the focus to the right edge, and regenerate
+	attachment.FileMeta.Focus.X = 1
+	suite.NoError(suite.manager.RegenerateRenditions(ctx, attachment))
+
+	// thumbnail and medium rendition should now be all blue
+	thumb = suite.decodeJPEG(ctx, attachment.Thumbnail.Path)
+	suite.False(isRed(thumb.At(10, 100)))
+	medium = suite.decodeJPEG(ctx, attachment.Medium.Path)
+	suite.False(isRed(medium.At(10, 100)))
+
+	// and the database should be up to date
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(attachment.Thumbnail.FileSize, dbAttachment.Thumbnail.FileSize)
+	suite.Equal(attachment.Medium, dbAttachment.Medium)
+}
+
+func (suite *ManagerTestSuite) decodeJPEG(ctx context.Context, path string) image.Image {
+	b, err := suite.storage.Get(ctx, path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(b))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return img
+}
+
+// isRed returns whether c is mostly red (allowing for jpeg fuzz).
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > b
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
@@ -0,0 +1,263 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// PNG chunk types containing metadata.
+const (
+	chunkTypeTEXT = 0x74455874
+	chunkTypeZTXT = 0x7a545874
+	chunkTypeITXT = 0x69545874 // also used for XMP
+	chunkTypeEXIF = 0x65584966
+	chunkTypeTIME = 0x74494d45
+)
+
+// isMetadataChunkType returns whether PNG chunk type
+// is one containing metadata (text, exif, xmp, time)
+// rather than image or colour information.
+func isMetadataChunkType(chunkType uint32) bool {
+	switch chunkType {
+	case chunkTypeTEXT,
+		chunkTypeZTXT,
+		chunkTypeITXT,
+		chunkTypeEXIF,
+		chunkTypeTIME:
+		return true
+	}
+	return false
+}
+
+// newPNGMetadataStripper wraps r to strip metadata chunks from a PNG
+// data stream, unlike pngAncillaryChunkStripper keeping the ancillary
+// chunks needed to correctly display the image (eg., colour profile).
+func newPNGMetadataStripper(r io.Reader) io.Reader {
+	return &pngAncillaryChunkStripper{
+		Reader: r,
+		keepChunk: func(chunkType uint32) bool {
+			return !isMetadataChunkType(chunkType)
+		},
+	}
+}
+
+// JPEG segment markers.
+const (
+	jpegMarkerSOI  = 0xD8 // start of image
+	jpegMarkerEOI  = 0xD9 // end of image
+	jpegMarkerSOS  = 0xDA // start of scan
+	jpegMarkerAPP1 = 0xE1 // exif / xmp
+	jpegMarkerAPPD = 0xED // photoshop / iptc
+	jpegMarkerCOM  = 0xFE // comment
+)
+
+var (
+	jpegXMPHeader    = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	jpegExtXMPHeader = []byte("http://ns.adobe.com/xmp/extension/\x00")
+)
+
+// isMetadataSegment returns whether the JPEG segment with given marker,
+// and payload starting with given prefix, contains metadata to discard.
+//
+// EXIF segments are left alone, as they're handled by exif-terminator,
+// which keeps the orientation tag needed to correctly display the image.
+func isMetadataSegment(marker byte, prefix []byte) bool {
+	switch marker {
+	case jpegMarkerAPP1:
+		return bytes.HasPrefix(prefix, jpegXMPHeader) ||
+			bytes.HasPrefix(prefix, jpegExtXMPHeader)
+	case jpegMarkerAPPD, jpegMarkerCOM:
+		return true
+	}
+	return false
+}
+
+// jpegMetadataStripper wraps another io.Reader to strip metadata segments
+// (XMP, IPTC, comments) from JPEG data, in the same manner that
+// pngAncillaryChunkStripper does for PNG chunks. Segments after the
+// start of the first scan, or data that isn't JPEG, are passed through
+// unmodified.
+//
+// A JPEG file consists of a 2-byte start of image marker and then a
+// series of segments, each of which is:
+//
+//   - a 2-byte marker (0xFF followed by the marker type).
+//   - a 2-byte uint16 length N, which includes these 2 bytes.
+//   - an (N - 2)-byte payload.
+//
+// The start of scan segment is followed by the entropy-coded image data.
+type jpegMetadataStripper struct {
+	// Reader is the wrapped io.Reader.
+	Reader io.Reader
+
+	// stickyErr is the first error returned from the wrapped io.Reader.
+	stickyErr error
+
+	// buffer[rIndex:wIndex] holds data read from the wrapped io.Reader that
+	// wasn't passed through yet. It's big enough to hold a segment marker,
+	// length, and enough of its payload to identify XMP segments.
+	buffer [4 + 35]byte
+	rIndex int
+	wIndex int
+
+	// pending and discard is the number of remaining bytes for (and whether to
+	// discard or pass through) the current segment-in-progress.
+	pending int64
+	discard bool
+
+	// passthrough is set true once we've reached image data, or if the data
+	// stream turns out not to be a (well-formed) JPEG. After this, the wrapped
+	// io.Reader's data is passed through without modification.
+	passthrough bool
+
+	// seenSOI is whether we've seen the 2-byte start of image marker.
+	seenSOI bool
+}
+
+// Read implements io.Reader.
+func (r *jpegMetadataStripper) Read(p []byte) (int, error) {
+	for {
+		// If the wrapped io.Reader returned a non-nil error, drain r.buffer
+		// (what data we have) and return that error (if fully drained).
+		if r.stickyErr != nil {
+			n := copy(p, r.buffer[r.rIndex:r.wIndex])
+			r.rIndex += n
+			if r.rIndex < r.wIndex {
+				return n, nil
+			}
+			return n, r.stickyErr
+		}
+
+		// Handle trivial requests, including draining our buffer.
+		if len(p) == 0 {
+			return 0, nil
+		} else if r.rIndex < r.wIndex {
+			n := copy(p, r.buffer[r.rIndex:r.wIndex])
+			r.rIndex += n
+			return n, nil
+		}
+
+		// From here onwards, our buffer is drained: r.rIndex == r.wIndex.
+
+		// Handle image data, or non-JPEG input.
+		if r.passthrough {
+			return r.Reader.Read(p)
+		}
+
+		// Continue processing any JPEG segment that's in progress, whether
+		// discarding it or passing it through.
+		for r.pending > 0 {
+			if int64(len(p)) > r.pending {
+				p = p[:r.pending]
+			}
+			n, err := r.Reader.Read(p)
+			r.pending -= int64(n)
+			r.stickyErr = err
+			if r.discard {
+				continue
+			}
+			return n, err
+		}
+
+		// We're either expecting the start of image
+		// marker, or the marker of the next segment.
+		// Either way, read 2 bytes.
+		r.rIndex = 0
+		if !r.read(0, 2) {
+			continue
+		}
+
+		if r.buffer[0] != 0xFF {
+			// Not a marker, bail.
+			r.passthrough = true
+			continue
+		}
+
+		marker := r.buffer[1]
+		if !r.seenSOI {
+			r.seenSOI = marker == jpegMarkerSOI
+			r.passthrough = !r.seenSOI
+			continue
+		}
+
+		switch {
+		case marker == jpegMarkerSOS:
+			// Image data from here
+			// on, leave it alone.
+			r.passthrough = true
+			continue
+
+		case marker == jpegMarkerEOI,
+			marker >= 0xD0 && marker <= 0xD7,
+			marker == 0x01:
+			// Standalone marker (end of image,
+			// restart, temporary) with no payload.
+			continue
+
+		case marker == 0xFF:
+			// Fill bytes; we can't tell where
+			// we're at any more, so bail.
+			r.passthrough = true
+			continue
+		}
+
+		// Read segment length.
+		if !r.read(2, 2) {
+			continue
+		}
+
+		length := int64(binary.BigEndian.Uint16(r.buffer[2:4])) - 2
+		if length < 0 {
+			// Invalid length, bail.
+			r.passthrough = true
+			continue
+		}
+
+		// Read as much of the payload
+		// as we need to identify it.
+		prefix := min(length, int64(len(r.buffer)-4))
+		if !r.read(4, int(prefix)) {
+			continue
+		}
+
+		r.pending = length - prefix
+		r.discard = isMetadataSegment(marker, r.buffer[4:4+prefix])
+		if r.discard {
+			r.rIndex = r.wIndex
+		}
+	}
+}
+
+// read reads n bytes from the wrapped io.Reader into r.buffer
+// at offset, updating r.wIndex, and returning false on error.
+func (r *jpegMetadataStripper) read(offset int, n int) bool {
+	var read int
+	read, r.stickyErr = io.ReadFull(r.Reader, r.buffer[offset:offset+n])
+	r.wIndex = offset + read
+	if r.stickyErr != nil {
+		// Undo io.ReadFull converting io.EOF to io.ErrUnexpectedEOF.
+		if r.stickyErr == io.ErrUnexpectedEOF {
+			r.stickyErr = io.EOF
+		}
+		return false
+	}
+	return true
+}
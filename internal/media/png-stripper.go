@@ -129,6 +129,10 @@ type pngAncillaryChunkStripper struct {
 
 	// seenMagic is whether we've seen the 8-byte PNG magic identifier.
 	seenMagic bool
+
+	// keepChunk, if set, is used instead of isNecessaryChunkType
+	// to decide whether to pass through (or discard) a chunk.
+	keepChunk func(chunkType uint32) bool
 }
 
 // Read implements io.Reader.
@@ -198,7 +202,11 @@ func (r *pngAncillaryChunkStripper) Read(p []byte) (int, error) {
 			// byte trailer, a checksum.
 			r.pending = int64(binary.BigEndian.Uint32(r.buffer[:4])) + 4
 			chunkType := binary.BigEndian.Uint32(r.buffer[4:])
-			r.discard = !isNecessaryChunkType(chunkType)
+			keep := isNecessaryChunkType
+			if r.keepChunk != nil {
+				keep = r.keepChunk
+			}
+			r.discard = !keep(chunkType)
 			if r.discard {
 				r.rIndex = r.wIndex
 			}
@@ -21,7 +21,6 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"image/jpeg"
 	"io"
 	"time"
 
@@ -30,6 +29,7 @@ import (
 	"codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -42,13 +42,14 @@ import (
 // currently being processed. It exposes functions
 // for retrieving data from the process.
 type ProcessingMedia struct {
-	media   *gtsmodel.MediaAttachment // processing media attachment details
-	dataFn  DataFunc                  // load-data function, returns media stream
-	recache bool                      // recaching existing (uncached) media
-	done    bool                      // done is set when process finishes with non ctx canceled type error
-	proc    runners.Processor         // proc helps synchronize only a singular running processing instance
-	err     error                     // error stores permanent error value when done
-	mgr     *Manager                  // mgr instance (access to db / storage)
+	media     *gtsmodel.MediaAttachment // processing media attachment details
+	dataFn    DataFunc                  // load-data function, returns media stream
+	recache   bool                      // recaching existing (uncached) media
+	transcode bool                      // video needs transcoding once processed
+	done      bool                      // done is set when process finishes with non ctx canceled type error
+	proc      runners.Processor         // proc helps synchronize only a singular running processing instance
+	err       error                     // error stores permanent error value when done
+	mgr       *Manager                  // mgr instance (access to db / storage)
 }
 
 // AttachmentID returns the ID of the underlying
@@ -180,6 +181,20 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 		}
 
 		err = errs.Combine()
+
+		if err == nil && p.transcode {
+			// Transcode video in the background, so we
+			// don't hold up eg., creating a status with
+			// this attachment. The original can be served
+			// (and has a thumbnail) in the meantime.
+			id := p.media.ID
+			go p.mgr.state.Workers.Media.Enqueue(func(ctx context.Context) {
+				if err := p.mgr.transcodeAttachment(ctx, id); err != nil {
+					log.Errorf(ctx, "error transcoding media %s: %v", id, err)
+				}
+			})
+		}
+
 		return err
 	})
 
@@ -260,12 +275,49 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	case "mp4":
 		// No problem.
 
+	case "webm", "mov", "mkv", "m4v":
+		if !config.GetMediaVideoTranscode() {
+			// We can only serve these as-is if
+			// we're able to transcode them, else
+			// most browsers won't play them anyway.
+			log.Warnf(ctx,
+				"media extension '%s' only supported with video transcoding enabled, "+
+					"will be processed as type '%s' with minimal metadata, and will not be cached locally",
+				info.Extension, gtsmodel.FileTypeUnknown,
+			)
+			store = false
+		}
+
 	case "gif":
 		// No problem
 
+	case "mp3", "ogg", "flac", "wav", "m4a":
+		// No problem, audio
+		// (ogg includes opus).
+
+	case "avif", "heic", "heif", "jxl":
+		// Most browsers can't display these (yet), so
+		// convert to a widely supported type and store
+		// that instead. This also drops all metadata.
+		ext := info.Extension
+		r, info, err = convertImage(ctx, r)
+		if err != nil {
+			return gtserror.Newf("error converting %s image: %w", ext, err)
+		}
+
 	case "jpg", "jpeg", "png", "webp":
+		if fileSize <= 0 {
+			// No file size was provided, but we need one
+			// to clean exif data; read the image into
+			// memory (up to max image size) to find it.
+			r, fileSize, err = bufferImage(r)
+			if err != nil {
+				return gtserror.Newf("error reading image: %w", err)
+			}
+		}
+
 		if fileSize > 0 {
-			// A file size was provided so we can clean
+			// We know the file size so we can clean
 			// exif data from image as we're streaming it.
 			r, err = terminator.Terminate(r, fileSize, info.Extension)
 			if err != nil {
@@ -273,6 +325,16 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 			}
 		}
 
+		// Strip remaining metadata which
+		// exif-terminator leaves alone.
+		// (webp xmp is already handled).
+		switch info.Extension {
+		case "jpg", "jpeg":
+			r = &jpegMetadataStripper{Reader: r}
+		case "png":
+			r = newPNGMetadataStripper(r)
+		}
+
 	default:
 		// The file is not a supported format that
 		// we can process, so we can't do much with it.
@@ -299,8 +361,15 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Prefer discovered mime type, fall back to
 	// generic "this contains some bytes" type.
 	mime := info.MIME.Value
-	if mime == "" {
+	switch {
+	case mime == "":
 		mime = "application/octet-stream"
+
+	case info.Extension == "m4a":
+		// Filetype gives non-standard
+		// 'audio/m4a', which browsers
+		// don't necessarily recognize.
+		mime = mimeAudioMp4
 	}
 	p.media.File.ContentType = mime
 
@@ -425,16 +494,23 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeImage
 
-	// .mp4 video type
-	case mimeVideoMp4:
-		video, err := decodeVideoFrame(rc)
+	// .mp4, .webm, .mov, .mkv, .m4v video type
+	case mimeVideoMp4, mimeVideoWebm, mimeVideoQuicktime, mimeVideoMatroska, mimeVideoM4v:
+		video, err := decodeVideoFrame(ctx, rc)
 		if err != nil {
 			return gtserror.Newf("error decoding video: %w", err)
 		}
 
-		// Set video frame as image.
+		// Set poster frame as image.
 		fullImg = video.frame
 
+		// If enabled, videos which browsers may not be able
+		// to play get transcoded once we're done processing.
+		p.transcode = config.GetMediaVideoTranscode() && !video.webSafe(
+			p.media.File.ContentType,
+			config.GetMediaVideoTranscodeMaxDimension(),
+		)
+
 		// Set video metadata in attachment info.
 		p.media.FileMeta.Original.Duration = &video.duration
 		p.media.FileMeta.Original.Framerate = &video.framerate
@@ -443,76 +519,55 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// Mark as no longer unknown type now
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeVideo
-	}
-
-	// fullImg should be in-memory by
-	// now so we're done with storage.
-	if err := rc.Close(); err != nil {
-		return gtserror.Newf("error closing file: %w", err)
-	}
-
-	// Set full-size dimensions in attachment info.
-	p.media.FileMeta.Original.Width = int(fullImg.Width())
-	p.media.FileMeta.Original.Height = int(fullImg.Height())
-	p.media.FileMeta.Original.Size = int(fullImg.Size())
-	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
-
-	// Get smaller thumbnail image
-	thumbImg := fullImg.Thumbnail()
 
-	// Garbage collector, you may
-	// now take our large son.
-	fullImg = nil
-
-	// Only generate blurhash
-	// from thumb if necessary.
-	if p.media.Blurhash == "" {
-		hash, err := thumbImg.Blurhash()
+	// .mp3, .ogg, .flac, .wav, .m4a audio type
+	case mimeAudioMpeg, mimeAudioOgg, mimeAudioFlac, mimeAudioWav, mimeAudioMp4:
+		audio, err := decodeAudio(ctx, rc)
 		if err != nil {
-			return gtserror.Newf("error generating blurhash: %w", err)
+			return gtserror.Newf("error decoding audio: %w", err)
 		}
 
-		// Set the attachment blurhash.
-		p.media.Blurhash = hash
-	}
+		// Set cover art or
+		// waveform as image.
+		fullImg = audio.preview
 
-	// Thumbnail shouldn't already exist in storage at this point,
-	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.Thumbnail.Path); have {
-		log.Warnf(ctx, "thumbnail already exists at storage path: %s", p.media.Thumbnail.Path)
+		// Set audio metadata in attachment info.
+		p.media.FileMeta.Original.Duration = &audio.duration
+		p.media.FileMeta.Original.Bitrate = &audio.bitrate
+		p.media.FileMeta.Original.Channels = &audio.channels
 
-		// Attempt to remove existing thumbnail at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.Thumbnail.Path); err != nil {
-			return gtserror.Newf("error removing thumbnail from storage: %v", err)
-		}
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeAudio
 	}
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
-		// Good enough for
-		// a thumbnail.
-		Quality: 70,
-	})
-
-	// Stream-encode the JPEG thumbnail image into storage.
-	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
-	if err != nil {
-		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
+	// fullImg should be in-memory by
+	// now so we're done with storage.
+	if err := rc.Close(); err != nil {
+		return gtserror.Newf("error closing file: %w", err)
 	}
 
-	// Set thumbnail dimensions in attachment info.
-	p.media.FileMeta.Small = gtsmodel.Small{
-		Width:  int(thumbImg.Width()),
-		Height: int(thumbImg.Height()),
-		Size:   int(thumbImg.Size()),
-		Aspect: thumbImg.AspectRatio(),
+	if p.media.Type != gtsmodel.FileTypeAudio {
+		// Set full-size dimensions in attachment info
+		// (audio has none, its image is just a preview).
+		p.media.FileMeta.Original.Width = int(fullImg.Width())
+		p.media.FileMeta.Original.Height = int(fullImg.Height())
+		p.media.FileMeta.Original.Size = int(fullImg.Size())
+		p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
 	}
 
-	// Set written image size.
-	p.media.Thumbnail.FileSize = int(sz)
+	// Generate and store the small and medium
+	// renditions, honouring any set focal point.
+	if err := p.mgr.storeRenditions(ctx, p.media, fullImg); err != nil {
+		return err
+	}
 
-	// Finally set the attachment as processed and update time.
+	// Finally set the attachment as processed (or, still
+	// processing, if it needs transcoding) and update time.
 	p.media.Processing = gtsmodel.ProcessingStatusProcessed
+	if p.transcode {
+		p.media.Processing = gtsmodel.ProcessingStatusProcessing
+	}
 	p.media.File.UpdatedAt = time.Now()
 
 	return nil
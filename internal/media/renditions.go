@@ -0,0 +1,230 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"image/jpeg"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// maxRenditionAspect is the most extreme aspect ratio (wide
+// or tall) allowed for small and medium renditions, beyond
+// which images are cropped around their focal point.
+const maxRenditionAspect = 2
+
+// RegenerateRenditions regenerates the small and medium renditions of the
+// given attachment from its original file, eg., after its focal point has
+// been changed, and updates the attachment in the database accordingly.
+//
+// Attachments that aren't cached, or which are audio (and therefore
+// have nothing to crop), are returned without changes.
+func (m *Manager) RegenerateRenditions(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+	if !*attachment.Cached || attachment.Type == gtsmodel.FileTypeAudio {
+		return nil
+	}
+
+	// Get a stream to the original file for decoding.
+	rc, err := m.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return gtserror.Newf("error loading file from storage: %w", err)
+	}
+	defer rc.Close()
+
+	var fullImg *gtsImage
+
+	switch attachment.File.ContentType {
+
+	// .jpeg, .gif, .webp image type
+	case mimeImageJpeg, mimeImageGif, mimeImageWebp:
+		fullImg, err = decodeImage(
+			rc,
+			imaging.AutoOrientation(true),
+		)
+		if err != nil {
+			return gtserror.Newf("error decoding image: %w", err)
+		}
+
+	// .png image (requires ancillary chunk stripping)
+	case mimeImagePng:
+		fullImg, err = decodeImage(
+			&pngAncillaryChunkStripper{Reader: rc},
+			imaging.AutoOrientation(true),
+		)
+		if err != nil {
+			return gtserror.Newf("error decoding image: %w", err)
+		}
+
+	// .mp4, .webm, .mov, .mkv, .m4v video type
+	case mimeVideoMp4, mimeVideoWebm, mimeVideoQuicktime, mimeVideoMatroska, mimeVideoM4v:
+		video, err := decodeVideoFrame(ctx, rc)
+		if err != nil {
+			return gtserror.Newf("error decoding video: %w", err)
+		}
+		fullImg = video.frame
+
+	default:
+		// Nothing we can
+		// regenerate from.
+		return nil
+	}
+
+	// fullImg is in-memory, done with storage.
+	if err := rc.Close(); err != nil {
+		return gtserror.Newf("error closing file: %w", err)
+	}
+
+	if err := m.storeRenditions(ctx, attachment, fullImg); err != nil {
+		return err
+	}
+
+	if err := m.state.DB.UpdateAttachment(ctx, attachment,
+		"thumbnail_file_size",
+		"thumbnail_updated_at",
+		"small_width",
+		"small_height",
+		"small_size",
+		"small_aspect",
+		"medium_path",
+		"medium_content_type",
+		"medium_file_size",
+		"medium_url",
+	); err != nil {
+		return gtserror.Newf("error updating attachment: %w", err)
+	}
+
+	return nil
+}
+
+// storeRenditions generates the small and medium renditions of the given
+// attachment from its full size image, cropping around the attachment's
+// focal point where the image is too wide or tall, and writes them to
+// storage, setting thumbnail, medium and (if unset) blurhash info.
+//
+// Note that the thumbnail path and URL are expected to already be set.
+func (m *Manager) storeRenditions(ctx context.Context, attachment *gtsmodel.MediaAttachment, fullImg *gtsImage) error {
+	// Audio images are only a preview (cover
+	// art or waveform), so aren't cropped and
+	// don't need a medium-sized rendition.
+	audio := attachment.Type == gtsmodel.FileTypeAudio
+
+	if !audio {
+		fullImg = fullImg.CropAspect(
+			maxRenditionAspect,
+			attachment.FileMeta.Focus.X,
+			attachment.FileMeta.Focus.Y,
+		)
+	}
+
+	// Get smaller thumbnail image
+	thumbImg := fullImg.Thumbnail()
+
+	// Only generate blurhash
+	// from thumb if necessary.
+	if attachment.Blurhash == "" {
+		hash, err := thumbImg.Blurhash()
+		if err != nil {
+			return gtserror.Newf("error generating blurhash: %w", err)
+		}
+
+		// Set the attachment blurhash.
+		attachment.Blurhash = hash
+	}
+
+	// Stream-encode the JPEG thumbnail image into storage,
+	// quality of 70 being good enough for a thumbnail.
+	sz, err := m.putJPEG(ctx, attachment.Thumbnail.Path, thumbImg, 70)
+	if err != nil {
+		return gtserror.Newf("error storing thumbnail: %w", err)
+	}
+
+	// Set thumbnail dimensions in attachment info.
+	attachment.FileMeta.Small = gtsmodel.Small{
+		Width:  int(thumbImg.Width()),
+		Height: int(thumbImg.Height()),
+		Size:   int(thumbImg.Size()),
+		Aspect: thumbImg.AspectRatio(),
+	}
+
+	// Set written image size.
+	attachment.Thumbnail.FileSize = sz
+	attachment.Thumbnail.UpdatedAt = time.Now()
+
+	if audio {
+		// No medium rendition.
+		return nil
+	}
+
+	// Always encode medium
+	// renditions as jpg.
+	attachment.Medium.ContentType = mimeImageJpeg
+	attachment.Medium.Path = uris.StoragePathForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeMedium),
+		attachment.ID,
+		"jpg",
+	)
+	attachment.Medium.URL = uris.URIForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeMedium),
+		attachment.ID,
+		"jpg",
+	)
+
+	// Stream-encode the JPEG medium image into storage.
+	sz, err = m.putJPEG(ctx, attachment.Medium.Path, fullImg.Medium(), 80)
+	if err != nil {
+		return gtserror.Newf("error storing medium rendition: %w", err)
+	}
+
+	// Set written image size.
+	attachment.Medium.FileSize = sz
+
+	return nil
+}
+
+// putJPEG stream-encodes img as a JPEG of given quality into storage at
+// path, replacing any existing file there, returning the written size.
+func (m *Manager) putJPEG(ctx context.Context, path string, img *gtsImage, quality int) (int, error) {
+	// Renditions are regenerated in-place, so remove any
+	// existing (possibly out-of-date) file at path first.
+	if have, _ := m.state.Storage.Has(ctx, path); have {
+		if err := m.state.Storage.Delete(ctx, path); err != nil {
+			return 0, gtserror.Newf("error removing %s from storage: %w", path, err)
+		}
+	}
+
+	// Create a JPEG encoder stream.
+	enc := img.ToJPEG(&jpeg.Options{
+		Quality: quality,
+	})
+
+	sz, err := m.state.Storage.PutStream(ctx, path, enc)
+	if err != nil {
+		return 0, gtserror.Newf("error stream-encoding %s to storage: %w", path, err)
+	}
+
+	return int(sz), nil
+}
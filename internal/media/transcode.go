@@ -0,0 +1,320 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// ffmpegTranscodeTimeout is the max
+// time allowed for transcoding a video.
+const ffmpegTranscodeTimeout = 10 * time.Minute
+
+// transcodedPathSize is the media size part of the storage
+// path used for transcoded videos whose original was an mp4
+// already, so that the two are always stored at distinct keys.
+const transcodedPathSize = "transcoded"
+
+// transcodeVideo uses ffmpeg to transcode the video file at inpath into
+// a web-safe H.264/AAC mp4 at outpath. If maxDim is greater than 0, the
+// video is scaled down (keeping aspect ratio) to fit within maxDim.
+func transcodeVideo(ctx context.Context, inpath string, outpath string, maxDim int) error {
+	ctx, cncl := context.WithTimeout(ctx, ffmpegTranscodeTimeout)
+	defer cncl()
+
+	// H.264 with yuv420p requires even dimensions,
+	// so always scale, even if only by a pixel.
+	scale := "scale=trunc(iw/2)*2:trunc(ih/2)*2"
+	if maxDim > 0 {
+		dim := strconv.Itoa(maxDim)
+		scale = "scale=w='min(iw," + dim + ")':h='min(ih," + dim + ")'" +
+			":force_original_aspect_ratio=decrease:force_divisible_by=2"
+	}
+
+	args := []string{
+		"-v", "error",
+		"-y", // outpath is an existing (empty) temp file
+		"-i", inpath,
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+		"-map_metadata", "-1",
+		"-vf", scale,
+		"-c:v", "libx264",
+		"-preset", "veryfast",
+		"-crf", "23",
+		"-pix_fmt", "yuv420p",
+		"-c:a", "aac",
+		"-b:a", "128k",
+		"-movflags", "+faststart",
+		"-f", "mp4",
+		outpath,
+	}
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	errOut := bytes.NewBuffer([]byte{})
+	cmd.Stderr = errOut
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg subprocess failed: %w:\n%s", err, errOut)
+	}
+
+	return nil
+}
+
+// transcodeAttachment transcodes the video of the attachment with given ID
+// into a web-safe H.264/AAC mp4, replacing the original file in storage.
+//
+// This is slow, so it's intended to be run on the media worker pool after
+// the attachment has been stored and given a thumbnail (and can therefore
+// already be attached to statuses). Until transcoding finishes, the
+// attachment has processing status 'processing' and its original file is
+// served. If transcoding fails, the original file is kept.
+func (m *Manager) transcodeAttachment(ctx context.Context, attachmentID string) error {
+	attachment, err := m.state.DB.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return gtserror.Newf("error getting attachment %s: %w", attachmentID, err)
+	}
+
+	if attachment.Processing != gtsmodel.ProcessingStatusProcessing {
+		// Already transcoded
+		// (or given up on).
+		return nil
+	}
+
+	err = m.transcode(ctx, attachment)
+	if err == nil {
+		return nil
+	}
+
+	// Something went wrong, just
+	// keep serving the original.
+	attachment.Processing = gtsmodel.ProcessingStatusProcessed
+	if err := m.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		log.Errorf(ctx, "error updating attachment %s: %v", attachmentID, err)
+	}
+
+	return err
+}
+
+// TranscodePending enqueues transcoding of any video attachments left
+// with processing status 'processing' by a previous run, as transcoding
+// tasks don't persist across restarts. If transcoding has since been
+// disabled, they're instead just marked as processed, to be served
+// as-is. This should be called once on startup.
+func (m *Manager) TranscodePending(ctx context.Context) error {
+	attachments, err := m.state.DB.GetAttachmentsByProcessingStatus(
+		gtscontext.SetBarebones(ctx),
+		gtsmodel.ProcessingStatusProcessing,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting processing attachments: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, attachment := range attachments {
+		if !config.GetMediaVideoTranscode() ||
+			(attachment.Type != gtsmodel.FileTypeVideo &&
+				attachment.Type != gtsmodel.FileTypeGifv) {
+			// Nothing to transcode,
+			// just serve what we have.
+			attachment.Processing = gtsmodel.ProcessingStatusProcessed
+			if err := m.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+				errs.Appendf("error updating attachment %s: %w", attachment.ID, err)
+			}
+			continue
+		}
+
+		id := attachment.ID
+		go m.state.Workers.Media.Enqueue(func(ctx context.Context) {
+			if err := m.transcodeAttachment(ctx, id); err != nil {
+				log.Errorf(ctx, "error transcoding media %s: %v", id, err)
+			}
+		})
+	}
+
+	return errs.Combine()
+}
+
+func (m *Manager) transcode(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+	// Transcoding needs the original written out to a file.
+	in, err := os.CreateTemp(os.TempDir(), "gts-transcode-in")
+	if err != nil {
+		return gtserror.Newf("error creating temporary file: %w", err)
+	}
+	defer removeTemp(ctx, in)
+
+	out, err := os.CreateTemp(os.TempDir(), "gts-transcode-out")
+	if err != nil {
+		return gtserror.Newf("error creating temporary file: %w", err)
+	}
+	defer removeTemp(ctx, out)
+
+	rc, err := m.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return gtserror.Newf("error loading file from storage: %w", err)
+	}
+
+	_, err = io.Copy(in, rc)
+	_ = rc.Close()
+	if err != nil {
+		return gtserror.Newf("error writing video for transcoding: %w", err)
+	}
+
+	maxDim := config.GetMediaVideoTranscodeMaxDimension()
+	if err := transcodeVideo(ctx, in.Name(), out.Name(), maxDim); err != nil {
+		return gtserror.Newf("error transcoding video: %w", err)
+	}
+
+	// Re-encoding (eg., an already heavily
+	// compressed video) can make it larger;
+	// don't exceed the configured size cap.
+	info, err := out.Stat()
+	if err != nil {
+		return gtserror.Newf("error checking transcoded video size: %w", err)
+	}
+
+	maxSize := int64(config.GetMediaVideoMaxSize())
+	if info.Size() > maxSize {
+		return gtserror.Newf("transcoded video size %d exceeds max video size %d", info.Size(), maxSize)
+	}
+
+	video, err := probeVideo(ctx, out.Name())
+	if err != nil {
+		return gtserror.Newf("error probing transcoded video: %w", err)
+	}
+
+	oldPath := attachment.File.Path
+	newPath := uris.StoragePathForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		attachment.ID,
+		mimeMp4,
+	)
+
+	if newPath == oldPath {
+		// Original was an mp4 already (just not a web-safe
+		// one). Store the transcoded video under its own key,
+		// so the original is still there to be served (or
+		// kept) if anything fails before the attachment is
+		// updated to point at the transcoded video.
+		newPath = uris.StoragePathForAttachment(
+			attachment.AccountID,
+			string(TypeAttachment),
+			transcodedPathSize,
+			attachment.ID,
+			mimeMp4,
+		)
+	}
+
+	// Remove anything left at the new path by a previous
+	// attempt that was interrupted before the attachment
+	// could be updated, as storage won't overwrite it.
+	if err := m.state.Storage.Delete(ctx, newPath); err != nil &&
+		!errors.Is(err, storage.ErrNotFound) {
+		return gtserror.Newf("error removing leftover transcoded video from storage: %w", err)
+	}
+
+	if _, err := out.Seek(0, io.SeekStart); err != nil {
+		return gtserror.Newf("error seeking transcoded video: %w", err)
+	}
+
+	sz, err := m.state.Storage.PutStream(ctx, newPath, out)
+	if err != nil {
+		return gtserror.Newf("error writing transcoded video to storage: %w", err)
+	}
+
+	// Update attachment to point at the transcoded
+	// video. The URL still contains the attachment ID,
+	// so any links to the original will keep working.
+	attachment.URL = uris.URIForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		attachment.ID,
+		mimeMp4,
+	)
+	attachment.File.Path = newPath
+	attachment.File.ContentType = mimeVideoMp4
+	attachment.File.FileSize = int(sz)
+	attachment.File.UpdatedAt = time.Now()
+	attachment.FileMeta.Original.Width = video.width
+	attachment.FileMeta.Original.Height = video.height
+	attachment.FileMeta.Original.Size = video.width * video.height
+	attachment.FileMeta.Original.Aspect = float32(video.width) / float32(video.height)
+	attachment.FileMeta.Original.Duration = &video.duration
+	attachment.FileMeta.Original.Framerate = &video.framerate
+	attachment.FileMeta.Original.Bitrate = &video.bitrate
+	attachment.Processing = gtsmodel.ProcessingStatusProcessed
+
+	if err := m.state.DB.UpdateAttachment(ctx, attachment,
+		"url",
+		"file_path",
+		"file_content_type",
+		"file_file_size",
+		"file_updated_at",
+		"original_width",
+		"original_height",
+		"original_size",
+		"original_aspect",
+		"original_duration",
+		"original_framerate",
+		"original_bitrate",
+		"processing",
+	); err != nil {
+		// Don't leave the transcoded video lying around.
+		if err := m.state.Storage.Delete(ctx, newPath); err != nil &&
+			!errors.Is(err, storage.ErrNotFound) {
+			log.Errorf(ctx, "error removing transcoded video from storage: %v", err)
+		}
+		return gtserror.Newf("error updating attachment: %w", err)
+	}
+
+	// Original is no longer needed.
+	if err := m.state.Storage.Delete(ctx, oldPath); err != nil &&
+		!errors.Is(err, storage.ErrNotFound) {
+		log.Errorf(ctx, "error removing original video from storage: %v", err)
+	}
+
+	return nil
+}
+
+// removeTemp closes and removes the given temporary file.
+func removeTemp(ctx context.Context, f *os.File) {
+	_ = f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		log.Errorf(ctx, "error removing temporary file %s: %v", f.Name(), err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 const (
 	mimeImage = "image"
 	mimeVideo = "video"
+	mimeAudio = "audio"
 
 	mimeJpeg      = "jpeg"
 	mimeImageJpeg = mimeImage + "/" + mimeJpeg
@@ -40,14 +41,52 @@ const (
 	mimeWebp      = "webp"
 	mimeImageWebp = mimeImage + "/" + mimeWebp
 
+	mimeAvif      = "avif"
+	mimeImageAvif = mimeImage + "/" + mimeAvif
+
+	mimeHeic      = "heic"
+	mimeImageHeic = mimeImage + "/" + mimeHeic
+
+	mimeHeif      = "heif"
+	mimeImageHeif = mimeImage + "/" + mimeHeif
+
+	mimeJxl      = "jxl"
+	mimeImageJxl = mimeImage + "/" + mimeJxl
+
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+	mimeAudioMp4 = mimeAudio + "/" + mimeMp4
+
+	mimeMpeg      = "mpeg"
+	mimeAudioMpeg = mimeAudio + "/" + mimeMpeg
+
+	mimeOgg      = "ogg"
+	mimeAudioOgg = mimeAudio + "/" + mimeOgg
+
+	mimeFlac      = "x-flac"
+	mimeAudioFlac = mimeAudio + "/" + mimeFlac
+
+	mimeWav      = "x-wav"
+	mimeAudioWav = mimeAudio + "/" + mimeWav
+
+	mimeWebm      = "webm"
+	mimeVideoWebm = mimeVideo + "/" + mimeWebm
+
+	mimeQuicktime      = "quicktime"
+	mimeVideoQuicktime = mimeVideo + "/" + mimeQuicktime
+
+	mimeMatroska      = "x-matroska"
+	mimeVideoMatroska = mimeVideo + "/" + mimeMatroska
+
+	mimeM4v      = "x-m4v"
+	mimeVideoM4v = mimeVideo + "/" + mimeM4v
 )
 
 type Size string
 
 const (
 	SizeSmall    Size = "small"    // SizeSmall is the key for small/thumbnail versions of media
+	SizeMedium   Size = "medium"   // SizeMedium is the key for medium-sized renditions of media
 	SizeOriginal Size = "original" // SizeOriginal is the key for original/fullsize versions of media and emoji
 	SizeStatic   Size = "static"   // SizeStatic is the key for static (non-animated) versions of emoji
 )
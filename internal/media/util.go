@@ -17,6 +17,13 @@
 
 package media
 
+import (
+	"bytes"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
 // newHdrBuf returns a buffer of suitable size to
 // read bytes from a file header or magic number.
 //
@@ -40,3 +47,23 @@ func newHdrBuf(fileSize int) []byte {
 
 	return make([]byte, bufSize)
 }
+
+// bufferImage reads the image stream r into memory, up to the
+// configured max image size, returning a reader of the buffered
+// image along with its size. If the image is larger than max image
+// size, a reader of the whole stream is returned with size 0.
+func bufferImage(r io.Reader) (io.Reader, int, error) {
+	limit := int64(config.GetMediaImageMaxSize())
+
+	b, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if int64(len(b)) > limit {
+		// Too big, just stream it.
+		return io.MultiReader(bytes.NewReader(b), r), 0, nil
+	}
+
+	return bytes.NewReader(b), len(b), nil
+}
@@ -18,161 +18,195 @@
 package media
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"image"
 	"io"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 type gtsVideo struct {
-	frame     *gtsImage
-	duration  float32 // in seconds
-	bitrate   uint64
-	framerate float32
+	frame      *gtsImage
+	width      int
+	height     int
+	duration   float32 // in seconds
+	bitrate    uint64
+	framerate  float32
+	videoCodec string // eg., "h264", "hevc", "vp9"
+	audioCodec string // eg., "aac", "opus"; empty if no audio
 }
 
-// decodeVideoFrame decodes and returns an image from a single frame in the given video stream.
-func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
+// webSafe returns whether the video, with given content-type, can be
+// played as-is by most browsers; ie., it's H.264 video (with optional
+// AAC audio) in an mp4 container. If maxDim is greater than 0, width
+// and height of the video must also be no greater than maxDim.
+func (v *gtsVideo) webSafe(contentType string, maxDim int) bool {
+	if contentType != mimeVideoMp4 {
+		return false
+	}
+
+	if v.videoCodec != "h264" {
+		return false
+	}
+
+	if v.audioCodec != "" && v.audioCodec != "aac" {
+		return false
+	}
+
+	if maxDim > 0 && (v.width > maxDim || v.height > maxDim) {
+		return false
+	}
+
+	return true
+}
+
+// decodeVideoFrame decodes metadata from the given video stream,
+// along with a poster frame picked from the start of the video.
+func decodeVideoFrame(ctx context.Context, r io.Reader) (*gtsVideo, error) {
 	tf, err := os.CreateTemp(os.TempDir(), "gts-video")
 	if err != nil {
 		return nil, fmt.Errorf("creating temporary file for video processing: %w", err)
 	}
-	// defer func() {
-	// 	os.Remove(tf.Name())
-	// }()
 
-	log.Infof(nil, "created temporary file for video processing: %s", tf.Name())
+	defer func() {
+		_ = tf.Close()
+		if err := os.Remove(tf.Name()); err != nil {
+			log.Errorf(ctx, "error removing temporary file %s: %v", tf.Name(), err)
+		}
+	}()
+
+	if _, err := io.Copy(tf, r); err != nil {
+		return nil, fmt.Errorf("writing video for processing: %w", err)
+	}
 
-	_, err = io.Copy(tf, r)
+	video, err := probeVideo(ctx, tf.Name())
+	if err != nil {
+		return nil, err
+	}
 
+	video.frame, err = extractPosterFrame(ctx, tf.Name())
 	if err != nil {
-		return nil, fmt.Errorf("writing video for processing: %w", err)
+		return nil, fmt.Errorf("extracting poster frame: %w", err)
 	}
 
-	prog := "ffprobe"
-	args := []string{
-		"-select_streams", "v",
-		"-show_entries", "stream=r_frame_rate,bit_rate,duration",
+	return video, nil
+}
+
+// probeVideo uses ffprobe to read dimensions, duration, bitrate,
+// framerate and codecs from the video file at path.
+func probeVideo(ctx context.Context, filepath string) (*gtsVideo, error) {
+	out, err := ffprobe(ctx, filepath,
+		"-show_entries", "stream=codec_type,codec_name,width,height,r_frame_rate,bit_rate,duration:format=duration,bit_rate",
 		"-of", "json",
-		tf.Name(),
-	}
-	cmd := exec.Command(prog, args...)
-	cmd.Stdin = r
-	out := bytes.NewBuffer(make([]byte, 0, 2048))
-	cmd.Stdout = out
-	cmdErrc := make(chan error, 1)
-	cmdErrOut, err := cmd.StderrPipe()
+	)
 	if err != nil {
 		return nil, err
 	}
-	if err := cmd.Start(); err != nil {
-		return nil, err
+
+	type stream struct {
+		CodecType string `json:"codec_type"`
+		CodecName string `json:"codec_name"`
+		Width     int    `json:"width"`
+		Height    int    `json:"height"`
+		Duration  string `json:"duration"`
+		BitRate   string `json:"bit_rate"`
+		FrameRate string `json:"r_frame_rate"`
 	}
-	defer cmd.Process.Kill()
-	go func() {
-		out, err := io.ReadAll(cmdErrOut)
-		if err != nil {
-			cmdErrc <- err
-			return
-		}
-		cmd.Wait()
-		if cmd.ProcessState.Success() {
-			cmdErrc <- nil
-			return
-		}
-		cmdErrc <- fmt.Errorf("metadata probe subprocess failed:\n%s", out)
-	}()
-	select {
-	case err := <-cmdErrc:
-		if err != nil {
-			return nil, err
-		}
-	case <-time.After(time.Second):
-		return nil, fmt.Errorf("timeout during metadata probe process")
-	}
-	streamInfo := &struct {
-		Streams []struct {
-			Duration  string `json:"duration"`
-			BitRate   string `json:"bit_rate"`
-			FrameRate string `json:"r_frame_rate"`
-		} `json:"streams"`
+
+	probe := &struct {
+		Streams []stream `json:"streams"`
+		Format  struct {
+			Duration string `json:"duration"`
+			BitRate  string `json:"bit_rate"`
+		} `json:"format"`
 	}{}
-	if err := json.Unmarshal(out.Bytes(), &streamInfo); err != nil {
+	if err := json.Unmarshal(out, probe); err != nil {
 		return nil, fmt.Errorf("failed parsing metadata: %w", err)
 	}
-	if len(streamInfo.Streams) == 0 {
+
+	// Find the first video and
+	// audio streams (if any).
+	var v, a *stream
+	for i := range probe.Streams {
+		s := &probe.Streams[i]
+		switch {
+		case s.CodecType == "video" && v == nil:
+			v = s
+		case s.CodecType == "audio" && a == nil:
+			a = s
+		}
+	}
+
+	if v == nil {
 		return nil, fmt.Errorf("media container did not contain any video streams")
 	}
 
-	s := streamInfo.Streams[0]
-	video := gtsVideo{}
+	video := gtsVideo{
+		width:      v.Width,
+		height:     v.Height,
+		videoCodec: v.CodecName,
+	}
+
+	if a != nil {
+		video.audioCodec = a.CodecName
+	}
+
+	// Not all containers have duration or bitrate set per stream
+	// (eg., webm), fall back to container format values for these.
+	duration, bitrate := v.Duration, v.BitRate
+	if duration == "" || duration == "N/A" {
+		duration = probe.Format.Duration
+	}
+	if bitrate == "" || bitrate == "N/A" {
+		bitrate = probe.Format.BitRate
+	}
 
 	// duration
-	dur, err := strconv.ParseFloat(s.Duration, 32)
+	dur, err := strconv.ParseFloat(duration, 32)
 	if err != nil {
-		return nil, fmt.Errorf("unable to decode video duration with value %s", s.Duration)
+		return nil, fmt.Errorf("unable to decode video duration with value %s", duration)
 	}
 	video.duration = float32(dur)
 
 	// bitrate
-	br, err := strconv.ParseUint(s.BitRate, 10, 64)
+	br, err := strconv.ParseUint(bitrate, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("unable to decode video bitrate with value %s", s.BitRate)
+		return nil, fmt.Errorf("unable to decode video bitrate with value %s", bitrate)
 	}
 	video.bitrate = br
 
 	// framerate
-	frParts := strings.Split(s.FrameRate, "/")
+	frParts := strings.Split(v.FrameRate, "/")
 	if len(frParts) != 2 {
-		return nil, fmt.Errorf("unable to decode video framerate with value %s", s.FrameRate)
+		return nil, fmt.Errorf("unable to decode video framerate with value %s", v.FrameRate)
 	}
 	frCount, err := strconv.Atoi(frParts[0])
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode video framerate count with value %s", frParts[0])
 	}
 	frTime, err := strconv.Atoi(frParts[1])
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode video framerate base with value %s", frParts[0])
+	if err != nil || frTime == 0 {
+		return nil, fmt.Errorf("unable to decode video framerate base with value %s", frParts[1])
 	}
 	video.framerate = float32(frCount) / float32(frTime)
 
-	frame, err := extractThumbnail(tf.Name())
-	if err != nil {
-		return nil, fmt.Errorf("extracting thumbnail: %w", err)
-	}
-	video.frame = frame
-
 	return &video, nil
 }
 
-func extractThumbnail(filepath string) (*gtsImage, error) {
-	args := []string{
-		"-i", filepath,
+// extractPosterFrame uses ffmpeg to pick a representative
+// frame from the start of the video file at path, skipping
+// eg., all-black frames that videos often start with.
+func extractPosterFrame(ctx context.Context, filepath string) (*gtsImage, error) {
+	return ffmpegImage(ctx, filepath,
+		"-an",
 		"-vf", "thumbnail=n=10",
 		"-frames:v", "1",
 		"-f", "image2pipe",
 		"-c:v", "mjpeg",
 		"pipe:1",
-	}
-
-	cmd := exec.Command("ffmpeg", args...)
-	b := bytes.NewBuffer([]byte{})
-	cmd.Stdout = b
-	err := cmd.Run()
-	if err != nil {
-		return nil, fmt.Errorf("extracting thumbnail using ffmpeg: %w", err)
-	}
-	img, _, err := image.Decode(b)
-	if err != nil {
-		return nil, fmt.Errorf("decoding generated thumbnail: %w", err)
-	}
-	return &gtsImage{img}, nil
+	)
 }
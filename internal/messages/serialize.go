@@ -0,0 +1,288 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// clientMsg is the serialized form of FromClientAPI.
+type clientMsg struct {
+	APObjectType    string          `json:"ap_object_type,omitempty"`
+	APActivityType  string          `json:"ap_activity_type,omitempty"`
+	GTSModelType    string          `json:"gts_model_type,omitempty"`
+	GTSModel        json.RawMessage `json:"gts_model,omitempty"`
+	OriginAccountID string          `json:"origin_account_id,omitempty"`
+	TargetAccountID string          `json:"target_account_id,omitempty"`
+}
+
+// fediMsg is the serialized form of FromFediAPI.
+type fediMsg struct {
+	APObjectType        string                 `json:"ap_object_type,omitempty"`
+	APActivityType      string                 `json:"ap_activity_type,omitempty"`
+	APIri               string                 `json:"ap_iri,omitempty"`
+	APObjectModel       map[string]interface{} `json:"ap_object_model,omitempty"`
+	GTSModelType        string                 `json:"gts_model_type,omitempty"`
+	GTSModel            json.RawMessage        `json:"gts_model,omitempty"`
+	RequestingAccountID string                 `json:"requesting_account_id,omitempty"`
+	ReceivingAccountID  string                 `json:"receiving_account_id,omitempty"`
+}
+
+// Serialize encodes the message as JSON, for persisting
+// it until it has been processed. Accounts are encoded
+// by ID only, and the GTSModel is encoded without any of
+// its populated relations, so after Deserialize() the
+// accounts must be fetched, and the model re-populated.
+func (msg *FromClientAPI) Serialize() ([]byte, error) {
+	modelType, model, err := serializeModel(msg.GTSModel)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(clientMsg{
+		APObjectType:    msg.APObjectType,
+		APActivityType:  msg.APActivityType,
+		GTSModelType:    modelType,
+		GTSModel:        model,
+		OriginAccountID: accountID(msg.OriginAccount),
+		TargetAccountID: accountID(msg.TargetAccount),
+	})
+}
+
+// Deserialize decodes the message from the JSON produced by
+// Serialize(). OriginAccount and TargetAccount will be set to
+// bare-bones models with only their IDs set, if they were set.
+func (msg *FromClientAPI) Deserialize(data []byte) error {
+	var cMsg clientMsg
+	if err := json.Unmarshal(data, &cMsg); err != nil {
+		return gtserror.Newf("error unmarshaling message: %w", err)
+	}
+
+	model, err := deserializeModel(cMsg.GTSModelType, cMsg.GTSModel)
+	if err != nil {
+		return err
+	}
+
+	msg.APObjectType = cMsg.APObjectType
+	msg.APActivityType = cMsg.APActivityType
+	msg.GTSModel = model
+	msg.OriginAccount = stubAccount(cMsg.OriginAccountID)
+	msg.TargetAccount = stubAccount(cMsg.TargetAccountID)
+	return nil
+}
+
+// Serialize encodes the message as JSON, for persisting
+// it until it has been processed. Accounts are encoded
+// by ID only, and the GTSModel is encoded without any of
+// its populated relations, so after Deserialize() the
+// accounts must be fetched, and the model re-populated.
+func (msg *FromFediAPI) Serialize() ([]byte, error) {
+	modelType, model, err := serializeModel(msg.GTSModel)
+	if err != nil {
+		return nil, err
+	}
+
+	fMsg := fediMsg{
+		APObjectType:        msg.APObjectType,
+		APActivityType:      msg.APActivityType,
+		GTSModelType:        modelType,
+		GTSModel:            model,
+		RequestingAccountID: accountID(msg.RequestingAccount),
+		ReceivingAccountID:  accountID(msg.ReceivingAccount),
+	}
+
+	if msg.APIri != nil {
+		fMsg.APIri = msg.APIri.String()
+	}
+
+	if msg.APObjectModel != nil {
+		t, ok := msg.APObjectModel.(vocab.Type)
+		if !ok {
+			return nil, gtserror.Newf("cannot serialize APObjectModel %T", msg.APObjectModel)
+		}
+
+		fMsg.APObjectModel, err = ap.Serialize(t)
+		if err != nil {
+			return nil, gtserror.Newf("error serializing APObjectModel: %w", err)
+		}
+	}
+
+	return json.Marshal(fMsg)
+}
+
+// Deserialize decodes the message from the JSON produced by
+// Serialize(). RequestingAccount and ReceivingAccount will be
+// set to bare-bones models with only their IDs set, if set.
+func (msg *FromFediAPI) Deserialize(data []byte) error {
+	var fMsg fediMsg
+	if err := json.Unmarshal(data, &fMsg); err != nil {
+		return gtserror.Newf("error unmarshaling message: %w", err)
+	}
+
+	model, err := deserializeModel(fMsg.GTSModelType, fMsg.GTSModel)
+	if err != nil {
+		return err
+	}
+
+	msg.APObjectType = fMsg.APObjectType
+	msg.APActivityType = fMsg.APActivityType
+	msg.GTSModel = model
+	msg.RequestingAccount = stubAccount(fMsg.RequestingAccountID)
+	msg.ReceivingAccount = stubAccount(fMsg.ReceivingAccountID)
+
+	if fMsg.APIri != "" {
+		msg.APIri, err = url.Parse(fMsg.APIri)
+		if err != nil {
+			return gtserror.Newf("error parsing APIri: %w", err)
+		}
+	}
+
+	if fMsg.APObjectModel != nil {
+		// The context passed here is only used
+		// by go-fed for type resolution callbacks.
+		msg.APObjectModel, err = streams.ToType(context.Background(), fMsg.APObjectModel)
+		if err != nil {
+			return gtserror.Newf("error resolving APObjectModel: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// newModel contains constructors for all the gtsmodel
+// types that may be set as the GTSModel of a message,
+// keyed by the name used to identify them when serialized.
+var newModel = map[string]func() interface{}{
+	"Account":       func() interface{} { return new(gtsmodel.Account) },
+	"Block":         func() interface{} { return new(gtsmodel.Block) },
+	"DomainBlock":   func() interface{} { return new(gtsmodel.DomainBlock) },
+	"Follow":        func() interface{} { return new(gtsmodel.Follow) },
+	"FollowRequest": func() interface{} { return new(gtsmodel.FollowRequest) },
+	"Move":          func() interface{} { return new(gtsmodel.Move) },
+	"PollVote":      func() interface{} { return new(gtsmodel.PollVote) },
+	"Report":        func() interface{} { return new(gtsmodel.Report) },
+	"Status":        func() interface{} { return new(gtsmodel.Status) },
+	"StatusFave":    func() interface{} { return new(gtsmodel.StatusFave) },
+}
+
+// serializeModel returns the name of the given
+// GTSModel's type, and the model encoded as JSON
+// with all of its populated relations cleared.
+func serializeModel(model interface{}) (string, json.RawMessage, error) {
+	if model == nil {
+		return "", nil, nil
+	}
+
+	rv := reflect.ValueOf(model)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return "", nil, gtserror.Newf("cannot serialize GTSModel %T", model)
+	}
+
+	name := rv.Elem().Type().Name()
+	if _, ok := newModel[name]; !ok {
+		return "", nil, gtserror.Newf("cannot serialize GTSModel %T", model)
+	}
+
+	b, err := json.Marshal(withoutRelations(rv.Elem()))
+	if err != nil {
+		return "", nil, gtserror.Newf("error marshaling GTSModel: %w", err)
+	}
+
+	return name, b, nil
+}
+
+// deserializeModel decodes the given JSON as the GTSModel
+// type with the given name, as returned by serializeModel().
+func deserializeModel(name string, data json.RawMessage) (interface{}, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	newFn, ok := newModel[name]
+	if !ok {
+		return nil, gtserror.Newf("unknown GTSModel type %s", name)
+	}
+
+	model := newFn()
+	if err := json.Unmarshal(data, model); err != nil {
+		return nil, gtserror.Newf("error unmarshaling GTSModel: %w", err)
+	}
+
+	return model, nil
+}
+
+// withoutRelations returns a pointer to a copy of the given
+// model struct, with any fields that hold related models
+// (ie., bun relations, or fields excluded from the db that
+// point to other models) set to zero. Aside from keeping
+// the serialized form small, this also avoids encoding
+// cycles such as Status.Poll <-> Poll.Status.
+func withoutRelations(rv reflect.Value) interface{} {
+	cp := reflect.New(rv.Type()).Elem()
+	cp.Set(rv)
+
+	for i := 0; i < cp.NumField(); i++ {
+		field := cp.Type().Field(i)
+		if !field.IsExported() || !isModelRef(field.Type) {
+			continue
+		}
+
+		tag := field.Tag.Get("bun")
+		if tag == "-" ||
+			strings.Contains(tag, "rel:") ||
+			strings.Contains(tag, "m2m:") {
+			cp.Field(i).Set(reflect.Zero(field.Type))
+		}
+	}
+
+	return cp.Addr().Interface()
+}
+
+// isModelRef returns whether t is a pointer
+// to a struct, or a slice of such pointers.
+func isModelRef(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Pointer &&
+		t.Elem().Kind() == reflect.Struct
+}
+
+func accountID(account *gtsmodel.Account) string {
+	if account == nil {
+		return ""
+	}
+	return account.ID
+}
+
+func stubAccount(id string) *gtsmodel.Account {
+	if id == "" {
+		return nil
+	}
+	return &gtsmodel.Account{ID: id}
+}
@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type SerializeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SerializeTestSuite) TestFromClientAPISerialize() {
+	origin := &gtsmodel.Account{ID: "01F8MH1H7YV1Z7D2C8K2730QBF", Username: "the_mighty_zork"}
+	status := &gtsmodel.Status{
+		ID:        "01HY8C3V1E3M0B2N7R7CHJ3QKW",
+		URI:       "http://localhost:8080/users/the_mighty_zork/statuses/01HY8C3V1E3M0B2N7R7CHJ3QKW",
+		Content:   "hello world",
+		AccountID: origin.ID,
+		Account:   origin,
+		Local:     util.Ptr(true),
+	}
+
+	// Set up a Status <-> Poll cycle,
+	// which must not be serialized.
+	status.Poll = &gtsmodel.Poll{ID: "01HY8C48D9W4BRH0YJ6K6GQXXP", Status: status}
+	status.PollID = status.Poll.ID
+
+	msg := messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       status,
+		OriginAccount:  origin,
+	}
+
+	b, err := msg.Serialize()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var out messages.FromClientAPI
+	if err := out.Deserialize(b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(ap.ObjectNote, out.APObjectType)
+	suite.Equal(ap.ActivityCreate, out.APActivityType)
+	suite.Equal(&gtsmodel.Account{ID: origin.ID}, out.OriginAccount)
+	suite.Nil(out.TargetAccount)
+
+	outStatus, ok := out.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		suite.FailNow("unexpected type")
+	}
+	suite.Equal(status.ID, outStatus.ID)
+	suite.Equal(status.Content, outStatus.Content)
+	suite.Equal(status.PollID, outStatus.PollID)
+	suite.True(*outStatus.Local)
+
+	// Relations are not kept.
+	suite.Nil(outStatus.Account)
+	suite.Nil(outStatus.Poll)
+
+	// Original model is untouched.
+	suite.NotNil(status.Account)
+	suite.NotNil(status.Poll)
+}
+
+func (suite *SerializeTestSuite) TestFromFediAPISerialize() {
+	const note = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/1",
+  "type": "Note",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "hello world",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`
+
+	statusable, err := ap.ResolveStatusable(context.Background(), io.NopCloser(strings.NewReader(note)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	iri, err := url.Parse("https://example.org/users/someone/statuses/1")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	msg := messages.FromFediAPI{
+		APObjectType:      ap.ObjectNote,
+		APActivityType:    ap.ActivityCreate,
+		APIri:             iri,
+		APObjectModel:     statusable,
+		RequestingAccount: &gtsmodel.Account{ID: "01F8MH5ZK5VRH73AKHQM6Y9VNX"},
+		ReceivingAccount:  &gtsmodel.Account{ID: "01F8MH1H7YV1Z7D2C8K2730QBF"},
+	}
+
+	b, err := msg.Serialize()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var out messages.FromFediAPI
+	if err := out.Deserialize(b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(iri.String(), out.APIri.String())
+	suite.Nil(out.GTSModel)
+	suite.Equal("01F8MH5ZK5VRH73AKHQM6Y9VNX", out.RequestingAccount.ID)
+	suite.Equal("01F8MH1H7YV1Z7D2C8K2730QBF", out.ReceivingAccount.ID)
+
+	outStatusable, ok := out.APObjectModel.(ap.Statusable)
+	if !ok {
+		suite.FailNow("unexpected type")
+	}
+	suite.Equal(iri.String(), ap.GetJSONLDId(outStatusable).String())
+}
+
+func (suite *SerializeTestSuite) TestSerializeUnknownModel() {
+	msg := messages.FromClientAPI{GTSModel: &gtsmodel.Tag{}}
+
+	_, err := msg.Serialize()
+	suite.Error(err)
+}
+
+func TestSerializeTestSuite(t *testing.T) {
+	suite.Run(t, new(SerializeTestSuite))
+}
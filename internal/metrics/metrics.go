@@ -26,10 +26,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -41,7 +44,7 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(db db.DB, workers *workers.Workers) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -124,6 +127,64 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
+	_, err = meter.Int64ObservableGauge(
+		"gotosocial.instance.total_unavailable_instances",
+		metric.WithDescription("Total number of other instances currently marked unavailable due to sustained delivery failures"),
+		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
+			unavailable, err := db.GetUnavailableInstances(c)
+			if err != nil {
+				return err
+			}
+			o.Observe(int64(len(unavailable)))
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	var (
+		clientAPIAttr = metric.WithAttributes(attribute.String("worker", gtsmodel.WorkerTypeClientAPI.String()))
+		fediAPIAttr   = metric.WithAttributes(attribute.String("worker", gtsmodel.WorkerTypeFediAPI.String()))
+	)
+
+	_, err = meter.Int64ObservableGauge(
+		"gotosocial.workers.queued_tasks",
+		metric.WithDescription("Number of tasks waiting in the in-memory queue of each worker pool"),
+		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(workers.ClientAPI.Queue()), clientAPIAttr)
+			o.Observe(int64(workers.Federator.Queue()), fediAPIAttr)
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	if config.GetAdvancedPersistWorkerQueues() {
+		_, err = meter.Int64ObservableGauge(
+			"gotosocial.workers.persisted_tasks",
+			metric.WithDescription("Number of tasks persisted in the database for each worker pool, including those being processed"),
+			metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
+				clientAPICount, err := db.CountWorkerTasks(c, gtsmodel.WorkerTypeClientAPI)
+				if err != nil {
+					return err
+				}
+				o.Observe(int64(clientAPICount), clientAPIAttr)
+
+				fediAPICount, err := db.CountWorkerTasks(c, gtsmodel.WorkerTypeFediAPI)
+				if err != nil {
+					return err
+				}
+				o.Observe(int64(fediAPICount), fediAPIAttr)
+				return nil
+			}),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -25,10 +25,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
 	"github.com/uptrace/bun"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(db db.DB, workers *workers.Workers) error {
 	if config.GetMetricsEnabled() {
 		return errors.New("metrics was disabled at build time")
 	}
@@ -19,6 +19,7 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -27,6 +28,10 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
+// tokenLastUsedFreq is the maximum frequency at which
+// a token's last-used time will be written to the database.
+const tokenLastUsedFreq = time.Hour
+
 // TokenCheck returns a new gin middleware for validating oauth tokens in requests.
 //
 // The middleware checks the request Authorization header for a valid oauth Bearer token.
@@ -34,6 +39,8 @@ import (
 // If no token was set in the Authorization header, or the token was invalid, the handler will return.
 //
 // If a valid oauth Bearer token was provided, it will be set on the gin context for further use.
+// Tokens are looked up fresh on each request, so a revoked token stops working immediately. The
+// token's last-used time is also updated here, at most once per tokenLastUsedFreq.
 //
 // Then, it will check which *gtsmodel.User the token belongs to. If the user is not confirmed, not approved,
 // or has been disabled, then the middleware will return early. Otherwise, the User will be set on the
@@ -65,6 +72,16 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 		c.Set(oauth.SessionAuthorizedToken, ti)
 
+		// Note when this token was last used, if not done recently.
+		if token, err := dbConn.GetTokenByAccess(ctx, ti.GetAccess()); err != nil {
+			log.Errorf(ctx, "database error looking for token: %s", err)
+		} else if time.Since(token.LastUsed) > tokenLastUsedFreq {
+			token.LastUsed = time.Now()
+			if err := dbConn.UpdateToken(ctx, token, "last_used"); err != nil {
+				log.Errorf(ctx, "database error updating token last used: %s", err)
+			}
+		}
+
 		// check for user-level token
 		if userID := ti.GetUserID(); userID != "" {
 			log.Tracef(ctx, "authenticated user %s with bearer token, scope is %s", userID, ti.GetScope())
@@ -0,0 +1,257 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope represents an OAuth scope, which
+// may be top-level (eg., "read") or granular
+// (eg., "read:statuses", "admin:write:reports").
+//
+// A top-level scope implies all of the granular
+// scopes beneath it, so "read" implies "read:statuses",
+// and "admin:read" implies "admin:read:accounts".
+type Scope string
+
+const (
+	// ScopeProfile allows only reading
+	// the profile of the authorized account.
+	ScopeProfile Scope = "profile"
+	ScopePush    Scope = "push"
+
+	ScopeRead              Scope = "read"
+	ScopeReadAccounts      Scope = ScopeRead + ":accounts"
+	ScopeReadBlocks        Scope = ScopeRead + ":blocks"
+	ScopeReadBookmarks     Scope = ScopeRead + ":bookmarks"
+	ScopeReadCustomEmojis  Scope = ScopeRead + ":custom_emojis"
+	ScopeReadFavourites    Scope = ScopeRead + ":favourites"
+	ScopeReadFilters       Scope = ScopeRead + ":filters"
+	ScopeReadFollows       Scope = ScopeRead + ":follows"
+	ScopeReadLists         Scope = ScopeRead + ":lists"
+	ScopeReadMutes         Scope = ScopeRead + ":mutes"
+	ScopeReadNotifications Scope = ScopeRead + ":notifications"
+	ScopeReadReports       Scope = ScopeRead + ":reports"
+	ScopeReadSearch        Scope = ScopeRead + ":search"
+	ScopeReadStatuses      Scope = ScopeRead + ":statuses"
+
+	ScopeWrite              Scope = "write"
+	ScopeWriteAccounts      Scope = ScopeWrite + ":accounts"
+	ScopeWriteBlocks        Scope = ScopeWrite + ":blocks"
+	ScopeWriteBookmarks     Scope = ScopeWrite + ":bookmarks"
+	ScopeWriteConversations Scope = ScopeWrite + ":conversations"
+	ScopeWriteFavourites    Scope = ScopeWrite + ":favourites"
+	ScopeWriteFilters       Scope = ScopeWrite + ":filters"
+	ScopeWriteFollows       Scope = ScopeWrite + ":follows"
+	ScopeWriteLists         Scope = ScopeWrite + ":lists"
+	ScopeWriteMedia         Scope = ScopeWrite + ":media"
+	ScopeWriteMutes         Scope = ScopeWrite + ":mutes"
+	ScopeWriteNotifications Scope = ScopeWrite + ":notifications"
+	ScopeWriteReports       Scope = ScopeWrite + ":reports"
+	ScopeWriteStatuses      Scope = ScopeWrite + ":statuses"
+
+	// ScopeFollow is a deprecated top-level scope,
+	// which implies read + write for blocks, follows,
+	// and mutes. Kept for compatibility with older apps.
+	ScopeFollow Scope = "follow"
+
+	// ScopeUser is a legacy GoToSocial top-level
+	// scope, formerly requested by the settings
+	// panel, which implies both read and write.
+	ScopeUser Scope = "user"
+
+	// ScopeAdmin is a GoToSocial-specific top-level
+	// scope, which implies both admin:read and admin:write.
+	ScopeAdmin Scope = "admin"
+
+	ScopeAdminRead                      Scope = ScopeAdmin + ":read"
+	ScopeAdminReadAccounts              Scope = ScopeAdminRead + ":accounts"
+	ScopeAdminReadReports               Scope = ScopeAdminRead + ":reports"
+	ScopeAdminReadDomainAllows          Scope = ScopeAdminRead + ":domain_allows"
+	ScopeAdminReadDomainBlocks          Scope = ScopeAdminRead + ":domain_blocks"
+	ScopeAdminReadIPBlocks              Scope = ScopeAdminRead + ":ip_blocks"
+	ScopeAdminReadEmailDomainBlocks     Scope = ScopeAdminRead + ":email_domain_blocks"
+	ScopeAdminReadCanonicalEmailBlocks  Scope = ScopeAdminRead + ":canonical_email_blocks"
+	ScopeAdminWrite                     Scope = ScopeAdmin + ":write"
+	ScopeAdminWriteAccounts             Scope = ScopeAdminWrite + ":accounts"
+	ScopeAdminWriteReports              Scope = ScopeAdminWrite + ":reports"
+	ScopeAdminWriteDomainAllows         Scope = ScopeAdminWrite + ":domain_allows"
+	ScopeAdminWriteDomainBlocks         Scope = ScopeAdminWrite + ":domain_blocks"
+	ScopeAdminWriteIPBlocks             Scope = ScopeAdminWrite + ":ip_blocks"
+	ScopeAdminWriteEmailDomainBlocks    Scope = ScopeAdminWrite + ":email_domain_blocks"
+	ScopeAdminWriteCanonicalEmailBlocks Scope = ScopeAdminWrite + ":canonical_email_blocks"
+)
+
+// knownScopes contains all scopes that
+// may be requested by applications.
+var knownScopes = []Scope{
+	ScopeProfile,
+	ScopePush,
+	ScopeRead,
+	ScopeReadAccounts,
+	ScopeReadBlocks,
+	ScopeReadBookmarks,
+	ScopeReadCustomEmojis,
+	ScopeReadFavourites,
+	ScopeReadFilters,
+	ScopeReadFollows,
+	ScopeReadLists,
+	ScopeReadMutes,
+	ScopeReadNotifications,
+	ScopeReadReports,
+	ScopeReadSearch,
+	ScopeReadStatuses,
+	ScopeWrite,
+	ScopeWriteAccounts,
+	ScopeWriteBlocks,
+	ScopeWriteBookmarks,
+	ScopeWriteConversations,
+	ScopeWriteFavourites,
+	ScopeWriteFilters,
+	ScopeWriteFollows,
+	ScopeWriteLists,
+	ScopeWriteMedia,
+	ScopeWriteMutes,
+	ScopeWriteNotifications,
+	ScopeWriteReports,
+	ScopeWriteStatuses,
+	ScopeFollow,
+	ScopeUser,
+	ScopeAdmin,
+	ScopeAdminRead,
+	ScopeAdminReadAccounts,
+	ScopeAdminReadReports,
+	ScopeAdminReadDomainAllows,
+	ScopeAdminReadDomainBlocks,
+	ScopeAdminReadIPBlocks,
+	ScopeAdminReadEmailDomainBlocks,
+	ScopeAdminReadCanonicalEmailBlocks,
+	ScopeAdminWrite,
+	ScopeAdminWriteAccounts,
+	ScopeAdminWriteReports,
+	ScopeAdminWriteDomainAllows,
+	ScopeAdminWriteDomainBlocks,
+	ScopeAdminWriteIPBlocks,
+	ScopeAdminWriteEmailDomainBlocks,
+	ScopeAdminWriteCanonicalEmailBlocks,
+}
+
+// followScopes contains the granular
+// scopes implied by deprecated ScopeFollow.
+var followScopes = []Scope{
+	ScopeReadBlocks,
+	ScopeWriteBlocks,
+	ScopeReadFollows,
+	ScopeWriteFollows,
+	ScopeReadMutes,
+	ScopeWriteMutes,
+}
+
+// Permits returns true if this scope
+// permits access requiring the wanted scope.
+func (s Scope) Permits(wanted Scope) bool {
+	switch {
+	case s == wanted:
+		// Exact match.
+		return true
+
+	case wanted == ScopeProfile:
+		// Profile scope is a subset
+		// of what read:accounts allows.
+		return s.Permits(ScopeReadAccounts)
+
+	case strings.HasPrefix(string(wanted), string(s)+":"):
+		// Top-level scope implies the
+		// granular scopes beneath it.
+		return true
+
+	case s == ScopeFollow:
+		// Deprecated follow scope
+		// implies a fixed set of others.
+		return slices.ContainsFunc(followScopes, func(f Scope) bool {
+			return f.Permits(wanted)
+		})
+
+	case s == ScopeUser:
+		// Legacy user scope
+		// implies read + write.
+		return ScopeRead.Permits(wanted) ||
+			ScopeWrite.Permits(wanted)
+
+	default:
+		return false
+	}
+}
+
+// ParseScopes parses the given space-separated
+// scopes string, returning an error if any of
+// the contained scopes are not known.
+func ParseScopes(scopes string) ([]Scope, error) {
+	fields := strings.Fields(scopes)
+	parsed := make([]Scope, 0, len(fields))
+
+	for _, field := range fields {
+		scope := Scope(field)
+		if !slices.Contains(knownScopes, scope) {
+			return nil, fmt.Errorf("unknown scope %q", field)
+		}
+
+		parsed = append(parsed, scope)
+	}
+
+	return parsed, nil
+}
+
+// ScopesPermit returns true if any of the given
+// space-separated scopes permit the wanted scope.
+//
+// An empty scopes string is treated as "read",
+// which is the default for apps and tokens.
+func ScopesPermit(scopes string, wanted Scope) bool {
+	fields := strings.Fields(scopes)
+	if len(fields) == 0 {
+		fields = []string{string(ScopeRead)}
+	}
+
+	return slices.ContainsFunc(fields, func(field string) bool {
+		return Scope(field).Permits(wanted)
+	})
+}
+
+// ScopesPermitAll returns an error if any of the
+// requested space-separated scopes is not permitted
+// by the allowed space-separated scopes, eg., when
+// checking the scopes requested during authorization
+// against the scopes an application registered with.
+func ScopesPermitAll(allowed string, requested string) error {
+	wanted, err := ParseScopes(requested)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range wanted {
+		if !ScopesPermit(allowed, scope) {
+			return fmt.Errorf("scope %q is not permitted by application scopes %q", scope, allowed)
+		}
+	}
+
+	return nil
+}
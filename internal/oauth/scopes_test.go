@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func TestScopesPermit(t *testing.T) {
+	for _, test := range []struct {
+		Scopes string
+		Wanted oauth.Scope
+		Expect bool
+	}{
+		{Scopes: "read", Wanted: oauth.ScopeReadStatuses, Expect: true},
+		{Scopes: "read", Wanted: oauth.ScopeWriteStatuses, Expect: false},
+		{Scopes: "", Wanted: oauth.ScopeReadAccounts, Expect: true},
+		{Scopes: "", Wanted: oauth.ScopeWriteAccounts, Expect: false},
+		{Scopes: "read:statuses", Wanted: oauth.ScopeReadStatuses, Expect: true},
+		{Scopes: "read:statuses", Wanted: oauth.ScopeRead, Expect: false},
+		{Scopes: "read:statuses", Wanted: oauth.ScopeReadNotifications, Expect: false},
+		{Scopes: "read:accounts", Wanted: oauth.ScopeProfile, Expect: true},
+		{Scopes: "profile", Wanted: oauth.ScopeReadAccounts, Expect: false},
+		{Scopes: "write read:lists", Wanted: oauth.ScopeWriteLists, Expect: true},
+		{Scopes: "write read:lists", Wanted: oauth.ScopeReadStatuses, Expect: false},
+		{Scopes: "follow", Wanted: oauth.ScopeWriteFollows, Expect: true},
+		{Scopes: "follow", Wanted: oauth.ScopeReadMutes, Expect: true},
+		{Scopes: "follow", Wanted: oauth.ScopeWriteStatuses, Expect: false},
+		{Scopes: "read write follow push", Wanted: oauth.ScopeAdminRead, Expect: false},
+		{Scopes: "admin", Wanted: oauth.ScopeAdminWriteReports, Expect: true},
+		{Scopes: "admin:read", Wanted: oauth.ScopeAdminReadDomainBlocks, Expect: true},
+		{Scopes: "admin:read", Wanted: oauth.ScopeAdminWriteDomainBlocks, Expect: false},
+		{Scopes: "readstuff", Wanted: oauth.ScopeReadStatuses, Expect: false},
+		{Scopes: "user admin", Wanted: oauth.ScopeReadStatuses, Expect: true},
+		{Scopes: "user admin", Wanted: oauth.ScopeWriteMedia, Expect: true},
+		{Scopes: "user", Wanted: oauth.ScopeAdminReadReports, Expect: false},
+		{Scopes: "admin", Wanted: oauth.ScopeAdminReadIPBlocks, Expect: true},
+	} {
+		if permit := oauth.ScopesPermit(test.Scopes, test.Wanted); permit != test.Expect {
+			t.Errorf("scopes %q wanted %q: expected %t, got %t", test.Scopes, test.Wanted, test.Expect, permit)
+		}
+	}
+}
+
+func TestScopesPermitAll(t *testing.T) {
+	for _, test := range []struct {
+		Allowed   string
+		Requested string
+		ExpectErr string
+	}{
+		{Allowed: "read write follow push", Requested: "read write"},
+		{Allowed: "read write follow push", Requested: "read:statuses write:media"},
+		{Allowed: "read", Requested: ""},
+		{Allowed: "user admin", Requested: "read write admin"},
+		{Allowed: "admin", Requested: "admin:read:ip_blocks admin:write:canonical_email_blocks"},
+		{
+			Allowed:   "read",
+			Requested: "read write",
+			ExpectErr: `scope "write" is not permitted by application scopes "read"`,
+		},
+		{
+			Allowed:   "read:statuses",
+			Requested: "read",
+			ExpectErr: `scope "read" is not permitted by application scopes "read:statuses"`,
+		},
+		{
+			Allowed:   "read write",
+			Requested: "read write:everything",
+			ExpectErr: `unknown scope "write:everything"`,
+		},
+	} {
+		err := oauth.ScopesPermitAll(test.Allowed, test.Requested)
+		switch {
+		case test.ExpectErr == "" && err != nil:
+			t.Errorf("allowed %q requested %q: unexpected error: %v", test.Allowed, test.Requested, err)
+		case test.ExpectErr != "" && (err == nil || err.Error() != test.ExpectErr):
+			t.Errorf("allowed %q requested %q: expected error %q, got %v", test.Allowed, test.Requested, test.ExpectErr, err)
+		}
+	}
+}
@@ -75,7 +75,7 @@ type s struct {
 }
 
 // New returns a new oauth server that implements the Server interface
-func New(ctx context.Context, database db.Basic) Server {
+func New(ctx context.Context, database db.DB) Server {
 	ts := newTokenStore(ctx, database)
 	cs := NewClientStore(database)
 
@@ -123,6 +123,21 @@ func New(ctx context.Context, database db.Basic) Server {
 		return userID, nil
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		ctx := context.Background()
+		if tgr.Request != nil {
+			ctx = tgr.Request.Context()
+		}
+
+		app, err := database.GetApplicationByClientID(ctx, tgr.ClientID)
+		if err != nil {
+			return false, err
+		}
+
+		// Only allow tokens to be generated with
+		// scopes that the application registered.
+		return ScopesPermitAll(app.Scopes, tgr.Scope) == nil, nil
+	})
 	return &s{
 		server: srv,
 	}
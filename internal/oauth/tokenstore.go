@@ -34,14 +34,14 @@ import (
 // tokenStore is an implementation of oauth2.TokenStore, which uses our db interface as a storage backend.
 type tokenStore struct {
 	oauth2.TokenStore
-	db db.Basic
+	db db.DB
 }
 
 // newTokenStore returns a token store that satisfies the oauth2.TokenStore interface.
 //
 // In order to allow tokens to 'expire', it will also set off a goroutine that iterates through
 // the tokens in the DB once per minute and deletes any that have expired.
-func newTokenStore(ctx context.Context, db db.Basic) oauth2.TokenStore {
+func newTokenStore(ctx context.Context, db db.DB) oauth2.TokenStore {
 	ts := &tokenStore{
 		db: db,
 	}
@@ -67,23 +67,17 @@ func newTokenStore(ctx context.Context, db db.Basic) oauth2.TokenStore {
 
 // sweep clears out old tokens that have expired; it should be run on a loop about once per minute or so.
 func (ts *tokenStore) sweep(ctx context.Context) error {
-	// select *all* tokens from the db
-	// todo: if this becomes expensive (ie., there are fucking LOADS of tokens) then figure out a better way.
-	tokens := new([]*gtsmodel.Token)
-	if err := ts.db.GetAll(ctx, tokens); err != nil {
+	// select only expired token IDs from the db,
+	// rather than loading every token into memory.
+	tokenIDs, err := ts.db.GetExpiredTokenIDs(ctx, time.Now())
+	if err != nil {
 		return err
 	}
 
-	// iterate through and remove expired tokens
-	now := time.Now()
-	for _, dbt := range *tokens {
-		// The zero value of a time.Time is 00:00 january 1 1970, which will always be before now. So:
-		// we only want to check if a token expired before now if the expiry time is *not zero*;
-		// ie., if it's been explicity set.
-		if !dbt.CodeExpiresAt.IsZero() && dbt.CodeExpiresAt.Before(now) || !dbt.RefreshExpiresAt.IsZero() && dbt.RefreshExpiresAt.Before(now) || !dbt.AccessExpiresAt.IsZero() && dbt.AccessExpiresAt.Before(now) {
-			if err := ts.db.DeleteByID(ctx, dbt.ID, dbt); err != nil {
-				return err
-			}
+	// remove expired tokens
+	for _, id := range tokenIDs {
+		if err := ts.db.DeleteTokenByID(ctx, id); err != nil {
+			return err
 		}
 	}
 
@@ -107,7 +101,7 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 		dbt.ID = dbtID
 	}
 
-	if err := ts.db.Put(ctx, dbt); err != nil {
+	if err := ts.db.PutToken(ctx, dbt); err != nil {
 		return fmt.Errorf("error in tokenstore create: %s", err)
 	}
 	return nil
@@ -115,17 +109,17 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 
 // RemoveByCode deletes a token from the DB based on the Code field
 func (ts *tokenStore) RemoveByCode(ctx context.Context, code string) error {
-	return ts.db.DeleteWhere(ctx, []db.Where{{Key: "code", Value: code}}, &gtsmodel.Token{})
+	return ts.db.DeleteTokenByCode(ctx, code)
 }
 
 // RemoveByAccess deletes a token from the DB based on the Access field
 func (ts *tokenStore) RemoveByAccess(ctx context.Context, access string) error {
-	return ts.db.DeleteWhere(ctx, []db.Where{{Key: "access", Value: access}}, &gtsmodel.Token{})
+	return ts.db.DeleteTokenByAccess(ctx, access)
 }
 
 // RemoveByRefresh deletes a token from the DB based on the Refresh field
 func (ts *tokenStore) RemoveByRefresh(ctx context.Context, refresh string) error {
-	return ts.db.DeleteWhere(ctx, []db.Where{{Key: "refresh", Value: refresh}}, &gtsmodel.Token{})
+	return ts.db.DeleteTokenByRefresh(ctx, refresh)
 }
 
 // GetByCode selects a token from the DB based on the Code field
@@ -133,10 +127,8 @@ func (ts *tokenStore) GetByCode(ctx context.Context, code string) (oauth2.TokenI
 	if code == "" {
 		return nil, nil
 	}
-	dbt := &gtsmodel.Token{
-		Code: code,
-	}
-	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "code", Value: code}}, dbt); err != nil {
+	dbt, err := ts.db.GetTokenByCode(ctx, code)
+	if err != nil {
 		return nil, err
 	}
 	return DBTokenToToken(dbt), nil
@@ -147,10 +139,8 @@ func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.To
 	if access == "" {
 		return nil, nil
 	}
-	dbt := &gtsmodel.Token{
-		Access: access,
-	}
-	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, dbt); err != nil {
+	dbt, err := ts.db.GetTokenByAccess(ctx, access)
+	if err != nil {
 		return nil, err
 	}
 	return DBTokenToToken(dbt), nil
@@ -161,10 +151,8 @@ func (ts *tokenStore) GetByRefresh(ctx context.Context, refresh string) (oauth2.
 	if refresh == "" {
 		return nil, nil
 	}
-	dbt := &gtsmodel.Token{
-		Refresh: refresh,
-	}
-	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "refresh", Value: refresh}}, dbt); err != nil {
+	dbt, err := ts.db.GetTokenByRefresh(ctx, refresh)
+	if err != nil {
 		return nil, err
 	}
 	return DBTokenToToken(dbt), nil
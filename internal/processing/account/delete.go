@@ -144,7 +144,7 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 
 		// Delete the token itself.
-		if err := p.state.DB.DeleteByID(ctx, t.ID, t); err != nil {
+		if err := p.state.DB.DeleteTokenByID(ctx, t.ID); err != nil {
 			return gtserror.Newf("db error deleting token: %w", err)
 		}
 	}
@@ -485,6 +485,32 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all scheduled statuses owned by given account.
+	// Any still-pending scheduler tasks for them will find
+	// nothing in the database when fired, and do nothing.
+	if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting scheduled statuses by account: %w", err)
+	}
+
+	// Delete all conversations owned by given account.
+	if err := p.state.DB.DeleteConversationsByOwnerAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting conversations by account: %w", err)
+	}
+
+	// Delete all tag follows of given account.
+	if err := p.state.DB.DeleteFollowedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting followed tags by account: %w", err)
+	}
+
+	// Delete all Web Push subscriptions of given account.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting web push subscriptions by account: %w", err)
+	}
+
 	return nil
 }
 
@@ -519,6 +545,7 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 	account.AlsoKnownAsURIs = nil
 	account.MovedToURI = ""
 	account.Discoverable = util.Ptr(false)
+	account.Indexable = util.Ptr(false)
 	account.SuspendedAt = now
 	account.SuspensionOrigin = origin
 
@@ -537,6 +564,7 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 		"also_known_as_uris",
 		"moved_to_uri",
 		"discoverable",
+		"indexable",
 		"suspended_at",
 		"suspension_origin",
 	}
@@ -56,6 +56,10 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Discoverable = form.Discoverable
 	}
 
+	if form.Indexable != nil {
+		account.Indexable = form.Indexable
+	}
+
 	if form.Bot != nil {
 		account.Bot = form.Bot
 	}
@@ -20,6 +20,7 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -44,11 +45,15 @@ func (p *Processor) AccountAction(
 	case gtsmodel.AdminActionSuspend:
 		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
 
+	case gtsmodel.AdminActionResetTwoFactor:
+		return p.accountActionResetTwoFactor(ctx, adminAcct, targetAcct, request.Text)
+
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionResetTwoFactor.String(),
 		}
 
 		err := fmt.Errorf(
@@ -100,3 +105,66 @@ func (p *Processor) accountActionSuspend(
 
 	return actionID, errWithCode
 }
+
+func (p *Processor) accountActionResetTwoFactor(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if targetAcct.IsRemote() {
+		err := fmt.Errorf("account %s is not a local account", targetAcct.ID)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionResetTwoFactor,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+			if err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Appendf("db error getting user: %w", err)
+				return errs
+			}
+
+			// Clear out all second factor
+			// state, so that the user can
+			// sign in with just a password
+			// and set up 2FA again.
+			user.TwoFactorSecret = ""
+			user.TwoFactorBackups = nil
+			user.TwoFactorEnabledAt = time.Time{}
+			user.TwoFactorLastStep = 0
+			user.TwoFactorFailures = 0
+			user.TwoFactorFailedAt = time.Time{}
+			if err := p.state.DB.UpdateUser(
+				ctx, user,
+				"two_factor_secret",
+				"two_factor_backups",
+				"two_factor_enabled_at",
+				"two_factor_last_step",
+				"two_factor_failures",
+				"two_factor_failed_at",
+			); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Appendf("db error updating user: %w", err)
+				return errs
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
@@ -20,6 +20,7 @@ package admin_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -77,6 +78,57 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+func (suite *AccountTestSuite) TestAccountActionResetTwoFactor() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionResetTwoFactor.String(),
+			Text:     "lost their phone",
+			TargetID: suite.testAccounts["local_account_1"].ID,
+		}
+	)
+
+	// Give the target user 2FA.
+	user, err := suite.db.GetUserByAccountID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.TwoFactorSecret = "JBSWY3DPEHPK3PXP"
+	user.TwoFactorBackups = []string{"some hash"}
+	user.TwoFactorEnabledAt = time.Now()
+	if err := suite.db.UpdateUser(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Ensure 2FA cleared from target user.
+	user, err = suite.db.GetUserByAccountID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.False(user.TwoFactorEnabled())
+	suite.Empty(user.TwoFactorSecret)
+	suite.Empty(user.TwoFactorBackups)
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = context.Background()
@@ -94,7 +146,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\" \"reset-2fa\"]")
 	suite.Empty(actionID)
 }
 
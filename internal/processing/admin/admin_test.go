@@ -114,6 +114,7 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 		suite.mediaManager,
 		&suite.state,
 		suite.emailSender,
+		testrig.NewWebPushSender(nil),
 	)
 
 	testrig.StartWorkers(&suite.state, suite.processor.Workers())
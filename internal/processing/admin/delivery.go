@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DeliveriesGet returns a page of queued outgoing
+// deliveries with the given status, optionally
+// limited to deliveries to the given inbox URI.
+func (p *Processor) DeliveriesGet(
+	ctx context.Context,
+	status gtsmodel.DeliveryStatus,
+	inboxURI string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	deliveries, err := p.state.DB.GetDeliveries(ctx,
+		status,
+		inboxURI,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting deliveries: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(deliveries)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := deliveries[count-1].ID
+	hi := deliveries[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, d := range deliveries {
+		apiDelivery, err := p.converter.DeliveryToAPIDelivery(ctx, d)
+		if err != nil {
+			log.Errorf(ctx, "error converting delivery to api model: %v", err)
+			continue
+		}
+
+		items = append(items, apiDelivery)
+	}
+
+	// Carry filter params
+	// through to next/prev.
+	query := make(url.Values)
+	query.Set("status", status.String())
+	if inboxURI != "" {
+		query.Set("inbox_uri", inboxURI)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/deliveries",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// DeliveryGet returns the queued outgoing delivery with the given ID.
+func (p *Processor) DeliveryGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.Delivery, gtserror.WithCode) {
+	delivery, errWithCode := p.getDelivery(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDelivery(ctx, delivery)
+}
+
+// DeliveryRequeue resets the queued outgoing delivery with the
+// given ID to be retried immediately, with a fresh set of attempts.
+// This can be used both to revive a failed delivery, and to skip
+// the remaining backoff period of a pending one.
+func (p *Processor) DeliveryRequeue(
+	ctx context.Context,
+	id string,
+) (*apimodel.Delivery, gtserror.WithCode) {
+	delivery, errWithCode := p.getDelivery(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.FailedAt = time.Time{}
+	if err := p.state.DB.UpdateDelivery(ctx,
+		delivery,
+		"attempts",
+		"next_attempt_at",
+		"failed_at",
+	); err != nil {
+		err := gtserror.Newf("error updating delivery: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Kick off processing of due deliveries
+	// so this one gets picked up straight away.
+	if err := p.transportController.ProcessDeliveries(ctx); err != nil {
+		log.Errorf(ctx, "error processing deliveries: %v", err)
+	}
+
+	return p.apiDelivery(ctx, delivery)
+}
+
+// DeliveryDelete removes the queued outgoing delivery with the
+// given ID, dropping it without any further delivery attempts.
+func (p *Processor) DeliveryDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.Delivery, gtserror.WithCode) {
+	delivery, errWithCode := p.getDelivery(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDeliveryByID(ctx, id); err != nil {
+		err := gtserror.Newf("error deleting delivery: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDelivery(ctx, delivery)
+}
+
+func (p *Processor) getDelivery(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Delivery, gtserror.WithCode) {
+	delivery, err := p.state.DB.GetDeliveryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("delivery %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("error getting delivery %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return delivery, nil
+}
+
+func (p *Processor) apiDelivery(
+	ctx context.Context,
+	delivery *gtsmodel.Delivery,
+) (*apimodel.Delivery, gtserror.WithCode) {
+	apiDelivery, err := p.converter.DeliveryToAPIDelivery(ctx, delivery)
+	if err != nil {
+		err := gtserror.Newf("error converting delivery to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDelivery, nil
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type DeliveryTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// putFailedDelivery stores a delivery
+// that has already been given up on.
+func (suite *DeliveryTestSuite) putFailedDelivery() *gtsmodel.Delivery {
+	now := time.Now()
+	delivery := &gtsmodel.Delivery{
+		ID:            id.NewULID(),
+		PubKeyID:      suite.testAccounts["local_account_1"].PublicKeyURI,
+		InboxURI:      "https://example.org/users/someone/inbox",
+		Data:          []byte(`{"type":"Create"}`),
+		Attempts:      14,
+		NextAttemptAt: now,
+		LastAttemptAt: now,
+		LastError:     "oh no",
+		FailedAt:      now,
+	}
+
+	if err := suite.state.DB.PutDeliveries(context.Background(), delivery); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return delivery
+}
+
+func (suite *DeliveryTestSuite) TestDeliveriesGet() {
+	ctx := context.Background()
+	delivery := suite.putFailedDelivery()
+
+	resp, errWithCode := suite.adminProcessor.DeliveriesGet(ctx,
+		gtsmodel.DeliveryStatusFailed,
+		"",
+		&paging.Page{Limit: 20},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if suite.Len(resp.Items, 1) {
+		suite.Equal(delivery.ID, resp.Items[0].(*apimodel.Delivery).ID)
+	}
+}
+
+func (suite *DeliveryTestSuite) TestDeliveryRequeue() {
+	ctx := context.Background()
+	delivery := suite.putFailedDelivery()
+
+	apiDelivery, errWithCode := suite.adminProcessor.DeliveryRequeue(ctx, delivery.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(delivery.ID, apiDelivery.ID)
+	suite.Equal("pending", apiDelivery.Status)
+	suite.Zero(apiDelivery.Attempts)
+	suite.Empty(apiDelivery.FailedAt)
+	suite.NotEmpty(apiDelivery.NextAttemptAt)
+	suite.JSONEq(`{"type":"Create"}`, string(apiDelivery.Activity))
+}
+
+func (suite *DeliveryTestSuite) TestDeliveryDelete() {
+	ctx := context.Background()
+	delivery := suite.putFailedDelivery()
+
+	apiDelivery, errWithCode := suite.adminProcessor.DeliveryDelete(ctx, delivery.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("failed", apiDelivery.Status)
+
+	// Delivery should now be gone.
+	_, errWithCode = suite.adminProcessor.DeliveryGet(ctx, delivery.ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func TestDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTestSuite))
+}
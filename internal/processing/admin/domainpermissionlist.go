@@ -0,0 +1,274 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// domainPermEntry models one entry
+// parsed from a domain permission list.
+type domainPermEntry struct {
+	domain        string // punycode domain
+	publicComment string // may be empty
+	obfuscate     bool   // defaults to false
+}
+
+// fetchDomainPermEntries fetches the list at the URI of
+// the given subscription, and parses it according to the
+// subscription's content type. Entries are deduplicated.
+//
+// An error will be returned if the list can't be fetched
+// or parsed, or if it contains no entries at all, as an
+// empty list is far more likely to be a mistake on the
+// remote end than an intent to remove all permissions.
+func (p *Processor) fetchDomainPermEntries(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) ([]domainPermEntry, error) {
+	// Fetch using the instance account.
+	tsport, err := p.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting transport: %w", err)
+	}
+
+	body, err := tsport.DereferenceDomainPermissions(ctx, permSub)
+	if err != nil {
+		return nil, gtserror.Newf("error fetching list: %w", err)
+	}
+	defer body.Close()
+
+	var parse func(io.Reader) ([]domainPermEntry, error)
+
+	switch permSub.ContentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		parse = parseDomainPermsCSV
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		parse = parseDomainPermsJSON
+	case gtsmodel.DomainPermSubContentTypePlain:
+		parse = parseDomainPermsPlain
+	default:
+		return nil, gtserror.Newf("unrecognized content type %s", permSub.ContentType)
+	}
+
+	entries, err := parse(body)
+	if err != nil {
+		err := gtserror.Newf("error parsing list as %s: %w", permSub.ContentType, err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Deduplicate + drop invalid entries.
+	seen := make(map[string]struct{}, len(entries))
+	deduped := make([]domainPermEntry, 0, len(entries))
+	for _, entry := range entries {
+		domain, ok := normalizeListDomain(entry.domain)
+		if !ok {
+			continue
+		}
+
+		if _, ok := seen[domain]; ok {
+			continue
+		}
+
+		seen[domain] = struct{}{}
+		entry.domain = domain
+		deduped = append(deduped, entry)
+	}
+
+	if len(deduped) == 0 {
+		err := gtserror.New("list contained no valid entries")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	return deduped, nil
+}
+
+// normalizeListDomain normalizes the given domain
+// from a domain permission list as punycode,
+// returning false if the domain is not valid.
+func normalizeListDomain(domain string) (string, bool) {
+	domain = strings.TrimSpace(domain)
+
+	// Permissions already apply to all subdomains,
+	// so just trim any wildcard prefix away.
+	domain = strings.TrimPrefix(domain, "*.")
+
+	// Obfuscated domains (eg., 'e*mple.org'),
+	// urls, accounts, etc. can't be used.
+	if domain == "" || strings.ContainsAny(domain, "*/:@ \t") {
+		return "", false
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil || domain == "" {
+		return "", false
+	}
+
+	// Permissions targeting
+	// ourselves make no sense.
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		return "", false
+	}
+
+	return domain, true
+}
+
+// parseDomainPermsCSV parses domain permissions from a CSV list
+// in the format exported by Mastodon, ie., with a header like:
+//
+//	#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+//
+// Only the domain column is required. If the list has no header,
+// the first column of each record is taken as the domain. Entries
+// with a severity other than "suspend" (eg., "silence") are skipped.
+func parseDomainPermsCSV(r io.Reader) ([]domainPermEntry, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1 // Allow variable number of fields.
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// Column indexes, -1 == not present.
+	var (
+		domainIdx        = 0
+		severityIdx      = -1
+		publicCommentIdx = -1
+		obfuscateIdx     = -1
+	)
+
+	// Check if the first record is a header.
+	header := make(map[string]int, len(records[0]))
+	for i, field := range records[0] {
+		field = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(field), "#"))
+		header[field] = i
+	}
+
+	if i, ok := header["domain"]; ok {
+		domainIdx = i
+		if i, ok := header["severity"]; ok {
+			severityIdx = i
+		}
+		if i, ok := header["public_comment"]; ok {
+			publicCommentIdx = i
+		}
+		if i, ok := header["obfuscate"]; ok {
+			obfuscateIdx = i
+		}
+
+		// Skip header.
+		records = records[1:]
+	}
+
+	// field safely returns field at
+	// index of record, or "" if not set.
+	field := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	entries := make([]domainPermEntry, 0, len(records))
+	for _, record := range records {
+		severity := field(record, severityIdx)
+		if severity != "" && severity != "suspend" {
+			// Not a full block
+			// (silence, noop).
+			continue
+		}
+
+		obfuscate, _ := strconv.ParseBool(field(record, obfuscateIdx))
+		entries = append(entries, domainPermEntry{
+			domain:        field(record, domainIdx),
+			publicComment: field(record, publicCommentIdx),
+			obfuscate:     obfuscate,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsJSON parses domain permissions from a JSON
+// list in the same format used for domain permission exports
+// and imports, ie., an array of domain permission objects.
+func parseDomainPermsJSON(r io.Reader) ([]domainPermEntry, error) {
+	apiPerms := make([]*apimodel.DomainPermission, 0)
+	if err := json.NewDecoder(r).Decode(&apiPerms); err != nil {
+		return nil, err
+	}
+
+	entries := make([]domainPermEntry, 0, len(apiPerms))
+	for _, apiPerm := range apiPerms {
+		if apiPerm == nil {
+			continue
+		}
+
+		entries = append(entries, domainPermEntry{
+			domain:        apiPerm.Domain.Domain,
+			publicComment: apiPerm.PublicComment,
+			obfuscate:     apiPerm.Obfuscate,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsPlain parses domain permissions from a
+// plaintext list of one domain per line. Empty lines and
+// lines beginning with '#' (comments) are skipped.
+func parseDomainPermsPlain(r io.Reader) ([]domainPermEntry, error) {
+	var entries []domainPermEntry
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = append(entries, domainPermEntry{
+			domain: line,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,342 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// apiDomainPermSub is a cheeky shortcut for returning
+// the API version of the given domain permission
+// subscription, or an appropriate error if something
+// goes wrong.
+func (p *Processor) apiDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	apiPermSub, err := p.converter.DomainPermSubToAPIDomainPermSub(ctx, permSub)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain permission subscription to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// getDomainPermSub gets the domain permission
+// subscription with the given id, returning an
+// appropriate error if it can't be found.
+func (p *Processor) getDomainPermSub(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no domain permission subscription exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting domain permission subscription %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return permSub, nil
+}
+
+// DomainPermissionSubscriptionGet returns one
+// domain permission subscription with the given id.
+func (p *Processor) DomainPermissionSubscriptionGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionsGetByPriority returns all domain
+// permission subscriptions of the given permission type, in
+// priority order (highest priority first).
+func (p *Processor) DomainPermissionSubscriptionsGetByPriority(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSubs, err := p.state.DB.GetDomainPermissionSubscriptionsByPriority(ctx, permType)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPermSubs := make([]*apimodel.DomainPermissionSubscription, len(permSubs))
+	for i, permSub := range permSubs {
+		apiPermSub, errWithCode := p.apiDomainPermSub(ctx, permSub)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiPermSubs[i] = apiPermSub
+	}
+
+	return apiPermSubs, nil
+}
+
+// DomainPermissionSubscriptionCreate creates a new domain permission
+// subscription from the given (validated) form. The subscription will
+// be processed for the first time on the next scheduled run, or an
+// admin can preview its effects using DomainPermissionSubscriptionTest.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		Priority:           uint8(util.PtrValueOr(form.Priority, 0)),
+		Title:              util.PtrValueOr(form.Title, ""),
+		PermissionType:     gtsmodel.NewDomainPermissionType(util.PtrValueOr(form.PermissionType, "")),
+		AdoptOrphans:       util.Ptr(util.PtrValueOr(form.AdoptOrphans, false)),
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		URI:                util.PtrValueOr(form.URI, ""),
+		ContentType:        util.PtrValueOr(form.ContentType, ""),
+		FetchUsername:      util.PtrValueOr(form.FetchUsername, ""),
+		FetchPassword:      util.PtrValueOr(form.FetchPassword, ""),
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, permSub); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = fmt.Errorf("a domain permission subscription already exists with uri %s", permSub.URI)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionUpdate updates the domain permission
+// subscription with the given id, using any set fields in the
+// given (validated) form. The permission type cannot be changed.
+func (p *Processor) DomainPermissionSubscriptionUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	columns := make([]string, 0, 7)
+
+	if form.Priority != nil {
+		permSub.Priority = uint8(*form.Priority)
+		columns = append(columns, "priority")
+	}
+
+	if form.Title != nil {
+		permSub.Title = *form.Title
+		columns = append(columns, "title")
+	}
+
+	if form.AdoptOrphans != nil {
+		permSub.AdoptOrphans = form.AdoptOrphans
+		columns = append(columns, "adopt_orphans")
+	}
+
+	if form.URI != nil {
+		permSub.URI = *form.URI
+		columns = append(columns, "uri")
+	}
+
+	if form.ContentType != nil {
+		permSub.ContentType = *form.ContentType
+		columns = append(columns, "content_type")
+	}
+
+	if form.FetchUsername != nil {
+		permSub.FetchUsername = *form.FetchUsername
+		columns = append(columns, "fetch_username")
+	}
+
+	if form.FetchPassword != nil {
+		permSub.FetchPassword = *form.FetchPassword
+		columns = append(columns, "fetch_password")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to update,
+		// just return as-is.
+		return p.apiDomainPermSub(ctx, permSub)
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, columns...); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = fmt.Errorf("a domain permission subscription already exists with uri %s", permSub.URI)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error updating domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionRemove removes the domain permission
+// subscription with the given id. If removeChildren is true, then
+// all domain permissions created by the subscription will also be
+// removed (processing side effects as usual). Otherwise, they will
+// be orphaned, ie., kept in place with no subscription attached.
+//
+// Returns the removed subscription as it was before removal.
+func (p *Processor) DomainPermissionSubscriptionRemove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	removeChildren bool,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the subscription to return, *before* the deletion goes through.
+	apiPermSub, errWithCode := p.apiDomainPermSub(ctx, permSub)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	children, err := p.domainPermSubChildren(ctx, permSub)
+	if err != nil {
+		err = gtserror.Newf("db error getting children of domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, child := range children {
+		if removeChildren {
+			// Remove the child + process side effects.
+			if _, _, errWithCode := p.DomainPermissionDelete(
+				ctx,
+				permSub.PermissionType,
+				adminAcct,
+				child.GetID(),
+			); errWithCode != nil {
+				return nil, errWithCode
+			}
+			continue
+		}
+
+		// Orphan the child by clearing its subscription ID.
+		if err := p.setDomainPermSubscriptionID(ctx, child, ""); err != nil {
+			err = gtserror.Newf("db error orphaning domain %s %s: %w", permSub.PermissionType.String(), child.GetDomain(), err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionSubscription(ctx, permSub.ID); err != nil {
+		err = gtserror.Newf("db error deleting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// DomainPermissionSubscriptionTest fetches and parses the list of the
+// domain permission subscription with the given id, and returns a preview
+// of the domain permissions that would be created, adopted, and removed by
+// processing it, without actually making any changes (ie., a dry run).
+func (p *Processor) DomainPermissionSubscriptionTest(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscriptionPreview, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Fetch + parse the list. Any error here is
+	// a problem with the list or the remote
+	// serving it, so return it to the caller.
+	entries, err := p.fetchDomainPermEntries(ctx, permSub)
+	if err != nil {
+		err = fmt.Errorf("error fetching or parsing list: %w", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Get priorities of all subscriptions of this type.
+	priorities, err := p.domainPermSubPriorities(ctx, permSub.PermissionType)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	changes, err := p.domainPermSubChanges(ctx, permSub, priorities, entries)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	preview := &apimodel.DomainPermissionSubscriptionPreview{
+		Create: make([]*apimodel.DomainPermission, 0, len(changes.create)),
+		Adopt:  make([]*apimodel.DomainPermission, 0, len(changes.adopt)),
+		Remove: make([]*apimodel.DomainPermission, 0, len(changes.remove)),
+	}
+
+	for _, entry := range changes.create {
+		preview.Create = append(preview.Create, &apimodel.DomainPermission{
+			Domain: apimodel.Domain{
+				Domain:        entry.domain,
+				PublicComment: entry.publicComment,
+			},
+			Obfuscate:      entry.obfuscate,
+			SubscriptionID: permSub.ID,
+		})
+	}
+
+	for _, perm := range changes.adopt {
+		apiPerm, errWithCode := p.apiDomainPerm(ctx, perm, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		preview.Adopt = append(preview.Adopt, apiPerm)
+	}
+
+	for _, perm := range changes.remove {
+		apiPerm, errWithCode := p.apiDomainPerm(ctx, perm, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		preview.Remove = append(preview.Remove, apiPerm)
+	}
+
+	return preview, nil
+}
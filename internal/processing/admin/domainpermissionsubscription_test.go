@@ -0,0 +1,308 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// createPermSub creates a block subscription
+// for the given uri + content type.
+func (suite *DomainPermissionSubscriptionTestSuite) createPermSub(
+	uri string,
+	contentType string,
+	priority int,
+	adoptOrphans bool,
+) *apimodel.DomainPermissionSubscription {
+	permSub, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.DomainPermissionSubscriptionRequest{
+			Priority:       &priority,
+			PermissionType: util.Ptr("block"),
+			AdoptOrphans:   &adoptOrphans,
+			URI:            &uri,
+			ContentType:    &contentType,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	return permSub
+}
+
+// awaitActions waits for any running admin actions to finish.
+func (suite *DomainPermissionSubscriptionTestSuite) awaitActions() {
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+}
+
+func domains(perms []*apimodel.DomainPermission) []string {
+	domains := make([]string, len(perms))
+	for i, perm := range perms {
+		domains[i] = perm.Domain.Domain
+	}
+	return domains
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestPreviewCSV() {
+	permSub := suite.createPermSub(
+		"https://lists.example.org/blocklist.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		0, false,
+	)
+
+	preview, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(context.Background(), permSub.ID)
+	suite.NoError(errWithCode)
+
+	// Silenced domain should be skipped.
+	suite.Equal([]string{"bumfaces.net", "peepee.poopoo", "nothanks.com"}, domains(preview.Create))
+	suite.Equal("big jerks", preview.Create[0].PublicComment)
+	suite.Equal(permSub.ID, preview.Create[0].SubscriptionID)
+	suite.Empty(preview.Adopt)
+	suite.Empty(preview.Remove)
+
+	// Dry run shouldn't have created anything.
+	_, err := suite.db.GetDomainBlock(context.Background(), "bumfaces.net")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestPreviewJSON() {
+	permSub := suite.createPermSub(
+		"https://lists.example.org/blocklist.json",
+		gtsmodel.DomainPermSubContentTypeJSON,
+		0, false,
+	)
+
+	preview, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(context.Background(), permSub.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"bumfaces.net", "peepee.poopoo", "nothanks.com"}, domains(preview.Create))
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestPreviewPlainAdoptOrphans() {
+	// Without adopting orphans, existing
+	// block on replyguys.com is left alone.
+	permSub := suite.createPermSub(
+		"https://lists.example.org/blocklist.txt",
+		gtsmodel.DomainPermSubContentTypePlain,
+		0, false,
+	)
+
+	preview, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(context.Background(), permSub.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"bumfaces.net", "peepee.poopoo", "nothanks.com"}, domains(preview.Create))
+	suite.Empty(preview.Adopt)
+
+	// With adopting orphans, it's adopted.
+	permSub, errWithCode = suite.adminProcessor.DomainPermissionSubscriptionUpdate(
+		context.Background(),
+		permSub.ID,
+		&apimodel.DomainPermissionSubscriptionRequest{AdoptOrphans: util.Ptr(true)},
+	)
+	suite.NoError(errWithCode)
+	suite.True(permSub.AdoptOrphans)
+
+	preview, errWithCode = suite.adminProcessor.DomainPermissionSubscriptionTest(context.Background(), permSub.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"replyguys.com"}, domains(preview.Adopt))
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestPreviewFetchError() {
+	permSub := suite.createPermSub(
+		"https://lists.example.org/does-not-exist.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		0, false,
+	)
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(context.Background(), permSub.ID)
+	suite.Error(errWithCode)
+	suite.Equal(422, errWithCode.Code())
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcess() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+	)
+
+	permSub := suite.createPermSub(
+		"https://lists.example.org/blocklist.txt",
+		gtsmodel.DomainPermSubContentTypePlain,
+		0, true,
+	)
+
+	// Put a block owned by the subscription
+	// which is no longer present in the list.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             "no-longer-listed.example.org",
+		CreatedByAccountID: adminAcc.ID,
+		Obfuscate:          util.Ptr(false),
+		SubscriptionID:     permSub.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+	suite.awaitActions()
+
+	// Listed domains should now be blocked by the
+	// subscription, including the adopted orphan.
+	for _, domain := range []string{
+		"bumfaces.net",
+		"peepee.poopoo",
+		"nothanks.com",
+		"replyguys.com",
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(permSub.ID, block.SubscriptionID)
+	}
+
+	// Unlisted domain should be unblocked.
+	_, err := suite.db.GetDomainBlock(ctx, "no-longer-listed.example.org")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Fetch status should be updated.
+	dbPermSub, err := suite.db.GetDomainPermissionSubscriptionByID(ctx, permSub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(dbPermSub.FetchedAt)
+	suite.Equal(dbPermSub.FetchedAt, dbPermSub.SuccessfullyFetchedAt)
+	suite.Empty(dbPermSub.Error)
+
+	// Count should reflect created + adopted blocks.
+	apiPermSub, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionGet(ctx, permSub.ID)
+	suite.NoError(errWithCode)
+	suite.EqualValues(4, apiPermSub.Count)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessPriority() {
+	ctx := context.Background()
+
+	// Lower priority subscription gets processed
+	// second, so it should leave alone any blocks
+	// owned by the higher priority subscription.
+	high := suite.createPermSub(
+		"https://lists.example.org/blocklist.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		100, false,
+	)
+	low := suite.createPermSub(
+		"https://lists.example.org/blocklist.txt",
+		gtsmodel.DomainPermSubContentTypePlain,
+		10, true,
+	)
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+	suite.awaitActions()
+
+	for domain, ownerID := range map[string]string{
+		"bumfaces.net":  high.ID,
+		"peepee.poopoo": high.ID,
+		"nothanks.com":  high.ID,
+		"replyguys.com": low.ID,
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(ownerID, block.SubscriptionID, domain)
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestRemoveOrphansChildren() {
+	ctx := context.Background()
+
+	permSub := suite.createPermSub(
+		"https://lists.example.org/blocklist.json",
+		gtsmodel.DomainPermSubContentTypeJSON,
+		0, false,
+	)
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+	suite.awaitActions()
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(
+		ctx,
+		suite.testAccounts["admin_account"],
+		permSub.ID,
+		false,
+	)
+	suite.NoError(errWithCode)
+
+	// Block should still exist, but orphaned.
+	block, err := suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(block.SubscriptionID)
+
+	// Subscription should be gone.
+	_, errWithCode = suite.adminProcessor.DomainPermissionSubscriptionGet(ctx, permSub.ID)
+	suite.Equal(404, errWithCode.Code())
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestRemoveWithChildren() {
+	ctx := context.Background()
+
+	permSub := suite.createPermSub(
+		"https://lists.example.org/blocklist.json",
+		gtsmodel.DomainPermSubContentTypeJSON,
+		0, false,
+	)
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+	suite.awaitActions()
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(
+		ctx,
+		suite.testAccounts["admin_account"],
+		permSub.ID,
+		true,
+	)
+	suite.NoError(errWithCode)
+	suite.awaitActions()
+
+	_, err := suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
@@ -0,0 +1,432 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// domainPermSubChanges models the changes that
+// processing a domain permission subscription
+// would make to existing domain permissions.
+type domainPermSubChanges struct {
+	create []domainPermEntry           // entries to create as new permissions
+	adopt  []gtsmodel.DomainPermission // existing permissions to adopt
+	remove []gtsmodel.DomainPermission // owned permissions no longer listed
+}
+
+// DomainPermissionSubscriptionsSchedule schedules domain permission
+// subscriptions to be processed according to the configured
+// instance-subscriptions-process-from and -every settings.
+func (p *Processor) DomainPermissionSubscriptionsSchedule() error {
+	const hourMinute = "15:04"
+
+	var (
+		now          = time.Now()
+		processEvery = config.GetInstanceSubscriptionsProcessEvery()
+		processFrom  = config.GetInstanceSubscriptionsProcessFrom()
+	)
+
+	// Parse processFrom as hh:mm.
+	// Resulting time will be on 1 Jan year zero.
+	processFromT, err := time.Parse(hourMinute, processFrom)
+	if err != nil {
+		return gtserror.Newf(
+			"error parsing '%s' in time format 'hh:mm': %w",
+			processFrom, err,
+		)
+	}
+
+	// Move from year zero to today.
+	firstProcessAt := time.Date(
+		now.Year(),
+		now.Month(),
+		now.Day(),
+		processFromT.Hour(),
+		processFromT.Minute(),
+		0,
+		0,
+		now.Location(),
+	)
+
+	// Ensure first processing is in the future.
+	for firstProcessAt.Before(now) {
+		firstProcessAt = firstProcessAt.Add(processEvery)
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting domain permission subscriptions processing")
+		p.DomainPermissionSubscriptionsProcess(ctx)
+		log.Infof(ctx, "finished domain permission subscriptions processing after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling domain permission subscriptions to run every %s, starting from %s; next run will be at %s",
+		processEvery, processFrom, firstProcessAt,
+	)
+
+	// Schedule processing to execute according to schedule.
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@domainpermsubs",
+		firstProcessAt,
+		processEvery,
+		fn,
+	) {
+		panic("failed to schedule @domainpermsubs")
+	}
+
+	return nil
+}
+
+// DomainPermissionSubscriptionsProcess processes all domain permission
+// subscriptions, first blocks and then allows, each in priority order.
+// Errors are logged rather than returned, as this is intended to be
+// run as a scheduled background job.
+func (p *Processor) DomainPermissionSubscriptionsProcess(ctx context.Context) {
+	for _, permType := range []gtsmodel.DomainPermissionType{
+		gtsmodel.DomainPermissionBlock,
+		gtsmodel.DomainPermissionAllow,
+	} {
+		permSubs, err := p.state.DB.GetDomainPermissionSubscriptionsByPriority(ctx, permType)
+		if err != nil {
+			log.Errorf(ctx, "db error getting domain %s subscriptions: %v", permType.String(), err)
+			continue
+		}
+
+		if len(permSubs) == 0 {
+			// Nothing to do.
+			continue
+		}
+
+		priorities := make(map[string]uint8, len(permSubs))
+		for _, permSub := range permSubs {
+			priorities[permSub.ID] = permSub.Priority
+		}
+
+		for _, permSub := range permSubs {
+			p.processDomainPermSub(ctx, permSub, priorities)
+		}
+	}
+}
+
+// processDomainPermSub fetches the list of the given
+// domain permission subscription, updates the fetch
+// status of the subscription, and creates, adopts,
+// and removes domain permissions accordingly.
+func (p *Processor) processDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	priorities map[string]uint8,
+) {
+	l := log.WithContext(ctx).WithField("subscription", permSub.ID)
+
+	// Fetch + parse the list.
+	entries, fetchErr := p.fetchDomainPermEntries(ctx, permSub)
+
+	// Update the fetch status.
+	permSub.FetchedAt = time.Now()
+	if fetchErr != nil {
+		permSub.Error = fetchErr.Error()
+	} else {
+		permSub.SuccessfullyFetchedAt = permSub.FetchedAt
+		permSub.Error = ""
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(
+		ctx,
+		permSub,
+		"fetched_at",
+		"successfully_fetched_at",
+		"error",
+	); err != nil {
+		l.Errorf("db error updating subscription: %v", err)
+		return
+	}
+
+	if fetchErr != nil {
+		l.Warnf("error fetching or parsing list: %v", fetchErr)
+		return
+	}
+
+	changes, err := p.domainPermSubChanges(ctx, permSub, priorities, entries)
+	if err != nil {
+		l.Errorf("error calculating changes: %v", err)
+		return
+	}
+
+	// Attribute changes to the creator of the
+	// subscription, falling back to the instance
+	// account if the creator is no longer around.
+	adminAcct := permSub.CreatedByAccount
+	if adminAcct == nil {
+		adminAcct, err = p.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			l.Errorf("db error getting instance account: %v", err)
+			return
+		}
+	}
+
+	var errs gtserror.MultiError
+
+	for _, entry := range changes.create {
+		if _, _, errWithCode := p.DomainPermissionCreate(
+			ctx,
+			permSub.PermissionType,
+			adminAcct,
+			entry.domain,
+			entry.obfuscate,
+			entry.publicComment,
+			"", // No private comment for subscription entries.
+			permSub.ID,
+		); errWithCode != nil {
+			errs.Appendf("error creating domain %s %s: %w", permSub.PermissionType.String(), entry.domain, errWithCode)
+		}
+	}
+
+	for _, perm := range changes.adopt {
+		if err := p.setDomainPermSubscriptionID(ctx, perm, permSub.ID); err != nil {
+			errs.Appendf("error adopting domain %s %s: %w", permSub.PermissionType.String(), perm.GetDomain(), err)
+		}
+	}
+
+	for _, perm := range changes.remove {
+		if _, _, errWithCode := p.DomainPermissionDelete(
+			ctx,
+			permSub.PermissionType,
+			adminAcct,
+			perm.GetID(),
+		); errWithCode != nil {
+			errs.Appendf("error removing domain %s %s: %w", permSub.PermissionType.String(), perm.GetDomain(), errWithCode)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		l.Errorf("error(s) processing subscription: %v", err)
+	}
+
+	l.Infof(
+		"processed subscription: %d created, %d adopted, %d removed",
+		len(changes.create), len(changes.adopt), len(changes.remove),
+	)
+}
+
+// domainPermSubChanges calculates the changes to existing domain
+// permissions that would result from applying the given entries
+// of the given subscription, without making any of them:
+//
+//   - domains with no permission yet are created.
+//   - orphaned permissions (no subscription) are adopted if the subscription adopts orphans.
+//   - permissions owned by a lower priority subscription are adopted.
+//   - permissions owned by an equal or higher priority subscription are left alone.
+//   - permissions owned by this subscription but no longer listed are removed.
+func (p *Processor) domainPermSubChanges(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	priorities map[string]uint8,
+	entries []domainPermEntry,
+) (*domainPermSubChanges, error) {
+	existing, err := p.domainPermsByDomain(ctx, permSub.PermissionType)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		changes = new(domainPermSubChanges)
+		listed  = make(map[string]struct{}, len(entries))
+	)
+
+	for _, entry := range entries {
+		listed[entry.domain] = struct{}{}
+
+		perm, ok := existing[entry.domain]
+		if !ok {
+			// No permission for
+			// this domain yet.
+			changes.create = append(changes.create, entry)
+			continue
+		}
+
+		ownerID := perm.GetSubscriptionID()
+		if ownerID == permSub.ID {
+			// Already ours.
+			continue
+		}
+
+		ownerPriority, owned := priorities[ownerID]
+		switch {
+
+		// Orphaned permission, either created
+		// manually or by a since-removed
+		// subscription. Adopt it if we should.
+		case !owned:
+			if *permSub.AdoptOrphans {
+				changes.adopt = append(changes.adopt, perm)
+			}
+
+		// Owned by a lower priority
+		// subscription, take it over.
+		case ownerPriority < permSub.Priority:
+			changes.adopt = append(changes.adopt, perm)
+		}
+	}
+
+	for _, perm := range existing {
+		if perm.GetSubscriptionID() != permSub.ID {
+			// Not ours.
+			continue
+		}
+
+		if _, ok := listed[perm.GetDomain()]; !ok {
+			// No longer listed.
+			changes.remove = append(changes.remove, perm)
+		}
+	}
+
+	// Sort by domain so that
+	// output is deterministic.
+	slices.SortFunc(changes.remove, func(a, b gtsmodel.DomainPermission) int {
+		return strings.Compare(a.GetDomain(), b.GetDomain())
+	})
+
+	return changes, nil
+}
+
+// domainPermSubPriorities returns a map of domain permission
+// subscription IDs to priorities for the given permission type.
+func (p *Processor) domainPermSubPriorities(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) (map[string]uint8, error) {
+	permSubs, err := p.state.DB.GetDomainPermissionSubscriptionsByPriority(ctx, permType)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+	}
+
+	priorities := make(map[string]uint8, len(permSubs))
+	for _, permSub := range permSubs {
+		priorities[permSub.ID] = permSub.Priority
+	}
+
+	return priorities, nil
+}
+
+// domainPermsByDomain returns all existing domain
+// permissions of the given type, keyed by domain.
+func (p *Processor) domainPermsByDomain(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) (map[string]gtsmodel.DomainPermission, error) {
+	var perms map[string]gtsmodel.DomainPermission
+
+	switch permType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocks(ctx)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting domain blocks: %w", err)
+		}
+
+		perms = make(map[string]gtsmodel.DomainPermission, len(blocks))
+		for _, block := range blocks {
+			perms[block.Domain] = block
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllows(ctx)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting domain allows: %w", err)
+		}
+
+		perms = make(map[string]gtsmodel.DomainPermission, len(allows))
+		for _, allow := range allows {
+			perms[allow.Domain] = allow
+		}
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permType)
+	}
+
+	return perms, nil
+}
+
+// domainPermSubChildren returns all domain permissions
+// created by (or adopted by) the given subscription.
+func (p *Processor) domainPermSubChildren(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) ([]gtsmodel.DomainPermission, error) {
+	var children []gtsmodel.DomainPermission
+
+	switch permSub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocksBySubscriptionID(ctx, permSub.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			children = append(children, block)
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllowsBySubscriptionID(ctx, permSub.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		for _, allow := range allows {
+			children = append(children, allow)
+		}
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permSub.PermissionType)
+	}
+
+	return children, nil
+}
+
+// setDomainPermSubscriptionID sets the subscription ID
+// of the given domain permission, and stores the change.
+// An empty subscription ID orphans the permission.
+func (p *Processor) setDomainPermSubscriptionID(
+	ctx context.Context,
+	perm gtsmodel.DomainPermission,
+	subscriptionID string,
+) error {
+	switch perm := perm.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = subscriptionID
+		return p.state.DB.UpdateDomainBlock(ctx, perm, "subscription_id")
+
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = subscriptionID
+		return p.state.DB.UpdateDomainAllow(ctx, perm, "subscription_id")
+
+	default:
+		return gtserror.Newf("unrecognized domain permission %T", perm)
+	}
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// InstancesGet returns the admin view of all remote instances
+// known to this instance, or only of those currently marked
+// unavailable due to sustained delivery failures.
+func (p *Processor) InstancesGet(
+	ctx context.Context,
+	unavailableOnly bool,
+) ([]*apimodel.AdminInstance, gtserror.WithCode) {
+	var (
+		instances []*gtsmodel.Instance
+		err       error
+	)
+
+	if unavailableOnly {
+		instances, err = p.state.DB.GetUnavailableInstances(ctx)
+	} else {
+		instances, err = p.state.DB.GetInstancePeers(ctx, true)
+	}
+
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting instances: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInstances := make([]*apimodel.AdminInstance, 0, len(instances))
+	for _, instance := range instances {
+		apiInstance, err := p.converter.InstanceToAdminAPIInstance(ctx, instance)
+		if err != nil {
+			err := gtserror.Newf("error converting instance to api model: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiInstances = append(apiInstances, apiInstance)
+	}
+
+	return apiInstances, nil
+}
+
+// InstanceGet returns the admin view of the
+// remote instance with the given domain.
+func (p *Processor) InstanceGet(
+	ctx context.Context,
+	domain string,
+) (*apimodel.AdminInstance, gtserror.WithCode) {
+	domain, err := util.Punify(domain)
+	if err != nil {
+		err := gtserror.Newf("error punifying domain %s: %w", domain, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if domain == config.GetHost() {
+		err := gtserror.New("domain is this instance")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("instance %s not found", domain)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("error getting instance %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInstance, err := p.converter.InstanceToAdminAPIInstance(ctx, instance)
+	if err != nil {
+		err := gtserror.Newf("error converting instance to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInstance, nil
+}
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InstanceTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *InstanceTestSuite) TestInstancesGetUnavailable() {
+	ctx := context.Background()
+
+	// No instances unavailable to start with.
+	instances, errWithCode := suite.adminProcessor.InstancesGet(ctx, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(instances)
+
+	// Mark one instance unavailable.
+	instance, err := suite.state.DB.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.DeliveryFailingSince = time.Now().Add(-48 * time.Hour)
+	instance.UnavailableAt = time.Now()
+	if err := suite.state.DB.UpdateInstance(ctx,
+		instance,
+		"delivery_failing_since",
+		"unavailable_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instances, errWithCode = suite.adminProcessor.InstancesGet(ctx, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(instances, 1) {
+		suite.Equal("fossbros-anonymous.io", instances[0].Domain)
+		suite.True(instances[0].Unavailable)
+		suite.NotEmpty(instances[0].UnavailableAt)
+		suite.NotEmpty(instances[0].DeliveryFailingSince)
+	}
+
+	// All peers are still listed otherwise.
+	instances, errWithCode = suite.adminProcessor.InstancesGet(ctx, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(instances, 2)
+}
+
+func (suite *InstanceTestSuite) TestInstanceGet() {
+	ctx := context.Background()
+
+	instance, errWithCode := suite.adminProcessor.InstanceGet(ctx, "example.org")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("example.org", instance.Domain)
+	suite.False(instance.Unavailable)
+
+	_, errWithCode = suite.adminProcessor.InstanceGet(ctx, "not.a.known.domain")
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func TestInstanceTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceTestSuite))
+}
@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// RelaysGet returns the admin view of
+// all relays this instance subscribes to.
+func (p *Processor) RelaysGet(
+	ctx context.Context,
+) ([]*apimodel.AdminRelay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.AdminRelay, 0, len(relays))
+	for _, relay := range relays {
+		apiRelay, errWithCode := p.apiRelay(ctx, relay)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiRelays = append(apiRelays, apiRelay)
+	}
+
+	return apiRelays, nil
+}
+
+// RelayCreate subscribes this instance to the relay with the
+// given inbox URL, by sending a Follow from the instance actor
+// to the relay's inbox. The relay will be in pending state
+// until the relay responds with an Accept or Reject.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	inboxURL string,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	inbox, err := url.Parse(inboxURL)
+	if err != nil ||
+		(inbox.Scheme != "http" && inbox.Scheme != "https") ||
+		inbox.Host == "" {
+		const text = "inbox_url must be an absolute http or https URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if inbox.Host == config.GetHost() ||
+		inbox.Host == config.GetAccountDomain() {
+		const text = "inbox_url must not point to this instance"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Normalize the stored inbox URL.
+	inboxURL = inbox.String()
+
+	existing, err := p.state.DB.GetRelayByInboxURI(ctx, inboxURL)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error checking for existing relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		text := fmt.Sprintf("relay with inbox_url %s already exists", inboxURL)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:        relayID,
+		InboxURI:  inboxURL,
+		FollowURI: uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+		State:     gtsmodel.RelayStatePending,
+	}
+
+	if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+		err := gtserror.Newf("error putting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to Follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deliverToRelay(ctx, relay, follow); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiRelay(ctx, relay)
+}
+
+// RelayDelete unsubscribes this instance from the relay
+// with the given ID, by sending an Undo of the instance
+// actor's Follow to the relay's inbox, and removing it.
+func (p *Processor) RelayDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("relay %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Prepare response before the model is deleted.
+	apiRelay, errWithCode := p.apiRelay(ctx, relay)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if relay.State != gtsmodel.RelayStateRejected {
+		// Relay may still consider us subscribed,
+		// so send an Undo of the instance Follow.
+		undo, err := p.converter.RelayToASUndoFollow(ctx, relay)
+		if err != nil {
+			err := gtserror.Newf("error converting relay to Undo: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.deliverToRelay(ctx, relay, undo); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
+
+// deliverToRelay serializes and delivers the given
+// activity from the instance actor to the relay's inbox.
+func (p *Processor) deliverToRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	activity vocab.Type,
+) error {
+	inbox, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing relay inbox %s: %w", relay.InboxURI, err)
+	}
+
+	m, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing activity: %w", err)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return gtserror.Newf("error marshaling activity: %w", err)
+	}
+
+	// Empty username gets
+	// the instance transport.
+	tsport, err := p.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	if err := tsport.Deliver(ctx, b, inbox); err != nil {
+		return gtserror.Newf("error delivering to relay inbox %s: %w", inbox, err)
+	}
+
+	return nil
+}
+
+func (p *Processor) apiRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	apiRelay, err := p.converter.RelayToAdminAPIRelay(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RelayTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RelayTestSuite) TestRelayCreateDelete() {
+	var (
+		ctx   = context.Background()
+		inbox = "https://relay.example.org/inbox"
+	)
+
+	apiRelay, errWithCode := suite.adminProcessor.RelayCreate(ctx, inbox)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(inbox, apiRelay.InboxURL)
+	suite.Equal("pending", apiRelay.State)
+	suite.Empty(apiRelay.ActorURI)
+
+	// Relay should be stored with a Follow
+	// URI belonging to the instance account.
+	relay, err := suite.state.DB.GetRelayByID(ctx, apiRelay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStatePending, relay.State)
+	suite.Equal("http://localhost:8080/users/localhost:8080/follow/"+relay.ID, relay.FollowURI)
+
+	// Adding the same relay again should conflict.
+	_, errWithCode = suite.adminProcessor.RelayCreate(ctx, inbox)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusConflict, errWithCode.Code())
+	}
+
+	apiRelays, errWithCode := suite.adminProcessor.RelaysGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiRelays, 1)
+
+	// Remove the relay.
+	if _, errWithCode := suite.adminProcessor.RelayDelete(ctx, apiRelay.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.RelayDelete(ctx, apiRelay.ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func (suite *RelayTestSuite) TestRelayCreateInvalid() {
+	ctx := context.Background()
+
+	for _, inbox := range []string{
+		"not a url",
+		"ftp://relay.example.org/inbox",
+		"http://localhost:8080/inbox",
+	} {
+		_, errWithCode := suite.adminProcessor.RelayCreate(ctx, inbox)
+		if suite.NotNil(errWithCode, inbox) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code(), inbox)
+		}
+	}
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
@@ -19,6 +19,7 @@ package processing
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -37,6 +38,12 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 		scopes = form.Scopes
 	}
 
+	// make sure only scopes we know about are requested
+	if _, err := oauth.ParseScopes(scopes); err != nil {
+		err := fmt.Errorf("invalid scopes: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
 	if err != nil {
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AppTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AppTestSuite) TestAppCreateDefaultScopes() {
+	app, errWithCode := suite.processor.AppCreate(context.Background(), nil, &model.ApplicationCreateRequest{
+		ClientName:   "some app",
+		RedirectURIs: "urn:ietf:wg:oauth:2.0:oob",
+	})
+	suite.NoError(errWithCode)
+
+	dbApp, err := suite.db.GetApplicationByClientID(context.Background(), app.ClientID)
+	suite.NoError(err)
+	suite.Equal("read", dbApp.Scopes)
+}
+
+func (suite *AppTestSuite) TestAppCreateGranularScopes() {
+	app, errWithCode := suite.processor.AppCreate(context.Background(), nil, &model.ApplicationCreateRequest{
+		ClientName:   "some app",
+		RedirectURIs: "urn:ietf:wg:oauth:2.0:oob",
+		Scopes:       "read:statuses write:media push",
+	})
+	suite.NoError(errWithCode)
+
+	dbApp, err := suite.db.GetApplicationByClientID(context.Background(), app.ClientID)
+	suite.NoError(err)
+	suite.Equal("read:statuses write:media push", dbApp.Scopes)
+}
+
+func (suite *AppTestSuite) TestAppCreateUnknownScope() {
+	app, errWithCode := suite.processor.AppCreate(context.Background(), nil, &model.ApplicationCreateRequest{
+		ClientName:   "some app",
+		RedirectURIs: "urn:ietf:wg:oauth:2.0:oob",
+		Scopes:       "read write:everything",
+	})
+	suite.Nil(app)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal(`invalid scopes: unknown scope "write:everything"`, errWithCode.Safe())
+}
+
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, &AppTestSuite{})
+}
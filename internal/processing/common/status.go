@@ -24,6 +24,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -184,7 +185,7 @@ func (p *Processor) GetAPIStatus(
 	apiStatus *apimodel.Status,
 	errWithCode gtserror.WithCode,
 ) {
-	apiStatus, err := p.converter.StatusToAPIStatus(ctx, target, requester)
+	apiStatus, err := p.converter.StatusToAPIStatus(ctx, target, requester, statusfilter.FilterContextNone, nil)
 	if err != nil {
 		err = gtserror.Newf("error converting status: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -260,7 +261,7 @@ func (p *Processor) getVisibleAPIStatuses(
 		}
 
 		// Convert the status to an API model representation.
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester)
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester, statusfilter.FilterContextNone, nil)
 		if err != nil {
 			l.Errorf("error converting status: %v", err)
 			continue
@@ -308,3 +309,38 @@ func (p *Processor) InvalidateTimelinedStatus(ctx context.Context, accountID str
 
 	return nil
 }
+
+// InvalidateTimelinesForAccount is a shortcut function for removing the home
+// timeline and all list timelines of the given accountID from memory, so that
+// they will be re-indexed and re-prepared next time they're fetched. This should
+// be called when something changes which affects how statuses are prepared for
+// the account as a whole, such as a filter being created, updated, or deleted.
+func (p *Processor) InvalidateTimelinesForAccount(ctx context.Context, accountID string) error {
+	// Get lists first + bail if this fails.
+	lists, err := p.state.DB.GetListsForAccountID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("db error getting lists for account %s: %w", accountID, err)
+	}
+
+	// Start new log entry with
+	// the above calling func's name.
+	l := log.
+		WithContext(ctx).
+		WithField("caller", log.Caller(3)).
+		WithField("accountID", accountID)
+
+	// Remove home + list timelines, just log
+	// if something goes wrong since this is not a showstopper.
+
+	if err := p.state.Timelines.Home.RemoveTimeline(ctx, accountID); err != nil {
+		l.Errorf("error removing home timeline: %v", err)
+	}
+
+	for _, list := range lists {
+		if err := p.state.Timelines.List.RemoveTimeline(ctx, list.ID); err != nil {
+			l.Errorf("error removing list timeline %s: %v", list.ID, err)
+		}
+	}
+
+	return nil
+}
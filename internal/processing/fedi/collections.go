@@ -46,7 +46,8 @@ func (p *Processor) InboxPost(ctx context.Context, w http.ResponseWriter, r *htt
 }
 
 // OutboxGet returns the activitypub representation of a local user's outbox.
-// This contains links to PUBLIC posts made by this user.
+// This contains Creates of PUBLIC posts made by this user, and Announces
+// of PUBLIC posts boosted by this user.
 func (p *Processor) OutboxGet(
 	ctx context.Context,
 	requestedUser string,
@@ -92,7 +93,7 @@ func (p *Processor) OutboxGet(
 
 	// scenario 2 -- get the requested page
 	// limit pages to 30 entries per page
-	publicStatuses, err := p.state.DB.GetAccountStatuses(ctx, receiver.ID, 30, true, true, maxID, minID, false, true)
+	publicStatuses, err := p.state.DB.GetAccountStatuses(ctx, receiver.ID, 30, true, false, maxID, minID, false, true)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -83,5 +83,11 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Filters affect how statuses are prepared,
+	// so clear the account's cached timelines.
+	if err := p.c.InvalidateTimelinesForAccount(ctx, account.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	return p.apiFilter(ctx, filterKeyword)
 }
@@ -63,5 +63,11 @@ func (p *Processor) Delete(
 		}
 	}
 
+	// Filters affect how statuses are prepared,
+	// so clear the account's cached timelines.
+	if err := p.c.InvalidateTimelinesForAccount(ctx, account.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
 	return nil
 }
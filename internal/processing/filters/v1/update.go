@@ -161,5 +161,11 @@ func (p *Processor) Update(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Filters affect how statuses are prepared,
+	// so clear the account's cached timelines.
+	if err := p.c.InvalidateTimelinesForAccount(ctx, account.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	return p.apiFilter(ctx, filterKeyword)
 }
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Create a new filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterCreateRequestV2) (*apimodel.FilterV2, gtserror.WithCode) {
+	filter := &gtsmodel.Filter{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Title:     form.Title,
+		Action:    typeutils.APIFilterActionToFilterAction(*form.FilterAction),
+	}
+	if form.ExpiresIn != nil && *form.ExpiresIn != 0 {
+		filter.ExpiresAt = time.Now().Add(time.Second * time.Duration(*form.ExpiresIn))
+	}
+	if errWithCode := applyFilterContexts(filter, form.Context); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	for _, formKeyword := range form.Keywords {
+		filter.Keywords = append(filter.Keywords, newFilterKeyword(
+			filter,
+			id.NewULID(),
+			formKeyword.Keyword,
+			formKeyword.WholeWord,
+		))
+	}
+
+	for _, formStatus := range form.Statuses {
+		// Ensure the status exists and is visible to the filter owner.
+		if _, errWithCode := p.c.GetVisibleTargetStatus(ctx, account, formStatus.StatusID, nil); errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		filter.Statuses = append(filter.Statuses, newFilterStatus(
+			filter,
+			id.NewULID(),
+			formStatus.StatusID,
+		))
+	}
+
+	if err := p.state.DB.PutFilter(ctx, filter); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("you already have a filter with this title, or duplicate keywords or statuses")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.invalidateTimelines(ctx, account); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiFilter(ctx, filter)
+}
@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type Processor struct {
+	// embedded common logic
+	c *common.Processor
+
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(common *common.Processor, state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		c:         common,
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getFilter fetches the filter with the given ID, returning
+// a not found error if it isn't owned by the given account.
+func (p *Processor) getFilter(ctx context.Context, account *gtsmodel.Account, filterID string) (*gtsmodel.Filter, gtserror.WithCode) {
+	filter, err := p.state.DB.GetFilterByID(ctx, filterID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if filter.AccountID != account.ID {
+		return nil, gtserror.NewErrorNotFound(nil)
+	}
+
+	return filter, nil
+}
+
+// invalidateTimelines clears cached timelines for the
+// given account after one of its filters has changed.
+func (p *Processor) invalidateTimelines(ctx context.Context, account *gtsmodel.Account) gtserror.WithCode {
+	if err := p.c.InvalidateTimelinesForAccount(ctx, account.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+	return nil
+}
+
+// applyFilterContexts sets the context fields of the
+// given filter from the given list of API filter contexts.
+func applyFilterContexts(filter *gtsmodel.Filter, contexts []apimodel.FilterContext) gtserror.WithCode {
+	contextHome := false
+	contextNotifications := false
+	contextPublic := false
+	contextThread := false
+	contextAccount := false
+	for _, context := range contexts {
+		switch context {
+		case apimodel.FilterContextHome:
+			contextHome = true
+		case apimodel.FilterContextNotifications:
+			contextNotifications = true
+		case apimodel.FilterContextPublic:
+			contextPublic = true
+		case apimodel.FilterContextThread:
+			contextThread = true
+		case apimodel.FilterContextAccount:
+			contextAccount = true
+		default:
+			return gtserror.NewErrorUnprocessableEntity(
+				fmt.Errorf("unsupported filter context '%s'", context),
+			)
+		}
+	}
+
+	filter.ContextHome = &contextHome
+	filter.ContextNotifications = &contextNotifications
+	filter.ContextPublic = &contextPublic
+	filter.ContextThread = &contextThread
+	filter.ContextAccount = &contextAccount
+	return nil
+}
+
+// newFilterKeyword returns a new filter keyword
+// belonging to the given filter, with the given values.
+func newFilterKeyword(filter *gtsmodel.Filter, id string, keyword string, wholeWord *bool) *gtsmodel.FilterKeyword {
+	return &gtsmodel.FilterKeyword{
+		ID:        id,
+		AccountID: filter.AccountID,
+		FilterID:  filter.ID,
+		Filter:    filter,
+		Keyword:   keyword,
+		WholeWord: util.Ptr(util.PtrValueOr(wholeWord, false)),
+	}
+}
+
+// newFilterStatus returns a new filter status
+// belonging to the given filter, for the given status.
+func newFilterStatus(filter *gtsmodel.Filter, id string, statusID string) *gtsmodel.FilterStatus {
+	return &gtsmodel.FilterStatus{
+		ID:        id,
+		AccountID: filter.AccountID,
+		FilterID:  filter.ID,
+		Filter:    filter,
+		StatusID:  statusID,
+	}
+}
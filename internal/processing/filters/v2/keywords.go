@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// KeywordsGetForFilterID looks up all filter keywords for the given filter.
+func (p *Processor) KeywordsGetForFilterID(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+) ([]*apimodel.FilterKeyword, gtserror.WithCode) {
+	filter, errWithCode := p.getFilter(ctx, account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiFilterKeywords := make([]*apimodel.FilterKeyword, 0, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		apiFilterKeywords = append(apiFilterKeywords, p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword))
+	}
+
+	return apiFilterKeywords, nil
+}
+
+// KeywordGet looks up a filter keyword by ID.
+func (p *Processor) KeywordGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterKeywordID string,
+) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	filterKeyword, errWithCode := p.getFilterKeyword(ctx, account, filterKeywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword), nil
+}
+
+// KeywordCreate adds a filter keyword to an existing filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) KeywordCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+	form *apimodel.FilterKeywordCreateUpdateRequest,
+) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	filter, errWithCode := p.getFilter(gtscontext.SetBarebones(ctx), account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterKeyword := newFilterKeyword(
+		filter,
+		id.NewULID(),
+		form.Keyword,
+		form.WholeWord,
+	)
+
+	if err := p.state.DB.PutFilterKeyword(ctx, filterKeyword); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("this filter already has this keyword")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.invalidateTimelines(ctx, account); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword), nil
+}
+
+// KeywordUpdate updates an existing filter keyword for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) KeywordUpdate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterKeywordID string,
+	form *apimodel.FilterKeywordCreateUpdateRequest,
+) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	filterKeyword, errWithCode := p.getFilterKeyword(ctx, account, filterKeywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterKeyword.Keyword = form.Keyword
+	filterKeyword.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+
+	if err := p.state.DB.UpdateFilterKeyword(ctx, filterKeyword, "keyword", "whole_word"); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("this filter already has this keyword")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.invalidateTimelines(ctx, account); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword), nil
+}
+
+// KeywordDelete deletes an existing filter keyword from a filter.
+func (p *Processor) KeywordDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterKeywordID string,
+) gtserror.WithCode {
+	filterKeyword, errWithCode := p.getFilterKeyword(ctx, account, filterKeywordID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilterKeywordByID(ctx, filterKeyword.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.invalidateTimelines(ctx, account)
+}
+
+// getFilterKeyword fetches the filter keyword with the given ID,
+// returning a not found error if it isn't owned by the given account.
+func (p *Processor) getFilterKeyword(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterKeywordID string,
+) (*gtsmodel.FilterKeyword, gtserror.WithCode) {
+	filterKeyword, err := p.state.DB.GetFilterKeywordByID(gtscontext.SetBarebones(ctx), filterKeywordID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if filterKeyword.AccountID != account.ID {
+		return nil, gtserror.NewErrorNotFound(nil)
+	}
+
+	return filterKeyword, nil
+}
@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// StatusesGetForFilterID looks up all filter statuses for the given filter.
+func (p *Processor) StatusesGetForFilterID(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+) ([]*apimodel.FilterStatus, gtserror.WithCode) {
+	filter, errWithCode := p.getFilter(ctx, account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiFilterStatuses := make([]*apimodel.FilterStatus, 0, len(filter.Statuses))
+	for _, filterStatus := range filter.Statuses {
+		apiFilterStatuses = append(apiFilterStatuses, p.converter.FilterStatusToAPIFilterStatus(ctx, filterStatus))
+	}
+
+	return apiFilterStatuses, nil
+}
+
+// StatusGet looks up a filter status by ID.
+func (p *Processor) StatusGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterStatusID string,
+) (*apimodel.FilterStatus, gtserror.WithCode) {
+	filterStatus, errWithCode := p.getFilterStatus(ctx, account, filterStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterStatusToAPIFilterStatus(ctx, filterStatus), nil
+}
+
+// StatusCreate adds a filter status to an existing filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) StatusCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+	form *apimodel.FilterStatusCreateRequest,
+) (*apimodel.FilterStatus, gtserror.WithCode) {
+	filter, errWithCode := p.getFilter(gtscontext.SetBarebones(ctx), account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure the status exists and is visible to the filter owner.
+	if _, errWithCode := p.c.GetVisibleTargetStatus(ctx, account, form.StatusID, nil); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterStatus := newFilterStatus(
+		filter,
+		id.NewULID(),
+		form.StatusID,
+	)
+
+	if err := p.state.DB.PutFilterStatus(ctx, filterStatus); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("this filter already has this status")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.invalidateTimelines(ctx, account); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterStatusToAPIFilterStatus(ctx, filterStatus), nil
+}
+
+// StatusDelete deletes an existing filter status from a filter.
+func (p *Processor) StatusDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterStatusID string,
+) gtserror.WithCode {
+	filterStatus, errWithCode := p.getFilterStatus(ctx, account, filterStatusID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilterStatusByID(ctx, filterStatus.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.invalidateTimelines(ctx, account)
+}
+
+// getFilterStatus fetches the filter status with the given ID,
+// returning a not found error if it isn't owned by the given account.
+func (p *Processor) getFilterStatus(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterStatusID string,
+) (*gtsmodel.FilterStatus, gtserror.WithCode) {
+	filterStatus, err := p.state.DB.GetFilterStatusByID(gtscontext.SetBarebones(ctx), filterStatusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if filterStatus.AccountID != account.ID {
+		return nil, gtserror.NewErrorNotFound(nil)
+	}
+
+	return filterStatus, nil
+}
@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Update an existing filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) Update(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+	form *apimodel.FilterUpdateRequestV2,
+) (*apimodel.FilterV2, gtserror.WithCode) {
+	filter, errWithCode := p.getFilter(ctx, account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Update only the filter columns that were provided.
+	filterColumns := make([]string, 0, 8)
+	if form.Title != nil {
+		filter.Title = *form.Title
+		filterColumns = append(filterColumns, "title")
+	}
+	if form.FilterAction != nil {
+		filter.Action = typeutils.APIFilterActionToFilterAction(*form.FilterAction)
+		filterColumns = append(filterColumns, "action")
+	}
+	if form.ExpiresIn != nil {
+		filter.ExpiresAt = time.Time{}
+		if *form.ExpiresIn != 0 {
+			filter.ExpiresAt = time.Now().Add(time.Second * time.Duration(*form.ExpiresIn))
+		}
+		filterColumns = append(filterColumns, "expires_at")
+	}
+	if form.Context != nil {
+		if errWithCode := applyFilterContexts(filter, *form.Context); errWithCode != nil {
+			return nil, errWithCode
+		}
+		filterColumns = append(filterColumns,
+			"context_home",
+			"context_notifications",
+			"context_public",
+			"context_thread",
+			"context_account",
+		)
+	}
+
+	// Work out which keywords to add, change, or remove.
+	filterKeywordsByID := make(map[string]*gtsmodel.FilterKeyword, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		filterKeywordsByID[filterKeyword.ID] = filterKeyword
+	}
+	filterKeywords := make([]*gtsmodel.FilterKeyword, 0, len(form.Keywords))
+	deleteFilterKeywordIDs := make([]string, 0, len(form.Keywords))
+	for _, formKeyword := range form.Keywords {
+		if formKeyword.ID == nil {
+			// New keyword.
+			filterKeywords = append(filterKeywords, newFilterKeyword(
+				filter,
+				id.NewULID(),
+				*formKeyword.Keyword,
+				formKeyword.WholeWord,
+			))
+			continue
+		}
+
+		filterKeyword, ok := filterKeywordsByID[*formKeyword.ID]
+		if !ok {
+			err := fmt.Errorf("filter keyword %s doesn't belong to this filter", *formKeyword.ID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		if util.PtrValueOr(formKeyword.Destroy, false) {
+			// Remove existing keyword.
+			deleteFilterKeywordIDs = append(deleteFilterKeywordIDs, filterKeyword.ID)
+			continue
+		}
+
+		// Change existing keyword.
+		if formKeyword.Keyword != nil {
+			filterKeyword.Keyword = *formKeyword.Keyword
+		}
+		if formKeyword.WholeWord != nil {
+			filterKeyword.WholeWord = util.Ptr(*formKeyword.WholeWord)
+		}
+		filterKeywords = append(filterKeywords, filterKeyword)
+	}
+
+	// Work out which statuses to add or remove.
+	// Existing filter statuses can't be changed.
+	filterStatusesByID := make(map[string]*gtsmodel.FilterStatus, len(filter.Statuses))
+	for _, filterStatus := range filter.Statuses {
+		filterStatusesByID[filterStatus.ID] = filterStatus
+	}
+	filterStatuses := make([]*gtsmodel.FilterStatus, 0, len(form.Statuses))
+	deleteFilterStatusIDs := make([]string, 0, len(form.Statuses))
+	for _, formStatus := range form.Statuses {
+		if formStatus.ID == nil {
+			// Ensure the status exists and is visible to the filter owner.
+			if _, errWithCode := p.c.GetVisibleTargetStatus(ctx, account, *formStatus.StatusID, nil); errWithCode != nil {
+				return nil, errWithCode
+			}
+
+			// New status.
+			filterStatuses = append(filterStatuses, newFilterStatus(
+				filter,
+				id.NewULID(),
+				*formStatus.StatusID,
+			))
+			continue
+		}
+
+		filterStatus, ok := filterStatusesByID[*formStatus.ID]
+		if !ok {
+			err := fmt.Errorf("filter status %s doesn't belong to this filter", *formStatus.ID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		if util.PtrValueOr(formStatus.Destroy, false) {
+			// Remove existing status.
+			deleteFilterStatusIDs = append(deleteFilterStatusIDs, filterStatus.ID)
+		}
+	}
+
+	// Only pass the keywords and statuses being created or changed.
+	filter.Keywords = filterKeywords
+	filter.Statuses = filterStatuses
+
+	filterKeywordColumns := []string{
+		"keyword",
+		"whole_word",
+	}
+	if err := p.state.DB.UpdateFilter(
+		ctx,
+		filter,
+		filterColumns,
+		filterKeywordColumns,
+		deleteFilterKeywordIDs,
+		deleteFilterStatusIDs,
+	); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("you already have a filter with this title, or duplicate keywords or statuses")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.invalidateTimelines(ctx, account); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Reload the filter to get the full set of keywords and statuses.
+	filter, errWithCode = p.getFilter(ctx, account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiFilter(ctx, filter)
+}
@@ -49,6 +49,13 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		}
 	}
 
+	// delete the medium rendition from storage
+	if attachment.Medium.Path != "" {
+		if err := p.state.Storage.Delete(ctx, attachment.Medium.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			errs = append(errs, fmt.Sprintf("remove medium rendition at path %s: %s", attachment.Medium.Path, err))
+		}
+	}
+
 	// delete the file from storage
 	if attachment.File.Path != "" {
 		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
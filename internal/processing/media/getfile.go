@@ -113,6 +113,8 @@ func parseSize(s string) (media.Size, error) {
 	switch s {
 	case string(media.SizeSmall):
 		return media.SizeSmall, nil
+	case string(media.SizeMedium):
+		return media.SizeMedium, nil
 	case string(media.SizeOriginal):
 		return media.SizeOriginal, nil
 	case string(media.SizeStatic):
@@ -220,6 +222,19 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		attachmentContent.ContentType = a.Thumbnail.ContentType
 		attachmentContent.ContentLength = int64(a.Thumbnail.FileSize)
 		storagePath = a.Thumbnail.Path
+	case media.SizeMedium:
+		if a.Medium.Path == "" {
+			// No medium rendition, eg., audio or
+			// media processed before they existed,
+			// so fall back to serving the thumbnail.
+			attachmentContent.ContentType = a.Thumbnail.ContentType
+			attachmentContent.ContentLength = int64(a.Thumbnail.FileSize)
+			storagePath = a.Thumbnail.Path
+			break
+		}
+		attachmentContent.ContentType = a.Medium.ContentType
+		attachmentContent.ContentLength = int64(a.Medium.FileSize)
+		storagePath = a.Medium.Path
 	default:
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for attachment", mediaSize))
 	}
@@ -208,6 +208,38 @@ func (suite *GetFileTestSuite) TestGetRemoteFileThumbnailUncached() {
 	suite.EqualValues(testAttachment.Thumbnail.FileSize, content.ContentLength)
 }
 
+func (suite *GetFileTestSuite) TestGetFileMediumFallback() {
+	ctx := context.Background()
+
+	// attachment has no medium rendition, so
+	// the thumbnail should be served instead
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.Empty(testAttachment.Medium.Path)
+
+	thumbnailBytes, err := suite.storage.Get(ctx, testAttachment.Thumbnail.Path)
+	suite.NoError(err)
+
+	fileName := path.Base(testAttachment.File.Path)
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, requestingAccount, &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAttachment),
+		MediaSize: string(media.SizeMedium),
+		FileName:  fileName,
+	})
+
+	suite.NoError(errWithCode)
+	suite.NotNil(content)
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+
+	suite.Equal(thumbnailBytes, b)
+	suite.Equal("image/jpeg", content.ContentType)
+	suite.EqualValues(testAttachment.Thumbnail.FileSize, content.ContentLength)
+}
+
 func TestGetFileTestSuite(t *testing.T) {
 	suite.Run(t, &GetFileTestSuite{})
 }
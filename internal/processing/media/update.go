@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
@@ -44,7 +45,10 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 		return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
 	}
 
-	var updatingColumns []string
+	var (
+		updatingColumns []string
+		refocused       bool
+	)
 
 	if form.Description != nil {
 		attachment.Description = text.SanitizeToPlaintext(*form.Description)
@@ -56,6 +60,8 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
+		refocused = focusx != attachment.FileMeta.Focus.X ||
+			focusy != attachment.FileMeta.Focus.Y
 		attachment.FileMeta.Focus.X = focusx
 		attachment.FileMeta.Focus.Y = focusy
 		updatingColumns = append(updatingColumns, "focus_x", "focus_y")
@@ -65,6 +71,18 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error updating media: %s", err))
 	}
 
+	if refocused {
+		// Regenerate renditions around the new focal point in
+		// the background, using a copy of the attachment so the
+		// media worker doesn't race with conversion below.
+		refocus := *attachment
+		_ = p.state.Workers.Media.MustEnqueueCtx(ctx, func(ctx context.Context) {
+			if err := p.mediaManager.RegenerateRenditions(ctx, &refocus); err != nil {
+				log.Errorf(ctx, "error regenerating renditions for attachment %s: %v", refocus.ID, err)
+			}
+		})
+	}
+
 	a, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getTokenID returns the database ID of the
+// token with the given access token string.
+// Web Push subscriptions are keyed by token.
+func (p *Processor) getTokenID(ctx context.Context, accessToken string) (string, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByAccess(ctx, accessToken)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.New("access token not found")
+			return "", gtserror.NewErrorUnauthorized(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting token: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return token.ID, nil
+}
+
+// getSubscription returns the Web Push subscription
+// created with the given access token string.
+func (p *Processor) getSubscription(ctx context.Context, accessToken string) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription, err := p.state.DB.GetWebPushSubscriptionByTokenID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if subscription == nil {
+		err := gtserror.New("no web push subscription exists for this access token")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return subscription, nil
+}
+
+func (p *Processor) apiSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) (*apimodel.WebPushSubscription, gtserror.WithCode) {
+	apiSubscription, err := p.converter.WebPushSubscriptionToAPIWebPushSubscription(ctx, subscription)
+	if err != nil {
+		err := gtserror.Newf("error converting web push subscription to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiSubscription, nil
+}
+
+// setData sets the alerts and policy
+// of subscription from the given data.
+func setData(subscription *gtsmodel.WebPushSubscription, data apimodel.WebPushSubscriptionRequestData) {
+	flags := &subscription.NotificationFlags
+	flags.Set(gtsmodel.NotificationFollow, data.Alerts.Follow)
+	flags.Set(gtsmodel.NotificationFollowRequest, data.Alerts.FollowRequest)
+	flags.Set(gtsmodel.NotificationFave, data.Alerts.Favourite)
+	flags.Set(gtsmodel.NotificationMention, data.Alerts.Mention)
+	flags.Set(gtsmodel.NotificationReblog, data.Alerts.Reblog)
+	flags.Set(gtsmodel.NotificationPoll, data.Alerts.Poll)
+	flags.Set(gtsmodel.NotificationStatus, data.Alerts.Status)
+
+	if data.Policy == "" {
+		subscription.Policy = gtsmodel.WebPushNotificationPolicyAll
+	} else {
+		subscription.Policy = gtsmodel.WebPushNotificationPolicy(data.Policy)
+	}
+}
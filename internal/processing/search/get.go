@@ -117,11 +117,12 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
+	// todo: Currently we only support offset for paging
+	// through status search results; for other types of
+	// search a caller can page using maxID or minID, but
+	// if they supply an offset greater than 0, return
+	// nothing as though there were no additional results.
+	if req.Offset > 0 && queryType != queryTypeStatuses {
 		return p.packageSearchResult(
 			ctx,
 			account,
@@ -219,6 +220,20 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		if offset > 0 {
+			// A URI can only have one
+			// result, which the caller
+			// has already been given.
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -773,7 +788,7 @@ func (p *Processor) byText(
 	if includeStatuses(queryType) {
 		// Search for statuses using the given text.
 		if err := p.statusesByText(ctx,
-			requestingAccount.ID,
+			requestingAccount,
 			maxID,
 			minID,
 			limit,
@@ -817,23 +832,41 @@ func (p *Processor) accountsByText(
 }
 
 // statusesByText searches in the database for limit
-// number of statuses using the given query text.
+// number of statuses using the given query text,
+// which may contain search operators (see parseStatusQuery).
 func (p *Processor) statusesByText(
 	ctx context.Context,
-	requestingAccountID string,
+	requestingAccount *gtsmodel.Account,
 	maxID string,
 	minID string,
 	limit int,
 	offset int,
-	query string,
+	queryText string,
 	appendStatus func(*gtsmodel.Status),
 ) error {
+	query, from := parseStatusQuery(queryText)
+
+	if from != "" {
+		// Resolve 'from:' to an account we know about.
+		fromAccount, err := p.fromAccount(ctx, requestingAccount, from)
+		if err != nil {
+			if gtserror.IsUnretrievable(err) {
+				// Account not known,
+				// so no results.
+				return nil
+			}
+			return err
+		}
+
+		query.FromAccountID = fromAccount.ID
+	}
+
 	statuses, err := p.state.DB.SearchForStatuses(
 		ctx,
-		requestingAccountID,
+		requestingAccount.ID,
 		query, maxID, minID, limit, offset)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return gtserror.Newf("error checking database for statuses using text %s: %w", query, err)
+		return gtserror.Newf("error checking database for statuses using text %s: %w", queryText, err)
 	}
 
 	for _, status := range statuses {
@@ -842,3 +875,38 @@ func (p *Processor) statusesByText(
 
 	return nil
 }
+
+// fromAccount returns the account given as the value of a
+// 'from:' search operator, which is either the string "me",
+// or the namestring of an account already known to us.
+func (p *Processor) fromAccount(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	from string,
+) (*gtsmodel.Account, error) {
+	if strings.EqualFold(from, "me") {
+		return requestingAccount, nil
+	}
+
+	if from[0] != '@' {
+		// Be generous and allow
+		// the '@' to be left out.
+		from = "@" + from
+	}
+
+	username, domain, err := util.ExtractNamestringParts(from)
+	if err != nil {
+		err = gtserror.Newf("could not parse %s as namestring: %w", from, err)
+		return nil, gtserror.SetUnretrievable(err)
+	}
+
+	// Don't resolve; only search
+	// statuses of accounts we know.
+	return p.accountByUsernameDomain(
+		ctx,
+		requestingAccount,
+		username,
+		domain,
+		false,
+	)
+}
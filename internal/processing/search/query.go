@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package search
+
+import (
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+const (
+	operatorFrom   = "from:"
+	operatorHas    = "has:"
+	operatorBefore = "before:"
+	operatorAfter  = "after:"
+
+	// Date format expected by
+	// before: and after: operators.
+	operatorDateLayout = "2006-01-02"
+)
+
+// parseStatusQuery parses any search operators out of the
+// given status search query text, returning a StatusSearchQuery
+// containing the remaining text, and the value of any 'from:'
+// operator, which the caller should resolve to an account.
+//
+// Supported operators are:
+//
+//   - from:me, from:@username, from:@username@domain
+//   - has:media
+//   - before:YYYY-MM-DD (exclusive of the given day)
+//   - after:YYYY-MM-DD (exclusive of the given day)
+//
+// Operators inside double quotes, or with values that can't
+// be parsed, are left in the query text to be matched as-is.
+func parseStatusQuery(queryText string) (*db.StatusSearchQuery, string) {
+	var (
+		query  = new(db.StatusSearchQuery)
+		from   string
+		text   = make([]string, 0, 4)
+		quoted bool
+	)
+
+	for _, word := range strings.Fields(queryText) {
+		if quoted || !parseOperator(query, &from, word) {
+			// Not an operator,
+			// keep as search text.
+			text = append(text, word)
+		}
+
+		if strings.Count(word, `"`)%2 == 1 {
+			// Word opens or closes
+			// a quoted phrase.
+			quoted = !quoted
+		}
+	}
+
+	query.Text = strings.Join(text, " ")
+	return query, from
+}
+
+// parseOperator tries to parse the given word as a search
+// operator, setting its value on query (or from), and
+// returning whether the word was a valid search operator.
+func parseOperator(query *db.StatusSearchQuery, from *string, word string) bool {
+	lower := strings.ToLower(word)
+
+	switch {
+	case strings.HasPrefix(lower, operatorFrom):
+		value := word[len(operatorFrom):]
+		if value == "" {
+			return false
+		}
+		*from = value
+		return true
+
+	case lower == operatorHas+"media":
+		query.HasMedia = true
+		return true
+
+	case strings.HasPrefix(lower, operatorBefore):
+		t, err := time.Parse(operatorDateLayout, word[len(operatorBefore):])
+		if err != nil {
+			return false
+		}
+
+		// Before the start of the given day.
+		query.Before = t
+		return true
+
+	case strings.HasPrefix(lower, operatorAfter):
+		t, err := time.Parse(operatorDateLayout, word[len(operatorAfter):])
+		if err != nil {
+			return false
+		}
+
+		// After the end of the given day.
+		query.After = t.Add(24*time.Hour - time.Nanosecond)
+		return true
+
+	default:
+		return false
+	}
+}
@@ -109,6 +109,15 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuoteID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -185,7 +194,19 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 		return errWithCode
 	}
 
-	if !*inReplyTo.Replyable {
+	// Check requester is permitted to reply
+	// by the in-reply-to interaction policy.
+	replyable, err := p.filter.StatusPolicyPermits(ctx,
+		requester,
+		inReplyTo,
+		inReplyTo.GetInteractionPolicy().CanReply,
+	)
+	if err != nil {
+		err := gtserror.Newf("error checking in-reply-to interaction policy: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !replyable {
 		const text = "in-reply-to status marked as not replyable"
 		return gtserror.NewErrorForbidden(errors.New(text), text)
 	}
@@ -199,6 +220,45 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quoteID string) gtserror.WithCode {
+	if quoteID == "" {
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quoteOf, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quoteID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quoteOf, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quoteOf,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if !quoteOf.IsQuotable(requester.ID) {
+		const text = "quoted status is not public or is marked as not quotable"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Set status fields from quoteOf.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfURI = quoteOf.URI
+	status.QuoteOfAccountID = quoteOf.AccountID
+	status.QuoteOfAccount = quoteOf.Account
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -267,7 +327,8 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+		if (attachment.StatusID != "" && attachment.StatusID != status.ID) ||
+			attachment.ScheduledStatusID != "" {
 			text := fmt.Sprintf("media %s already attached to status", mediaID)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
@@ -292,6 +353,7 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 	boostable := true
 	replyable := true
 	likeable := true
+	quotable := true
 
 	// If visibility isn't set on the form, then just take the account default.
 	// If that's also not set, take the default for the whole instance.
@@ -307,8 +369,11 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 
 	switch vis {
 	case gtsmodel.VisibilityPublic:
-		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out
-		break
+		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out,
+		// except for quotable, since being quoted is something authors may reasonably want to opt out of
+		if form.Quotable != nil {
+			quotable = *form.Quotable
+		}
 	case gtsmodel.VisibilityUnlocked:
 		// for unlocked the user can set any combination of flags they like so look at them all to see if they're set and then apply them
 		if form.Federated != nil {
@@ -327,6 +392,10 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 			likeable = *form.Likeable
 		}
 
+		if form.Quotable != nil {
+			quotable = *form.Quotable
+		}
+
 	case gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityMutualsOnly:
 		// for followers or mutuals only, boostable will *always* be false, but the other fields can be set so check and apply them
 		boostable = false
@@ -351,14 +420,76 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 		likeable = true
 	}
 
+	// Derive interaction policy from the advanced flags,
+	// then apply any interaction policy from the form,
+	// which takes precedence over the advanced flags.
+	policy := gtsmodel.InteractionPolicy{
+		CanReply: flagPolicyValue(replyable),
+		CanLike:  flagPolicyValue(likeable),
+	}
+
+	if vis == gtsmodel.VisibilityPublic ||
+		vis == gtsmodel.VisibilityUnlocked {
+		// Other visibilities are never boostable
+		// by anyone but the author, so there's no
+		// need to store that in the policy.
+		policy.CanBoost = flagPolicyValue(boostable)
+	}
+
+	if form.InteractionPolicy != nil && vis != gtsmodel.VisibilityDirect {
+		if v := form.InteractionPolicy.CanReply; v != "" {
+			policy.CanReply = typeutils.APIPolicyValueToPolicyValue(v)
+		}
+
+		if v := form.InteractionPolicy.CanLike; v != "" {
+			policy.CanLike = typeutils.APIPolicyValueToPolicyValue(v)
+		}
+
+		// Only public and unlisted statuses can be
+		// boosted by anyone but the author anyway.
+		if v := form.InteractionPolicy.CanBoost; v != "" && boostable {
+			policy.CanBoost = typeutils.APIPolicyValueToPolicyValue(v)
+		}
+	}
+
+	// Store "everyone" as the empty default.
+	if policy.CanReply == gtsmodel.PolicyValueEveryone {
+		policy.CanReply = ""
+	}
+
+	if policy.CanBoost == gtsmodel.PolicyValueEveryone {
+		policy.CanBoost = ""
+	}
+
+	if policy.CanLike == gtsmodel.PolicyValueEveryone {
+		policy.CanLike = ""
+	}
+
+	// Keep the advanced flags in
+	// step with the interaction policy.
+	boostable = boostable && policy.CanBoost != gtsmodel.PolicyValueNobody
+	replyable = policy.CanReply != gtsmodel.PolicyValueNobody
+	likeable = policy.CanLike != gtsmodel.PolicyValueNobody
+
 	status.Visibility = vis
 	status.Federated = &federated
 	status.Boostable = &boostable
 	status.Replyable = &replyable
 	status.Likeable = &likeable
+	status.Quotable = &quotable
+	status.InteractionPolicy = policy
 	return nil
 }
 
+// flagPolicyValue returns the interaction
+// policy value equivalent to an advanced flag.
+func flagPolicyValue(flag bool) gtsmodel.PolicyValue {
+	if flag {
+		return ""
+	}
+	return gtsmodel.PolicyValueNobody
+}
+
 func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
 	if form.Language != "" {
 		status.Language = form.Language
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusCreateTestSuite struct {
@@ -286,6 +287,148 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["admin_account_status_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this post",
+			QuoteID:     quoteOf.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Quoted status should be
+	// included in the response.
+	if suite.NotNil(apiStatus.Quote) {
+		suite.Equal(quoteOf.ID, apiStatus.Quote.ID)
+	}
+
+	dbStatus, dbErr := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.Equal(quoteOf.ID, dbStatus.QuoteOfID)
+	suite.Equal(quoteOf.URI, dbStatus.QuoteOfURI)
+	suite.Equal(quoteOf.AccountID, dbStatus.QuoteOfAccountID)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteNotQuotable() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := new(gtsmodel.Status)
+	*quoteOf = *suite.testStatuses["admin_account_status_1"]
+
+	// Author doesn't want this one quoted.
+	quoteOf.Quotable = util.Ptr(false)
+	if err := suite.state.DB.UpdateStatus(ctx, quoteOf, "quotable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this post",
+			QuoteID:     quoteOf.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "quoted status is not public or is marked as not quotable")
+	suite.Nil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteFollowersOnly() {
+	ctx := context.Background()
+
+	// local_account_1 follows local_account_2,
+	// so can see this one, but can't quote it.
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["local_account_2_status_7"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "look at this post",
+			QuoteID:     quoteOf.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "quoted status is not public or is marked as not quotable")
+	suite.Nil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessInteractionPolicy() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "only people i mention can reply to this",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			InteractionPolicy: &apimodel.InteractionPolicyRequest{
+				CanReply: apimodel.PolicyValueMentioned,
+				CanLike:  apimodel.PolicyValueFollowers,
+			},
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(&apimodel.InteractionPolicy{
+		CanReply: apimodel.PolicyValueMentioned,
+		CanBoost: apimodel.PolicyValueEveryone,
+		CanLike:  apimodel.PolicyValueFollowers,
+	}, apiStatus.InteractionPolicy)
+
+	// local_account_2 isn't mentioned,
+	// so shouldn't be able to reply.
+	replyForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "hey, let me in!",
+			InReplyToID: apiStatus.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiReply, err := suite.status.Create(ctx,
+		suite.testAccounts["local_account_2"],
+		suite.testApplications["application_1"],
+		replyForm,
+	)
+	suite.EqualError(err, "in-reply-to status marked as not replyable")
+	suite.Nil(apiReply)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
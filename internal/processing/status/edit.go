@@ -0,0 +1,343 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Edit processes the given form to edit an existing status owned by requester,
+// storing the previous version of the status as an edit in the status history.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	form *apimodel.StatusEditRequest,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
+) {
+	status, errWithCode := p.getOwnStatus(ctx, requester, statusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure status populated; we need the current
+	// attachments, mentions and poll for comparison.
+	if err := p.state.DB.PopulateStatus(ctx, status); err != nil {
+		err := gtserror.Newf("error populating status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+	status.Account = requester
+
+	// Snapshot the status as it currently is,
+	// before any of the edits are applied to it.
+	edit := statusToEdit(status)
+
+	// Wrap edit in a create form so we
+	// can reuse the status create logic.
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      form.Status,
+			MediaIDs:    form.MediaIDs,
+			Poll:        form.Poll,
+			Sensitive:   form.Sensitive,
+			SpoilerText: form.SpoilerText,
+			Language:    form.Language,
+			ContentType: form.ContentType,
+		},
+	}
+
+	// Take note of the old mentions so we
+	// can tidy up the ones no longer in use.
+	oldMentionIDs := status.MentionIDs
+	oldPoll := status.Poll
+
+	// Reset the fields that will be
+	// regenerated from the edit form.
+	status.Attachments = nil
+	status.AttachmentIDs = nil
+	status.Mentions = nil
+	status.MentionIDs = nil
+	status.Tags = nil
+	status.TagIDs = nil
+	status.Emojis = nil
+	status.EmojiIDs = nil
+	status.Text = form.Status
+	status.Sensitive = &form.Sensitive
+
+	if errWithCode := p.processMediaIDs(ctx, createForm, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+
+	// Set a candidate poll from the form; whether it
+	// actually replaces the old one gets decided once
+	// the option titles have been formatted below.
+	status.Poll = nil
+	status.PollID = ""
+	if form.Poll != nil {
+		secs := time.Duration(form.Poll.ExpiresIn)
+		status.Poll = &gtsmodel.Poll{
+			ID:         id.NewULID(),
+			Multiple:   &form.Poll.Multiple,
+			HideCounts: &form.Poll.HideTotals,
+			Options:    form.Poll.Options,
+			StatusID:   status.ID,
+			Status:     status,
+			ExpiresAt:  now.Add(secs * time.Second),
+		}
+		status.PollID = status.Poll.ID
+	}
+
+	if err := processLanguage(createForm, status.Language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.processContent(ctx, p.parseMention, createForm, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check whether the poll was changed by this edit.
+	if pollUnchanged(oldPoll, status.Poll) {
+		// Keep the old poll (and its votes!) as-is.
+		status.Poll = oldPoll
+		status.PollID = oldPoll.ID
+	} else {
+		if oldPoll != nil {
+			// Poll changed or removed, drop the old one.
+			if err := p.deletePoll(ctx, oldPoll.ID); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		if status.Poll != nil {
+			// Try to insert the new status poll in the database.
+			if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
+				err := gtserror.Newf("error inserting poll in db: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+	}
+
+	// Update the status AS type to reflect poll presence.
+	if status.Poll != nil {
+		status.ActivityStreamsType = ap.ActivityQuestion
+	} else {
+		status.ActivityStreamsType = ap.ObjectNote
+	}
+
+	// Mark the status as edited.
+	status.EditedAt = now
+
+	if err := p.state.DB.UpdateStatus(ctx, status,
+		"content",
+		"content_warning",
+		"text",
+		"language",
+		"sensitive",
+		"attachments",
+		"mentions",
+		"tags",
+		"emojis",
+		"poll_id",
+		"activity_streams_type",
+		"edited_at",
+	); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Store the previous version in the status history.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		err := gtserror.Newf("error inserting status edit in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Note that media no longer in use by the status is
+	// left attached to it, as the edit we just stored
+	// still references it; the media cleaner knows to
+	// keep it around for as long as the status exists.
+
+	// Delete any mentions no longer in use by the status.
+	for _, mentionID := range oldMentionIDs {
+		if slices.Contains(status.MentionIDs, mentionID) {
+			continue
+		}
+
+		if err := p.state.DB.DeleteMentionByID(ctx, mentionID); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error deleting mention %s: %v", mentionID, err)
+		}
+	}
+
+	// Send it to the client API worker for async side-effects.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		OriginAccount:  requester,
+	})
+
+	if status.Poll != nil && status.Poll != oldPoll {
+		// Now that the status is updated, and side effects queued,
+		// attempt to schedule an expiry handler for the new poll.
+		if err := p.polls.ScheduleExpiry(ctx, status.Poll); err != nil {
+			log.Errorf(ctx, "error scheduling poll expiry: %v", err)
+		}
+	}
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// HistoryGet gets the edit history of the target status, taking account of privacy settings and blocks etc.
+func (p *Processor) HistoryGet(ctx context.Context, requester *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	edits, err := p.converter.StatusToAPIEdits(ctx, targetStatus)
+	if err != nil {
+		err = gtserror.Newf("error converting status edits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return edits, nil
+}
+
+// SourceGet gets the plain-text source of a status owned by requester, for use when editing.
+func (p *Processor) SourceGet(ctx context.Context, requester *gtsmodel.Account, statusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	status, errWithCode := p.getOwnStatus(ctx, requester, statusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return &apimodel.StatusSource{
+		ID:          status.ID,
+		Text:        status.Text,
+		SpoilerText: text.SanitizeToPlaintext(status.ContentWarning),
+	}, nil
+}
+
+// getOwnStatus fetches the status with given ID,
+// ensuring that it was authored by requester and
+// is not a boost (boosts cannot be edited).
+func (p *Processor) getOwnStatus(ctx context.Context, requester *gtsmodel.Account, statusID string) (*gtsmodel.Status, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error fetching status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil || status.BoostOfID != "" {
+		const text = "target status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if status.AccountID != requester.ID {
+		const text = "status doesn't belong to requesting account"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	return status, nil
+}
+
+// deletePoll deletes the poll with given ID,
+// any votes in it, and its scheduled expiry.
+func (p *Processor) deletePoll(ctx context.Context, pollID string) error {
+	if err := p.state.DB.DeletePollByID(ctx, pollID); err != nil {
+		return gtserror.Newf("error deleting poll: %w", err)
+	}
+
+	if err := p.state.DB.DeletePollVotes(ctx, pollID); err != nil {
+		return gtserror.Newf("error deleting poll votes: %w", err)
+	}
+
+	// Cancel any scheduled expiry task for poll.
+	_ = p.state.Workers.Scheduler.Cancel(pollID)
+	return nil
+}
+
+// pollUnchanged returns whether the newly formatted
+// poll is the same as the old one, in which case the
+// old poll should be kept to preserve its votes.
+func pollUnchanged(old, new *gtsmodel.Poll) bool {
+	if old == nil || new == nil {
+		return false
+	}
+	return slices.Equal(old.Options, new.Options) &&
+		util.PtrValueOr(old.Multiple, false) ==
+			util.PtrValueOr(new.Multiple, false)
+}
+
+// statusToEdit returns a snapshot of the
+// current version of status as a StatusEdit.
+func statusToEdit(status *gtsmodel.Status) *gtsmodel.StatusEdit {
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      status.EditedAt,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		Language:       status.Language,
+		Sensitive:      util.Ptr(util.PtrValueOr(status.Sensitive, false)),
+		AttachmentIDs:  status.AttachmentIDs,
+		Attachments:    status.Attachments,
+		StatusID:       status.ID,
+	}
+
+	if edit.CreatedAt.IsZero() {
+		// Never edited before,
+		// so this is the original.
+		edit.CreatedAt = status.CreatedAt
+	}
+
+	if status.Poll != nil {
+		edit.PollOptions = status.Poll.Options
+		edit.PollVotes = status.Poll.Votes
+	}
+
+	return edit
+}
@@ -62,7 +62,19 @@ func (p *Processor) getFaveableStatus(
 		return nil, nil, errWithCode
 	}
 
-	if !*target.Likeable {
+	// Check requester is permitted to fave
+	// by the target status interaction policy.
+	likeable, err := p.filter.StatusPolicyPermits(ctx,
+		requester,
+		target,
+		target.GetInteractionPolicy().CanLike,
+	)
+	if err != nil {
+		err = gtserror.Newf("error checking status interaction policy: %w", err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !likeable {
 		err := errors.New("status is not faveable")
 		return nil, nil, gtserror.NewErrorForbidden(err, err.Error())
 	}
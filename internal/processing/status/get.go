@@ -19,12 +19,16 @@ package status
 
 import (
 	"context"
+	"errors"
 	"slices"
 	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 // Get gets the given status, taking account of privacy settings and blocks etc.
@@ -210,7 +214,76 @@ func TopoSort(apiStatuses []*apimodel.Status, targetAccountID string) {
 
 // ContextGet returns the context (previous and following posts) from the given status ID.
 func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.contextGet(ctx, requestingAccount, targetStatusID, p.converter.StatusToAPIStatus)
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	convert := func(ctx context.Context, status *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*apimodel.Status, error) {
+		return p.converter.StatusToAPIStatus(ctx, status, requestingAccount, statusfilter.FilterContextThread, filters)
+	}
+
+	apiContext, errWithCode := p.contextGet(ctx, requestingAccount, targetStatusID, convert)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Fetch any replies in the thread
+	// that we may be missing, async.
+	p.backfillReplies(ctx,
+		requestingAccount,
+		targetStatusID,
+		convert,
+	)
+
+	return apiContext, nil
+}
+
+// backfillReplies enqueues an asynchronous backfill of remote replies in the
+// thread of the given status. If any new replies were fetched, the status is
+// streamed to the requesting account as a status update, to indicate to clients
+// that they should reload its context.
+func (p *Processor) backfillReplies(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetStatusID string,
+	convert func(context.Context, *gtsmodel.Status, *gtsmodel.Account) (*apimodel.Status, error),
+) {
+	p.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+		targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %s: %v", targetStatusID, err)
+			return
+		}
+
+		fetched, err := p.federator.BackfillReplies(ctx,
+			requestingAccount.Username,
+			targetStatus,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error backfilling replies of %s: %v", targetStatus.URI, err)
+		}
+
+		if !fetched {
+			// No
+			// change.
+			return
+		}
+
+		apiStatus, err := convert(ctx, targetStatus, requestingAccount)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Status is hidden from requester
+			// by a filter; nothing to stream.
+			return
+		}
+		if err != nil {
+			log.Errorf(ctx, "error converting status %s: %v", targetStatus.URI, err)
+			return
+		}
+
+		p.stream.StatusUpdate(ctx, requestingAccount, apiStatus, stream.TimelineHome)
+	})
 }
 
 // WebContextGet is like ContextGet, but is explicitly
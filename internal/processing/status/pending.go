@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// PendingRepliesGet returns a page of replies to the requesting
+// account's statuses that are being held for its approval.
+func (p *Processor) PendingRepliesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	statuses, err := p.state.DB.GetPendingReplies(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting pending replies: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(statuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := statuses[count-1].ID
+	hi := statuses[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, status := range statuses {
+		apiStatus, errWithCode := p.c.GetAPIStatus(ctx, requester, status)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error converting pending reply %s: %v", status.ID, errWithCode)
+			continue
+		}
+		items = append(items, apiStatus)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/statuses/pending_replies",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// PendingReplyApprove approves the pending reply with the given ID,
+// making it visible as a normal reply to the requesting account's status.
+func (p *Processor) PendingReplyApprove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, errWithCode := p.getPendingReply(ctx, requester, statusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Mark the reply as approved.
+	status.PendingApproval = util.Ptr(false)
+	if err := p.state.DB.UpdateStatus(ctx, status, "pending_approval"); err != nil {
+		err := gtserror.Newf("error updating status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process side effects asynchronously.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       status,
+		OriginAccount:  requester,
+		TargetAccount:  status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// PendingReplyReject rejects the pending reply with the given ID,
+// removing it from this instance and informing the reply author.
+func (p *Processor) PendingReplyReject(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, errWithCode := p.getPendingReply(ctx, requester, statusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before the status is
+	// wiped, so it can be returned.
+	apiStatus, errWithCode := p.c.GetAPIStatus(ctx, requester, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Process side effects asynchronously.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       status,
+		OriginAccount:  requester,
+		TargetAccount:  status.Account,
+	})
+
+	return apiStatus, nil
+}
+
+// getPendingReply fetches the status with the given ID, ensuring
+// it is a reply to the requester that is awaiting their approval.
+func (p *Processor) getPendingReply(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+) (*gtsmodel.Status, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil ||
+		!status.IsPendingApproval() ||
+		status.InReplyToAccountID != requester.ID {
+		const text = "pending reply not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return status, nil
+}
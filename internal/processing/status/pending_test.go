@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusPendingTestSuite struct {
+	StatusStandardTestSuite
+}
+
+// pendingReply marks admin's reply to
+// local_account_1 as pending approval.
+func (suite *StatusPendingTestSuite) pendingReply(ctx context.Context) *gtsmodel.Status {
+	reply := new(gtsmodel.Status)
+	*reply = *suite.testStatuses["admin_account_status_3"]
+
+	reply.PendingApproval = util.Ptr(true)
+	if err := suite.state.DB.UpdateStatus(ctx, reply, "pending_approval"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return reply
+}
+
+func (suite *StatusPendingTestSuite) TestPendingRepliesGet() {
+	ctx := context.Background()
+	reply := suite.pendingReply(ctx)
+
+	resp, errWithCode := suite.status.PendingRepliesGet(ctx,
+		suite.testAccounts["local_account_1"],
+		&paging.Page{Limit: 20},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(resp.Items, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(reply.ID, resp.Items[0].(*apimodel.Status).ID)
+
+	// Nothing pending for anyone else.
+	resp, errWithCode = suite.status.PendingRepliesGet(ctx,
+		suite.testAccounts["local_account_2"],
+		&paging.Page{Limit: 20},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(resp.Items)
+}
+
+func (suite *StatusPendingTestSuite) TestPendingReplyApprove() {
+	ctx := context.Background()
+	reply := suite.pendingReply(ctx)
+
+	// Only the replied-to account can approve.
+	_, errWithCode := suite.status.PendingReplyApprove(ctx,
+		suite.testAccounts["local_account_2"],
+		reply.ID,
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	apiStatus, errWithCode := suite.status.PendingReplyApprove(ctx,
+		suite.testAccounts["local_account_1"],
+		reply.ID,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(reply.ID, apiStatus.ID)
+
+	dbStatus, err := suite.state.DB.GetStatusByID(ctx, reply.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbStatus.IsPendingApproval())
+
+	// Can't approve twice.
+	_, errWithCode = suite.status.PendingReplyApprove(ctx,
+		suite.testAccounts["local_account_1"],
+		reply.ID,
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestStatusPendingTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPendingTestSuite))
+}
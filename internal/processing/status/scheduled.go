@@ -0,0 +1,576 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// scheduledStatusMinDelay is the minimum amount of time in
+	// the future at which statuses can be scheduled to be posted.
+	scheduledStatusMinDelay = 5 * time.Minute
+
+	// scheduledStatusesMaxTotal is the maximum number
+	// of statuses one account may have scheduled at once.
+	scheduledStatusesMaxTotal = 300
+
+	// scheduledStatusesMaxDaily is the maximum number of
+	// statuses one account may have scheduled for a single day.
+	scheduledStatusesMaxDaily = 25
+)
+
+// ScheduledStatusCreate processes the given form to schedule a new status to be posted
+// at the form's scheduled_at time, returning the api model representation of the scheduled
+// status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledStatusCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.ScheduledStatus,
+	gtserror.WithCode,
+) {
+	scheduledAt, errWithCode := parseScheduledAt(form.ScheduledAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.checkScheduledLimits(ctx, requester, scheduledAt, nil); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Use a placeholder status to check the
+	// form using the same logic as creating
+	// a status, so that any errors with it
+	// are returned now rather than later.
+	status := &gtsmodel.Status{
+		Account:   requester,
+		AccountID: requester.ID,
+	}
+
+	if errWithCode := p.processInReplyTo(ctx,
+		requester,
+		status,
+		form.InReplyToID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuoteID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processVisibility(form, requester.Settings.Privacy, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:                id.NewULID(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		AccountID:         requester.ID,
+		Account:           requester,
+		ScheduledAt:       scheduledAt,
+		Text:              form.Status,
+		MediaIDs:          status.AttachmentIDs,
+		Sensitive:         &form.Sensitive,
+		SpoilerText:       form.SpoilerText,
+		Visibility:        status.Visibility,
+		InReplyToID:       status.InReplyToID,
+		QuoteID:           status.QuoteOfID,
+		Language:          form.Language,
+		ContentType:       string(form.ContentType),
+		Federated:         status.Federated,
+		Boostable:         status.Boostable,
+		Replyable:         status.Replyable,
+		Likeable:          status.Likeable,
+		Quotable:          status.Quotable,
+		InteractionPolicy: status.InteractionPolicy,
+		ApplicationID:     application.ID,
+		Application:       application,
+	}
+
+	if form.Poll != nil {
+		scheduled.PollOptions = form.Poll.Options
+		scheduled.PollExpiresIn = form.Poll.ExpiresIn
+		scheduled.PollMultiple = &form.Poll.Multiple
+		scheduled.PollHideTotals = &form.Poll.HideTotals
+	}
+
+	// Mark attachments as belonging to the scheduled
+	// status, so they can't be used by another status.
+	for _, attachment := range status.Attachments {
+		attachment.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			err := gtserror.Newf("error updating attachment: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+	scheduled.MediaAttachments = status.Attachments
+
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		err := gtserror.Newf("error inserting scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.scheduleStatus(ctx, scheduled); err != nil {
+		log.Errorf(ctx, "error scheduling status: %v", err)
+	}
+
+	return p.toAPIScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusesGet returns a page of statuses scheduled by requester.
+func (p *Processor) ScheduledStatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusesForAccount(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(scheduled)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := scheduled[count-1].ID
+	hi := scheduled[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, s := range scheduled {
+		apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, s)
+		if err != nil {
+			log.Errorf(ctx, "error converting scheduled status to api model: %v", err)
+			continue
+		}
+
+		items = append(items, apiScheduled)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledStatusGet returns the status with given ID scheduled by requester.
+func (p *Processor) ScheduledStatusGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.toAPIScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusUpdate updates the time at which the status with given ID
+// scheduled by requester will be posted, rescheduling it accordingly.
+func (p *Processor) ScheduledStatusUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+	form *apimodel.ScheduledStatusUpdateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := parseScheduledAt(form.ScheduledAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check limits at the new time, not
+	// counting the status being moved.
+	if errWithCode := p.checkScheduledLimits(ctx,
+		requester,
+		scheduledAt,
+		scheduled,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduled.ScheduledAt = scheduledAt
+	if err := p.state.DB.UpdateScheduledStatus(ctx, scheduled, "scheduled_at"); err != nil {
+		err := gtserror.Newf("error updating scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Cancel the existing task and
+	// reschedule at the updated time.
+	_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+	if err := p.scheduleStatus(ctx, scheduled); err != nil {
+		log.Errorf(ctx, "error rescheduling status: %v", err)
+	}
+
+	return p.toAPIScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusDelete cancels and deletes the status with given ID scheduled by requester.
+func (p *Processor) ScheduledStatusDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) gtserror.WithCode {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Cancel any scheduled task for status.
+	_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+
+	if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ScheduledStatusesScheduleAll schedules tasks to post every
+// scheduled status in the database when its time comes. Any
+// statuses that were due while the instance was not running
+// will be posted immediately. This should be called once on
+// startup, as scheduler tasks don't persist across restarts.
+func (p *Processor) ScheduledStatusesScheduleAll(ctx context.Context) error {
+	// Fetch all scheduled statuses from the database (barebones models are enough).
+	scheduled, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, s := range scheduled {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.scheduleStatus(ctx, s); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// scheduleStatus adds the given scheduled status to the
+// scheduler, to be posted at its scheduled time.
+func (p *Processor) scheduleStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduled.ID,
+		scheduled.ScheduledAt,
+		p.onScheduledAt(scheduled.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduled.ID)
+	}
+
+	atStr := scheduled.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s to be posted at '%s'", scheduled.ID, atStr)
+	return nil
+}
+
+// onScheduledAt returns a callback function to be used by the
+// scheduler when it's time to post the given scheduled status.
+func (p *Processor) onScheduledAt(scheduledID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of scheduled status from database.
+		scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting scheduled status %s from db: %v", scheduledID, err)
+			}
+			return
+		}
+
+		if scheduled.Account == nil || scheduled.Account.IsSuspended() {
+			// Author gone or suspended,
+			// nothing left to post as.
+			if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+				log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledID, err)
+			}
+			return
+		}
+
+		application := scheduled.Application
+		if application == nil {
+			// Application may have been removed
+			// since, just post without it.
+			application = &gtsmodel.Application{}
+		}
+
+		// Release the scheduled status' hold on its
+		// attachments, so they can be used by the
+		// status that's about to be created.
+		for _, attachment := range scheduled.MediaAttachments {
+			attachment.ScheduledStatusID = ""
+			if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+				log.Errorf(ctx, "error updating attachment %s: %v", attachment.ID, err)
+			}
+		}
+
+		if _, errWithCode := p.Create(ctx,
+			scheduled.Account,
+			application,
+			scheduledToCreateForm(scheduled),
+		); errWithCode != nil {
+			log.Errorf(ctx, "error posting scheduled status %s: %v", scheduledID, errWithCode)
+		}
+
+		// Whether posting succeeded or not, there's no
+		// sense in trying again, so remove from database.
+		if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledID); err != nil {
+			log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledID, err)
+		}
+	}
+}
+
+// parseScheduledAt parses the given scheduled_at time
+// string, checking that it's far enough in the future.
+func parseScheduledAt(scheduledAtStr string) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtStr)
+	if err != nil {
+		text := fmt.Sprintf("could not parse scheduled_at %s as ISO 8601 datetime", scheduledAtStr)
+		return time.Time{}, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if time.Until(scheduledAt) < scheduledStatusMinDelay {
+		text := fmt.Sprintf("scheduled_at must be at least %s in the future", scheduledStatusMinDelay)
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return scheduledAt, nil
+}
+
+// checkScheduledLimits checks that scheduling a status
+// for requester at the given time won't exceed their limits
+// for total and daily number of scheduled statuses. If moving
+// is set, it is the already scheduled status being moved to
+// the given time, and so is excluded from the counts.
+func (p *Processor) checkScheduledLimits(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledAt time.Time,
+	moving *gtsmodel.ScheduledStatus,
+) gtserror.WithCode {
+	total, err := p.state.DB.CountScheduledStatusesForAccount(ctx,
+		requester.ID,
+		time.Time{},
+		time.Time{},
+	)
+	if err != nil {
+		err := gtserror.Newf("error counting scheduled statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if moving != nil {
+		total--
+	}
+
+	if total >= scheduledStatusesMaxTotal {
+		text := fmt.Sprintf("total number of scheduled statuses cannot exceed %d", scheduledStatusesMaxTotal)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	dayStart := scheduledAt.UTC().Truncate(24 * time.Hour)
+	dayEnd := dayStart.Add(24 * time.Hour)
+	daily, err := p.state.DB.CountScheduledStatusesForAccount(ctx,
+		requester.ID,
+		dayStart,
+		dayEnd,
+	)
+	if err != nil {
+		err := gtserror.Newf("error counting scheduled statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if moving != nil &&
+		!moving.ScheduledAt.Before(dayStart) &&
+		moving.ScheduledAt.Before(dayEnd) {
+		// Status is being moved within
+		// the same day, don't count it.
+		daily--
+	}
+
+	if daily >= scheduledStatusesMaxDaily {
+		text := fmt.Sprintf("number of statuses scheduled for one day cannot exceed %d", scheduledStatusesMaxDaily)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return nil
+}
+
+// getOwnScheduledStatus fetches the scheduled status
+// with given ID, ensuring it was scheduled by requester.
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting scheduled status %s: %w", scheduledID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduled == nil || scheduled.AccountID != requester.ID {
+		const text = "scheduled status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return scheduled, nil
+}
+
+// deleteScheduledStatus deletes the given scheduled status,
+// releasing its hold on any attachments so they're either
+// available for use again, or cleaned up as unused media.
+func (p *Processor) deleteScheduledStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	for _, attachment := range scheduled.MediaAttachments {
+		attachment.ScheduledStatusID = ""
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			return gtserror.Newf("error updating attachment %s: %w", attachment.ID, err)
+		}
+	}
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		return gtserror.Newf("error deleting scheduled status: %w", err)
+	}
+
+	return nil
+}
+
+// toAPIScheduledStatus converts the given scheduled status to frontend
+// API model, returning an appropriate error with HTTP code on failure.
+func (p *Processor) toAPIScheduledStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiScheduled, nil
+}
+
+// scheduledToCreateForm converts the given scheduled
+// status back into the form it was submitted as, so
+// that it can be posted as a regular status.
+func scheduledToCreateForm(scheduled *gtsmodel.ScheduledStatus) *apimodel.AdvancedStatusCreateForm {
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      scheduled.Text,
+			MediaIDs:    scheduled.MediaIDs,
+			InReplyToID: scheduled.InReplyToID,
+			QuoteID:     scheduled.QuoteID,
+			Sensitive:   util.PtrValueOr(scheduled.Sensitive, false),
+			SpoilerText: scheduled.SpoilerText,
+			Language:    scheduled.Language,
+			ContentType: apimodel.StatusContentType(scheduled.ContentType),
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: scheduled.Federated,
+			Boostable: scheduled.Boostable,
+			Replyable: scheduled.Replyable,
+			Likeable:  scheduled.Likeable,
+			Quotable:  scheduled.Quotable,
+		},
+	}
+
+	if policy := scheduled.InteractionPolicy; !policy.IsZero() {
+		form.InteractionPolicy = &apimodel.InteractionPolicyRequest{
+			CanReply: apimodel.PolicyValue(policy.CanReply),
+			CanBoost: apimodel.PolicyValue(policy.CanBoost),
+			CanLike:  apimodel.PolicyValue(policy.CanLike),
+		}
+	}
+
+	// Convert visibility back to form value, taking
+	// care not to lose mutuals-only in translation.
+	switch scheduled.Visibility {
+	case gtsmodel.VisibilityPublic:
+		form.Visibility = apimodel.VisibilityPublic
+	case gtsmodel.VisibilityUnlocked:
+		form.Visibility = apimodel.VisibilityUnlisted
+	case gtsmodel.VisibilityFollowersOnly:
+		form.Visibility = apimodel.VisibilityPrivate
+	case gtsmodel.VisibilityMutualsOnly:
+		form.Visibility = apimodel.VisibilityMutualsOnly
+	case gtsmodel.VisibilityDirect:
+		form.Visibility = apimodel.VisibilityDirect
+	}
+
+	if len(scheduled.PollOptions) > 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduled.PollOptions,
+			ExpiresIn:  scheduled.PollExpiresIn,
+			Multiple:   util.PtrValueOr(scheduled.PollMultiple, false),
+			HideTotals: util.PtrValueOr(scheduled.PollHideTotals, false),
+		}
+	}
+
+	return form
+}
@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusTestSuite struct {
+	StatusStandardTestSuite
+}
+
+// putScheduled inserts a scheduled status for
+// account at the given time directly in the db.
+func (suite *ScheduledStatusTestSuite) putScheduled(account *gtsmodel.Account, at time.Time) *gtsmodel.ScheduledStatus {
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     account.ID,
+		ScheduledAt:   at,
+		Text:          "hello world",
+		Visibility:    gtsmodel.VisibilityPublic,
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+
+	if err := suite.db.PutScheduledStatus(context.Background(), scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return scheduled
+}
+
+func scheduledCreateForm(at time.Time) *apimodel.AdvancedStatusCreateForm {
+	return &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this one's for later",
+			Visibility:  apimodel.VisibilityPublic,
+			ScheduledAt: at.Format(time.RFC3339),
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateDailyLimit() {
+	var (
+		ctx         = context.Background()
+		account     = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		day         = time.Now().UTC().Truncate(24 * time.Hour).Add(72 * time.Hour)
+	)
+
+	// Fill the day up to one below the limit.
+	for i := 0; i < 24; i++ {
+		suite.putScheduled(account, day.Add(time.Duration(i)*time.Minute))
+	}
+
+	// The 25th status for the day is fine.
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, account, application, scheduledCreateForm(day.Add(12*time.Hour)))
+	suite.NoError(errWithCode)
+	suite.Equal("this one's for later", apiScheduled.Params.Text)
+
+	// The 26th is one too many.
+	_, errWithCode = suite.status.ScheduledStatusCreate(ctx, account, application, scheduledCreateForm(day.Add(13*time.Hour)))
+	suite.EqualError(errWithCode, "number of statuses scheduled for one day cannot exceed 25")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// But the next day is still free.
+	_, errWithCode = suite.status.ScheduledStatusCreate(ctx, account, application, scheduledCreateForm(day.Add(36*time.Hour)))
+	suite.NoError(errWithCode)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusUpdateDailyLimit() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		day     = time.Now().UTC().Truncate(24 * time.Hour).Add(72 * time.Hour)
+	)
+
+	// Fill the day right up to the limit.
+	var full []*gtsmodel.ScheduledStatus
+	for i := 0; i < 25; i++ {
+		full = append(full, suite.putScheduled(account, day.Add(time.Duration(i)*time.Minute)))
+	}
+
+	// Moving a status from another
+	// day into the full day must fail.
+	other := suite.putScheduled(account, day.Add(36*time.Hour))
+	_, errWithCode := suite.status.ScheduledStatusUpdate(ctx, account, other.ID, &apimodel.ScheduledStatusUpdateRequest{
+		ScheduledAt: day.Add(12 * time.Hour).Format(time.RFC3339),
+	})
+	suite.EqualError(errWithCode, "number of statuses scheduled for one day cannot exceed 25")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Status should not have been moved.
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, other.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbScheduled.ScheduledAt.Equal(other.ScheduledAt))
+
+	// Moving a status within the full day is fine.
+	newAt := day.Add(12 * time.Hour)
+	apiScheduled, errWithCode := suite.status.ScheduledStatusUpdate(ctx, account, full[0].ID, &apimodel.ScheduledStatusUpdateRequest{
+		ScheduledAt: newAt.Format(time.RFC3339),
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(full[0].ID, apiScheduled.ID)
+
+	dbScheduled, err = suite.db.GetScheduledStatusByID(ctx, full[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbScheduled.ScheduledAt.Equal(newAt))
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusFires() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	before, err := suite.db.CountAccountStatuses(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Put a status that's due very soon, and
+	// schedule everything as done on startup.
+	scheduled := suite.putScheduled(account, time.Now().Add(time.Second))
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Wait for the scheduled status to be
+	// posted and removed from the database.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+		return err != nil
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to be posted")
+	}
+
+	after, err := suite.db.CountAccountStatuses(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before+1, after)
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, account.ID, 1, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("hello world", statuses[0].Text)
+	suite.Equal(gtsmodel.VisibilityPublic, statuses[0].Visibility)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, &ScheduledStatusTestSuite{})
+}
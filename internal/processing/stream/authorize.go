@@ -26,37 +26,40 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-// Authorize returns an oauth2 token info in response to an access token query from the streaming API
-func (p *Processor) Authorize(ctx context.Context, accessToken string) (*gtsmodel.Account, gtserror.WithCode) {
+// Authorize returns the account and the space-separated scopes of the
+// given access token, in response to an access token query from the
+// streaming API. Checking the scopes is left up to the caller, since
+// the scope required differs depending on the stream(s) requested.
+func (p *Processor) Authorize(ctx context.Context, accessToken string) (*gtsmodel.Account, string, gtserror.WithCode) {
 	ti, err := p.oauthServer.LoadAccessToken(ctx, accessToken)
 	if err != nil {
 		err := fmt.Errorf("could not load access token: %s", err)
-		return nil, gtserror.NewErrorUnauthorized(err)
+		return nil, "", gtserror.NewErrorUnauthorized(err)
 	}
 
 	uid := ti.GetUserID()
 	if uid == "" {
 		err := fmt.Errorf("no userid in token")
-		return nil, gtserror.NewErrorUnauthorized(err)
+		return nil, "", gtserror.NewErrorUnauthorized(err)
 	}
 
 	user, err := p.state.DB.GetUserByID(ctx, uid)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			err := fmt.Errorf("no user found for validated uid %s", uid)
-			return nil, gtserror.NewErrorUnauthorized(err)
+			return nil, "", gtserror.NewErrorUnauthorized(err)
 		}
-		return nil, gtserror.NewErrorInternalError(err)
+		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
 	acct, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			err := fmt.Errorf("no account found for validated uid %s", uid)
-			return nil, gtserror.NewErrorUnauthorized(err)
+			return nil, "", gtserror.NewErrorUnauthorized(err)
 		}
-		return nil, gtserror.NewErrorInternalError(err)
+		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
-	return acct, nil
+	return acct, ti.GetScope(), nil
 }
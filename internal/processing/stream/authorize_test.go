@@ -30,15 +30,17 @@ type AuthorizeTestSuite struct {
 }
 
 func (suite *AuthorizeTestSuite) TestAuthorize() {
-	account1, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_1"].Access)
+	account1, scopes1, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_1"].Access)
 	suite.NoError(err)
 	suite.Equal(suite.testAccounts["local_account_1"].ID, account1.ID)
+	suite.Equal(suite.testTokens["local_account_1"].Scope, scopes1)
 
-	account2, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_2"].Access)
+	account2, scopes2, err := suite.streamProcessor.Authorize(context.Background(), suite.testTokens["local_account_2"].Access)
 	suite.NoError(err)
 	suite.Equal(suite.testAccounts["local_account_2"].ID, account2.ID)
+	suite.Equal(suite.testTokens["local_account_2"].Scope, scopes2)
 
-	noAccount, err := suite.streamProcessor.Authorize(context.Background(), "aaaaaaaaaaaaaaaaaaaaa!!")
+	noAccount, _, err := suite.streamProcessor.Authorize(context.Background(), "aaaaaaaaaaaaaaaaaaaaa!!")
 	suite.EqualError(err, "could not load access token: "+db.ErrNoEntries.Error())
 	suite.Nil(noAccount)
 }
@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// Follow makes the given account follow the tag with the given
+// name, creating the tag if necessary. Following an already
+// followed tag is a no-op.
+func (p *Processor) Follow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagName string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, tagNameNormal, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		// We didn't have a tag with
+		// this name yet, create one.
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: tagNameNormal,
+		}
+
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err = gtserror.Newf("db error putting new tag %s: %w", tagNameNormal, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	followedTag := &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	}
+
+	err := p.state.DB.PutFollowedTag(ctx, followedTag)
+	if err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		err = gtserror.Newf("db error following tag %s: %w", tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err == nil {
+		// Newly followed, so statuses with
+		// this tag now belong on home timeline.
+		p.invalidateHomeTimeline(ctx, account)
+	}
+
+	return p.apiTag(ctx, tag, true)
+}
+
+// Unfollow makes the given account unfollow the tag with
+// the given name. Unfollowing a tag that isn't followed
+// is a no-op.
+func (p *Processor) Unfollow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagName string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, tagNameNormal, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		// Can't follow a tag that
+		// doesn't exist, so stub it.
+		return p.apiTag(ctx, &gtsmodel.Tag{Name: tagNameNormal}, false)
+	}
+
+	following, err := p.state.DB.IsAccountFollowingTag(ctx, account.ID, tag.ID)
+	if err != nil {
+		err = gtserror.Newf("db error checking if account %s follows tag %s: %w", account.ID, tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if following {
+		if err := p.state.DB.DeleteFollowedTag(ctx, account.ID, tag.ID); err != nil {
+			err = gtserror.Newf("db error unfollowing tag %s: %w", tag.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Statuses with this tag may no
+		// longer belong on home timeline.
+		p.invalidateHomeTimeline(ctx, account)
+	}
+
+	return p.apiTag(ctx, tag, false)
+}
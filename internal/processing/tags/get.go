@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Get returns the tag with the given name,
+// and whether the given account follows it.
+//
+// Tags that aren't yet known to this instance
+// are returned as normal, since they could be
+// used, and followed, at any time.
+func (p *Processor) Get(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	tagName string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, tagNameNormal, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		// Nobody has used or followed
+		// this tag yet, so just stub it.
+		return p.apiTag(ctx, &gtsmodel.Tag{Name: tagNameNormal}, false)
+	}
+
+	following, err := p.state.DB.IsAccountFollowingTag(ctx, account.ID, tag.ID)
+	if err != nil {
+		err = gtserror.Newf("db error checking if account %s follows tag %s: %w", account.ID, tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiTag(ctx, tag, following)
+}
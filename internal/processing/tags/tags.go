@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getTag normalizes the given tag name and gets the
+// tag with that name from the db. If the tag doesn't
+// exist, nil is returned with no error, along with
+// the normalized name.
+func (p *Processor) getTag(ctx context.Context, tagName string) (*gtsmodel.Tag, string, gtserror.WithCode) {
+	// Normalize + validate tag name.
+	tagNameNormal, ok := text.NormalizeHashtag(tagName)
+	if !ok {
+		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", tagName)
+		return nil, "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, tagNameNormal)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Real db error.
+		err = gtserror.Newf("db error getting tag by name: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	if tag != nil && (!*tag.Useable || !*tag.Listable) {
+		// Obey mastodon API by returning 404 for this.
+		err := gtserror.Newf("tag %s not useable/listable on this instance", tag.Name)
+		return nil, "", gtserror.NewErrorNotFound(err)
+	}
+
+	return tag, tagNameNormal, nil
+}
+
+// apiTag converts the given tag to its API
+// representation, with the following flag set.
+func (p *Processor) apiTag(
+	ctx context.Context,
+	tag *gtsmodel.Tag,
+	following bool,
+) (*apimodel.Tag, gtserror.WithCode) {
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+	if err != nil {
+		err = gtserror.Newf("error converting tag %s to api model: %w", tag.Name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTag.Following = util.Ptr(following)
+	return &apiTag, nil
+}
+
+// invalidateHomeTimeline drops cached home timeline
+// visibility and contents for the given account, so
+// that changes to followed tags take effect immediately.
+func (p *Processor) invalidateHomeTimeline(ctx context.Context, account *gtsmodel.Account) {
+	p.state.Caches.Visibility.Invalidate("RequesterID", account.ID)
+
+	if err := p.state.Timelines.Home.RemoveTimeline(ctx, account.ID); err != nil {
+		log.Errorf(ctx, "error removing home timeline for account %s: %v", account.ID, err)
+	}
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// GetAll returns a page of the access tokens
+// authorized by the given user, newest first.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	user *gtsmodel.User,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tokens for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(tokens)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := tokens[count-1].ID
+	hi := tokens[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, token := range tokens {
+		apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			err = gtserror.Newf("error converting token %s to api: %w", token.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		items = append(items, apiToken)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/tokens",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// Get returns the access token with the given
+// ID, if it was authorized by the given user.
+func (p *Processor) Get(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user.ID, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err = gtserror.Newf("error converting token %s to api: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
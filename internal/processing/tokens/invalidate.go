@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Invalidate revokes the access token with the given ID,
+// if it was authorized by the given user, returning the
+// token as it was just before it was deleted.
+//
+// Revocation takes effect immediately: any further
+// requests using the token will be unauthorized.
+func (p *Processor) Invalidate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user.ID, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, as
+	// we can't do it after the fact.
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err = gtserror.Newf("error converting token %s to api: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deleteToken(ctx, token); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
+
+// InvalidateForApplication revokes all access tokens
+// that the given user has authorized for the application
+// with the given ID, returning the revoked tokens.
+func (p *Processor) InvalidateForApplication(
+	ctx context.Context,
+	user *gtsmodel.User,
+	appID string,
+) ([]*apimodel.TokenInfo, gtserror.WithCode) {
+	if appID == "" {
+		const text = "application_id must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	app, err := p.state.DB.GetApplicationByID(ctx, appID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting application %s: %w", appID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if app == nil {
+		err := gtserror.Newf("application %s not found", appID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	// Get all of the user's tokens, there
+	// won't be so many that paging is needed.
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tokens for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTokens := make([]*apimodel.TokenInfo, 0)
+	for _, token := range tokens {
+		if token.ClientID != app.ClientID {
+			// Belongs to another app.
+			continue
+		}
+
+		apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			err = gtserror.Newf("error converting token %s to api: %w", token.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.deleteToken(ctx, token); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+// deleteToken deletes the given token, along with
+// the Web Push subscription created with it, if any.
+func (p *Processor) deleteToken(ctx context.Context, token *gtsmodel.Token) error {
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error deleting push subscription for token %s: %w", token.ID, err)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		return gtserror.Newf("db error deleting token %s: %w", token.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/tokens"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokensTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testUsers        map[string]*gtsmodel.User
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+
+	tokens tokens.Processor
+}
+
+func (suite *TokensTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+
+	suite.tokens = tokens.New(&suite.state, typeutils.NewConverter(&suite.state))
+
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *TokensTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *TokensTestSuite) TestGetAll() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	resp, errWithCode := suite.tokens.GetAll(ctx, user, nil)
+	suite.NoError(errWithCode)
+
+	// Only the user's access token should be
+	// listed, not the pending authorization code.
+	suite.Len(resp.Items, 1)
+	tokenInfo := resp.Items[0].(*apimodel.TokenInfo)
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokenInfo.ID)
+	suite.Equal("really cool gts application", tokenInfo.Application.Name)
+}
+
+func (suite *TokensTestSuite) TestGetOtherUsersToken() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := suite.testTokens["local_account_2"]
+
+	_, errWithCode := suite.tokens.Get(ctx, user, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TokensTestSuite) TestInvalidate() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := suite.testTokens["local_account_1"]
+
+	// Load token into the cache first.
+	_, err := suite.db.GetTokenByAccess(ctx, token.Access)
+	suite.NoError(err)
+
+	tokenInfo, errWithCode := suite.tokens.Invalidate(ctx, user, token.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(token.ID, tokenInfo.ID)
+	suite.Equal(token.Scope, tokenInfo.Scope)
+
+	// Token should be gone straight away.
+	_, err = suite.db.GetTokenByAccess(ctx, token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Invalidating again should 404.
+	_, errWithCode = suite.tokens.Invalidate(ctx, user, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TokensTestSuite) TestInvalidateForApplication() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := suite.testTokens["local_account_1"]
+	app := suite.testApplications["application_1"]
+
+	tokenInfos, errWithCode := suite.tokens.InvalidateForApplication(ctx, user, app.ID)
+	suite.NoError(errWithCode)
+	suite.Len(tokenInfos, 1)
+	suite.Equal(token.ID, tokenInfos[0].ID)
+	suite.Equal(app.ID, tokenInfos[0].Application.ID)
+
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// The application's own client credentials token
+	// doesn't belong to the user, so should remain.
+	_, err = suite.db.GetTokenByID(ctx, suite.testTokens["local_account_1_client_application_token"].ID)
+	suite.NoError(err)
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensTestSuite))
+}
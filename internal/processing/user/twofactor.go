@@ -0,0 +1,411 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/qrcode"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// Number of backup codes generated at once.
+	twoFactorBackupCodes = 8
+
+	// Random bytes per backup code;
+	// 6 bytes gives 10 base32 chars.
+	twoFactorBackupCodeBytes = 6
+
+	// Scale in pixels of each
+	// module of the QR code image.
+	twoFactorQRCodeScale = 6
+
+	// Number of incorrect codes a user may
+	// enter in a row before being locked out.
+	twoFactorMaxFailures = 5
+
+	// How long a user is locked out for after
+	// too many incorrect codes, counted from
+	// the last incorrect code they entered.
+	twoFactorLockout = 15 * time.Minute
+)
+
+// backupCodeEncoding is the
+// encoding used for backup codes.
+var backupCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorStatusGet returns the second factor
+// authentication status of the given user.
+func (p *Processor) TwoFactorStatusGet(user *gtsmodel.User) *apimodel.TwoFactorStatus {
+	if !user.TwoFactorEnabled() {
+		return &apimodel.TwoFactorStatus{}
+	}
+
+	return &apimodel.TwoFactorStatus{
+		Enabled:              true,
+		EnabledAt:            util.FormatISO8601(user.TwoFactorEnabledAt),
+		BackupCodesRemaining: len(user.TwoFactorBackups),
+	}
+}
+
+// TwoFactorSetup generates and stores a new TOTP
+// secret for the given user, returning it along with
+// a QR code for scanning into an authenticator app.
+//
+// 2FA is not enabled until the secret has been
+// confirmed with a valid code via TwoFactorEnable.
+func (p *Processor) TwoFactorSetup(
+	ctx context.Context,
+	user *gtsmodel.User,
+) (*apimodel.TwoFactorSetup, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two-factor authentication is already enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	account := user.Account
+	if account == nil {
+		var err error
+		account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		err := gtserror.Newf("error generating secret: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Store the secret on the user, replacing
+	// any from a previous unfinished setup.
+	user.TwoFactorSecret = secret
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	uri := totp.URI(config.GetHost(), account.Username, secret)
+
+	code, err := qrcode.Encode(uri)
+	if err != nil {
+		err := gtserror.Newf("error encoding qr code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	png, err := code.PNG(twoFactorQRCodeScale)
+	if err != nil {
+		err := gtserror.Newf("error rendering qr code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorSetup{
+		Secret: secret,
+		URI:    uri,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// TwoFactorEnable confirms a TOTP secret previously generated by
+// TwoFactorSetup using the given code, and enables two-factor
+// authentication for the user. A fresh set of backup codes
+// is returned; these are not retrievable again later.
+func (p *Processor) TwoFactorEnable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const text = "two-factor authentication is already enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if user.TwoFactorSecret == "" {
+		const text = "two-factor authentication has not been set up; call setup first"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	step, ok := totp.Verify(user.TwoFactorSecret, code, time.Now(), 0)
+	if !ok {
+		const text = "code was incorrect"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	codes, hashes, err := newBackupCodes()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Store the step of the code used to
+	// enable 2FA, so it can't then be
+	// reused to sign in.
+	user.TwoFactorBackups = hashes
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorLastStep = step
+	user.TwoFactorFailures = 0
+	user.TwoFactorFailedAt = time.Time{}
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+		"two_factor_failures",
+		"two_factor_failed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorBackupCodes{BackupCodes: codes}, nil
+}
+
+// TwoFactorDisable disables two-factor authentication
+// for the given user, after checking their password.
+func (p *Processor) TwoFactorDisable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) gtserror.WithCode {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if !user.TwoFactorEnabled() {
+		const text = "two-factor authentication is not enabled"
+		return gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorLastStep = 0
+	user.TwoFactorFailures = 0
+	user.TwoFactorFailedAt = time.Time{}
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+		"two_factor_failures",
+		"two_factor_failed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// TwoFactorBackupCodesRegenerate replaces the given user's
+// backup codes with a fresh set, after checking their password.
+func (p *Processor) TwoFactorBackupCodesRegenerate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) (*apimodel.TwoFactorBackupCodes, gtserror.WithCode) {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if !user.TwoFactorEnabled() {
+		const text = "two-factor authentication is not enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	codes, hashes, err := newBackupCodes()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorBackups = hashes
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_backups",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorBackupCodes{BackupCodes: codes}, nil
+}
+
+// TwoFactorLocked returns whether the given user has entered
+// too many incorrect second factor codes in a row recently,
+// in which case TwoFactorCheck will reject all codes until
+// the lockout has expired.
+func (p *Processor) TwoFactorLocked(user *gtsmodel.User) bool {
+	return user.TwoFactorFailures >= twoFactorMaxFailures &&
+		time.Since(user.TwoFactorFailedAt) < twoFactorLockout
+}
+
+// TwoFactorCheck returns whether the given code is valid
+// for the user's second factor, either as a current TOTP
+// code that hasn't been used before, or as an unused
+// backup code. Backup codes are removed from the user
+// once they've been used.
+//
+// Incorrect codes are counted against the user, and
+// no code is valid while the user is locked out; see
+// TwoFactorLocked.
+func (p *Processor) TwoFactorCheck(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) (bool, error) {
+	if !user.TwoFactorEnabled() {
+		return false, gtserror.New("two-factor authentication is not enabled")
+	}
+
+	if p.TwoFactorLocked(user) {
+		// Don't even look.
+		return false, nil
+	}
+
+	if step, ok := totp.Verify(
+		user.TwoFactorSecret,
+		code,
+		time.Now(),
+		user.TwoFactorLastStep,
+	); ok {
+		// Match! Store the step so
+		// this code can't be reused.
+		user.TwoFactorLastStep = step
+		user.TwoFactorFailures = 0
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_last_step",
+			"two_factor_failures",
+		); err != nil {
+			return false, gtserror.Newf("db error updating user: %w", err)
+		}
+
+		return true, nil
+	}
+
+	if ok, err := p.useBackupCode(ctx, user, code); err != nil || ok {
+		return ok, err
+	}
+
+	// No match, count this failure against
+	// the user, starting the count again
+	// if the last failure was long ago.
+	if time.Since(user.TwoFactorFailedAt) >= twoFactorLockout {
+		user.TwoFactorFailures = 0
+	}
+	user.TwoFactorFailures++
+	user.TwoFactorFailedAt = time.Now()
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_failures",
+		"two_factor_failed_at",
+	); err != nil {
+		return false, gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return false, nil
+}
+
+// useBackupCode returns whether the given code matches
+// one of the user's unused backup codes, removing the
+// matched code from the user so it can't be reused.
+func (p *Processor) useBackupCode(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) (bool, error) {
+	// Normalize input the same way backup
+	// codes are formatted when generated.
+	code = strings.ToLower(code)
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+
+	if code == "" {
+		return false, nil
+	}
+
+	for i, hash := range user.TwoFactorBackups {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(code)) != nil {
+			continue
+		}
+
+		// Match! Drop this code so it can't be reused.
+		user.TwoFactorBackups = append(user.TwoFactorBackups[:i:i], user.TwoFactorBackups[i+1:]...)
+		user.TwoFactorFailures = 0
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_backups",
+			"two_factor_failures",
+		); err != nil {
+			return false, gtserror.Newf("db error updating user: %w", err)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// newBackupCodes generates a new set of backup
+// codes, returning them along with their hashes.
+func newBackupCodes() ([]string, []string, error) {
+	codes := make([]string, twoFactorBackupCodes)
+	hashes := make([]string, twoFactorBackupCodes)
+
+	for i := range codes {
+		b := make([]byte, twoFactorBackupCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, gtserror.Newf("error generating backup code: %w", err)
+		}
+
+		code := strings.ToLower(backupCodeEncoding.EncodeToString(b))
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, gtserror.Newf("error hashing backup code: %w", err)
+		}
+
+		codes[i] = code
+		hashes[i] = string(hash)
+	}
+
+	return codes, hashes, nil
+}
@@ -0,0 +1,269 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableDisable() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	suite.False(suite.user.TwoFactorStatusGet(user).Enabled)
+
+	setup, errWithCode := suite.user.TwoFactorSetup(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.NotEmpty(setup.Secret)
+	suite.Equal("otpauth://totp/localhost:8080:the_mighty_zork?algorithm=SHA1&digits=6&issuer=localhost%3A8080&period=30&secret="+setup.Secret, setup.URI)
+	suite.True(strings.HasPrefix(setup.QRCode, "data:image/png;base64,"))
+
+	// Secret is stored, but 2FA isn't enabled yet.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(setup.Secret, dbUser.TwoFactorSecret)
+	suite.False(dbUser.TwoFactorEnabled())
+
+	// Wrong code should be rejected.
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "000000x")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	backups, errWithCode := suite.user.TwoFactorEnable(ctx, user, code)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(backups.BackupCodes, 8)
+
+	status := suite.user.TwoFactorStatusGet(user)
+	suite.True(status.Enabled)
+	suite.Equal(8, status.BackupCodesRemaining)
+
+	// Setting up again while
+	// enabled should conflict.
+	_, errWithCode = suite.user.TwoFactorSetup(ctx, user)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Disabling needs the right password.
+	errWithCode = suite.user.TwoFactorDisable(ctx, user, "not the password")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	errWithCode = suite.user.TwoFactorDisable(ctx, user, "password")
+	suite.NoError(errWithCode)
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbUser.TwoFactorEnabled())
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.Empty(dbUser.TwoFactorBackups)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorCheck() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	setup, errWithCode := suite.user.TwoFactorSetup(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	backups, errWithCode := suite.user.TwoFactorEnable(ctx, user, code)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Code used to enable 2FA can't be reused.
+	valid, err := suite.user.TwoFactorCheck(ctx, user, code)
+	suite.NoError(err)
+	suite.False(valid)
+
+	// Next TOTP code is valid.
+	next, err := totp.Code(setup.Secret, time.Now().Add(totp.Period))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	valid, err = suite.user.TwoFactorCheck(ctx, user, next)
+	suite.NoError(err)
+	suite.True(valid)
+
+	// But only the once.
+	valid, err = suite.user.TwoFactorCheck(ctx, user, next)
+	suite.NoError(err)
+	suite.False(valid)
+
+	// Garbage is not.
+	valid, err = suite.user.TwoFactorCheck(ctx, user, "abcdefghij")
+	suite.NoError(err)
+	suite.False(valid)
+
+	// Backup code is valid,
+	// even when shouted.
+	backup := strings.ToUpper(backups.BackupCodes[3])
+	valid, err = suite.user.TwoFactorCheck(ctx, user, backup)
+	suite.NoError(err)
+	suite.True(valid)
+
+	// But only the once.
+	valid, err = suite.user.TwoFactorCheck(ctx, user, backup)
+	suite.NoError(err)
+	suite.False(valid)
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbUser.TwoFactorBackups, 7)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorLockout() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	setup, errWithCode := suite.user.TwoFactorSetup(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, errWithCode := suite.user.TwoFactorEnable(ctx, user, code); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Enter a wrong code five times.
+	for i := 0; i < 5; i++ {
+		suite.False(suite.user.TwoFactorLocked(user))
+
+		valid, err := suite.user.TwoFactorCheck(ctx, user, "abcdef")
+		suite.NoError(err)
+		suite.False(valid)
+	}
+
+	// Failures are stored on the user.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(5, dbUser.TwoFactorFailures)
+	suite.True(suite.user.TwoFactorLocked(dbUser))
+
+	// Now even a good code is rejected.
+	next, err := totp.Code(setup.Secret, time.Now().Add(totp.Period))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	valid, err := suite.user.TwoFactorCheck(ctx, dbUser, next)
+	suite.NoError(err)
+	suite.False(valid)
+
+	// Pretend the lockout has expired.
+	dbUser.TwoFactorFailedAt = time.Now().Add(-time.Hour)
+	suite.False(suite.user.TwoFactorLocked(dbUser))
+
+	// Good code is accepted
+	// and failures are reset.
+	valid, err = suite.user.TwoFactorCheck(ctx, dbUser, next)
+	suite.NoError(err)
+	suite.True(valid)
+	suite.Zero(dbUser.TwoFactorFailures)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorDisableResetsLockout() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	setup, errWithCode := suite.user.TwoFactorSetup(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, errWithCode := suite.user.TwoFactorEnable(ctx, user, code); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Get locked out.
+	for i := 0; i < 5; i++ {
+		if _, err := suite.user.TwoFactorCheck(ctx, user, "abcdef"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	suite.True(suite.user.TwoFactorLocked(user))
+
+	// Disabling 2FA should clear
+	// the incorrect code count.
+	if errWithCode := suite.user.TwoFactorDisable(ctx, user, "password"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbUser.TwoFactorFailures)
+	suite.True(dbUser.TwoFactorFailedAt.IsZero())
+	suite.False(suite.user.TwoFactorLocked(dbUser))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
@@ -19,10 +19,12 @@ package workers
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -170,9 +172,84 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	if _, err := f.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
 		return gtserror.Newf("error sending Create activity via outbox %s: %w", outboxIRI, err)
 	}
+
+	// Fan the Create out to any relays we're subscribed to.
+	if err := f.relayStatus(ctx, status, create); err != nil {
+		return gtserror.Newf("error relaying Create activity: %w", err)
+	}
+
 	return nil
 }
 
+// relayStatus delivers the given Create activity for
+// status to the inboxes of all accepted relays, if the
+// status is suitable for relaying. Only public top-level
+// posts (or self-replies) are relayed, to match the
+// behaviour of other relay-capable implementations.
+func (f *federate) relayStatus(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	create vocab.ActivityStreamsCreate,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic {
+		// Only relay
+		// public posts.
+		return nil
+	}
+
+	if status.InReplyToID != "" &&
+		status.InReplyToAccountID != status.AccountID {
+		// Don't relay replies
+		// to other accounts.
+		return nil
+	}
+
+	relays, err := f.state.DB.GetRelays(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	// Gather inboxes of accepted relays.
+	inboxes := make([]*url.URL, 0, len(relays))
+	for _, relay := range relays {
+		if !relay.Accepted() {
+			continue
+		}
+
+		inbox, err := parseURI(relay.InboxURI)
+		if err != nil {
+			return err
+		}
+
+		inboxes = append(inboxes, inbox)
+	}
+
+	if len(inboxes) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Serialize the Create to bytes.
+	m, err := ap.Serialize(create)
+	if err != nil {
+		return gtserror.Newf("error serializing Create: %w", err)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return gtserror.Newf("error marshaling Create: %w", err)
+	}
+
+	// Deliver from the status author's transport,
+	// since the Create is signed as coming from them.
+	tsport, err := f.TransportController().NewTransportForUsername(ctx, status.Account.Username)
+	if err != nil {
+		return gtserror.Newf("error getting transport for %s: %w", status.Account.Username, err)
+	}
+
+	return tsport.BatchDeliver(ctx, b, inboxes)
+}
+
 func (f *federate) CreatePollVote(ctx context.Context, poll *gtsmodel.Poll, vote *gtsmodel.PollVote) error {
 	// Extract status from poll.
 	status := poll.Status
@@ -677,6 +754,86 @@ func (f *federate) RejectFollow(ctx context.Context, follow *gtsmodel.Follow) er
 	return nil
 }
 
+// AcceptReply federates an Accept of the given pending
+// reply from the local account that was replied to,
+// to the remote account that authored the reply.
+func (f *federate) AcceptReply(ctx context.Context, status *gtsmodel.Status) error {
+	return f.respondReply(ctx, status, streams.NewActivityStreamsAccept())
+}
+
+// RejectReply federates a Reject of the given pending
+// reply from the local account that was replied to,
+// to the remote account that authored the reply.
+func (f *federate) RejectReply(ctx context.Context, status *gtsmodel.Status) error {
+	return f.respondReply(ctx, status, streams.NewActivityStreamsReject())
+}
+
+// respondReply fills in and sends the given Accept or
+// Reject activity in response to a pending reply.
+func (f *federate) respondReply(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	activity ap.Activityable,
+) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status: %w", err)
+	}
+
+	// Bail if reply author is ours:
+	// nothing needs to be federated.
+	if status.Account == nil || status.Account.IsLocal() {
+		return nil
+	}
+
+	// Bail if replied-to account isn't
+	// ours: we can't respond to a reply
+	// on another instance's behalf.
+	if status.InReplyToAccount == nil || status.InReplyToAccount.IsRemote() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(status.InReplyToAccount.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	actorIRI, err := parseURI(status.InReplyToAccount.URI)
+	if err != nil {
+		return err
+	}
+
+	replyIRI, err := parseURI(status.URI)
+	if err != nil {
+		return err
+	}
+
+	authorIRI, err := parseURI(status.Account.URI)
+	if err != nil {
+		return err
+	}
+
+	// Set the replied-to account as Actor,
+	// the reply as Object, and address the
+	// activity To the author of the reply.
+	ap.AppendActorIRIs(activity, actorIRI)
+	ap.AppendObjectIRIs(activity, replyIRI)
+	ap.AppendTo(activity, authorIRI)
+
+	// Send the activity via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, activity,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			activity, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) Like(ctx context.Context, fave *gtsmodel.StatusFave) error {
 	// Populate model.
 	if err := f.state.DB.PopulateStatusFave(ctx, fave); err != nil {
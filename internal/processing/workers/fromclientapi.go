@@ -48,15 +48,26 @@ type clientAPI struct {
 }
 
 func (p *Processor) EnqueueClientAPI(cctx context.Context, msgs ...messages.FromClientAPI) {
+	// Persist messages first (if enabled),
+	// so they aren't lost if we go down
+	// before they've been processed.
+	taskIDs := p.persistClientAPI(cctx, msgs)
+	p.enqueueClientAPI(cctx, taskIDs, msgs)
+}
+
+func (p *Processor) enqueueClientAPI(cctx context.Context, taskIDs []string, msgs []messages.FromClientAPI) {
 	_ = p.workers.ClientAPI.MustEnqueueCtx(cctx, func(wctx context.Context) {
 		// Copy caller ctx values to worker's.
 		wctx = gtscontext.WithValues(wctx, cctx)
 
 		// Process worker messages.
-		for _, msg := range msgs {
+		for i, msg := range msgs {
 			if err := p.ProcessFromClientAPI(wctx, msg); err != nil {
 				log.Errorf(wctx, "error processing client API message: %v", err)
 			}
+
+			// Processed, remove any persisted task.
+			p.deleteTask(wctx, taskIDs[i])
 		}
 	})
 }
@@ -136,20 +147,28 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg messages.From
 
 	// ACCEPT SOMETHING
 	case ap.ActivityAccept:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// ACCEPT FOLLOW (request)
 		case ap.ActivityFollow:
 			return p.clientAPI.AcceptFollow(ctx, cMsg)
+
+		// ACCEPT NOTE/STATUS (pending reply)
+		case ap.ObjectNote:
+			return p.clientAPI.AcceptReply(ctx, cMsg)
 		}
 
 	// REJECT SOMETHING
 	case ap.ActivityReject:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// REJECT FOLLOW (request)
 		case ap.ActivityFollow:
 			return p.clientAPI.RejectFollowRequest(ctx, cMsg)
+
+		// REJECT NOTE/STATUS (pending reply)
+		case ap.ObjectNote:
+			return p.clientAPI.RejectReply(ctx, cMsg)
 		}
 
 	// UNDO SOMETHING
@@ -311,6 +330,24 @@ func (p *clientAPI) CreateFollowReq(ctx context.Context, cMsg messages.FromClien
 		log.Errorf(ctx, "error federating follow request: %v", err)
 	}
 
+	if err := p.state.DB.PopulateFollowRequest(ctx, followRequest); err != nil {
+		return gtserror.Newf("error populating follow request: %w", err)
+	}
+
+	if followRequest.TargetAccount.IsRemote() {
+		// Newly followed remote account, enqueue a backfill
+		// of its latest statuses, so that its profile (and
+		// the follower's view of it) doesn't start out empty.
+		p.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+			if err := p.federate.BackfillAccount(ctx,
+				followRequest.Account.Username,
+				followRequest.TargetAccount,
+			); err != nil {
+				log.Errorf(ctx, "error backfilling followed account: %v", err)
+			}
+		})
+	}
+
 	return nil
 }
 
@@ -498,6 +535,49 @@ func (p *clientAPI) RejectFollowRequest(ctx context.Context, cMsg messages.FromC
 	return nil
 }
 
+func (p *clientAPI) AcceptReply(ctx context.Context, cMsg messages.FromClientAPI) error {
+	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", cMsg.GTSModel)
+	}
+
+	// Interaction counts changed on the replied status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, status.InReplyToID)
+
+	// Reply is now approved, so timeline
+	// it as if it had just been received.
+	if err := p.surface.timelineAndNotifyStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error timelining and notifying status: %v", err)
+	}
+
+	if err := p.federate.AcceptReply(ctx, status); err != nil {
+		log.Errorf(ctx, "error federating reply accept: %v", err)
+	}
+
+	return nil
+}
+
+func (p *clientAPI) RejectReply(ctx context.Context, cMsg messages.FromClientAPI) error {
+	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", cMsg.GTSModel)
+	}
+
+	// Federate the Reject before wiping,
+	// while we still have the full model.
+	if err := p.federate.RejectReply(ctx, status); err != nil {
+		log.Errorf(ctx, "error federating reply reject: %v", err)
+	}
+
+	// Remove our copy of the rejected reply.
+	if err := p.utilF.wipeStatus(ctx, status, true); err != nil {
+		log.Errorf(ctx, "error wiping status: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoFollow(ctx context.Context, cMsg messages.FromClientAPI) error {
 	follow, ok := cMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
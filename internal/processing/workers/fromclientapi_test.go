@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
@@ -150,6 +151,8 @@ func (suite *FromClientAPITestSuite) statusJSON(
 		ctx,
 		status,
 		requestingAccount,
+		statusfilter.FilterContextNone,
+		nil,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -244,7 +247,7 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotification() {
 		suite.FailNow("timed out waiting for new status notification")
 	}
 
-	apiNotif, err := suite.typeconverter.NotificationToAPINotification(ctx, notif)
+	apiNotif, err := suite.typeconverter.NotificationToAPINotification(ctx, notif, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -750,6 +753,192 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoostNoReblogs() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDirect() {
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		streams          = suite.openStreams(ctx, receivingAccount, nil)
+		directStream     = streams[stream.TimelineDirect]
+
+		// Admin account DMs a reply to turtle.
+		status = suite.newStatus(
+			ctx,
+			postingAccount,
+			gtsmodel.VisibilityDirect,
+			suite.testStatuses["local_account_2_status_1"],
+			nil,
+		)
+	)
+
+	// Process the new status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message in direct stream.
+	suite.checkStreamed(
+		directStream,
+		true,
+		"",
+		stream.EventTypeConversation,
+	)
+
+	// Turtle should now have an unread conversation with admin.
+	conversation, err := suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		status.ThreadID,
+		receivingAccount.ID,
+		[]string{postingAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, conversation.LastStatusID)
+	suite.False(*conversation.Read)
+
+	// Admin should have a read conversation with turtle.
+	conversation, err = suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		status.ThreadID,
+		postingAccount.ID,
+		[]string{receivingAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, conversation.LastStatusID)
+	suite.True(*conversation.Read)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFollowedTag() {
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		testTag          = suite.testTags["welcome"]
+		streams          = suite.openStreams(ctx, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+
+		// Admin account posts a new top-level
+		// status using a tag turtle follows.
+		// Turtle doesn't follow admin, so
+		// the status only reaches turtle's
+		// home timeline via the followed tag.
+		status = suite.newStatus(
+			ctx,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	status.TagIDs = []string{testTag.ID}
+	if err := suite.db.UpdateStatus(ctx, status, "tags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: receivingAccount.ID,
+		TagID:     testTag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusJSON := suite.statusJSON(
+		ctx,
+		status,
+		receivingAccount,
+	)
+
+	// Process the new status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message in home stream.
+	suite.checkStreamed(
+		homeStream,
+		true,
+		statusJSON,
+		stream.EventTypeUpdate,
+	)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFollowedTagUnlisted() {
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		testTag          = suite.testTags["welcome"]
+		streams          = suite.openStreams(ctx, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+
+		// Admin account posts an unlisted status
+		// using a tag turtle follows, which should
+		// not reach turtle's home timeline.
+		status = suite.newStatus(
+			ctx,
+			postingAccount,
+			gtsmodel.VisibilityUnlocked,
+			nil,
+			nil,
+		)
+	)
+
+	status.TagIDs = []string{testTag.ID}
+	if err := suite.db.UpdateStatus(ctx, status, "tags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: receivingAccount.ID,
+		TagID:     testTag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check no message in home stream.
+	suite.checkStreamed(
+		homeStream,
+		false,
+		"",
+		"",
+	)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	var (
 		ctx                  = context.Background()
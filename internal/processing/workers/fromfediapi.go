@@ -19,10 +19,12 @@ package workers
 
 import (
 	"context"
+	"errors"
 
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -47,15 +49,26 @@ type fediAPI struct {
 }
 
 func (p *Processor) EnqueueFediAPI(cctx context.Context, msgs ...messages.FromFediAPI) {
+	// Persist messages first (if enabled),
+	// so they aren't lost if we go down
+	// before they've been processed.
+	taskIDs := p.persistFediAPI(cctx, msgs)
+	p.enqueueFediAPI(cctx, taskIDs, msgs)
+}
+
+func (p *Processor) enqueueFediAPI(cctx context.Context, taskIDs []string, msgs []messages.FromFediAPI) {
 	_ = p.workers.Federator.MustEnqueueCtx(cctx, func(wctx context.Context) {
 		// Copy caller ctx values to worker's.
 		wctx = gtscontext.WithValues(wctx, cctx)
 
 		// Process worker messages.
-		for _, msg := range msgs {
+		for i, msg := range msgs {
 			if err := p.ProcessFromFediAPI(wctx, msg); err != nil {
 				log.Errorf(wctx, "error processing fedi API message: %v", err)
 			}
+
+			// Processed, remove any persisted task.
+			p.deleteTask(wctx, taskIDs[i])
 		}
 	})
 }
@@ -220,6 +233,16 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 		return nil
 	}
 
+	if status.IsPendingApproval() {
+		// Reply is held for approval by the
+		// replied-to account; only notify them,
+		// and don't timeline it until approved.
+		if err := p.surface.notifyPendingReply(ctx, status); err != nil {
+			log.Errorf(ctx, "error notifying pending reply: %v", err)
+		}
+		return nil
+	}
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status; uncache the
 		// prepared version from all timelines. The status dereferencer
@@ -242,7 +265,10 @@ func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg messages.FromFediAPI)
 	}
 
 	// Insert the new poll vote in the database.
-	if err := p.state.DB.PutPollVote(ctx, vote); err != nil {
+	// It may already exist if this message is
+	// being replayed from a persisted queue.
+	if err := p.state.DB.PutPollVote(ctx, vote); err != nil &&
+		!errors.Is(err, db.ErrAlreadyExists) {
 		return gtserror.Newf("error inserting poll vote in db: %w", err)
 	}
 
@@ -305,6 +331,16 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg messages.FromFediAPI
 		followRequest.AccountID,
 		followRequest.TargetAccountID,
 	)
+	if errors.Is(err, db.ErrNoEntries) {
+		// Follow request may already have been
+		// accepted, if this message is being
+		// replayed from a persisted queue.
+		follow, err = p.state.DB.GetFollow(
+			ctx,
+			followRequest.AccountID,
+			followRequest.TargetAccountID,
+		)
+	}
 	if err != nil {
 		return gtserror.Newf("error accepting follow request: %w", err)
 	}
@@ -0,0 +1,230 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// ReplayQueued re-enqueues all client API and federator messages
+// persisted by a previous run which were never processed, eg.,
+// because the instance was stopped or crashed before getting to
+// them. This should be called once at startup, after the worker
+// enqueue functions have been set, and before any new messages
+// are enqueued, otherwise they may be processed twice.
+//
+// Persisted messages are replayed even if persisted worker
+// queues have since been disabled, so none are lost.
+func (p *Processor) ReplayQueued(ctx context.Context) error {
+	tasks, err := p.state.DB.GetWorkerTasks(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting worker tasks: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	log.Infof(ctx, "replaying %d queued worker messages", len(tasks))
+
+	for _, task := range tasks {
+		if err := p.replayTask(ctx, task); err != nil {
+			// Task can't be replayed, drop it
+			// so we don't try again next time.
+			log.Errorf(ctx, "error replaying worker task %s: %v", task.ID, err)
+			p.deleteTask(ctx, task.ID)
+		}
+	}
+
+	return nil
+}
+
+// replayTask deserializes and re-enqueues the given
+// persisted task on the appropriate worker pool.
+func (p *Processor) replayTask(ctx context.Context, task *gtsmodel.WorkerTask) error {
+	taskIDs := []string{task.ID}
+
+	switch task.WorkerType {
+	case gtsmodel.WorkerTypeClientAPI:
+		var msg messages.FromClientAPI
+		if err := msg.Deserialize(task.TaskData); err != nil {
+			return err
+		}
+
+		var err error
+
+		// Refetch the message accounts,
+		// which were stored by ID only.
+		msg.OriginAccount, err = p.getTaskAccount(ctx, msg.OriginAccount)
+		if err != nil {
+			return err
+		}
+
+		msg.TargetAccount, err = p.getTaskAccount(ctx, msg.TargetAccount)
+		if err != nil {
+			return err
+		}
+
+		p.enqueueClientAPI(ctx, taskIDs, []messages.FromClientAPI{msg})
+		return nil
+
+	case gtsmodel.WorkerTypeFediAPI:
+		var msg messages.FromFediAPI
+		if err := msg.Deserialize(task.TaskData); err != nil {
+			return err
+		}
+
+		var err error
+
+		// Refetch the message accounts,
+		// which were stored by ID only.
+		msg.RequestingAccount, err = p.getTaskAccount(ctx, msg.RequestingAccount)
+		if err != nil {
+			return err
+		}
+
+		msg.ReceivingAccount, err = p.getTaskAccount(ctx, msg.ReceivingAccount)
+		if err != nil {
+			return err
+		}
+
+		p.enqueueFediAPI(ctx, taskIDs, []messages.FromFediAPI{msg})
+		return nil
+
+	default:
+		return gtserror.Newf("unknown worker type %d", task.WorkerType)
+	}
+}
+
+// getTaskAccount fetches the full model of a bare-bones
+// account deserialized from a persisted worker task.
+func (p *Processor) getTaskAccount(ctx context.Context, stub *gtsmodel.Account) (*gtsmodel.Account, error) {
+	if stub == nil {
+		return nil, nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, stub.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting account %s: %w", stub.ID, err)
+	}
+
+	return account, nil
+}
+
+// persistClientAPI persists the given client API messages as worker
+// tasks, if persisted worker queues are enabled. Returned are the IDs
+// of the tasks in order of msgs, empty where a msg wasn't persisted.
+func (p *Processor) persistClientAPI(ctx context.Context, msgs []messages.FromClientAPI) []string {
+	if !config.GetAdvancedPersistWorkerQueues() {
+		return make([]string, len(msgs))
+	}
+
+	data := make([][]byte, len(msgs))
+	for i := range msgs {
+		b, err := msgs[i].Serialize()
+		if err != nil {
+			log.Warnf(ctx, "not persisting client API message: %v", err)
+			continue
+		}
+		data[i] = b
+	}
+
+	return p.putTasks(ctx, gtsmodel.WorkerTypeClientAPI, data)
+}
+
+// persistFediAPI persists the given federator messages as worker
+// tasks, if persisted worker queues are enabled. Returned are the IDs
+// of the tasks in order of msgs, empty where a msg wasn't persisted.
+func (p *Processor) persistFediAPI(ctx context.Context, msgs []messages.FromFediAPI) []string {
+	if !config.GetAdvancedPersistWorkerQueues() {
+		return make([]string, len(msgs))
+	}
+
+	data := make([][]byte, len(msgs))
+	for i := range msgs {
+		b, err := msgs[i].Serialize()
+		if err != nil {
+			log.Warnf(ctx, "not persisting fedi API message: %v", err)
+			continue
+		}
+		data[i] = b
+	}
+
+	return p.putTasks(ctx, gtsmodel.WorkerTypeFediAPI, data)
+}
+
+// putTasks stores the given serialized messages as tasks
+// for the given worker type, returning the IDs of the tasks
+// in order of data, empty where nothing was stored.
+func (p *Processor) putTasks(ctx context.Context, workerType gtsmodel.WorkerType, data [][]byte) []string {
+	var (
+		taskIDs = make([]string, len(data))
+		tasks   = make([]*gtsmodel.WorkerTask, 0, len(data))
+		now     = time.Now()
+	)
+
+	for i, b := range data {
+		if b == nil {
+			// Wasn't serialized.
+			continue
+		}
+
+		task := &gtsmodel.WorkerTask{
+			ID:         id.NewULID(),
+			CreatedAt:  now,
+			WorkerType: workerType,
+			TaskData:   b,
+		}
+
+		taskIDs[i] = task.ID
+		tasks = append(tasks, task)
+	}
+
+	if err := p.state.DB.PutWorkerTasks(ctx, tasks...); err != nil {
+		// Messages are still processed from memory,
+		// they just won't survive a restart.
+		log.Errorf(ctx, "db error persisting %s worker tasks: %v", workerType, err)
+		return make([]string, len(data))
+	}
+
+	return taskIDs
+}
+
+// deleteTask removes the persisted worker task with
+// given ID, if any, once it no longer needs processing.
+func (p *Processor) deleteTask(ctx context.Context, taskID string) {
+	if taskID == "" {
+		// Not persisted.
+		return
+	}
+
+	if err := p.state.DB.DeleteWorkerTaskByID(ctx, taskID); err != nil {
+		log.Errorf(ctx, "db error deleting worker task %s: %v", taskID, err)
+	}
+}
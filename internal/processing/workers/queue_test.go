@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type QueueTestSuite struct {
+	WorkersTestSuite
+}
+
+// newFaveMsg returns a fedi API message for a new
+// fave by remote_account_1 of a local status, with
+// the fave already stored in the database.
+func (suite *QueueTestSuite) newFaveMsg() messages.FromFediAPI {
+	var (
+		favedAccount  = suite.testAccounts["local_account_1"]
+		favedStatus   = suite.testStatuses["local_account_1_status_1"]
+		favingAccount = suite.testAccounts["remote_account_1"]
+	)
+
+	fave := &gtsmodel.StatusFave{
+		ID:              id.NewULID(),
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: favedAccount.ID,
+		TargetAccount:   favedAccount,
+		StatusID:        favedStatus.ID,
+		Status:          favedStatus,
+		URI:             favingAccount.URI + "/faves/" + id.NewULID(),
+	}
+
+	if err := suite.db.Put(context.Background(), fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return messages.FromFediAPI{
+		APObjectType:      ap.ActivityLike,
+		APActivityType:    ap.ActivityCreate,
+		GTSModel:          fave,
+		RequestingAccount: favingAccount,
+		ReceivingAccount:  favedAccount,
+	}
+}
+
+// waitForFaveNotif waits for the fave
+// in msg to have been notified.
+func (suite *QueueTestSuite) waitForFaveNotif(msg messages.FromFediAPI) {
+	fave := msg.GTSModel.(*gtsmodel.StatusFave)
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetNotification(
+			context.Background(),
+			gtsmodel.NotificationFave,
+			fave.TargetAccountID,
+			fave.AccountID,
+			fave.StatusID,
+		)
+		return err == nil
+	}) {
+		suite.FailNow("timed out waiting for fave notification")
+	}
+}
+
+// waitForNoTasks waits for all
+// persisted tasks to be removed.
+func (suite *QueueTestSuite) waitForNoTasks() {
+	if !testrig.WaitFor(func() bool {
+		tasks, err := suite.db.GetWorkerTasks(context.Background())
+		return err == nil && len(tasks) == 0
+	}) {
+		suite.FailNow("timed out waiting for worker tasks to be removed")
+	}
+}
+
+func (suite *QueueTestSuite) TestEnqueuePersisted() {
+	config.SetAdvancedPersistWorkerQueues(true)
+	defer config.SetAdvancedPersistWorkerQueues(false)
+
+	msg := suite.newFaveMsg()
+	suite.processor.Workers().EnqueueFediAPI(context.Background(), msg)
+
+	// Message should be processed,
+	// and its task removed after.
+	suite.waitForFaveNotif(msg)
+	suite.waitForNoTasks()
+}
+
+func (suite *QueueTestSuite) TestReplayQueued() {
+	ctx := context.Background()
+	msg := suite.newFaveMsg()
+
+	// Persist the message as though it had
+	// been left over from a previous run.
+	data, err := msg.Serialize()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutWorkerTasks(ctx, &gtsmodel.WorkerTask{
+		ID:         id.NewULID(),
+		CreatedAt:  time.Now(),
+		WorkerType: gtsmodel.WorkerTypeFediAPI,
+		TaskData:   data,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Also persist a task that can't be
+	// deserialized, which should be dropped.
+	if err := suite.db.PutWorkerTasks(ctx, &gtsmodel.WorkerTask{
+		ID:         id.NewULID(),
+		CreatedAt:  time.Now(),
+		WorkerType: gtsmodel.WorkerTypeClientAPI,
+		TaskData:   []byte(`{"gts_model_type":"Nonsense"}`),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ReplayQueued(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.waitForFaveNotif(msg)
+	suite.waitForNoTasks()
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
@@ -22,10 +22,12 @@ import (
 	"errors"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 // notifyMentions iterates through mentions on the
@@ -283,6 +285,39 @@ func (s *surface) notifyAnnounce(
 	return nil
 }
 
+// notifyPendingReply notifies the account replied
+// to by the given status that the reply is being
+// held for their approval before it is shown.
+func (s *surface) notifyPendingReply(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.state.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	if status.InReplyToAccount == nil ||
+		status.InReplyToAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// notify replied-to account
+	// of pending reply by account.
+	if err := s.notify(ctx,
+		gtsmodel.NotificationPendingReply,
+		status.InReplyToAccount,
+		status.Account,
+		status.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying replied-to account %s: %w", status.InReplyToAccountID, err)
+	}
+
+	return nil
+}
+
 func (s *surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status) error {
 	// Beforehand, ensure the passed status is fully populated.
 	if err := s.state.DB.PopulateStatus(ctx, status); err != nil {
@@ -333,8 +368,8 @@ func (s *surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 	return errs.Combine()
 }
 
-// notify creates, inserts, and streams a new
-// notification to the target account if it
+// notify creates, inserts, streams, and pushes
+// a new notification to the target account if it
 // doesn't yet exist with the given parameters.
 //
 // It filters out non-local target accounts, so
@@ -389,12 +424,28 @@ func (s *surface) notify(
 		return gtserror.Newf("error putting notification in database: %w", err)
 	}
 
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", targetAccount.ID, err)
+	}
+
 	// Stream notification to the user.
-	apiNotif, err := s.converter.NotificationToAPINotification(ctx, notif)
+	apiNotif, err := s.converter.NotificationToAPINotification(ctx, notif, filters)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Don't stream notifications
+		// for hidden statuses.
+		return nil
+	}
 	if err != nil {
 		return gtserror.Newf("error converting notification to api representation: %w", err)
 	}
 	s.stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Queue push of notification to the
+	// user's Web Push subscriptions, if any.
+	if err := s.webPushSender.Send(ctx, notif, apiNotif); err != nil {
+		log.Errorf(ctx, "error sending web push notification %s: %v", notif.ID, err)
+	}
+
 	return nil
 }
@@ -20,14 +20,18 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // timelineAndNotifyStatus inserts the given status into the HOME
@@ -66,14 +70,162 @@ func (s *surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Timeline the status for each local account following one
+	// of its tags, who didn't already get it as an author follower.
+	if err := s.timelineStatusForTagFollowers(ctx, status, follows); err != nil {
+		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Update conversations of local accounts taking part in this status, if it's a DM.
+	if err := s.updateConversationsForStatus(ctx, status); err != nil {
+		return gtserror.Newf("error updating conversations for status %s: %w", status.ID, err)
+	}
+
 	return nil
 }
 
+// updateConversationsForStatus updates the conversation of each
+// local account participating in the given status (the author,
+// and mentioned accounts), creating conversations as necessary,
+// and streams the updated conversations to those accounts.
+//
+// This is a no-op for statuses that are not direct messages.
+func (s *surface) updateConversationsForStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect {
+		// Only DMs are part of conversations.
+		return nil
+	}
+
+	if status.BoostOfID != "" {
+		// Boosts of DMs aren't a thing,
+		// but check anyway to be sure.
+		return nil
+	}
+
+	if status.ThreadID == "" {
+		// Unthreaded status, this means no
+		// local account is involved at all.
+		return nil
+	}
+
+	// Gather IDs of all participants in
+	// this status, and which are local.
+	participantIDs := make([]string, 0, 1+len(status.Mentions))
+	localParticipants := make([]*gtsmodel.Account, 0, 1+len(status.Mentions))
+
+	addParticipant := func(account *gtsmodel.Account) {
+		if slices.Contains(participantIDs, account.ID) {
+			// Already added.
+			return
+		}
+
+		participantIDs = append(participantIDs, account.ID)
+		if account.IsLocal() {
+			localParticipants = append(localParticipants, account)
+		}
+	}
+
+	var errs gtserror.MultiError
+
+	addParticipant(status.Account)
+	for _, mention := range status.Mentions {
+		// Set status on the mention (stops
+		// the below function populating it).
+		mention.Status = status
+
+		// Ensure the mention target is populated.
+		if err := s.state.DB.PopulateMention(ctx, mention); err != nil {
+			errs.Appendf("error populating mention %s: %w", mention.ID, err)
+			continue
+		}
+		addParticipant(mention.TargetAccount)
+	}
+
+	for _, localAccount := range localParticipants {
+		// Make sure this account can see the
+		// status, eg., it hasn't blocked the author.
+		visible, err := s.filter.StatusVisible(ctx, localAccount, status)
+		if err != nil {
+			errs.Appendf("error checking status %s visibility for account %s: %w", status.ID, localAccount.ID, err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		// Other participants are everyone
+		// in the status except this account.
+		otherAccountIDs := slices.DeleteFunc(
+			slices.Clone(participantIDs),
+			func(id string) bool { return id == localAccount.ID },
+		)
+
+		// Look for an existing conversation
+		// for this thread and participants.
+		conversation, err := s.state.DB.GetConversationByThreadAndAccountIDs(
+			gtscontext.SetBarebones(ctx),
+			status.ThreadID,
+			localAccount.ID,
+			otherAccountIDs,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error getting conversation for account %s: %w", localAccount.ID, err)
+			continue
+		}
+
+		if conversation == nil {
+			// No conversation yet, create one.
+			conversation = &gtsmodel.Conversation{
+				ID:              id.NewULID(),
+				AccountID:       localAccount.ID,
+				OtherAccountIDs: otherAccountIDs,
+				ThreadID:        status.ThreadID,
+			}
+		} else if conversation.LastStatusID > status.ID {
+			// Conversation already has a newer
+			// status (eg., this is an older one
+			// being dereferenced), just link it.
+			if err := s.state.DB.LinkConversationToStatus(ctx, conversation.ID, status.ID); err != nil {
+				errs.Appendf("error linking conversation %s to status %s: %w", conversation.ID, status.ID, err)
+			}
+			continue
+		}
+
+		// Update conversation with the new
+		// last status; it's read already if
+		// this account wrote the status.
+		conversation.LastStatusID = status.ID
+		conversation.LastStatus = status
+		conversation.Read = util.Ptr(localAccount.ID == status.AccountID)
+
+		if err := s.state.DB.UpsertConversation(ctx, conversation); err != nil {
+			errs.Appendf("error upserting conversation for account %s: %w", localAccount.ID, err)
+			continue
+		}
+
+		if err := s.state.DB.LinkConversationToStatus(ctx, conversation.ID, status.ID); err != nil {
+			errs.Appendf("error linking conversation %s to status %s: %w", conversation.ID, status.ID, err)
+			continue
+		}
+
+		// Stream the updated conversation to the account.
+		apiConversation, err := s.converter.ConversationToAPIConversation(ctx, conversation)
+		if err != nil {
+			errs.Appendf("error converting conversation %s to frontend representation: %w", conversation.ID, err)
+			continue
+		}
+		s.stream.Conversation(ctx, localAccount, apiConversation)
+	}
+
+	return errs.Combine()
+}
+
 // timelineAndNotifyStatusForFollowers iterates through the given
 // slice of followers of the account that posted the given status,
 // adding the status to list timelines + home timelines of each
@@ -173,6 +325,84 @@ func (s *surface) timelineAndNotifyStatusForFollowers(
 	return errs.Combine()
 }
 
+// timelineStatusForTagFollowers inserts the given status into
+// the HOME timelines of local accounts that follow any of the
+// tags used in the status, skipping accounts that follow the
+// author, since they'll have been handled already via follows.
+//
+// Only public, original (ie., not boosted) statuses are
+// timelined for tag followers.
+func (s *surface) timelineStatusForTagFollowers(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	follows []*gtsmodel.Follow,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" ||
+		len(status.TagIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Get all accounts following any of this status's tags.
+	accountIDs, err := s.state.DB.GetAccountIDsFollowingTagIDs(ctx, status.TagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting tag followers for status %s: %w", status.ID, err)
+	}
+
+	// Drop accounts that already had the
+	// status timelined via their follow.
+	accountIDs = slices.DeleteFunc(accountIDs, func(accountID string) bool {
+		return slices.ContainsFunc(follows, func(follow *gtsmodel.Follow) bool {
+			return follow.AccountID == accountID
+		})
+	})
+
+	var errs gtserror.MultiError
+
+	for _, accountID := range accountIDs {
+		account, err := s.state.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			errs.Appendf("error getting tag follower account %s: %w", accountID, err)
+			continue
+		}
+
+		if !account.IsLocal() {
+			// Only local accounts
+			// have home timelines.
+			continue
+		}
+
+		// Check to see if the status is timelineable for this account,
+		// which will also check for blocks between account and author.
+		timelineable, err := s.filter.StatusHomeTimelineable(
+			ctx, account, status,
+		)
+		if err != nil {
+			errs.Appendf("error checking status %s hometimelineability: %w", status.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			// Nothing to do.
+			continue
+		}
+
+		if _, err := s.timelineStatus(
+			ctx,
+			s.state.Timelines.Home.IngestOne,
+			account.ID, // home timelines are keyed by account ID
+			account,
+			status,
+			stream.TimelineHome,
+		); err != nil {
+			errs.Appendf("error home timelining status for tag follower: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
 // listTimelineStatusForFollow puts the given status
 // in any eligible lists owned by the given follower.
 func (s *surface) listTimelineStatusForFollow(
@@ -342,8 +572,20 @@ func (s *surface) timelineStatus(
 		return false, nil
 	}
 
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+		return true, err
+	}
+
 	// The status was inserted so stream it to the user.
-	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account)
+	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account, statusfilter.FilterContextHome, filters)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Don't stream hidden statuses; they'll
+		// be dropped from the timeline when it's
+		// next prepared for the user.
+		return true, nil
+	}
 	if err != nil {
 		err = gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
 		return true, err
@@ -362,6 +604,9 @@ func (s *surface) deleteStatusFromTimelines(ctx context.Context, statusID string
 	if err := s.state.Timelines.List.WipeItemFromAllTimelines(ctx, statusID); err != nil {
 		return err
 	}
+	if err := s.state.DB.DeleteStatusFromConversations(ctx, statusID); err != nil {
+		return err
+	}
 	s.stream.Delete(ctx, statusID)
 	return nil
 }
@@ -545,7 +790,17 @@ func (s *surface) timelineStreamStatusUpdate(
 	status *gtsmodel.Status,
 	streamType string,
 ) error {
-	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account)
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+		return err
+	}
+
+	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account, statusfilter.FilterContextHome, filters)
+	if errors.Is(err, statusfilter.ErrHideStatus) {
+		// Don't stream updates of hidden statuses.
+		return nil
+	}
 	if err != nil {
 		err = gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
 		return err
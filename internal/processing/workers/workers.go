@@ -26,10 +26,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
 type Processor struct {
+	state     *state.State
 	workers   *workers.Workers
 	clientAPI *clientAPI
 	fediAPI   *fediAPI
@@ -41,6 +43,7 @@ func New(
 	converter *typeutils.Converter,
 	filter *visibility.Filter,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
 	account *account.Processor,
 	media *media.Processor,
 	stream *stream.Processor,
@@ -48,11 +51,12 @@ func New(
 	// Init surface logic
 	// wrapper struct.
 	surface := &surface{
-		state:       state,
-		converter:   converter,
-		stream:      stream,
-		filter:      filter,
-		emailSender: emailSender,
+		state:         state,
+		converter:     converter,
+		stream:        stream,
+		filter:        filter,
+		emailSender:   emailSender,
+		webPushSender: webPushSender,
 	}
 
 	// Init federate logic
@@ -72,6 +76,7 @@ func New(
 	}
 
 	return Processor{
+		state:   state,
 		workers: &state.Workers,
 		clientAPI: &clientAPI{
 			state:     state,
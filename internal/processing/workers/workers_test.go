@@ -124,7 +124,7 @@ func (suite *WorkersTestSuite) SetupTest() {
 	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", nil)
 
-	suite.processor = processing.NewProcessor(cleaner.New(&suite.state), suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, &suite.state, suite.emailSender)
+	suite.processor = processing.NewProcessor(cleaner.New(&suite.state), suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaManager, &suite.state, suite.emailSender, testrig.NewWebPushSender(nil))
 	testrig.StartWorkers(&suite.state, suite.processor.Workers())
 
 	suite.state.Workers.EnqueueClientAPI = suite.processor.Workers().EnqueueClientAPI
@@ -147,6 +147,7 @@ func (suite *WorkersTestSuite) openStreams(ctx context.Context, account *gtsmode
 		stream.TimelineHome,
 		stream.TimelinePublic,
 		stream.TimelineNotifications,
+		stream.TimelineDirect,
 	} {
 		stream, err := suite.processor.Stream().Open(ctx, account, streamType)
 		if err != nil {
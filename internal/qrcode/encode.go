@@ -0,0 +1,211 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package qrcode
+
+// version describes the error correction block
+// structure of one symbol version at level M.
+type version struct {
+	ecPerBlock int   // EC codewords per block.
+	blocks1    int   // Number of blocks in group 1.
+	data1      int   // Data codewords per group 1 block.
+	blocks2    int   // Number of blocks in group 2 (data1+1 codewords each).
+	alignment  []int // Alignment pattern centre coordinates.
+}
+
+// dataCodewords returns the total number
+// of data codewords this version holds.
+func (v version) dataCodewords() int {
+	return v.blocks1*v.data1 + v.blocks2*(v.data1+1)
+}
+
+// versions holds block structure for level M, indexed by version number.
+var versions = [...]version{
+	{},
+	{10, 1, 16, 0, nil},
+	{16, 1, 28, 0, []int{6, 18}},
+	{26, 1, 44, 0, []int{6, 22}},
+	{18, 2, 32, 0, []int{6, 26}},
+	{24, 2, 43, 0, []int{6, 30}},
+	{16, 4, 27, 0, []int{6, 34}},
+	{18, 4, 31, 0, []int{6, 22, 38}},
+	{22, 2, 38, 2, []int{6, 24, 42}},
+	{22, 3, 36, 2, []int{6, 26, 46}},
+	{26, 4, 43, 1, []int{6, 28, 50}},
+	{30, 1, 50, 4, []int{6, 30, 54}},
+	{22, 6, 36, 2, []int{6, 32, 58}},
+	{22, 8, 37, 1, []int{6, 34, 62}},
+	{24, 4, 40, 5, []int{6, 26, 46, 66}},
+	{24, 5, 41, 5, []int{6, 26, 48, 70}},
+	{28, 7, 45, 3, []int{6, 26, 50, 74}},
+	{28, 10, 46, 1, []int{6, 30, 54, 78}},
+	{26, 9, 43, 4, []int{6, 30, 56, 82}},
+	{26, 3, 44, 11, []int{6, 30, 58, 86}},
+	{26, 3, 41, 13, []int{6, 34, 62, 90}},
+}
+
+// countBits returns the width of the byte
+// mode character count indicator for version.
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// encodedBits returns the number of bits needed to
+// encode n bytes in byte mode at the given version.
+func encodedBits(version int, n int) int {
+	return 4 + countBits(version) + n*8
+}
+
+// encodeData returns the data codewords for the
+// given content, including mode indicator,
+// character count, terminator and padding.
+func encodeData(version int, data []byte) []byte {
+	capacity := versions[version].dataCodewords()
+	bb := &bitBuffer{}
+
+	bb.append(0b0100, 4) // Byte mode.
+	bb.append(len(data), countBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	// Terminator of up to
+	// four zero bits.
+	bb.append(0, min(4, capacity*8-bb.n))
+
+	// Pad to a byte boundary.
+	if rem := bb.n % 8; rem != 0 {
+		bb.append(0, 8-rem)
+	}
+
+	// Fill remaining capacity
+	// with alternating pad bytes.
+	for pad := 0xEC; len(bb.b) < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	return bb.b
+}
+
+// interleave splits data into error correction blocks,
+// computes EC codewords for each, and interleaves the
+// result into the final codeword sequence.
+func interleave(version int, data []byte) []byte {
+	v := versions[version]
+	divisor := rsDivisor(v.ecPerBlock)
+
+	numBlocks := v.blocks1 + v.blocks2
+	dataBlocks := make([][]byte, 0, numBlocks)
+	ecBlocks := make([][]byte, 0, numBlocks)
+
+	for i := 0; i < numBlocks; i++ {
+		n := v.data1
+		if i >= v.blocks1 {
+			n++
+		}
+
+		block := data[:n]
+		data = data[n:]
+
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+	}
+
+	out := make([]byte, 0, v.dataCodewords()+numBlocks*v.ecPerBlock)
+	for i := 0; i <= v.data1; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			out = append(out, block[i])
+		}
+	}
+
+	return out
+}
+
+// rsDivisor returns the coefficients of the Reed-Solomon
+// generator polynomial of the given degree, highest
+// power first and excluding the leading 1.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+
+	return result
+}
+
+// rsRemainder returns the Reed-Solomon EC
+// codewords for data using the given divisor.
+func rsRemainder(data []byte, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplies x and y in GF(2^8)
+// modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMul(x byte, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer is an append-only, big-endian bit sequence.
+type bitBuffer struct {
+	b []byte
+	n int // Number of bits written.
+}
+
+func (bb *bitBuffer) append(value int, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if bb.n%8 == 0 {
+			bb.b = append(bb.b, 0)
+		}
+		if (value>>i)&1 != 0 {
+			bb.b[len(bb.b)-1] |= 1 << (7 - bb.n%8)
+		}
+		bb.n++
+	}
+}
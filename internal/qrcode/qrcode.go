@@ -0,0 +1,428 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package qrcode implements a small, dependency free QR code
+// encoder, sufficient for rendering short strings such as
+// otpauth:// URIs to a scannable image.
+//
+// Only byte mode at error correction level M is supported, with
+// symbol versions 1 to 20 (up to 666 bytes of input). See ISO/IEC
+// 18004 for the details of the format.
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrTooLong is returned when the given
+// content does not fit in a version 20 symbol.
+var ErrTooLong = errors.New("qrcode: content too long")
+
+// quietZone is the width in modules
+// of the light border around a symbol.
+const quietZone = 4
+
+// Code is an encoded QR code symbol.
+type Code struct {
+	// Size is the width and height
+	// of the symbol in modules.
+	Size int
+
+	modules  []bool // dark modules, row-major
+	function []bool // modules reserved for function patterns
+}
+
+// Encode encodes the given content as a QR code,
+// using the smallest version the content fits in.
+func Encode(content string) (*Code, error) {
+	data := []byte(content)
+
+	// Find the smallest version
+	// the content will fit into.
+	version := 0
+	for v := 1; v < len(versions); v++ {
+		if encodedBits(v, len(data)) <= versions[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	size := version*4 + 17
+	c := &Code{
+		Size:     size,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+
+	c.drawFunctionPatterns(version)
+	c.drawCodewords(interleave(version, encodeData(version, data)))
+
+	// Pick the mask with the
+	// lowest penalty score.
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // XOR undoes it.
+	}
+
+	c.applyMask(best)
+	c.drawFormatBits(best)
+
+	return c, nil
+}
+
+// Dark returns whether the module
+// at column x and row y is dark.
+func (c *Code) Dark(x int, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y*c.Size+x]
+}
+
+// Image renders the code as a black on white image,
+// with each module drawn as a scale*scale square and
+// a quiet zone of 4 modules around the symbol.
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	width := (c.Size + quietZone*2) * scale
+	img := image.NewPaletted(
+		image.Rect(0, 0, width, width),
+		color.Palette{color.White, color.Black},
+	)
+
+	for y := 0; y < width; y++ {
+		for x := 0; x < width; x++ {
+			if c.Dark(x/scale-quietZone, y/scale-quietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	return img
+}
+
+// PNG renders the code at the given
+// scale, and encodes it as a PNG image.
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Image(scale)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// set sets the module at x, y, optionally
+// reserving it as part of a function pattern.
+func (c *Code) set(x int, y int, dark bool, function bool) {
+	c.modules[y*c.Size+x] = dark
+	if function {
+		c.function[y*c.Size+x] = true
+	}
+}
+
+// drawFunctionPatterns draws the finder, alignment and timing
+// patterns, and reserves the format and version info areas.
+func (c *Code) drawFunctionPatterns(version int) {
+	size := c.Size
+
+	// Timing patterns.
+	for i := 0; i < size; i++ {
+		c.set(6, i, i%2 == 0, true)
+		c.set(i, 6, i%2 == 0, true)
+	}
+
+	// Finder patterns (incl. separators)
+	// in three of the four corners.
+	c.drawFinder(3, 3)
+	c.drawFinder(size-4, 3)
+	c.drawFinder(3, size-4)
+
+	// Alignment patterns, skipping
+	// those that overlap the finders.
+	pos := versions[version].alignment
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			if (i == 0 && j == 0) ||
+				(i == 0 && j == last) ||
+				(i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	// Reserve format bits with
+	// a placeholder for now.
+	c.drawFormatBits(0)
+
+	// Version info.
+	if version >= 7 {
+		rem := version
+		for i := 0; i < 12; i++ {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := version<<12 | rem
+
+		for i := 0; i < 18; i++ {
+			dark := (bits>>i)&1 != 0
+			a := size - 11 + i%3
+			b := i / 3
+			c.set(a, b, dark, true)
+			c.set(b, a, dark, true)
+		}
+	}
+}
+
+// drawFinder draws a finder pattern and
+// its separator centred on x, y.
+func (c *Code) drawFinder(x int, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.Size || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.set(xx, yy, dist != 2 && dist != 4, true)
+		}
+	}
+}
+
+// drawAlignment draws an
+// alignment pattern centred on x, y.
+func (c *Code) drawAlignment(x int, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1, true)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format
+// info for error correction level M and the given mask.
+func (c *Code) drawFormatBits(mask int) {
+	const eclM = 0 // Format bits for level M.
+
+	data := eclM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+	size := c.Size
+
+	// First copy, around the top left finder.
+	for i := 0; i <= 5; i++ {
+		c.set(8, i, bit(i), true)
+	}
+	c.set(8, 7, bit(6), true)
+	c.set(8, 8, bit(7), true)
+	c.set(7, 8, bit(8), true)
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(i), true)
+	}
+
+	// Second copy, split between
+	// the other two finders.
+	for i := 0; i < 8; i++ {
+		c.set(size-1-i, 8, bit(i), true)
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, size-15+i, bit(i), true)
+	}
+
+	// Always dark.
+	c.set(8, size-8, true, true)
+}
+
+// drawCodewords places the given codewords in the
+// zigzag pattern over all non-function modules.
+func (c *Code) drawCodewords(codewords []byte) {
+	size := c.Size
+	i := 0
+
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// Skip the vertical
+			// timing pattern.
+			right = 5
+		}
+
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if upward {
+					y = size - 1 - vert
+				}
+
+				if c.function[y*size+x] {
+					continue
+				}
+
+				// Any leftover remainder
+				// bits are left light.
+				if i < len(codewords)*8 {
+					c.modules[y*size+x] = (codewords[i>>3]>>(7-i&7))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask XORs the given mask
+// pattern over all non-function modules.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			i := y*c.Size + x
+			if invert && !c.function[i] {
+				c.modules[i] = !c.modules[i]
+			}
+		}
+	}
+}
+
+// penalty scores the current symbol according to
+// the four mask evaluation rules; lower is better.
+func (c *Code) penalty() int {
+	size := c.Size
+	penalty := 0
+
+	// Rules 1 and 3: runs of same colored modules,
+	// and finder-like patterns, in rows and columns.
+	for _, vertical := range []bool{false, true} {
+		for a := 0; a < size; a++ {
+			line := make([]bool, size)
+			for b := 0; b < size; b++ {
+				if vertical {
+					line[b] = c.Dark(a, b)
+				} else {
+					line[b] = c.Dark(b, a)
+				}
+			}
+
+			run := 1
+			for b := 1; b <= size; b++ {
+				if b < size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+
+			for b := 0; b+11 <= size; b++ {
+				if finderLike(line[b:b+11], false) || finderLike(line[b:b+11], true) {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	// Rule 2: 2x2 blocks of the same color.
+	for y := 0; y < size-1; y++ {
+		for x := 0; x < size-1; x++ {
+			d := c.Dark(x, y)
+			if d == c.Dark(x+1, y) &&
+				d == c.Dark(x, y+1) &&
+				d == c.Dark(x+1, y+1) {
+				penalty += 3
+			}
+		}
+	}
+
+	// Rule 4: balance of dark
+	// and light modules.
+	dark := 0
+	for _, m := range c.modules {
+		if m {
+			dark++
+		}
+	}
+	total := size * size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	penalty += max(k, 0) * 10
+
+	return penalty
+}
+
+// finderLike returns whether the given 11 modules match
+// 1:1:3:1:1 dark/light followed (or preceded) by 4 light.
+func finderLike(m []bool, reversed bool) bool {
+	pattern := [11]bool{true, false, true, true, true, false, true, false, false, false, false}
+	for i, want := range pattern {
+		j := i
+		if reversed {
+			j = len(m) - 1 - i
+		}
+		if m[j] != want {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// Worked example for "HELLO WORLD" at 1-M.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expect := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if ec := rsRemainder(data, rsDivisor(10)); !bytes.Equal(ec, expect) {
+		t.Fatalf("expected %v, got %v", expect, ec)
+	}
+}
+
+func TestVersionCapacity(t *testing.T) {
+	// Remainder bits left over after placing
+	// all codewords, per ISO/IEC 18004 table 1.
+	remainder := func(v int) int {
+		switch {
+		case v == 1 || (v >= 7 && v <= 13):
+			return 0
+		case v <= 6:
+			return 7
+		default:
+			return 3
+		}
+	}
+
+	for v := 1; v < len(versions); v++ {
+		size := v*4 + 17
+		c := &Code{
+			Size:     size,
+			modules:  make([]bool, size*size),
+			function: make([]bool, size*size),
+		}
+		c.drawFunctionPatterns(v)
+
+		free := 0
+		for _, f := range c.function {
+			if !f {
+				free++
+			}
+		}
+
+		total := versions[v].dataCodewords() + (versions[v].blocks1+versions[v].blocks2)*versions[v].ecPerBlock
+		if expect := total*8 + remainder(v); free != expect {
+			t.Errorf("version %d: expected %d data modules, got %d", v, expect, free)
+		}
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	c, err := Encode("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Level M, mask 5.
+	c.drawFormatBits(5)
+
+	var sb strings.Builder
+	for i := 14; i >= 0; i-- {
+		// Read back the copy drawn
+		// along the bottom left.
+		var x, y int
+		if i >= 8 {
+			x, y = 8, c.Size-15+i
+		} else {
+			x, y = c.Size-1-i, 8
+		}
+		if c.Dark(x, y) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+
+	if expect := "100000011001110"; sb.String() != expect {
+		t.Fatalf("expected %s, got %s", expect, sb.String())
+	}
+}
+
+func TestVersionInfo(t *testing.T) {
+	c, err := Encode(strings.Repeat("a", 120))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Size != 45 {
+		t.Fatalf("expected version 7 symbol, got size %d", c.Size)
+	}
+
+	var bits int
+	for i := 17; i >= 0; i-- {
+		bits <<= 1
+		if c.Dark(c.Size-11+i%3, i/3) {
+			bits |= 1
+		}
+	}
+
+	if bits != 0x07C94 {
+		t.Fatalf("expected version info %05x, got %05x", 0x07C94, bits)
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("a", 667)); err != ErrTooLong {
+		t.Fatalf("expected ErrTooLong, got %v", err)
+	}
+
+	c, err := Encode(strings.Repeat("a", 666))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Size != 97 {
+		t.Fatalf("expected version 20 symbol, got size %d", c.Size)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	c, err := Encode("otpauth://totp/example.org:someone?secret=JBSWY3DPEHPK3PXP&issuer=example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := c.PNG(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w := img.Bounds().Dx(); w != (c.Size+8)*4 {
+		t.Fatalf("unexpected image width %d", w)
+	}
+
+	// Top left finder should be dark
+	// just inside the quiet zone.
+	if r, _, _, _ := img.At(16, 16).RGBA(); r != 0 {
+		t.Fatal("expected dark finder module")
+	}
+}
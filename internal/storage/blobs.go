@@ -0,0 +1,235 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// BlobKeyPrefix is the storage key prefix
+// under which content-addressed blobs are stored.
+const BlobKeyPrefix = "blobs/"
+
+// blobRefKeysPageSize is the number of blob ref
+// keys fetched per page when walking storage keys.
+const blobRefKeysPageSize = 200
+
+// BlobIndex is the database index of content-addressed blobs, mapping
+// storage keys to the hashes of the blobs holding their values, and
+// reference counting blobs. It is implemented by db.StorageBlob.
+type BlobIndex interface {
+	GetStorageBlobRef(ctx context.Context, key string) (string, error)
+	GetStorageBlobRefKeys(ctx context.Context, after string, limit int) ([]string, error)
+	PutStorageBlobRef(ctx context.Context, key string, hash string, size int64) (int, error)
+	DeleteStorageBlobRef(ctx context.Context, key string) (int, error)
+}
+
+// BlobKey returns the storage key of the blob with given hash.
+func BlobKey(hash string) string {
+	return BlobKeyPrefix + hash[:2] + "/" + hash
+}
+
+// Dedupe moves the value stored directly at key into content-addressed
+// blob storage, referencing it from key, so that it's stored only once
+// no matter how many keys have the same value. It returns false if the
+// key was already deduplicated. Blobs must be set on the Driver.
+func (d *Driver) Dedupe(ctx context.Context, key string) (bool, error) {
+	if d.Blobs == nil {
+		return false, gtserror.New("blob index not set")
+	}
+
+	if strings.HasPrefix(key, BlobKeyPrefix) {
+		// This is a blob itself.
+		return false, nil
+	}
+
+	// Check whether key is already deduplicated.
+	hash, err := d.Blobs.GetStorageBlobRef(ctx, key)
+	if err != nil {
+		return false, gtserror.Newf("error getting blob ref for %s: %w", key, err)
+	} else if hash != "" {
+		return false, nil
+	}
+
+	rc, err := d.Storage.ReadStream(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	// Spool value to temporary file for hashing.
+	tmp, hash, size, err := spool(rc)
+	_ = rc.Close()
+	if err != nil {
+		return false, err
+	}
+	defer removeSpool(ctx, tmp)
+
+	// Store as blob, referenced from key.
+	if err := d.putBlob(ctx, key, hash, size, tmp); err != nil {
+		return false, err
+	}
+
+	// Value is now read from the blob,
+	// so remove the original copy.
+	if err := d.Storage.Remove(ctx, key); err != nil && !errors.Is(err, ErrNotFound) {
+		return true, gtserror.Newf("error removing %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// resolve returns the key the value for key is actually stored
+// at in the underlying storage; that of the blob referenced by
+// key if it's deduplicated, else simply the key itself.
+func (d *Driver) resolve(ctx context.Context, key string) (string, error) {
+	if d.Blobs == nil {
+		return key, nil
+	}
+
+	hash, err := d.Blobs.GetStorageBlobRef(ctx, key)
+	if err != nil {
+		return "", gtserror.Newf("error getting blob ref for %s: %w", key, err)
+	}
+
+	if hash == "" {
+		// Not deduplicated.
+		return key, nil
+	}
+
+	return BlobKey(hash), nil
+}
+
+// putBlob references the blob with given hash and size from key, writing
+// the blob value from r to storage if it's not already stored.
+func (d *Driver) putBlob(ctx context.Context, key string, hash string, size int64, r io.Reader) error {
+	unlock := d.blobLocks.Lock(hash)
+	defer unlock()
+
+	refs, err := d.Blobs.PutStorageBlobRef(ctx, key, hash, size)
+	if err != nil {
+		return gtserror.Newf("error putting blob ref for %s: %w", key, err)
+	}
+
+	if refs > 1 {
+		// Blob is already
+		// stored, all done.
+		return nil
+	}
+
+	// This is a new blob, write it to storage. Blobs are content-addressed,
+	// so if it already exists (eg., left over from an interrupted delete),
+	// then it already holds the correct value and can be used as-is.
+	_, err = d.Storage.WriteStream(ctx, BlobKey(hash), r)
+	if err != nil && !errors.Is(err, ErrAlreadyExists) {
+		// Drop the ref to the blob we failed to write.
+		if _, err := d.Blobs.DeleteStorageBlobRef(ctx, key); err != nil {
+			log.Errorf(ctx, "error deleting blob ref for %s: %v", key, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// deleteBlobRef removes the ref to blob with given hash from key,
+// removing the blob from storage if there are no references left.
+func (d *Driver) deleteBlobRef(ctx context.Context, key string, hash string) error {
+	unlock := d.blobLocks.Lock(hash)
+	defer unlock()
+
+	refs, err := d.Blobs.DeleteStorageBlobRef(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error deleting blob ref for %s: %w", key, err)
+	}
+
+	if refs > 0 {
+		// Blob still in use.
+		return nil
+	}
+
+	return d.Storage.Remove(ctx, BlobKey(hash))
+}
+
+// walkBlobRefKeys walks all the storage keys referencing blobs.
+func (d *Driver) walkBlobRefKeys(ctx context.Context, walk func(context.Context, string) error) error {
+	var after string
+
+	for {
+		keys, err := d.Blobs.GetStorageBlobRefKeys(ctx, after, blobRefKeysPageSize)
+		if err != nil {
+			return gtserror.Newf("error getting blob ref keys: %w", err)
+		}
+
+		if len(keys) == 0 {
+			// Reached the end.
+			return nil
+		}
+
+		for _, key := range keys {
+			if err := walk(ctx, key); err != nil {
+				return err
+			}
+		}
+
+		// Page on from the last key.
+		after = keys[len(keys)-1]
+	}
+}
+
+// spool copies the value from r to a temporary file, returning the file
+// (seeked back to start) along with the value's SHA-256 hash and size.
+func spool(r io.Reader) (*os.File, string, int64, error) {
+	tmp, err := os.CreateTemp("", "gotosocial-blob-*")
+	if err != nil {
+		return nil, "", 0, gtserror.Newf("error creating temp file: %w", err)
+	}
+
+	// Hash the value while copying.
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, "", 0, gtserror.Newf("error writing temp file: %w", err)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, "", 0, gtserror.Newf("error seeking temp file: %w", err)
+	}
+
+	return tmp, hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// removeSpool closes and removes the temporary file from spool().
+func removeSpool(ctx context.Context, tmp *os.File) {
+	_ = tmp.Close()
+	if err := os.Remove(tmp.Name()); err != nil {
+		log.Errorf(ctx, "error removing temp file %s: %v", tmp.Name(), err)
+	}
+}
@@ -18,16 +18,21 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"mime"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"codeberg.org/gruf/go-bytesize"
 	"codeberg.org/gruf/go-cache/v3/ttl"
+	"codeberg.org/gruf/go-mutexes"
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -63,48 +68,139 @@ type Driver struct {
 	Proxy          bool
 	Bucket         string
 	PresignedCache *ttl.Cache[string, PresignedURL]
+
+	// Blobs, if set, enables content-addressed
+	// deduplication of values stored in storage.
+	Blobs BlobIndex
+
+	// blobLocks protects blob reference
+	// counting and writes, keyed by hash.
+	blobLocks mutexes.MutexMap
 }
 
 // Get returns the byte value for key in storage.
 func (d *Driver) Get(ctx context.Context, key string) ([]byte, error) {
+	key, err := d.resolve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
 	return d.Storage.ReadBytes(ctx, key)
 }
 
 // GetStream returns an io.ReadCloser for the value bytes at key in the storage.
 func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := d.resolve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
 	return d.Storage.ReadStream(ctx, key)
 }
 
 // Put writes the supplied value bytes at key in the storage
 func (d *Driver) Put(ctx context.Context, key string, value []byte) (int, error) {
-	return d.Storage.WriteBytes(ctx, key, value)
+	if d.Blobs == nil {
+		return d.Storage.WriteBytes(ctx, key, value)
+	}
+
+	// Check key isn't already in use, as
+	// underlying storage doesn't overwrite.
+	if have, err := d.Has(ctx, key); err != nil {
+		return 0, err
+	} else if have {
+		return 0, ErrAlreadyExists
+	}
+
+	// Hash value and store as blob.
+	sum := sha256.Sum256(value)
+	hash := hex.EncodeToString(sum[:])
+	if err := d.putBlob(ctx, key, hash, int64(len(value)), bytes.NewReader(value)); err != nil {
+		return 0, err
+	}
+
+	return len(value), nil
 }
 
 // PutStream writes the bytes from supplied reader at key in the storage
 func (d *Driver) PutStream(ctx context.Context, key string, r io.Reader) (int64, error) {
-	return d.Storage.WriteStream(ctx, key, r)
+	if d.Blobs == nil {
+		return d.Storage.WriteStream(ctx, key, r)
+	}
+
+	// Check key isn't already in use, as
+	// underlying storage doesn't overwrite.
+	if have, err := d.Has(ctx, key); err != nil {
+		return 0, err
+	} else if have {
+		return 0, ErrAlreadyExists
+	}
+
+	// Spool value to temporary file, as we
+	// need its hash to know where to put it.
+	tmp, hash, size, err := spool(r)
+	if err != nil {
+		return 0, err
+	}
+	defer removeSpool(ctx, tmp)
+
+	// Store value as blob.
+	if err := d.putBlob(ctx, key, hash, size, tmp); err != nil {
+		return 0, err
+	}
+
+	return size, nil
 }
 
 // Remove attempts to remove the supplied key (and corresponding value) from storage.
 func (d *Driver) Delete(ctx context.Context, key string) error {
-	return d.Storage.Remove(ctx, key)
+	if d.Blobs == nil {
+		return d.Storage.Remove(ctx, key)
+	}
+
+	hash, err := d.Blobs.GetStorageBlobRef(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error getting blob ref for %s: %w", key, err)
+	}
+
+	if hash == "" {
+		// Not deduplicated.
+		return d.Storage.Remove(ctx, key)
+	}
+
+	// Drop ref, removing blob if unused.
+	return d.deleteBlobRef(ctx, key, hash)
 }
 
 // Has checks if the supplied key is in the storage.
 func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
+	key, err := d.resolve(ctx, key)
+	if err != nil {
+		return false, err
+	}
 	return d.Storage.Stat(ctx, key)
 }
 
-// WalkKeys walks the keys in the storage.
+// WalkKeys walks the keys in the storage. If deduplication is
+// enabled, this walks keys referencing blobs rather than blobs.
 func (d *Driver) WalkKeys(ctx context.Context, walk func(context.Context, string) error) error {
-	return d.Storage.WalkKeys(ctx, storage.WalkKeysOptions{
+	if err := d.Storage.WalkKeys(ctx, storage.WalkKeysOptions{
 		WalkFn: func(ctx context.Context, entry storage.Entry) error {
 			if entry.Key == "store.lock" {
 				return nil // skip this.
 			}
+			if d.Blobs != nil && strings.HasPrefix(entry.Key, BlobKeyPrefix) {
+				return nil // walked by ref below.
+			}
 			return walk(ctx, entry.Key)
 		},
-	})
+	}); err != nil {
+		return err
+	}
+
+	if d.Blobs == nil {
+		return nil
+	}
+
+	return d.walkBlobRefKeys(ctx, walk)
 }
 
 // Close will close the storage, releasing any file locks.
@@ -130,7 +226,16 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 		return &e.Value
 	}
 
-	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, urlCacheTTL, url.Values{
+	// Get actual object key, in case
+	// key refers to a deduplicated blob.
+	objKey, err := d.resolve(ctx, key)
+	if err != nil {
+		return nil
+	}
+
+	// Content type is derived from the requested key,
+	// as blob keys are content hashes (no extension).
+	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, objKey, urlCacheTTL, url.Values{
 		"response-content-type": []string{mime.TypeByExtension(path.Ext(key))},
 	})
 	if err != nil {
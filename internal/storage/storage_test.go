@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gostorage "codeberg.org/gruf/go-store/v2/storage"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+	db      db.DB
+	state   state.State
+	storage *storage.Driver
+}
+
+func (suite *StorageTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.state.Caches.Init()
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	testrig.CreateTestTables(suite.db)
+
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.storage.Blobs = suite.db
+}
+
+func (suite *StorageTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// blobKeys returns the keys of blobs in underlying storage.
+func (suite *StorageTestSuite) blobKeys(ctx context.Context) []string {
+	var keys []string
+	if err := suite.storage.Storage.WalkKeys(ctx, gostorage.WalkKeysOptions{
+		WalkFn: func(_ context.Context, entry gostorage.Entry) error {
+			if strings.HasPrefix(entry.Key, storage.BlobKeyPrefix) {
+				keys = append(keys, entry.Key)
+			}
+			return nil
+		},
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return keys
+}
+
+// walkKeys returns the keys walked by the storage driver.
+func (suite *StorageTestSuite) walkKeys(ctx context.Context) []string {
+	var keys []string
+	if err := suite.storage.WalkKeys(ctx, func(_ context.Context, key string) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return keys
+}
+
+func (suite *StorageTestSuite) TestPutDeduplicates() {
+	ctx := context.Background()
+	value := []byte("some media")
+
+	// Store the same value at two keys,
+	// once from bytes and once streamed.
+	if _, err := suite.storage.Put(ctx, "a/1.png", value); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.storage.PutStream(ctx, "b/2.png", strings.NewReader(string(value))); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Keys can't be overwritten.
+	_, err := suite.storage.Put(ctx, "a/1.png", value)
+	suite.ErrorIs(err, storage.ErrAlreadyExists)
+
+	// Only one blob should be stored,
+	// but both keys should be walked.
+	suite.Len(suite.blobKeys(ctx), 1)
+	suite.ElementsMatch([]string{"a/1.png", "b/2.png"}, suite.walkKeys(ctx))
+
+	for _, key := range []string{"a/1.png", "b/2.png"} {
+		b, err := suite.storage.Get(ctx, key)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(value, b)
+	}
+
+	// Deleting one key should leave
+	// the blob in place for the other.
+	if err := suite.storage.Delete(ctx, "a/1.png"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	have, err := suite.storage.Has(ctx, "a/1.png")
+	suite.NoError(err)
+	suite.False(have)
+
+	b, err := suite.storage.Get(ctx, "b/2.png")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(value, b)
+
+	// Deleting the last key
+	// should remove the blob.
+	if err := suite.storage.Delete(ctx, "b/2.png"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.blobKeys(ctx))
+	suite.Empty(suite.walkKeys(ctx))
+}
+
+func (suite *StorageTestSuite) TestDedupeExisting() {
+	ctx := context.Background()
+	value := []byte("some media")
+
+	// Store the same value at two keys
+	// directly, as before deduplication.
+	for _, key := range []string{"a/1.png", "b/2.png"} {
+		if _, err := suite.storage.Storage.WriteBytes(ctx, key, value); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	for _, key := range []string{"a/1.png", "b/2.png"} {
+		deduped, err := suite.storage.Dedupe(ctx, key)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(deduped)
+	}
+
+	// Deduping again should be a no-op.
+	deduped, err := suite.storage.Dedupe(ctx, "a/1.png")
+	suite.NoError(err)
+	suite.False(deduped)
+
+	// Original copies should be gone, leaving one blob.
+	for _, key := range []string{"a/1.png", "b/2.png"} {
+		have, err := suite.storage.Storage.Stat(ctx, key)
+		suite.NoError(err)
+		suite.False(have)
+
+		b, err := suite.storage.Get(ctx, key)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(value, b)
+	}
+	suite.Len(suite.blobKeys(ctx), 1)
+}
+
+func TestStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(StorageTestSuite))
+}
@@ -42,6 +42,10 @@ const (
 	// user's timeline has been edited (yes this
 	// is a confusing name, blame Mastodon ...).
 	EventTypeStatusUpdate = "status.update"
+
+	// EventTypeConversation -- a user
+	// should be shown an updated conversation.
+	EventTypeConversation = "conversation"
 )
 
 const (
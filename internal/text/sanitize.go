@@ -163,6 +163,12 @@ var regular *bluemonday.Policy = func() *bluemonday.Policy {
 // Source: https://github.com/microcosm-cc/bluemonday#usage
 var strict *bluemonday.Policy = bluemonday.StrictPolicy()
 
+// Spaced policy is the strict policy, but with
+// whitespace inserted in place of stripped elements,
+// so that eg., "<p>one</p><p>two</p>" doesn't end up
+// being flattened to the single word "onetwo".
+var spaced *bluemonday.Policy = bluemonday.StrictPolicy().AddSpaceWhenStrippingTag(true)
+
 // removeHTML strictly removes *all* recognized
 // HTML elements from the given string.
 func removeHTML(in string) string {
@@ -189,3 +195,19 @@ func SanitizeToPlaintext(in string) string {
 	content = html.UnescapeString(content)
 	return strings.TrimSpace(content)
 }
+
+// SanitizeToSearchText removes all html elements from the
+// given string, replacing them with whitespace, and returns
+// plaintext suitable for inclusion in a full-text search index.
+func SanitizeToSearchText(in string) string {
+	// Unescape first to catch any tricky critters.
+	content := html.UnescapeString(in)
+
+	// Remove all detected HTML.
+	content = spaced.Sanitize(content)
+
+	// Unescape again to return plaintext,
+	// and collapse any repeated whitespace.
+	content = html.UnescapeString(content)
+	return strings.Join(strings.Fields(content), " ")
+}
@@ -25,6 +25,7 @@ import (
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -254,6 +255,13 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 						removeElements = append(removeElements, e)
 						return true, nil
 					}
+					if errors.Is(err, statusfilter.ErrHideStatus) {
+						// This item is hidden by the timeline owner's
+						// filters, so remove it and skip past it.
+						l.Debugf("item %s is hidden by filters; will remove from timeline", entry.itemID)
+						removeElements = append(removeElements, e)
+						return true, nil
+					}
 					// We've got a proper db error.
 					err = gtserror.Newf("db error while trying to prepare %s: %w", entry.itemID, err)
 					return false, err
@@ -348,6 +356,13 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 					removeElements = append(removeElements, e)
 					continue
 				}
+				if errors.Is(err, statusfilter.ErrHideStatus) {
+					// This item is hidden by the timeline owner's
+					// filters, so remove it and skip past it.
+					l.Debugf("item %s is hidden by filters; will remove from timeline", entry.itemID)
+					removeElements = append(removeElements, e)
+					continue
+				}
 				// We've got a proper db error.
 				err = gtserror.Newf("db error while trying to prepare %s: %w", entry.itemID, err)
 				return nil, err
@@ -24,6 +24,7 @@ import (
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -245,6 +246,17 @@ func (t *timeline) IndexAndPrepareOne(ctx context.Context, statusID string, boos
 
 	preparable, err := t.prepareFunction(ctx, t.timelineID, statusID)
 	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// This item is hidden by the timeline
+			// owner's filters, so take it back out.
+			for e := t.items.data.Front(); e != nil; e = e.Next() {
+				if e.Value == postIndexEntry {
+					t.items.data.Remove(e)
+					break
+				}
+			}
+			return false, nil
+		}
 		return true, gtserror.Newf("error preparing: %w", err)
 	}
 	postIndexEntry.prepared = preparable
@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package totp implements time-based one-time
+// passwords as described in RFC 6238, using the
+// defaults understood by common authenticator
+// apps: HMAC-SHA1, 6 digits, and a 30 second step.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the length of generated codes.
+	Digits = 6
+
+	// Period is the time step of generated codes.
+	Period = 30 * time.Second
+
+	// skew is the number of time steps either side of
+	// the current one in which a code is still accepted,
+	// to allow for clock drift and slow typists.
+	skew = 1
+
+	// secretLen is the length in bytes of a
+	// generated secret (the RFC 4226 recommendation).
+	secretLen = 20
+)
+
+// encoding is the base32 encoding used for secrets.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret returns a new random base32-encoded secret.
+func NewSecret() (string, error) {
+	b := make([]byte, secretLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Code returns the code for the given
+// base32-encoded secret at the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, counter(t)), nil
+}
+
+// Validate returns whether the given code is valid
+// for the base32-encoded secret at the given time,
+// allowing one time step of drift either way.
+func Validate(secret string, passcode string, t time.Time) bool {
+	_, valid := Verify(secret, passcode, t, 0)
+	return valid
+}
+
+// Verify is like Validate, but only accepts codes for
+// time steps later than the given last step, returning
+// the time step of the accepted code. Callers should
+// store this and pass it as last on the next call,
+// so that a code can't be used more than once.
+func Verify(secret string, passcode string, t time.Time, last int64) (int64, bool) {
+	passcode = strings.TrimSpace(passcode)
+	if len(passcode) != Digits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	var (
+		step  int64
+		valid bool
+	)
+
+	c := counter(t)
+	for i := c - skew; i <= c+skew; i++ {
+		// Check every step to keep
+		// timing independent of match.
+		match := subtle.ConstantTimeCompare([]byte(code(key, i)), []byte(passcode)) == 1
+		if match && int64(i) > last {
+			step, valid = int64(i), true
+		}
+	}
+
+	return step, valid
+}
+
+// URI returns an otpauth:// key URI for the given
+// secret, suitable for encoding as a QR code to be
+// scanned by an authenticator app.
+//
+// See: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func URI(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
+
+// decodeSecret decodes a base32 secret, tolerating
+// lowercase, whitespace and trailing padding.
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+	return encoding.DecodeString(secret)
+}
+
+// counter returns the time step counter for t.
+func counter(t time.Time) uint64 {
+	return uint64(t.Unix() / int64(Period.Seconds()))
+}
+
+// code computes the HOTP value (RFC 4226) for
+// key and counter, truncated to Digits digits.
+func code(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package totp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+// base32 of the RFC 6238 SHA1 test seed "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeRFC6238(t *testing.T) {
+	// Test vectors from RFC 6238 appendix B,
+	// truncated to the last six digits.
+	for _, test := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	} {
+		code, err := totp.Code(rfcSecret, time.Unix(test.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code != test.code {
+			t.Errorf("at %d: expected %s, got %s", test.unix, test.code, code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	code, err := totp.Code(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !totp.Validate(secret, code, now) {
+		t.Error("expected current code to be valid")
+	}
+
+	if !totp.Validate(secret, " "+code+" ", now.Add(totp.Period)) {
+		t.Error("expected code to be valid one step later")
+	}
+
+	if totp.Validate(secret, code, now.Add(3*totp.Period)) {
+		t.Error("expected code to be invalid three steps later")
+	}
+
+	if totp.Validate(secret, "", now) {
+		t.Error("expected empty code to be invalid")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret, err := totp.NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	code, err := totp.Code(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	step, ok := totp.Verify(secret, code, now, 0)
+	if !ok {
+		t.Fatal("expected current code to be valid")
+	}
+
+	if _, ok := totp.Verify(secret, code, now, step); ok {
+		t.Error("expected code to be invalid once used")
+	}
+
+	if _, ok := totp.Verify(secret, code, now.Add(totp.Period), step); ok {
+		t.Error("expected code to be invalid once used, one step later")
+	}
+
+	next, err := totp.Code(secret, now.Add(totp.Period))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nextStep, ok := totp.Verify(secret, next, now, step)
+	if !ok {
+		t.Fatal("expected next code to be valid")
+	}
+
+	if nextStep != step+1 {
+		t.Errorf("expected step %d, got %d", step+1, nextStep)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("example.org", "someone", "JBSWY3DPEHPK3PXP")
+	expect := "otpauth://totp/example.org:someone?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=JBSWY3DPEHPK3PXP"
+
+	if uri != expect {
+		t.Fatalf("expected %s, got %s", expect, uri)
+	}
+}
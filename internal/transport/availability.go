@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// instanceUnavailableAfter is how long deliveries to an
+	// instance must have been failing, with no successful
+	// delivery in between, before it's marked unavailable.
+	instanceUnavailableAfter = 24 * time.Hour
+
+	// instanceDeliveredAtFreq is how often the time of the
+	// latest successful delivery to a healthy instance is
+	// written to the db, to avoid a write per delivery.
+	instanceDeliveredAtFreq = time.Hour
+)
+
+// ErrInstanceUnavailable is returned when a delivery to,
+// or a dereference from, an instance that has been marked
+// unavailable due to sustained delivery failures is skipped.
+var ErrInstanceUnavailable = errors.New("instance marked unavailable")
+
+// getInstance returns the instance entry for the remote
+// instance serving the given URL. Instances are normally
+// stored under their host, but an instance may instead be
+// stored under an account domain that differs from the host
+// its inboxes are served at, so for inbox URLs this falls
+// back to the instance of the account owning the inbox.
+func (c *controller) getInstance(ctx context.Context, to *url.URL) (*gtsmodel.Instance, error) {
+	// Instance and account models
+	// don't need populating here.
+	ctx = gtscontext.SetBarebones(ctx)
+
+	instance, err := c.state.DB.GetInstance(ctx, to.Host)
+	if !errors.Is(err, db.ErrNoEntries) {
+		return instance, err
+	}
+
+	// Look for account with this inbox.
+	account, err := c.state.DB.GetAccountByInboxURI(ctx, to.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Domain == "" || account.Domain == to.Host {
+		// Nothing else to check.
+		return nil, db.ErrNoEntries
+	}
+
+	return c.state.DB.GetInstance(ctx, account.Domain)
+}
+
+// instanceUnavailable returns whether the instance serving
+// the given URL has been marked unavailable.
+func (c *controller) instanceUnavailable(ctx context.Context, to *url.URL) bool {
+	instance, err := c.getInstance(ctx, to)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting instance %s: %v", to.Host, err)
+		}
+		return false
+	}
+	return instance.Unavailable()
+}
+
+// recordDelivery records the outcome of a delivery attempt
+// to the instance serving the given inbox, marking it
+// unavailable if deliveries to it have been failing for long enough.
+func (c *controller) recordDelivery(ctx context.Context, to *url.URL, err error) {
+	instance, dbErr := c.getInstance(ctx, to)
+	if dbErr != nil {
+		if !errors.Is(dbErr, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting instance %s: %v", to.Host, dbErr)
+		}
+		return
+	}
+
+	// Take a copy to avoid
+	// modifying cached model.
+	instance2 := new(gtsmodel.Instance)
+	*instance2 = *instance
+	instance = instance2
+
+	var (
+		now     = time.Now()
+		columns []string
+	)
+
+	if !deliveryErrUnreachable(err) {
+		// Remote instance is up (even if it
+		// may have refused this delivery).
+		if !instance.DeliveryFailingSince.IsZero() ||
+			now.Sub(instance.DeliveredAt) >= instanceDeliveredAtFreq {
+			instance.DeliveredAt = now
+			instance.DeliveryFailingSince = time.Time{}
+			columns = []string{"delivered_at", "delivery_failing_since"}
+		}
+	} else {
+		// Remote instance could not be reached.
+		instance.DeliveryFailedAt = now
+		columns = []string{"delivery_failed_at"}
+
+		if instance.DeliveryFailingSince.IsZero() {
+			// First of a new run of failures.
+			instance.DeliveryFailingSince = now
+			columns = append(columns, "delivery_failing_since")
+		}
+
+		if !instance.Unavailable() &&
+			now.Sub(instance.DeliveryFailingSince) >= instanceUnavailableAfter {
+			// Failing for long enough, give up on it
+			// until a periodic probe shows it's back.
+			instance.UnavailableAt = now
+			columns = append(columns, "unavailable_at")
+			log.Warnf(ctx, "marking instance %s unavailable, deliveries failing since %s", instance.Domain, instance.DeliveryFailingSince)
+		}
+	}
+
+	if len(columns) == 0 {
+		// Nothing to record.
+		return
+	}
+
+	if err := c.state.DB.UpdateInstance(ctx, instance, columns...); err != nil {
+		log.Errorf(ctx, "error updating instance %s: %v", instance.Domain, err)
+	}
+}
+
+func (c *controller) ProbeUnavailableInstances(ctx context.Context) error {
+	instances, err := c.state.DB.GetUnavailableInstances(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting unavailable instances: %w", err)
+	}
+
+	for _, instance := range instances {
+		if err := c.probeInstance(ctx, instance); err != nil {
+			log.Debugf(ctx, "instance %s still unavailable: %v", instance.Domain, err)
+			continue
+		}
+
+		// Take a copy to avoid
+		// modifying cached model.
+		instance2 := new(gtsmodel.Instance)
+		*instance2 = *instance
+		instance = instance2
+
+		// Instance is back, resume
+		// delivering to it as normal.
+		instance.UnavailableAt = time.Time{}
+		instance.DeliveryFailingSince = time.Time{}
+		if err := c.state.DB.UpdateInstance(ctx,
+			instance,
+			"unavailable_at",
+			"delivery_failing_since",
+		); err != nil {
+			log.Errorf(ctx, "error updating instance %s: %v", instance.Domain, err)
+			continue
+		}
+
+		log.Infof(ctx, "instance %s reachable again, marked available", instance.Domain)
+	}
+
+	return nil
+}
+
+// probeInstance makes a single unsigned request to the nodeinfo
+// endpoint of the given instance, returning an error if the
+// instance could not be reached, or responded with a server error.
+func (c *controller) probeInstance(ctx context.Context, instance *gtsmodel.Instance) error {
+	probeURL, err := url.Parse(instance.URI)
+	if err != nil || probeURL.Host == "" {
+		probeURL = &url.URL{Scheme: "https", Host: instance.Domain}
+	}
+	probeURL.Path = "/.well-known/nodeinfo"
+	probeURL.RawQuery = ""
+
+	// Don't retry + backoff, the
+	// next probe will come soon.
+	ctx = gtscontext.SetFastFail(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode >= 500 {
+		return gtserror.NewFromResponse(rsp)
+	}
+
+	return nil
+}
+
+// deliveryErrUnreachable returns whether the given delivery
+// error indicates the remote instance could not be reached,
+// as opposed to it being reached and refusing the delivery.
+func deliveryErrUnreachable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+
+	case errors.Is(err, httpclient.ErrReservedAddr),
+		errors.Is(err, context.Canceled):
+		// Local problem, says
+		// nothing about remote.
+		return false
+	}
+
+	switch code := gtserror.StatusCode(err); {
+	case code == 0:
+		// No response at all, network
+		// error, timeout, DNS failure etc.
+		return true
+
+	case code >= 500:
+		// Remote is erroring.
+		return true
+	}
+
+	return false
+}
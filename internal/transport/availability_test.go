@@ -0,0 +1,320 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AvailabilityTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *AvailabilityTestSuite) TestCircuitBreaker() {
+	var (
+		ctx    = context.Background()
+		data   = []byte(`{"type":"Create"}`)
+		domain = "fossbros-anonymous.io"
+		inbox  = testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+
+		// Status code to respond
+		// to all requests with.
+		code atomic.Int64
+	)
+
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		code := int(code.Load())
+		return &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+			Request:    req,
+		}, nil
+	}, "../../testrig/media")
+
+	controller := testrig.NewTestTransportController(&suite.state, httpClient)
+	tp, err := controller.NewTransportForUsername(ctx, "the_mighty_zork")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Pretend deliveries to the
+	// instance have been failing
+	// for more than a day already.
+	instance, err := suite.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.DeliveryFailingSince = time.Now().Add(-25 * time.Hour)
+	if err := suite.state.DB.UpdateInstance(ctx, instance, "delivery_failing_since"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// One more failed delivery
+	// should trip the breaker.
+	code.Store(http.StatusBadGateway)
+	if err := tp.Deliver(ctx, data, inbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		instance, err = suite.state.DB.GetInstance(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return instance.Unavailable()
+	}) {
+		suite.FailNow("timed out waiting for instance to be marked unavailable")
+	}
+	suite.False(instance.DeliveryFailedAt.IsZero())
+
+	// New deliveries to the instance should
+	// be queued, but held back until it's back.
+	before := suite.countDeliveries(ctx, inbox)
+	if err := tp.BatchDeliver(ctx, data, []*url.URL{inbox}); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before+1, suite.countDeliveries(ctx, inbox))
+
+	delivery := suite.getDelivery(ctx, inbox)
+	suite.Zero(delivery.Attempts)
+	suite.True(delivery.NextAttemptAt.After(time.Now().Add(30 * time.Minute)))
+
+	// Dereferencing should be skipped too.
+	_, err = tp.Dereference(ctx, testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan"))
+	suite.ErrorIs(err, transport.ErrInstanceUnavailable)
+
+	// Probe while still failing
+	// leaves instance unavailable.
+	if err := controller.ProbeUnavailableInstances(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance, err = suite.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(instance.Unavailable())
+
+	// Successful probe makes it available again.
+	code.Store(http.StatusOK)
+	if err := controller.ProbeUnavailableInstances(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance, err = suite.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(instance.Unavailable())
+	suite.True(instance.DeliveryFailingSince.IsZero())
+}
+
+func (suite *AvailabilityTestSuite) TestDeliverySurvivesUnavailable() {
+	var (
+		ctx    = context.Background()
+		data   = []byte(`{"type":"Create"}`)
+		domain = "fossbros-anonymous.io"
+		inbox  = testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+
+		// Status code to respond
+		// to all requests with.
+		code atomic.Int64
+	)
+
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		code := int(code.Load())
+		return &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+			Request:    req,
+		}, nil
+	}, "../../testrig/media")
+
+	controller := testrig.NewTestTransportController(&suite.state, httpClient)
+	tp, err := controller.NewTransportForUsername(ctx, "the_mighty_zork")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// First attempt at the delivery fails.
+	code.Store(http.StatusBadGateway)
+	if err := tp.BatchDeliver(ctx, data, []*url.URL{inbox}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var delivery *gtsmodel.Delivery
+	if !testrig.WaitFor(func() bool {
+		delivery = suite.getDelivery(ctx, inbox)
+		return delivery != nil && delivery.Attempts == 1
+	}) {
+		suite.FailNow("timed out waiting for delivery attempt")
+	}
+
+	// Instance is then marked unavailable
+	// while the delivery awaits its retry.
+	instance, err := suite.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.UnavailableAt = time.Now()
+	if err := suite.state.DB.UpdateInstance(ctx, instance, "unavailable_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// When the retry comes round, the delivery
+	// should be postponed, not given up on, and
+	// that shouldn't count as an attempt.
+	suite.makeDue(ctx, delivery)
+	if err := controller.ProcessDeliveries(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		delivery = suite.getDelivery(ctx, inbox)
+		return delivery.NextAttemptAt.After(time.Now().Add(30 * time.Minute))
+	}) {
+		suite.FailNow("timed out waiting for delivery to be postponed")
+	}
+	suite.False(delivery.Failed())
+	suite.Equal(1, delivery.Attempts)
+
+	// Instance comes back.
+	code.Store(http.StatusAccepted)
+	if err := controller.ProbeUnavailableInstances(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Next time round the delivery goes through.
+	suite.makeDue(ctx, delivery)
+	if err := controller.ProcessDeliveries(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		return suite.countDeliveries(ctx, inbox) == 0
+	}) {
+		suite.FailNow("timed out waiting for delivery")
+	}
+}
+
+func (suite *AvailabilityTestSuite) TestUnavailableByAccountDomain() {
+	var (
+		ctx    = context.Background()
+		data   = []byte(`{"type":"Create"}`)
+		domain = "fossbros-anonymous.io"
+		inbox  = testrig.URLMustParse("http://gts.fossbros-anonymous.io/users/foss_satan_2/inbox")
+	)
+
+	// Account at fossbros-anonymous.io
+	// with inbox served at a different host.
+	account := &gtsmodel.Account{}
+	*account = *suite.testAccounts["remote_account_1"]
+	account.ID = "01J2B5W1GWQ4MS9TCHNJ2MFNW2"
+	account.Username = "foss_satan_2"
+	account.URI = "http://gts.fossbros-anonymous.io/users/foss_satan_2"
+	account.URL = "http://gts.fossbros-anonymous.io/@foss_satan_2"
+	account.InboxURI = inbox.String()
+	account.OutboxURI = "http://gts.fossbros-anonymous.io/users/foss_satan_2/outbox"
+	account.FollowersURI = "http://gts.fossbros-anonymous.io/users/foss_satan_2/followers"
+	account.FollowingURI = "http://gts.fossbros-anonymous.io/users/foss_satan_2/following"
+	account.FeaturedCollectionURI = "http://gts.fossbros-anonymous.io/users/foss_satan_2/collections/featured"
+	account.PublicKeyURI = "http://gts.fossbros-anonymous.io/users/foss_satan_2#main-key"
+	if err := suite.state.DB.PutAccount(ctx, account); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Mark the account's instance unavailable.
+	instance, err := suite.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.UnavailableAt = time.Now()
+	if err := suite.state.DB.UpdateInstance(ctx, instance, "unavailable_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tp, err := suite.federator.TransportController().NewTransportForUsername(ctx, "the_mighty_zork")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delivery to the inbox should be held back,
+	// as the instance is found by account domain.
+	if err := tp.Deliver(ctx, data, inbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	delivery := suite.getDelivery(ctx, inbox)
+	if delivery == nil {
+		suite.FailNow("delivery not queued")
+	}
+	suite.True(delivery.NextAttemptAt.After(time.Now().Add(30 * time.Minute)))
+}
+
+// getDelivery returns the first queued delivery to inbox, if any.
+func (suite *AvailabilityTestSuite) getDelivery(ctx context.Context, inbox *url.URL) *gtsmodel.Delivery {
+	deliveries, err := suite.state.DB.GetDeliveries(ctx,
+		gtsmodel.DeliveryStatusAny,
+		inbox.String(),
+		&paging.Page{Limit: 1},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return deliveries[0]
+}
+
+// makeDue sets the given delivery to be due for its next attempt now.
+func (suite *AvailabilityTestSuite) makeDue(ctx context.Context, delivery *gtsmodel.Delivery) {
+	delivery.NextAttemptAt = time.Now().Add(-time.Second)
+	if err := suite.state.DB.UpdateDelivery(ctx, delivery, "next_attempt_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *AvailabilityTestSuite) countDeliveries(ctx context.Context, inbox *url.URL) int {
+	deliveries, err := suite.state.DB.GetDeliveries(ctx,
+		gtsmodel.DeliveryStatusAny,
+		inbox.String(),
+		&paging.Page{Limit: 100},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return len(deliveries)
+}
+
+func TestAvailabilityTestSuite(t *testing.T) {
+	suite.Run(t, new(AvailabilityTestSuite))
+}
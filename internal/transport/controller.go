@@ -28,7 +28,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"runtime"
+	"sync"
 
 	"codeberg.org/gruf/go-byteutil"
 	"codeberg.org/gruf/go-cache/v3"
@@ -47,6 +47,15 @@ type Controller interface {
 
 	// NewTransportForUsername searches for account with username, and returns result of .NewTransport().
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+
+	// ProcessDeliveries enqueues processing of all queued deliveries
+	// which are due to be (re)attempted, on the delivery worker pool.
+	ProcessDeliveries(ctx context.Context) error
+
+	// ProbeUnavailableInstances checks whether any remote instances marked
+	// unavailable due to sustained delivery failures are reachable again,
+	// marking them available to resume deliveries to them if so.
+	ProbeUnavailableInstances(ctx context.Context) error
 }
 
 type controller struct {
@@ -56,24 +65,23 @@ type controller struct {
 	client    pub.HttpClient
 	trspCache cache.TTLCache[string, *transport]
 	userAgent string
-	senders   int // no. concurrent batch delivery routines.
+
+	// inboxes contains the URIs of remote inboxes
+	// whose queued deliveries are currently being
+	// processed, mapped to whether processing
+	// should be run again once finished.
+	inboxes   map[string]bool
+	inboxesMu sync.Mutex
 }
 
 // NewController returns an implementation of the Controller interface for creating new transports
 func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.Clock, client pub.HttpClient) Controller {
 	var (
-		host             = config.GetHost()
-		proto            = config.GetProtocol()
-		version          = config.GetSoftwareVersion()
-		senderMultiplier = config.GetAdvancedSenderMultiplier()
+		host    = config.GetHost()
+		proto   = config.GetProtocol()
+		version = config.GetSoftwareVersion()
 	)
 
-	senders := senderMultiplier * runtime.GOMAXPROCS(0)
-	if senders < 1 {
-		// Clamp senders to 1.
-		senders = 1
-	}
-
 	c := &controller{
 		state:     state,
 		fedDB:     federatingDB,
@@ -81,7 +89,7 @@ func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.C
 		client:    client,
 		trspCache: cache.NewTTL[string, *transport](0, 100, 0),
 		userAgent: fmt.Sprintf("gotosocial/%s (+%s://%s)", version, proto, host),
-		senders:   senders,
+		inboxes:   make(map[string]bool),
 	}
 
 	return c
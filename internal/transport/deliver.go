@@ -21,79 +21,40 @@ import (
 	"context"
 	"net/http"
 	"net/url"
-	"sync"
+	"time"
 
 	"codeberg.org/gruf/go-byteutil"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
 	var (
-		// errs accumulates errors received during
-		// attempted delivery by deliverer routines.
-		errs gtserror.MultiError
-
-		// wait blocks until all sender
-		// routines have returned.
-		wait sync.WaitGroup
-
-		// mutex protects 'recipients' and
-		// 'errs' for concurrent access.
-		mutex sync.Mutex
-
 		// Get current instance host info.
 		domain = config.GetAccountDomain()
 		host   = config.GetHost()
+
+		// Time at which deliveries are queued.
+		now = time.Now()
+
+		// Deliveries to queue.
+		deliveries = make([]*gtsmodel.Delivery, 0, len(recipients))
 	)
 
-	// Block on expect no. senders.
-	wait.Add(t.controller.senders)
-
-	for i := 0; i < t.controller.senders; i++ {
-		go func() {
-			// Mark returned.
-			defer wait.Done()
-
-			for {
-				// Acquire lock.
-				mutex.Lock()
-
-				if len(recipients) == 0 {
-					// Reached end.
-					mutex.Unlock()
-					return
-				}
-
-				// Pop next recipient.
-				i := len(recipients) - 1
-				to := recipients[i]
-				recipients = recipients[:i]
-
-				// Done with lock.
-				mutex.Unlock()
-
-				// Skip delivery to recipient if it is "us".
-				if to.Host == host || to.Host == domain {
-					continue
-				}
-
-				// Attempt to deliver data to recipient.
-				if err := t.deliver(ctx, b, to); err != nil {
-					mutex.Lock() // safely append err to accumulator.
-					errs.Appendf("error delivering to %s: %w", to, err)
-					mutex.Unlock()
-				}
-			}
-		}()
-	}
+	for _, to := range recipients {
+		// Skip delivery to recipient if it is "us".
+		if to.Host == host || to.Host == domain {
+			continue
+		}
 
-	// Wait for finish.
-	wait.Wait()
+		deliveries = append(deliveries, t.newDelivery(ctx, b, to, now))
+	}
 
-	// Return combined err.
-	return errs.Combine()
+	// Queue deliveries for sending.
+	return t.controller.queueDeliveries(ctx, deliveries)
 }
 
 func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
@@ -102,8 +63,28 @@ func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
 		return nil
 	}
 
-	// Deliver data to recipient.
-	return t.deliver(ctx, b, to)
+	// Queue delivery for sending.
+	delivery := t.newDelivery(ctx, b, to, time.Now())
+	return t.controller.queueDeliveries(ctx, []*gtsmodel.Delivery{delivery})
+}
+
+// newDelivery prepares a new delivery of data to
+// recipient, signed by this transport's public key.
+// If the recipient's instance is currently unreachable,
+// the delivery is held back until it's next been probed.
+func (t *transport) newDelivery(ctx context.Context, b []byte, to *url.URL, now time.Time) *gtsmodel.Delivery {
+	nextAttemptAt := now
+	if t.controller.instanceUnavailable(ctx, to) {
+		nextAttemptAt = now.Add(deliveryUnavailableBackoff)
+	}
+
+	return &gtsmodel.Delivery{
+		ID:            id.NewULID(),
+		PubKeyID:      t.pubKeyID,
+		InboxURI:      to.String(),
+		Data:          b,
+		NextAttemptAt: nextAttemptAt,
+	}
 }
 
 func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) error {
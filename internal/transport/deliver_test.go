@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DeliverTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *DeliverTestSuite) TestBatchDeliverQueue() {
+	var (
+		ctx  = context.Background()
+		data = []byte(`{"type":"Create"}`)
+
+		okInbox      = testrig.URLMustParse("https://ok.example.org/inbox")
+		retryInbox   = testrig.URLMustParse("https://retry.example.org/inbox")
+		refusedInbox = testrig.URLMustParse("https://refused.example.org/inbox")
+	)
+
+	// Respond to POSTs with a status
+	// code depending on the inbox host.
+	codes := map[string]int{
+		okInbox.Host:      http.StatusAccepted,
+		retryInbox.Host:   http.StatusServiceUnavailable,
+		refusedInbox.Host: http.StatusForbidden,
+	}
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		code := codes[req.URL.Host]
+		return &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+			Request:    req,
+		}, nil
+	}, "../../testrig/media")
+
+	controller := testrig.NewTestTransportController(&suite.state, httpClient)
+	transport, err := controller.NewTransportForUsername(ctx, "the_mighty_zork")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := transport.BatchDeliver(ctx, data, []*url.URL{
+		okInbox,
+		retryInbox,
+		refusedInbox,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Wait until all three deliveries
+	// have been attempted once.
+	var deliveries []*gtsmodel.Delivery
+	if !testrig.WaitFor(func() bool {
+		deliveries, err = suite.state.DB.GetDeliveries(ctx,
+			gtsmodel.DeliveryStatusAny,
+			"",
+			&paging.Page{Limit: 10},
+		)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if len(deliveries) != 2 {
+			return false
+		}
+
+		for _, d := range deliveries {
+			if d.Attempts != 1 {
+				return false
+			}
+		}
+
+		return true
+	}) {
+		suite.FailNow("timed out waiting for deliveries")
+	}
+
+	byInbox := make(map[string]*gtsmodel.Delivery, len(deliveries))
+	for _, d := range deliveries {
+		byInbox[d.InboxURI] = d
+	}
+
+	// Successful delivery was dropped from the queue.
+	suite.NotContains(byInbox, okInbox.String())
+
+	// Temporary failure is pending retry after backoff.
+	retry := byInbox[retryInbox.String()]
+	if suite.NotNil(retry) {
+		suite.False(retry.Failed())
+		suite.True(retry.NextAttemptAt.After(time.Now()))
+		suite.Equal(data, retry.Data)
+		suite.Contains(retry.LastError, "Service Unavailable")
+	}
+
+	// Refusal is given up on straight away.
+	refused := byInbox[refusedInbox.String()]
+	if suite.NotNil(refused) {
+		suite.True(refused.Failed())
+		suite.Contains(refused.LastError, "Forbidden")
+	}
+}
+
+func TestDeliverTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliverTestSuite))
+}
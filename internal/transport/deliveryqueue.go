@@ -0,0 +1,299 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// deliveryBatchSize is the max no. pending deliveries
+	// to one inbox that are fetched from the db at once.
+	deliveryBatchSize = 100
+
+	// deliveryMaxAttempts is the no. attempts after which
+	// a failing delivery will be given up on. With the
+	// backoff below, this spans a little over two days.
+	deliveryMaxAttempts = 14
+
+	// deliveryBackoffMin and deliveryBackoffMax
+	// bound the time waited between attempts.
+	deliveryBackoffMin = time.Minute
+	deliveryBackoffMax = 12 * time.Hour
+
+	// deliveryUnavailableBackoff is the time waited before
+	// rechecking a delivery to an instance marked unavailable,
+	// matching how often such instances are probed.
+	deliveryUnavailableBackoff = time.Hour
+)
+
+// deliveryBackoff returns the time to wait before
+// reattempting a delivery that has been attempted
+// the given no. times, doubling with each attempt.
+func deliveryBackoff(attempts int) time.Duration {
+	backoff := deliveryBackoffMin
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= deliveryBackoffMax {
+			return deliveryBackoffMax
+		}
+	}
+	return backoff
+}
+
+// queueDeliveries stores the given deliveries in the
+// database queue, then enqueues processing of each
+// of the affected inboxes on the delivery worker pool.
+func (c *controller) queueDeliveries(ctx context.Context, deliveries []*gtsmodel.Delivery) error {
+	if len(deliveries) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	if err := c.state.DB.PutDeliveries(ctx, deliveries...); err != nil {
+		return gtserror.Newf("error queueing deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		c.processInbox(delivery.InboxURI)
+	}
+
+	return nil
+}
+
+func (c *controller) ProcessDeliveries(ctx context.Context) error {
+	inboxURIs, err := c.state.DB.GetDueDeliveryInboxURIs(ctx, time.Now())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting due delivery inboxes: %w", err)
+	}
+
+	for _, inboxURI := range inboxURIs {
+		c.processInbox(inboxURI)
+	}
+
+	return nil
+}
+
+// processInbox enqueues processing of queued deliveries to
+// the given inbox on the delivery worker pool. If the inbox
+// is already being processed, it is instead marked to be
+// processed again once finished, so only one worker at a
+// time handles any one inbox, preserving delivery order.
+func (c *controller) processInbox(inboxURI string) {
+	c.inboxesMu.Lock()
+	if _, ok := c.inboxes[inboxURI]; ok {
+		c.inboxes[inboxURI] = true
+		c.inboxesMu.Unlock()
+		return
+	}
+	c.inboxes[inboxURI] = false
+	c.inboxesMu.Unlock()
+
+	go c.state.Workers.Delivery.Enqueue(func(ctx context.Context) {
+		for {
+			c.deliverToInbox(ctx, inboxURI)
+
+			c.inboxesMu.Lock()
+			if again := c.inboxes[inboxURI]; !again || ctx.Err() != nil {
+				// Done with this inbox.
+				delete(c.inboxes, inboxURI)
+				c.inboxesMu.Unlock()
+				return
+			}
+
+			// More deliveries were queued
+			// while we were processing, go
+			// round again to handle them.
+			c.inboxes[inboxURI] = false
+			c.inboxesMu.Unlock()
+		}
+	})
+}
+
+// deliverToInbox attempts all pending deliveries to the
+// given inbox, oldest first, stopping at the first one
+// which is not yet due, or which fails and is scheduled
+// for a retry, so that later deliveries wait behind it.
+func (c *controller) deliverToInbox(ctx context.Context, inboxURI string) {
+	for {
+		deliveries, err := c.state.DB.GetPendingDeliveriesForInbox(ctx,
+			inboxURI,
+			deliveryBatchSize,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			if ctx.Err() == nil {
+				log.Errorf(ctx, "error getting pending deliveries to %s: %v", inboxURI, err)
+			}
+			return
+		}
+
+		for _, delivery := range deliveries {
+			if ctx.Err() != nil {
+				// Shutting down, the rest
+				// will be picked up again
+				// on next startup.
+				return
+			}
+
+			if delivery.NextAttemptAt.After(time.Now()) {
+				// Not yet due.
+				return
+			}
+
+			if !c.attemptDelivery(ctx, delivery) {
+				// Will be retried.
+				return
+			}
+		}
+
+		if len(deliveries) < deliveryBatchSize {
+			// Reached end.
+			return
+		}
+	}
+}
+
+// attemptDelivery makes one attempt at the given delivery,
+// removing it from the queue on success, and otherwise
+// scheduling a retry or giving up on it. Returns false
+// only if the delivery remains pending for later retry.
+func (c *controller) attemptDelivery(ctx context.Context, delivery *gtsmodel.Delivery) bool {
+	err := c.deliver(ctx, delivery)
+	if err == nil {
+		// Delivered! Drop from queue.
+		if err := c.state.DB.DeleteDeliveryByID(ctx, delivery.ID); err != nil {
+			log.Errorf(ctx, "error deleting delivery %s: %v", delivery.ID, err)
+		}
+		return true
+	}
+
+	if ctx.Err() != nil {
+		// Interrupted by shutdown, this
+		// doesn't count as an attempt.
+		return false
+	}
+
+	now := time.Now()
+
+	if errors.Is(err, ErrInstanceUnavailable) {
+		// Instance is marked unavailable so this wasn't
+		// really attempted; don't count it, just check
+		// again once the instance has been re-probed.
+		delivery.NextAttemptAt = now.Add(deliveryUnavailableBackoff)
+		if err := c.state.DB.UpdateDelivery(ctx, delivery, "next_attempt_at"); err != nil {
+			log.Errorf(ctx, "error updating delivery %s: %v", delivery.ID, err)
+		}
+		log.Debugf(ctx, "delivery %s to %s postponed: %v", delivery.ID, delivery.InboxURI, err)
+		return false
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.LastError = err.Error()
+	columns := []string{"attempts", "last_attempt_at", "last_error"}
+
+	retry := !deliveryErrPermanent(err) &&
+		delivery.Attempts < deliveryMaxAttempts
+
+	if retry {
+		// Back off before next attempt.
+		backoff := deliveryBackoff(delivery.Attempts)
+		delivery.NextAttemptAt = now.Add(backoff)
+		columns = append(columns, "next_attempt_at")
+		log.Debugf(ctx, "delivery %s to %s failed, retrying in %s: %v", delivery.ID, delivery.InboxURI, backoff, err)
+	} else {
+		// Give up on this one.
+		delivery.FailedAt = now
+		columns = append(columns, "failed_at")
+		log.Warnf(ctx, "delivery %s to %s failed after %d attempt(s), giving up: %v", delivery.ID, delivery.InboxURI, delivery.Attempts, err)
+	}
+
+	if err := c.state.DB.UpdateDelivery(ctx, delivery, columns...); err != nil {
+		log.Errorf(ctx, "error updating delivery %s: %v", delivery.ID, err)
+	}
+
+	return !retry
+}
+
+// deliver performs the given delivery using
+// a transport for the delivery's public key.
+func (c *controller) deliver(ctx context.Context, delivery *gtsmodel.Delivery) error {
+	account, err := c.state.DB.GetAccountByPubkeyID(ctx, delivery.PubKeyID)
+	if err != nil {
+		return gtserror.Newf("error getting account for public key %s: %w", delivery.PubKeyID, err)
+	}
+
+	transp, err := c.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	to, err := url.Parse(delivery.InboxURI)
+	if err != nil {
+		return gtserror.Newf("invalid inbox uri %s: %w", delivery.InboxURI, err)
+	}
+
+	if c.instanceUnavailable(ctx, to) {
+		// Don't bother trying.
+		return ErrInstanceUnavailable
+	}
+
+	// Attempt the delivery, recording
+	// the outcome against the instance.
+	err = transp.(*transport).deliver(ctx, delivery.Data, to)
+	if ctx.Err() == nil {
+		c.recordDelivery(ctx, to, err)
+	}
+
+	return err
+}
+
+// deliveryErrPermanent returns whether the given delivery
+// error is one that retrying the delivery won't fix.
+func deliveryErrPermanent(err error) bool {
+	switch {
+	case errors.Is(err, db.ErrNoEntries),
+		errors.Is(err, httpclient.ErrReservedAddr):
+		// Sending account is gone,
+		// or inbox is disallowed.
+		return true
+	}
+
+	switch code := gtserror.StatusCode(err); {
+	case code == http.StatusRequestTimeout,
+		code == http.StatusTooManyRequests:
+		// Remote might accept later.
+		return false
+
+	case code >= 400 && code < 500:
+		// Remote refused it.
+		return true
+	}
+
+	return false
+}
@@ -75,6 +75,9 @@ type Transport interface {
 
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
 	Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error)
+
+	// DereferenceDomainPermissions fetches the domain permission list at the URI of the given subscription, returning the response body.
+	DereferenceDomainPermissions(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) (io.ReadCloser, error)
 }
 
 // transport implements the Transport interface.
@@ -94,6 +97,11 @@ func (t *transport) GET(r *http.Request) (*http.Response, error) {
 		return nil, errors.New("must be GET request")
 	}
 
+	if t.controller.instanceUnavailable(r.Context(), r.URL) {
+		// Don't bother trying.
+		return nil, ErrInstanceUnavailable
+	}
+
 	// Prepare HTTP GET signing func with opts.
 	sign := t.signGET(httpsig.SignatureOption{
 		ExcludeQueryStringFromPathPseudoHeader: false,
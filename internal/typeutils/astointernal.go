@@ -23,6 +23,7 @@ import (
 	"net/url"
 
 	"github.com/miekg/dns"
+	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -141,6 +142,10 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	discoverable := ap.GetDiscoverable(accountable)
 	acct.Discoverable = &discoverable
 
+	// Extract account indexability (default = false).
+	indexable := ap.GetIndexable(accountable)
+	acct.Indexable = &indexable
+
 	// Extract the URL property.
 	urls := ap.GetURL(accountable)
 	if len(urls) == 0 {
@@ -310,14 +315,48 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		status.Mentions = mentions
 	}
 
-	// status.ContentWarning
-	//
-	// Topic or content warning for this status;
-	// prefer Summary, fall back to Name.
-	if summary := ap.ExtractSummary(statusable); summary != "" {
-		status.ContentWarning = summary
-	} else {
-		status.ContentWarning = ap.ExtractName(statusable)
+	switch statusable.GetTypeName() {
+	case ap.ObjectArticle, ap.ObjectPage, ap.ObjectEvent:
+		// status.Title
+		// status.Abstract
+		// status.ContentWarning
+		//
+		// Longer-form statuses carry a title in Name,
+		// and an abstract in Summary, rather than a
+		// content warning. Only use the abstract as a
+		// content warning if status is marked sensitive.
+		status.Title = ap.ExtractName(statusable)
+		status.Abstract = ap.ExtractSummary(statusable)
+		if ap.ExtractSensitive(statusable) {
+			status.ContentWarning = status.Abstract
+		}
+
+		// status.StartTime
+		// status.EndTime
+		// status.Location
+		//
+		// When and where this status takes place,
+		// only really expected to be set on Events.
+		if withStart, ok := statusable.(ap.WithStartTime); ok {
+			status.StartTime = ap.GetStartTime(withStart)
+		}
+		if withEnd, ok := statusable.(ap.WithEndTime); ok {
+			status.EndTime = ap.GetEndTime(withEnd)
+		}
+		if withLocation, ok := statusable.(ap.WithLocation); ok {
+			status.Location = ap.ExtractLocation(withLocation)
+		}
+
+	default:
+		// status.ContentWarning
+		//
+		// Topic or content warning for this status;
+		// prefer Summary, fall back to Name.
+		if summary := ap.ExtractSummary(statusable); summary != "" {
+			status.ContentWarning = summary
+		} else {
+			status.ContentWarning = ap.ExtractName(statusable)
+		}
 	}
 
 	// status.Published
@@ -383,6 +422,36 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	// status.QuoteOfAccountID
+	// status.QuoteOfAccount
+	//
+	// Status that this status quotes, if applicable.
+	// As with inReplyTo, if we don't have the quoted
+	// status in the database, just set the URI and
+	// assume we can deref it later.
+	if quoteOfURI := ap.ExtractQuoteURI(statusable); quoteOfURI != nil {
+		status.QuoteOfURI = quoteOfURI.String()
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, status.QuoteOfURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", status.QuoteOfURI, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+			status.QuoteOfAccountID = quoteOf.AccountID
+			status.QuoteOfAccount = quoteOf.Account
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -393,15 +462,23 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		return nil, gtserror.SetMalformed(err)
 	}
 
-	// Advanced visibility toggles for this status.
-	//
-	// TODO: a lot of work to be done here -- a new type
-	// needs to be created for this in go-fed/activity.
-	// Until this is implemented, assume all true.
+	// Advanced visibility toggles for this status,
+	// derived from the status interaction policy.
+	// Interactions not specified by the policy
+	// are assumed to be permitted to everyone.
+	status.InteractionPolicy = gtsmodel.InteractionPolicy{
+		CanReply: asPolicyValue(statusable, ap.PolicyCanReply, status.Account),
+		CanBoost: asPolicyValue(statusable, ap.PolicyCanAnnounce, status.Account),
+		CanLike:  asPolicyValue(statusable, ap.PolicyCanLike, status.Account),
+	}
 	status.Federated = util.Ptr(true)
-	status.Boostable = util.Ptr(true)
-	status.Replyable = util.Ptr(true)
-	status.Likeable = util.Ptr(true)
+	status.Boostable = util.Ptr(status.InteractionPolicy.CanBoost != gtsmodel.PolicyValueNobody)
+	status.Replyable = util.Ptr(status.InteractionPolicy.CanReply != gtsmodel.PolicyValueNobody)
+	status.Likeable = util.Ptr(status.InteractionPolicy.CanLike != gtsmodel.PolicyValueNobody)
+
+	// Quote policy is federated as part of the
+	// status interaction policy; assume true if unset.
+	status.Quotable = util.Ptr(ap.GetCanQuote(statusable))
 
 	// status.Sensitive
 	sensitive := ap.ExtractSensitive(statusable)
@@ -837,3 +914,45 @@ func (c *Converter) getASObjectStatus(ctx context.Context, id string, with ap.Wi
 
 	return status, nil
 }
+
+// asPolicyValue converts the IRIs that are always permitted to
+// perform the given interaction with statusable into the closest
+// matching policy value, relative to the given status author.
+//
+// Returns an empty value (ie., everyone) if the interaction
+// isn't specified by the status interaction policy at all.
+func asPolicyValue(
+	statusable ap.Statusable,
+	interaction string,
+	author *gtsmodel.Account,
+) gtsmodel.PolicyValue {
+	always, ok := ap.GetInteractionPolicyAlways(statusable, interaction)
+	if !ok {
+		return ""
+	}
+
+	var followers, others bool
+	for _, iri := range always {
+		switch {
+		case pub.IsPublic(iri):
+			// Anyone may interact.
+			return ""
+		case iri == author.FollowersURI:
+			// Followers may interact.
+			followers = true
+		case iri != author.URI:
+			// Some other account,
+			// likely one mentioned.
+			others = true
+		}
+	}
+
+	switch {
+	case followers:
+		return gtsmodel.PolicyValueFollowers
+	case others:
+		return gtsmodel.PolicyValueMentioned
+	default:
+		return gtsmodel.PolicyValueNobody
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
@@ -150,6 +151,7 @@ func (suite *ASToInternalTestSuite) TestParseGargron() {
 	suite.Equal("https://mastodon.social/inbox", *acct.SharedInboxURI)
 	suite.Equal([]string{"https://tooting.ai/users/Gargron"}, acct.AlsoKnownAsURIs)
 	suite.Equal(int64(1458086400), acct.CreatedAt.Unix())
+	suite.True(*acct.Indexable)
 }
 
 func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
@@ -281,10 +283,58 @@ func (suite *ASToInternalTestSuite) TestParseBookwyrmStatus() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Equal("Review of \"Dracula\" (5 stars): A great read, not just for codifying vampire lore, but the way it's built from letters and diaries.", status.ContentWarning)
+	suite.Equal("Review of \"Dracula\" (5 stars): A great read, not just for codifying vampire lore, but the way it's built from letters and diaries.", status.Title)
+	suite.Empty(status.ContentWarning)
 	suite.Len(status.Attachments, 1)
 }
 
+func (suite *ASToInternalTestSuite) TestParseMobilizonEvent() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + authorAccount.URI + `/events/0fc4e7a6",
+  "type": "Event",
+  "url": "` + authorAccount.URI + `/events/0fc4e7a6",
+  "published": "2024-05-01T10:00:00Z",
+  "attributedTo": "` + authorAccount.URI + `",
+  "name": "Community Repair Café",
+  "summary": "Bring along your broken things!",
+  "content": "<p>Come along and learn how to fix your stuff.</p>",
+  "startTime": "2024-06-01T13:00:00Z",
+  "endTime": "2024-06-01T17:00:00Z",
+  "location": {
+    "type": "Place",
+    "name": "Community Center, Main Street"
+  },
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "` + authorAccount.FollowersURI + `"
+  ]
+}`
+
+	t := suite.jsonToType(raw)
+	asEvent, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), asEvent)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(ap.ObjectEvent, status.ActivityStreamsType)
+	suite.Equal("Community Repair Café", status.Title)
+	suite.Equal("Bring along your broken things!", status.Abstract)
+	suite.Empty(status.ContentWarning)
+	suite.Equal("2024-06-01T13:00:00Z", status.StartTime.Format(time.RFC3339))
+	suite.Equal("2024-06-01T17:00:00Z", status.EndTime.Format(time.RFC3339))
+	suite.Equal("Community Center, Main Street", status.Location)
+}
+
 func (suite *ASToInternalTestSuite) TestParseFlag1() {
 	reportedAccount := suite.testAccounts["local_account_1"]
 	reportingAccount := suite.testAccounts["remote_account_1"]
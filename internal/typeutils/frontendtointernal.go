@@ -47,3 +47,27 @@ func APIMarkerNameToMarkerName(m apimodel.MarkerName) gtsmodel.MarkerName {
 	}
 	return ""
 }
+
+func APIFilterActionToFilterAction(m apimodel.FilterAction) gtsmodel.FilterAction {
+	switch m {
+	case apimodel.FilterActionWarn:
+		return gtsmodel.FilterActionWarn
+	case apimodel.FilterActionHide:
+		return gtsmodel.FilterActionHide
+	}
+	return ""
+}
+
+func APIPolicyValueToPolicyValue(m apimodel.PolicyValue) gtsmodel.PolicyValue {
+	switch m {
+	case apimodel.PolicyValueEveryone:
+		return gtsmodel.PolicyValueEveryone
+	case apimodel.PolicyValueFollowers:
+		return gtsmodel.PolicyValueFollowers
+	case apimodel.PolicyValueMentioned:
+		return gtsmodel.PolicyValueMentioned
+	case apimodel.PolicyValueNobody:
+		return gtsmodel.PolicyValueNobody
+	}
+	return ""
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"html"
 	"net/url"
 	"strings"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // AccountToAS converts a gts model account into an activity streams person, suitable for federation
@@ -166,6 +168,15 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	discoverableProp.Set(*a.Discoverable)
 	person.SetTootDiscoverable(discoverableProp)
 
+	// indexable
+	// Public statuses may be included in
+	// full-text search results elsewhere.
+	// Only included if true, as other
+	// implementations default to false.
+	if util.PtrValueOr(a.Indexable, false) {
+		ap.SetIndexable(person, true)
+	}
+
 	// devices
 	// NOT IMPLEMENTED, probably won't implement
 
@@ -488,6 +499,11 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		ap.SetUpdated(status, s.EditedAt)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -559,6 +575,21 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		}
 		tagProp.AppendTootHashtag(asHashtag)
 	}
+
+	// tag -- quote
+	if s.QuoteOfURI != "" {
+		qURI, err := url.Parse(s.QuoteOfURI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing url %s: %w", s.QuoteOfURI, err)
+		}
+
+		// FEP-e232 object link to quoted status.
+		tagProp.AppendActivityStreamsLink(quoteLink(qURI))
+
+		// Set the non-standard quote properties
+		// too, for software without FEP-e232.
+		ap.SetQuoteURI(status, qURI)
+	}
 	status.SetActivityStreamsTag(tagProp)
 
 	// parse out some URIs we need here
@@ -628,12 +659,20 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 
 	// content -- the actual post
 	// itself, plus the language
+	content := s.Content
+	if s.QuoteOfURI != "" && util.PtrValueOr(s.Local, false) {
+		// Append a link to the quoted status, so
+		// software that doesn't support quotes
+		// can still show what this quotes.
+		content += quoteInline(s)
+	}
+
 	contentProp := streams.NewActivityStreamsContentProperty()
-	contentProp.AppendXMLSchemaString(s.Content)
+	contentProp.AppendXMLSchemaString(content)
 
 	if s.Language != "" {
 		contentProp.AppendRDFLangString(map[string]string{
-			s.Language: s.Content,
+			s.Language: content,
 		})
 	}
 
@@ -672,9 +711,99 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
+	// interactionPolicy -- public and unlisted
+	// statuses are assumed quotable by anyone,
+	// so only set a quote policy to restrict that.
+	if (s.Visibility == gtsmodel.VisibilityPublic ||
+		s.Visibility == gtsmodel.VisibilityUnlocked) &&
+		!util.PtrValueOr(s.Quotable, true) {
+		ap.SetCanQuote(status, authorAccountURI)
+	}
+
+	// interactionPolicy -- everyone is assumed to be
+	// permitted to interact with the status, so only
+	// set those interactions that are restricted.
+	policyRules := []struct {
+		interaction string
+		value       gtsmodel.PolicyValue
+	}{
+		{ap.PolicyCanReply, s.InteractionPolicy.CanReply},
+		{ap.PolicyCanAnnounce, s.InteractionPolicy.CanBoost},
+		{ap.PolicyCanLike, s.InteractionPolicy.CanLike},
+	}
+
+	for _, rule := range policyRules {
+		if rule.value.OrEveryone() == gtsmodel.PolicyValueEveryone {
+			continue
+		}
+
+		always := []*url.URL{authorAccountURI}
+
+		if rule.value == gtsmodel.PolicyValueFollowers {
+			always = append(always, authorFollowersURI)
+		}
+
+		if rule.value == gtsmodel.PolicyValueFollowers ||
+			rule.value == gtsmodel.PolicyValueMentioned {
+			for _, m := range mentions {
+				mentionedURI, err := url.Parse(m.TargetAccountURI)
+				if err != nil {
+					return nil, gtserror.Newf("error parsing mentioned account uri: %w", err)
+				}
+				always = append(always, mentionedURI)
+			}
+		}
+
+		var approvalRequired []*url.URL
+		if rule.interaction == ap.PolicyCanReply {
+			// Replies from anyone else are queued
+			// for approval rather than dropped.
+			approvalRequired = []*url.URL{publicURI}
+		}
+
+		ap.SetInteractionPolicy(status, rule.interaction, always, approvalRequired)
+	}
+
 	return status, nil
 }
 
+// quoteLink returns a new FEP-e232
+// object link to the given quoted status.
+func quoteLink(quoteOfURI *url.URL) vocab.ActivityStreamsLink {
+	link := streams.NewActivityStreamsLink()
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.Set(quoteOfURI)
+	link.SetActivityStreamsHref(hrefProp)
+
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(ap.ObjectLinkMediaType)
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	relProp := streams.NewActivityStreamsRelProperty()
+	relProp.AppendRFCRfc5988(ap.QuoteRel)
+	link.SetActivityStreamsRel(relProp)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteOfURI.String())
+	link.SetActivityStreamsName(nameProp)
+
+	return link
+}
+
+// quoteInline returns an html paragraph
+// linking to the status quoted by s.
+func quoteInline(s *gtsmodel.Status) string {
+	href := s.QuoteOfURI
+	if s.QuoteOf != nil && s.QuoteOf.URL != "" {
+		// Prefer web URL.
+		href = s.QuoteOf.URL
+	}
+
+	href = html.EscapeString(href)
+	return `<p class="quote-inline">RE: <a href="` + href + `">` + href + `</a></p>`
+}
+
 func (c *Converter) addPollToAS(ctx context.Context, poll *gtsmodel.Poll, dst ap.Pollable) error {
 	var optionsProp interface {
 		// the minimum interface for appending AS Notes
@@ -932,6 +1061,82 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts a gts model relay subscription into the
+// activity streams Follow sent from the instance actor to the relay.
+// By convention, relay Follows take the Public collection as object.
+func (c *Converter) RelayToASFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsFollow, error) {
+	instanceAcct, err := c.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	actorIRI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", instanceAcct.URI, err)
+	}
+
+	followIRI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", r.FollowURI, err)
+	}
+
+	publicIRI, err := url.Parse(pub.PublicActivityPubIRI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", pub.PublicActivityPubIRI, err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+
+	// Set the instance actor as actor.
+	followActor := streams.NewActivityStreamsActorProperty()
+	followActor.AppendIRI(actorIRI)
+	follow.SetActivityStreamsActor(followActor)
+
+	// Set the id.
+	followIDProp := streams.NewJSONLDIdProperty()
+	followIDProp.SetIRI(followIRI)
+	follow.SetJSONLDId(followIDProp)
+
+	// Set Public as the object.
+	followObjectProp := streams.NewActivityStreamsObjectProperty()
+	followObjectProp.AppendIRI(publicIRI)
+	follow.SetActivityStreamsObject(followObjectProp)
+
+	return follow, nil
+}
+
+// RelayToASUndoFollow converts a gts model relay subscription into
+// an activity streams Undo of the Follow sent from the instance
+// actor to the relay, used to unsubscribe from the relay.
+func (c *Converter) RelayToASUndoFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsUndo, error) {
+	follow, err := c.RelayToASFollow(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	undoIRI, err := url.Parse(r.FollowURI + "#undo")
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", r.FollowURI, err)
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the id.
+	undoIDProp := streams.NewJSONLDIdProperty()
+	undoIDProp.SetIRI(undoIRI)
+	undo.SetJSONLDId(undoIDProp)
+
+	// Same actor as the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+
+	// Set the whole Follow as object.
+	undoObjectProp := streams.NewActivityStreamsObjectProperty()
+	undoObjectProp.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(undoObjectProp)
+
+	return undo, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1461,6 +1666,9 @@ func (c *Converter) StatusURIsToASRepliesPage(ctx context.Context, status *gtsmo
 // OutboxID is used to create the 'partOf' field in the collection.
 //
 // Appropriate 'next' and 'prev' fields will be created based on the highest and lowest IDs present in the statuses slice.
+//
+// Regular statuses are wrapped in a Create, while boost wrapper statuses are given as an Announce.
+// Statuses that are not federated, or are pending approval, are skipped.
 // the goal is to end up with something like this:
 //
 //	{
@@ -1490,10 +1698,10 @@ func (c *Converter) StatusesToASOutboxPage(ctx context.Context, outboxID string,
 	pageIDProp := streams.NewJSONLDIdProperty()
 	pageID := fmt.Sprintf("%s?page=true", outboxID)
 	if minID != "" {
-		pageID = fmt.Sprintf("%s&minID=%s", pageID, minID)
+		pageID = fmt.Sprintf("%s&min_id=%s", pageID, minID)
 	}
 	if maxID != "" {
-		pageID = fmt.Sprintf("%s&maxID=%s", pageID, maxID)
+		pageID = fmt.Sprintf("%s&max_id=%s", pageID, maxID)
 	}
 	pageIDURI, err := url.Parse(pageID)
 	if err != nil {
@@ -1516,20 +1724,58 @@ func (c *Converter) StatusesToASOutboxPage(ctx context.Context, outboxID string,
 	var highest string
 	var lowest string
 	for _, s := range statuses {
-		note, err := c.StatusToAS(ctx, s)
-		if err != nil {
-			return nil, err
-		}
-
-		activity := WrapStatusableInCreate(note, true)
-		itemsProp.AppendActivityStreamsCreate(activity)
-
+		// Update paging values even for
+		// skipped statuses, so that callers
+		// can still page past them.
 		if highest == "" || s.ID > highest {
 			highest = s.ID
 		}
 		if lowest == "" || s.ID < lowest {
 			lowest = s.ID
 		}
+
+		if !util.PtrValueOr(s.Federated, true) || s.IsPendingApproval() {
+			// Not for
+			// federation.
+			continue
+		}
+
+		if s.BoostOfID == "" {
+			// Regular status,
+			// wrap in a Create.
+			note, err := c.StatusToAS(ctx, s)
+			if err != nil {
+				return nil, err
+			}
+
+			activity := WrapStatusableInCreate(note, true)
+			itemsProp.AppendActivityStreamsCreate(activity)
+			continue
+		}
+
+		// Boost wrapper status;
+		// ensure boost populated.
+		if s.BoostOf == nil ||
+			s.BoostOfAccount == nil ||
+			s.Account == nil {
+			if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+				return nil, gtserror.Newf("error populating boost %s: %w", s.ID, err)
+			}
+		}
+
+		if s.BoostOf == nil || s.BoostOfAccount == nil ||
+			!util.PtrValueOr(s.BoostOf.Federated, true) {
+			// Boosted status gone,
+			// or not for federation.
+			continue
+		}
+
+		announce, err := c.BoostToAS(ctx, s, s.Account, s.BoostOfAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		itemsProp.AppendActivityStreamsAnnounce(announce)
 	}
 	page.SetActivityStreamsOrderedItems(itemsProp)
 
@@ -784,6 +784,65 @@ func (suite *InternalToASTestSuite) TestStatusesToASOutboxPage() {
 }`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusesToASOutboxPageWithBoosts() {
+	testAccount := suite.testAccounts["admin_account"]
+	ctx := context.Background()
+
+	// get public statuses + boosts from testaccount
+	statuses, err := suite.db.GetAccountStatuses(ctx, testAccount.ID, 30, true, false, "", "", false, true)
+	suite.NoError(err)
+
+	page, err := suite.typeconverter.StatusesToASOutboxPage(ctx, testAccount.OutboxURI, "", "", statuses)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(page)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://localhost:8080/users/admin/outbox?page=true",
+  "next": "http://localhost:8080/users/admin/outbox?page=true\u0026max_id=01F8MH75CBF9JFX4ZAD54N0W0R",
+  "orderedItems": [
+    {
+      "actor": "http://localhost:8080/users/admin",
+      "cc": [
+        "http://localhost:8080/users/the_mighty_zork",
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "id": "http://localhost:8080/users/admin/statuses/01G36SF3V6Y6V5BF9P4R7PQG7G",
+      "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+      "published": "2021-10-20T10:41:37Z",
+      "to": "http://localhost:8080/users/admin/followers",
+      "type": "Announce"
+    },
+    {
+      "actor": "http://localhost:8080/users/admin",
+      "cc": "http://localhost:8080/users/admin/followers",
+      "id": "http://localhost:8080/users/admin/statuses/01F8MHAAY43M6RJ473VQFCVH37/activity#Create",
+      "object": "http://localhost:8080/users/admin/statuses/01F8MHAAY43M6RJ473VQFCVH37",
+      "published": "2021-10-20T12:36:45Z",
+      "to": "https://www.w3.org/ns/activitystreams#Public",
+      "type": "Create"
+    },
+    {
+      "actor": "http://localhost:8080/users/admin",
+      "cc": "http://localhost:8080/users/admin/followers",
+      "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/activity#Create",
+      "object": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+      "published": "2021-10-20T11:36:45Z",
+      "to": "https://www.w3.org/ns/activitystreams#Public",
+      "type": "Create"
+    }
+  ],
+  "partOf": "http://localhost:8080/users/admin/outbox",
+  "prev": "http://localhost:8080/users/admin/outbox?page=true\u0026min_id=01G36SF3V6Y6V5BF9P4R7PQG7G",
+  "type": "OrderedCollectionPage"
+}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestSelfBoostFollowersOnlyToAS() {
 	ctx := context.Background()
 
@@ -19,20 +19,24 @@ package typeutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -248,6 +252,7 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	var (
 		locked       = boolPtrDef("locked", a.Locked, true)
 		discoverable = boolPtrDef("discoverable", a.Discoverable, false)
+		indexable    = util.PtrValueOr(a.Indexable, false)
 		bot          = boolPtrDef("bot", a.Bot, false)
 	)
 
@@ -280,6 +285,7 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		CustomCSS:       customCSS,
 		EnableRSS:       enableRSS,
 		HideCollections: hideCollections,
+		Indexable:       indexable,
 		Role:            role,
 		Moved:           moved,
 	}
@@ -483,6 +489,38 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gts model token into its api representation,
+// which describes the token and the application that created it, but doesn't
+// reveal the token itself. It should only be shown to the owner of the token.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, t *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	app, err := c.state.DB.GetApplicationByClientID(ctx, t.ClientID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting application with client id %s: %w", t.ClientID, err)
+	}
+
+	apiApp, err := c.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, gtserror.Newf("error converting application to api: %w", err)
+	}
+
+	// Include app ID so the owner of
+	// the token can refer back to it.
+	apiApp.ID = app.ID
+
+	var lastUsed string
+	if !t.LastUsed.IsZero() {
+		lastUsed = util.FormatISO8601(t.LastUsed)
+	}
+
+	return &apimodel.TokenInfo{
+		ID:          t.ID,
+		CreatedAt:   util.FormatISO8601(t.CreatedAt),
+		LastUsed:    lastUsed,
+		Scope:       t.Scope,
+		Application: apiApp,
+	}, nil
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	apiAttachment := apimodel.Attachment{
@@ -559,6 +597,19 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 		if i := a.FileMeta.Original.Bitrate; i != nil {
 			apiAttachment.Meta.Original.Bitrate = int(*i)
 		}
+
+	case gtsmodel.FileTypeAudio:
+		if i := a.FileMeta.Original.Duration; i != nil {
+			apiAttachment.Meta.Original.Duration = *i
+		}
+
+		if i := a.FileMeta.Original.Bitrate; i != nil {
+			apiAttachment.Meta.Original.Bitrate = int(*i)
+		}
+
+		if i := a.FileMeta.Original.Channels; i != nil {
+			apiAttachment.Meta.Original.Channels = *i
+		}
 	}
 
 	return apiAttachment, nil
@@ -679,16 +730,40 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 // (frontend) representation for serialization on the API.
 //
 // Requesting account can be nil.
+//
+// Filter context can be the empty string if these statuses are not being filtered.
+//
+// If there is a matching "hide" filter, the returned status will be nil with a ErrHideStatus error;
+// callers need to handle that case by excluding it from results.
 func (c *Converter) StatusToAPIStatus(
 	ctx context.Context,
 	s *gtsmodel.Status,
 	requestingAccount *gtsmodel.Account,
+	filterContext statusfilter.FilterContext,
+	filters []*gtsmodel.Filter,
 ) (*apimodel.Status, error) {
 	apiStatus, err := c.statusToFrontend(ctx, s, requestingAccount)
 	if err != nil {
 		return nil, err
 	}
 
+	// Apply any matching filters to the status,
+	// or to the boosted status if this is a boost.
+	filtered := s
+	if s.BoostOf != nil {
+		filtered = s.BoostOf
+	}
+
+	filterResults, err := c.statusToAPIFilterResults(ctx, filtered, requestingAccount, filterContext, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	apiStatus.Filtered = filterResults
+	if apiStatus.Reblog != nil {
+		apiStatus.Reblog.Filtered = filterResults
+	}
+
 	// Normalize status for the API by pruning
 	// out unknown attachment types and replacing
 	// them with a helpful message.
@@ -699,6 +774,134 @@ func (c *Converter) StatusToAPIStatus(
 	return apiStatus, nil
 }
 
+// statusToAPIFilterResults applies filters to a status and returns an API filter result object.
+// The result may be nil if no filters matched.
+// If the status should not be returned at all, it returns the ErrHideStatus error.
+func (c *Converter) statusToAPIFilterResults(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	filterContext statusfilter.FilterContext,
+	filters []*gtsmodel.Filter,
+) ([]apimodel.FilterResult, error) {
+	if filterContext == statusfilter.FilterContextNone || len(filters) == 0 {
+		// Nothing to filter.
+		return nil, nil
+	}
+
+	if requestingAccount == nil || s.AccountID == requestingAccount.ID {
+		// Only filter for logged-in users,
+		// and never filter their own statuses.
+		return nil, nil
+	}
+
+	// Gather the text fields of the status that keywords are matched against.
+	fields := make([]string, 0, 2+len(s.Attachments))
+	if s.Content != "" {
+		fields = append(fields, text.SanitizeToPlaintext(s.Content))
+	}
+	if s.ContentWarning != "" {
+		fields = append(fields, s.ContentWarning)
+	}
+	for _, attachment := range s.Attachments {
+		if attachment.Description != "" {
+			fields = append(fields, attachment.Description)
+		}
+	}
+	if s.Poll != nil {
+		fields = append(fields, s.Poll.Options...)
+	}
+
+	now := time.Now()
+	filterResults := make([]apimodel.FilterResult, 0, len(filters))
+	for _, filter := range filters {
+		if !filterAppliesInContext(filter, filterContext) {
+			// Filter doesn't apply here.
+			continue
+		}
+
+		if !filter.ExpiresAt.IsZero() && filter.ExpiresAt.Before(now) {
+			// Filter has expired.
+			continue
+		}
+
+		// List any matching keywords.
+		keywordMatches := make([]string, 0, len(filter.Keywords))
+		for _, filterKeyword := range filter.Keywords {
+			if filterKeyword.Regexp == nil {
+				// Keyword regexes are usually compiled
+				// when loaded from the database, but
+				// compile here if that didn't happen.
+				if err := filterKeyword.Compile(); err != nil {
+					return nil, gtserror.Newf("error compiling filter keyword %s regex: %w", filterKeyword.ID, err)
+				}
+			}
+
+			for _, field := range fields {
+				if filterKeyword.Regexp.MatchString(field) {
+					keywordMatches = append(keywordMatches, filterKeyword.Keyword)
+					break
+				}
+			}
+		}
+
+		// Check whether this status is specifically filtered.
+		statusMatches := make([]string, 0, 1)
+		for _, filterStatus := range filter.Statuses {
+			if s.ID == filterStatus.StatusID {
+				statusMatches = append(statusMatches, filterStatus.StatusID)
+				break
+			}
+		}
+
+		if len(keywordMatches) == 0 && len(statusMatches) == 0 {
+			// Filter didn't match.
+			continue
+		}
+
+		switch filter.Action {
+		case gtsmodel.FilterActionWarn:
+			// Record what matched.
+			apiFilter, err := c.FilterToAPIFilterV2(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			filterResults = append(filterResults, apimodel.FilterResult{
+				Filter:         *apiFilter,
+				KeywordMatches: keywordMatches,
+				StatusMatches:  statusMatches,
+			})
+
+		case gtsmodel.FilterActionHide:
+			// Don't show this status. Immediate return.
+			return nil, statusfilter.ErrHideStatus
+		}
+	}
+
+	if len(filterResults) == 0 {
+		return nil, nil
+	}
+
+	return filterResults, nil
+}
+
+// filterAppliesInContext returns whether a given filter applies in a given context.
+func filterAppliesInContext(filter *gtsmodel.Filter, filterContext statusfilter.FilterContext) bool {
+	switch filterContext {
+	case statusfilter.FilterContextHome:
+		return util.PtrValueOr(filter.ContextHome, false)
+	case statusfilter.FilterContextNotifications:
+		return util.PtrValueOr(filter.ContextNotifications, false)
+	case statusfilter.FilterContextPublic:
+		return util.PtrValueOr(filter.ContextPublic, false)
+	case statusfilter.FilterContextThread:
+		return util.PtrValueOr(filter.ContextThread, false)
+	case statusfilter.FilterContextAccount:
+		return util.PtrValueOr(filter.ContextAccount, false)
+	}
+	return false
+}
+
 // StatusToWebStatus converts a gts model status into an
 // api representation suitable for serving into a web template.
 //
@@ -733,6 +936,17 @@ func (c *Converter) StatusToWebStatus(
 		}
 	}
 
+	if quote := webStatus.Quote; quote != nil {
+		// Quoted status is rendered in the same
+		// template, so it needs a language tag too.
+		quote.LanguageTag = new(language.Language)
+		if lang := quote.Language; lang != nil {
+			if langTag, err := language.Parse(*lang); err == nil {
+				quote.LanguageTag = langTag
+			}
+		}
+	}
+
 	if poll := webStatus.Poll; poll != nil {
 		// Calculate vote share of each poll option and
 		// format them for easier template consumption.
@@ -890,9 +1104,33 @@ func (c *Converter) statusToFrontend(
 		Emojis:             apiEmojis,
 		Card:               nil, // TODO: implement cards
 		Text:               s.Text,
+		InteractionPolicy:  c.InteractionPolicyToAPIInteractionPolicy(s.InteractionPolicy),
+	}
+
+	if s.Title != "" {
+		// Prepend title (and abstract, if
+		// not already shown as the content
+		// warning) to the status content.
+		apiStatus.Title = s.Title
+		apiStatus.Content = titledContent(s)
+	}
+
+	if !s.StartTime.IsZero() {
+		apiStatus.Event = &apimodel.StatusEvent{
+			StartTime: util.FormatISO8601(s.StartTime),
+			Location:  s.Location,
+		}
+
+		if !s.EndTime.IsZero() {
+			apiStatus.Event.EndTime = util.Ptr(util.FormatISO8601(s.EndTime))
+		}
 	}
 
 	// Nullable fields.
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
 	if s.InReplyToID != "" {
 		apiStatus.InReplyToID = util.Ptr(s.InReplyToID)
 	}
@@ -906,7 +1144,9 @@ func (c *Converter) statusToFrontend(
 	}
 
 	if s.BoostOf != nil {
-		reblog, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount)
+		// Filters are applied by the caller,
+		// to both the boost and boosted status.
+		reblog, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount, statusfilter.FilterContextNone, nil)
 		if err != nil {
 			return nil, gtserror.Newf("error converting boosted status: %w", err)
 		}
@@ -914,6 +1154,13 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Reblog = &apimodel.StatusReblogged{reblog}
 	}
 
+	if s.QuoteOf != nil {
+		apiStatus.Quote, err = c.quoteToFrontend(ctx, s.QuoteOf, requestingAccount)
+		if err != nil {
+			log.Errorf(ctx, "error converting quoted status: %v", err)
+		}
+	}
+
 	if app := s.CreatedWithApplication; app != nil {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
@@ -945,6 +1192,207 @@ func (c *Converter) statusToFrontend(
 	return apiStatus, nil
 }
 
+// quoteToFrontend converts the given quoted status into
+// its frontend representation, to be embedded in the status
+// quoting it. Returns nil if the quoted status should not be
+// shown to requesting account. Quotes of quotes are left out.
+//
+// Requesting account can be nil.
+func (c *Converter) quoteToFrontend(
+	ctx context.Context,
+	quoteOf *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.StatusQuoted, error) {
+	if quoteOf.Visibility != gtsmodel.VisibilityPublic &&
+		quoteOf.Visibility != gtsmodel.VisibilityUnlocked {
+		// Only public or unlisted statuses
+		// should have been quoted anyway.
+		return nil, nil
+	}
+
+	if requestingAccount != nil {
+		// Don't show quotes of statuses
+		// by blocked / blocking accounts.
+		blocked, err := c.state.DB.IsEitherBlocked(ctx,
+			requestingAccount.ID,
+			quoteOf.AccountID,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error checking blocks: %w", err)
+		}
+
+		if blocked {
+			return nil, nil
+		}
+	}
+
+	// Copy the quoted status without its
+	// own quote, so we only go one level.
+	quoteOfCopy := new(gtsmodel.Status)
+	*quoteOfCopy = *quoteOf
+	quoteOfCopy.QuoteOfID = ""
+	quoteOfCopy.QuoteOf = nil
+
+	quote, err := c.StatusToAPIStatus(ctx,
+		quoteOfCopy,
+		requestingAccount,
+		statusfilter.FilterContextNone,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.StatusQuoted{Status: quote}, nil
+}
+
+// StatusToAPIEdits converts the edit history of a gts model status into its
+// api (frontend) representation for serialization on the API, oldest first.
+//
+// The final entry in the returned slice is the current version of the status.
+func (c *Converter) StatusToAPIEdits(ctx context.Context, s *gtsmodel.Status) ([]*apimodel.StatusEdit, error) {
+	// Ensure status fully populated
+	// (we need account, poll, emojis).
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		return nil, gtserror.Newf("error populating status: %w", err)
+	}
+
+	// Fetch all historical edits of the status.
+	edits, err := c.state.DB.GetStatusEditsByStatusID(ctx, s.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting status edits: %w", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status author: %w", err)
+	}
+
+	// Status edits don't keep track of which emojis
+	// were in use at each revision, so just use the
+	// emojis from the current version of the status.
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status emojis: %v", err)
+	}
+
+	// Append the current version of the status
+	// as a final "edit" to the end of the history.
+	var pollOptions []string
+	if s.Poll != nil {
+		pollOptions = s.Poll.Options
+	}
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+	edits = append(edits, &gtsmodel.StatusEdit{
+		CreatedAt:      createdAt,
+		Content:        s.Content,
+		ContentWarning: s.ContentWarning,
+		Sensitive:      s.Sensitive,
+		AttachmentIDs:  s.AttachmentIDs,
+		Attachments:    s.Attachments,
+		PollOptions:    pollOptions,
+	})
+
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(edits))
+	for _, edit := range edits {
+		apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, edit.Attachments, edit.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting status edit attachments: %v", err)
+		}
+
+		// Normalize attachments in the same
+		// way as we do for the API status.
+		_, apiAttachments = placeholdUnknownAttachments(apiAttachments)
+
+		var apiPoll *apimodel.StatusEditPoll
+		if len(edit.PollOptions) > 0 {
+			apiPoll = &apimodel.StatusEditPoll{
+				Options: make([]apimodel.StatusEditPollOption, len(edit.PollOptions)),
+			}
+			for i, title := range edit.PollOptions {
+				apiPoll.Options[i].Title = title
+			}
+		}
+
+		apiEdits = append(apiEdits, &apimodel.StatusEdit{
+			Content:          edit.Content,
+			SpoilerText:      edit.ContentWarning,
+			Sensitive:        util.PtrValueOr(edit.Sensitive, false),
+			CreatedAt:        util.FormatISO8601(edit.CreatedAt),
+			Account:          apiAccount,
+			Poll:             apiPoll,
+			MediaAttachments: apiAttachments,
+			Emojis:           apiEmojis,
+		})
+	}
+
+	return apiEdits, nil
+}
+
+// ScheduledStatusToAPIScheduledStatus converts a gts model scheduled status into its api (frontend) representation.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, error) {
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.MediaAttachments, s.MediaIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting scheduled status attachments: %v", err)
+	}
+
+	scheduledAt := util.FormatISO8601(s.ScheduledAt)
+
+	params := &apimodel.StatusParams{
+		Text:          s.Text,
+		MediaIDs:      s.MediaIDs,
+		Sensitive:     util.PtrValueOr(s.Sensitive, false),
+		SpoilerText:   s.SpoilerText,
+		Visibility:    c.VisToAPIVis(ctx, s.Visibility),
+		ScheduledAt:   scheduledAt,
+		ApplicationID: s.ApplicationID,
+	}
+
+	if s.InReplyToID != "" {
+		params.InReplyToID = &s.InReplyToID
+	}
+
+	if s.QuoteID != "" {
+		params.QuoteID = &s.QuoteID
+	}
+
+	params.InteractionPolicy = c.InteractionPolicyToAPIInteractionPolicy(s.InteractionPolicy)
+
+	if s.Language != "" {
+		params.Language = &s.Language
+	}
+
+	if s.ContentType != "" {
+		contentType := apimodel.StatusContentType(s.ContentType)
+		params.ContentType = &contentType
+	}
+
+	if len(s.PollOptions) > 0 {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    s.PollOptions,
+			ExpiresIn:  s.PollExpiresIn,
+			Multiple:   util.PtrValueOr(s.PollMultiple, false),
+			HideTotals: util.PtrValueOr(s.PollHideTotals, false),
+		}
+	}
+
+	apiScheduledStatus := &apimodel.ScheduledStatus{
+		ID:               s.ID,
+		ScheduledAt:      scheduledAt,
+		Params:           params,
+		MediaAttachments: make([]apimodel.Attachment, 0, len(apiAttachments)),
+	}
+
+	for _, a := range apiAttachments {
+		apiScheduledStatus.MediaAttachments = append(apiScheduledStatus.MediaAttachments, *a)
+	}
+
+	return apiScheduledStatus, nil
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -960,6 +1408,20 @@ func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apim
 	return ""
 }
 
+// InteractionPolicyToAPIInteractionPolicy converts a gts interaction policy
+// into its api equivalent, returning nil if everyone may interact.
+func (c *Converter) InteractionPolicyToAPIInteractionPolicy(p gtsmodel.InteractionPolicy) *apimodel.InteractionPolicy {
+	if p.IsZero() {
+		return nil
+	}
+
+	return &apimodel.InteractionPolicy{
+		CanReply: apimodel.PolicyValue(p.CanReply.OrEveryone()),
+		CanBoost: apimodel.PolicyValue(p.CanBoost.OrEveryone()),
+		CanLike:  apimodel.PolicyValue(p.CanLike.OrEveryone()),
+	}
+}
+
 // InstanceRuleToAdminAPIRule converts a local instance rule into its api equivalent for serving at /api/v1/admin/instance/rules/:id
 func (c *Converter) InstanceRuleToAPIRule(r gtsmodel.Rule) apimodel.InstanceRule {
 	return apimodel.InstanceRule{
@@ -1020,7 +1482,7 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedAttachmentMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1155,7 +1617,7 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedAttachmentMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1216,8 +1678,15 @@ func (c *Converter) RelationshipToAPIRelationship(ctx context.Context, r *gtsmod
 	}, nil
 }
 
-// NotificationToAPINotification converts a gts notification into a api notification
-func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmodel.Notification) (*apimodel.Notification, error) {
+// NotificationToAPINotification converts a gts notification into a api notification.
+//
+// If the notification's status matches a "hide" filter, this returns a ErrHideStatus error;
+// callers need to handle that case by excluding the notification from results.
+func (c *Converter) NotificationToAPINotification(
+	ctx context.Context,
+	n *gtsmodel.Notification,
+	filters []*gtsmodel.Filter,
+) (*apimodel.Notification, error) {
 	if n.TargetAccount == nil {
 		tAccount, err := c.state.DB.GetAccountByID(ctx, n.TargetAccountID)
 		if err != nil {
@@ -1258,8 +1727,11 @@ func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 		}
 
 		var err error
-		apiStatus, err = c.StatusToAPIStatus(ctx, n.Status, n.TargetAccount)
+		apiStatus, err = c.StatusToAPIStatus(ctx, n.Status, n.TargetAccount, statusfilter.FilterContextNotifications, filters)
 		if err != nil {
+			if errors.Is(err, statusfilter.ErrHideStatus) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("NotificationToapi: error converting status to api: %s", err)
 		}
 	}
@@ -1278,6 +1750,51 @@ func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 	}, nil
 }
 
+// ConversationToAPIConversation converts a gts conversation
+// into its api representation, from the point of view of the
+// conversation's owner (ie., the requesting account).
+func (c *Converter) ConversationToAPIConversation(
+	ctx context.Context,
+	conversation *gtsmodel.Conversation,
+) (*apimodel.Conversation, error) {
+	if err := c.state.DB.PopulateConversation(ctx, conversation); err != nil {
+		return nil, gtserror.Newf("error populating conversation %s: %w", conversation.ID, err)
+	}
+
+	// Participants are everyone in the conversation
+	// except the owner, unless the owner is talking
+	// only to themself, in which case show the owner.
+	participants := conversation.OtherAccounts
+	if len(participants) == 0 {
+		participants = []*gtsmodel.Account{conversation.Account}
+	}
+
+	apiAccounts := make([]apimodel.Account, 0, len(participants))
+	for _, account := range participants {
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s to api: %w", account.ID, err)
+		}
+		apiAccounts = append(apiAccounts, *apiAccount)
+	}
+
+	var apiLastStatus *apimodel.Status
+	if conversation.LastStatus != nil {
+		var err error
+		apiLastStatus, err = c.StatusToAPIStatus(ctx, conversation.LastStatus, conversation.Account, statusfilter.FilterContextNone, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status %s to api: %w", conversation.LastStatusID, err)
+		}
+	}
+
+	return &apimodel.Conversation{
+		ID:         conversation.ID,
+		Accounts:   apiAccounts,
+		Unread:     !util.PtrValueOr(conversation.Read, false),
+		LastStatus: apiLastStatus,
+	}, nil
+}
+
 // DomainPermToAPIDomainPerm converts a gts model domin block or allow into an api domain permission.
 func (c *Converter) DomainPermToAPIDomainPerm(
 	ctx context.Context,
@@ -1314,6 +1831,155 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	return domainPerm, nil
 }
 
+// DomainPermSubToAPIDomainPermSub converts the given domain permission
+// subscription into its API model representation, including a count
+// of the domain permissions currently created by this subscription.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(
+	ctx context.Context,
+	d *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, error) {
+	// Count domain permissions
+	// owned by this subscription.
+	var count int
+	switch d.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := c.state.DB.GetDomainBlocksBySubscriptionID(ctx, d.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting domain blocks for subscription %s: %w", d.ID, err)
+		}
+		count = len(blocks)
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := c.state.DB.GetDomainAllowsBySubscriptionID(ctx, d.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting domain allows for subscription %s: %w", d.ID, err)
+		}
+		count = len(allows)
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", d.PermissionType)
+	}
+
+	var (
+		fetchedAt             string
+		successfullyFetchedAt string
+	)
+
+	if !d.FetchedAt.IsZero() {
+		fetchedAt = util.FormatISO8601(d.FetchedAt)
+	}
+
+	if !d.SuccessfullyFetchedAt.IsZero() {
+		successfullyFetchedAt = util.FormatISO8601(d.SuccessfullyFetchedAt)
+	}
+
+	return &apimodel.DomainPermissionSubscription{
+		ID:                    d.ID,
+		Priority:              d.Priority,
+		Title:                 d.Title,
+		PermissionType:        d.PermissionType.String(),
+		AdoptOrphans:          *d.AdoptOrphans,
+		CreatedBy:             d.CreatedByAccountID,
+		CreatedAt:             util.FormatISO8601(d.CreatedAt),
+		URI:                   d.URI,
+		ContentType:           d.ContentType,
+		FetchUsername:         d.FetchUsername,
+		FetchPassword:         d.FetchPassword,
+		FetchedAt:             fetchedAt,
+		SuccessfullyFetchedAt: successfullyFetchedAt,
+		Error:                 d.Error,
+		Count:                 uint64(count),
+	}, nil
+}
+
+// InstanceToAdminAPIInstance converts the given remote instance
+// into its admin API model, including delivery availability.
+func (c *Converter) InstanceToAdminAPIInstance(
+	ctx context.Context,
+	i *gtsmodel.Instance,
+) (*apimodel.AdminInstance, error) {
+	instance := &apimodel.AdminInstance{
+		ID:          i.ID,
+		Domain:      i.Domain,
+		URI:         i.URI,
+		Title:       i.Title,
+		Version:     i.Version,
+		CreatedAt:   util.FormatISO8601(i.CreatedAt),
+		Unavailable: i.Unavailable(),
+	}
+
+	if !i.SuspendedAt.IsZero() {
+		instance.SuspendedAt = util.FormatISO8601(i.SuspendedAt)
+	}
+
+	if !i.UnavailableAt.IsZero() {
+		instance.UnavailableAt = util.FormatISO8601(i.UnavailableAt)
+	}
+
+	if !i.DeliveredAt.IsZero() {
+		instance.DeliveredAt = util.FormatISO8601(i.DeliveredAt)
+	}
+
+	if !i.DeliveryFailedAt.IsZero() {
+		instance.DeliveryFailedAt = util.FormatISO8601(i.DeliveryFailedAt)
+	}
+
+	if !i.DeliveryFailingSince.IsZero() {
+		instance.DeliveryFailingSince = util.FormatISO8601(i.DeliveryFailingSince)
+	}
+
+	return instance, nil
+}
+
+// RelayToAdminAPIRelay converts the given relay
+// into its admin API model representation.
+func (c *Converter) RelayToAdminAPIRelay(
+	ctx context.Context,
+	r *gtsmodel.Relay,
+) (*apimodel.AdminRelay, error) {
+	return &apimodel.AdminRelay{
+		ID:        r.ID,
+		InboxURL:  r.InboxURI,
+		State:     r.State.String(),
+		ActorURI:  r.ActorURI,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+	}, nil
+}
+
+// DeliveryToAPIDelivery converts the given queued
+// delivery into its admin API model representation.
+func (c *Converter) DeliveryToAPIDelivery(
+	ctx context.Context,
+	d *gtsmodel.Delivery,
+) (*apimodel.Delivery, error) {
+	if !json.Valid(d.Data) {
+		return nil, gtserror.Newf("delivery %s contains invalid json", d.ID)
+	}
+
+	delivery := &apimodel.Delivery{
+		ID:        d.ID,
+		CreatedAt: util.FormatISO8601(d.CreatedAt),
+		InboxURI:  d.InboxURI,
+		Attempts:  d.Attempts,
+		LastError: d.LastError,
+		Activity:  json.RawMessage(d.Data),
+	}
+
+	if d.Failed() {
+		delivery.Status = gtsmodel.DeliveryStatusFailed.String()
+		delivery.FailedAt = util.FormatISO8601(d.FailedAt)
+	} else {
+		delivery.Status = gtsmodel.DeliveryStatusPending.String()
+		delivery.NextAttemptAt = util.FormatISO8601(d.NextAttemptAt)
+	}
+
+	if !d.LastAttemptAt.IsZero() {
+		delivery.LastAttemptAt = util.FormatISO8601(d.LastAttemptAt)
+	}
+
+	return delivery, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1411,7 +2077,7 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 	for _, s := range r.Statuses {
-		status, err := c.StatusToAPIStatus(ctx, s, requestingAccount)
+		status, err := c.StatusToAPIStatus(ctx, s, requestingAccount, statusfilter.FilterContextNone, nil)
 		if err != nil {
 			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting status with id %s to api status: %w", s.ID, err)
 		}
@@ -1652,6 +2318,82 @@ func (c *Converter) FilterKeywordToAPIFilterV1(ctx context.Context, filterKeywor
 	}
 	filter := filterKeyword.Filter
 
+	return &apimodel.FilterV1{
+		// v1 filters have a single keyword each, so we use the filter keyword ID as the v1 filter ID.
+		ID:           filterKeyword.ID,
+		Phrase:       filterKeyword.Keyword,
+		Context:      filterToAPIFilterContexts(filter),
+		WholeWord:    util.PtrValueOr(filterKeyword.WholeWord, false),
+		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
+		Irreversible: filter.Action == gtsmodel.FilterActionHide,
+	}, nil
+}
+
+// FilterToAPIFilterV2 converts one GTS model filter into an API v2 filter.
+func (c *Converter) FilterToAPIFilterV2(ctx context.Context, filter *gtsmodel.Filter) (*apimodel.FilterV2, error) {
+	apiFilterKeywords := make([]apimodel.FilterKeyword, 0, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		apiFilterKeywords = append(apiFilterKeywords, *c.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword))
+	}
+
+	apiFilterStatuses := make([]apimodel.FilterStatus, 0, len(filter.Statuses))
+	for _, filterStatus := range filter.Statuses {
+		apiFilterStatuses = append(apiFilterStatuses, *c.FilterStatusToAPIFilterStatus(ctx, filterStatus))
+	}
+
+	return &apimodel.FilterV2{
+		ID:           filter.ID,
+		Title:        filter.Title,
+		Context:      filterToAPIFilterContexts(filter),
+		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
+		FilterAction: filterActionToAPIFilterAction(filter.Action),
+		Keywords:     apiFilterKeywords,
+		Statuses:     apiFilterStatuses,
+	}, nil
+}
+
+// FilterKeywordToAPIFilterKeyword converts a GTS model filter keyword into an API v2 filter keyword.
+func (c *Converter) FilterKeywordToAPIFilterKeyword(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword) *apimodel.FilterKeyword {
+	return &apimodel.FilterKeyword{
+		ID:        filterKeyword.ID,
+		Keyword:   filterKeyword.Keyword,
+		WholeWord: util.PtrValueOr(filterKeyword.WholeWord, false),
+	}
+}
+
+// FilterStatusToAPIFilterStatus converts a GTS model filter status into an API v2 filter status.
+func (c *Converter) FilterStatusToAPIFilterStatus(ctx context.Context, filterStatus *gtsmodel.FilterStatus) *apimodel.FilterStatus {
+	return &apimodel.FilterStatus{
+		ID:       filterStatus.ID,
+		StatusID: filterStatus.StatusID,
+	}
+}
+
+// WebPushSubscriptionToAPIWebPushSubscription converts a GTS model Web Push subscription into an API model Web Push subscription.
+func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) (*apimodel.WebPushSubscription, error) {
+	instance, err := c.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return nil, gtserror.Newf("error getting local instance: %w", err)
+	}
+
+	return &apimodel.WebPushSubscription{
+		ID:        subscription.ID,
+		Endpoint:  subscription.Endpoint,
+		ServerKey: instance.VAPIDPublicKey,
+		Alerts: apimodel.WebPushSubscriptionAlerts{
+			Follow:        subscription.NotificationFlags.Get(gtsmodel.NotificationFollow),
+			FollowRequest: subscription.NotificationFlags.Get(gtsmodel.NotificationFollowRequest),
+			Favourite:     subscription.NotificationFlags.Get(gtsmodel.NotificationFave),
+			Mention:       subscription.NotificationFlags.Get(gtsmodel.NotificationMention),
+			Reblog:        subscription.NotificationFlags.Get(gtsmodel.NotificationReblog),
+			Poll:          subscription.NotificationFlags.Get(gtsmodel.NotificationPoll),
+			Status:        subscription.NotificationFlags.Get(gtsmodel.NotificationStatus),
+		},
+		Policy: apimodel.WebPushNotificationPolicy(subscription.Policy),
+	}, nil
+}
+
+func filterToAPIFilterContexts(filter *gtsmodel.Filter) []apimodel.FilterContext {
 	apiContexts := make([]apimodel.FilterContext, 0, apimodel.FilterContextNumValues)
 	if util.PtrValueOr(filter.ContextHome, false) {
 		apiContexts = append(apiContexts, apimodel.FilterContextHome)
@@ -1668,21 +2410,24 @@ func (c *Converter) FilterKeywordToAPIFilterV1(ctx context.Context, filterKeywor
 	if util.PtrValueOr(filter.ContextAccount, false) {
 		apiContexts = append(apiContexts, apimodel.FilterContextAccount)
 	}
+	return apiContexts
+}
 
-	var expiresAt *string
-	if !filter.ExpiresAt.IsZero() {
-		expiresAt = util.Ptr(util.FormatISO8601(filter.ExpiresAt))
+func filterExpiresAtToAPIFilterExpiresAt(expiresAt time.Time) *string {
+	if expiresAt.IsZero() {
+		return nil
 	}
+	return util.Ptr(util.FormatISO8601(expiresAt))
+}
 
-	return &apimodel.FilterV1{
-		// v1 filters have a single keyword each, so we use the filter keyword ID as the v1 filter ID.
-		ID:           filterKeyword.ID,
-		Phrase:       filterKeyword.Keyword,
-		Context:      apiContexts,
-		WholeWord:    util.PtrValueOr(filterKeyword.WholeWord, false),
-		ExpiresAt:    expiresAt,
-		Irreversible: filter.Action == gtsmodel.FilterActionHide,
-	}, nil
+func filterActionToAPIFilterAction(action gtsmodel.FilterAction) apimodel.FilterAction {
+	switch action {
+	case gtsmodel.FilterActionWarn:
+		return apimodel.FilterActionWarn
+	case gtsmodel.FilterActionHide:
+		return apimodel.FilterActionHide
+	}
+	return apimodel.FilterActionNone
 }
 
 // convertEmojisToAPIEmojis will convert a slice of GTS model emojis to frontend API model emojis, falling back to IDs if no GTS models supplied.
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -427,7 +428,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendBloc
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 	testStatus := suite.testStatuses["admin_account_status_1"]
 	requestingAccount := suite.testAccounts["local_account_1"]
-	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
 	suite.NoError(err)
 
 	b, err := json.MarshalIndent(apiStatus, "", "  ")
@@ -537,11 +538,119 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 }`, string(b))
 }
 
+// Test that a status which is filtered with a warn filter by the requesting user has `filtered` set correctly.
+func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+	requestingAccount := suite.testAccounts["local_account_1"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+	)
+	suite.NoError(err)
+
+	if suite.Len(apiStatus.Filtered, 1) {
+		filterResult := apiStatus.Filtered[0]
+		suite.Equal(expectedMatchingFilter.ID, filterResult.Filter.ID)
+		suite.Equal(expectedMatchingFilter.Title, filterResult.Filter.Title)
+		suite.Equal([]string{"fnord"}, filterResult.KeywordMatches)
+		suite.Empty(filterResult.StatusMatches)
+	}
+}
+
+// Test that a status which is filtered with a warn filter in a different context isn't filtered.
+func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontendOtherContext() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+	requestingAccount := suite.testAccounts["local_account_1"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextNotifications,
+		requestingAccountFilters,
+	)
+	suite.NoError(err)
+	suite.Empty(apiStatus.Filtered)
+}
+
+// Test that a status which is filtered by status ID with a warn filter has `filtered` set correctly.
+func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusByIDToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_2"]
+	expectedMatchingFilterStatus := &gtsmodel.FilterStatus{
+		ID:        "01HWXQDY8EE182AVNWQM4JH6TZ",
+		AccountID: requestingAccount.ID,
+		FilterID:  expectedMatchingFilter.ID,
+		Filter:    expectedMatchingFilter,
+		StatusID:  testStatus.ID,
+	}
+	expectedMatchingFilter.Statuses = []*gtsmodel.FilterStatus{expectedMatchingFilterStatus}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+	)
+	suite.NoError(err)
+
+	if suite.Len(apiStatus.Filtered, 1) {
+		filterResult := apiStatus.Filtered[0]
+		suite.Equal(expectedMatchingFilter.ID, filterResult.Filter.ID)
+		suite.Empty(filterResult.KeywordMatches)
+		suite.Equal([]string{testStatus.ID}, filterResult.StatusMatches)
+	}
+}
+
+// Test that a status which is filtered with a hide filter by the requesting user results in the ErrHideStatus error.
+func (suite *InternalToFrontendTestSuite) TestHideFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+	requestingAccount := suite.testAccounts["local_account_1"]
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilter.Action = gtsmodel.FilterActionHide
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	requestingAccountFilters := []*gtsmodel.Filter{expectedMatchingFilter}
+
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		statusfilter.FilterContextHome,
+		requestingAccountFilters,
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments() {
 	testStatus := suite.testStatuses["remote_account_2_status_1"]
 	requestingAccount := suite.testAccounts["admin_account"]
 
-	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
 	suite.NoError(err)
 
 	b, err := json.MarshalIndent(apiStatus, "", "  ")
@@ -774,7 +883,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
 	*testStatus = *suite.testStatuses["admin_account_status_1"]
 	testStatus.Language = ""
 	requestingAccount := suite.testAccounts["local_account_1"]
-	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
 	suite.NoError(err)
 
 	b, err := json.MarshalIndent(apiStatus, "", "  ")
@@ -970,7 +1079,16 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -1095,7 +1213,16 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "image/avif",
+        "image/heic",
+        "image/heif",
+        "image/jxl",
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/x-wav",
+        "audio/mp4"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -2060,6 +2187,33 @@ func (suite *InternalToFrontendTestSuite) TestRelationshipFollowRequested() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestEventStatusToFrontend() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["remote_account_1_status_1"]
+	testStatus.ActivityStreamsType = "Event"
+	testStatus.Content = "<p>Come along and learn how to fix your stuff.</p>"
+	testStatus.Title = "Community Repair Café"
+	testStatus.Abstract = "Bring along your broken things!"
+	testStatus.StartTime = testrig.TimeMustParse("2024-06-01T13:00:00Z")
+	testStatus.Location = "Community Center, Main Street"
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	suite.NoError(err)
+
+	suite.Equal("Community Repair Café", apiStatus.Title)
+	suite.Equal(`<p><a href="`+testStatus.URL+`" rel="nofollow noreferrer noopener" target="_blank"><strong>Community Repair Café</strong></a></p><p>Bring along your broken things!</p><hr><p>Come along and learn how to fix your stuff.</p>`, apiStatus.Content)
+
+	b, err := json.MarshalIndent(apiStatus.Event, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "start_time": "2024-06-01T13:00:00.000Z",
+  "end_time": null,
+  "location": "Community Center, Main Street"
+}`, string(b))
+}
+
 func TestInternalToFrontendTestSuite(t *testing.T) {
 	suite.Run(t, new(InternalToFrontendTestSuite))
 }
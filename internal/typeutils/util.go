@@ -20,6 +20,7 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/url"
 	"path"
 	"slices"
@@ -166,6 +167,46 @@ func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimode
 	return text.SanitizeToHTML(note.String()), arr
 }
 
+// titledContent returns the content of the given Article,
+// Page or Event status, with its title (linked to the status
+// URL) prepended. Its abstract is also prepended, unless it
+// is already shown to the viewer as the content warning.
+//
+// Returned text will be run through the sanitizer before being returned, to
+// ensure that malicious links don't cause issues.
+//
+// Example:
+//
+//	<p><a href="https://example.org/blog/my-first-post"><strong>My First Post</strong></a></p>
+//	<p>In which I write my first post.</p>
+//	<hr>
+//	<p>Hello world! This is my first post...</p>
+func titledContent(s *gtsmodel.Status) string {
+	url := s.URL
+	if url == "" {
+		url = s.URI
+	}
+
+	var content strings.Builder
+	content.WriteString(`<p><a href="` + html.EscapeString(url) + `">`)
+	content.WriteString(`<strong>` + html.EscapeString(s.Title) + `</strong>`)
+	content.WriteString(`</a></p>`)
+	if s.Abstract != "" && s.Abstract != s.ContentWarning {
+		if strings.HasPrefix(s.Abstract, "<p>") {
+			// Already paragraphed.
+			content.WriteString(s.Abstract)
+		} else {
+			content.WriteString(`<p>` + s.Abstract + `</p>`)
+		}
+	}
+	if s.Content != "" {
+		content.WriteString(`<hr>`)
+		content.WriteString(s.Content)
+	}
+
+	return text.SanitizeToHTML(content.String())
+}
+
 // ContentToContentLanguage tries to
 // extract a content string and language
 // tag string from the given intermediary
@@ -45,6 +45,7 @@ const (
 	maximumProfileFields          = 6
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
+	maximumFilterTitleLength      = 200
 )
 
 // Password returns a helpful error if the given password
@@ -308,6 +309,19 @@ func MarkerName(name string) error {
 	return fmt.Errorf("marker timeline name '%s' was not recognized, valid options are '%s', '%s'", name, apimodel.MarkerNameHome, apimodel.MarkerNameNotifications)
 }
 
+// FilterTitle validates the title of a new or updated filter.
+func FilterTitle(title string) error {
+	if title == "" {
+		return fmt.Errorf("filter title must be provided, and must be no more than %d chars", maximumFilterTitleLength)
+	}
+
+	if length := len([]rune(title)); length > maximumFilterTitleLength {
+		return fmt.Errorf("filter title length must be no more than %d chars, provided title was %d chars", maximumFilterTitleLength, length)
+	}
+
+	return nil
+}
+
 // FilterKeyword validates the title of a new or updated List.
 func FilterKeyword(keyword string) error {
 	if keyword == "" {
@@ -348,3 +362,18 @@ func FilterContexts(contexts []apimodel.FilterContext) error {
 	}
 	return nil
 }
+
+// FilterAction validates the action of a new or updated filter.
+func FilterAction(action apimodel.FilterAction) error {
+	switch action {
+	case apimodel.FilterActionWarn,
+		apimodel.FilterActionHide:
+		return nil
+	}
+	return fmt.Errorf(
+		"filter action '%s' was not recognized, valid options are '%s', '%s'",
+		action,
+		apimodel.FilterActionWarn,
+		apimodel.FilterActionHide,
+	)
+}
@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Record size of encrypted payloads. The whole
+	// payload must fit in a single record, so it
+	// also bounds the size of our push messages.
+	recordSize = 4096
+
+	// Required length of the client's auth secret.
+	authSecretLength = 16
+)
+
+// ValidateSubscriptionKeys checks that the given base64-encoded
+// client keys of a Web Push subscription can be used to encrypt
+// notifications, returning a descriptive error if not.
+func ValidateSubscriptionKeys(p256dh string, auth string) error {
+	_, _, err := parseSubscriptionKeys(p256dh, auth)
+	return err
+}
+
+// parseSubscriptionKeys decodes the given base64-encoded
+// P-256 public key and auth secret of a subscription.
+func parseSubscriptionKeys(p256dh string, auth string) (*ecdh.PublicKey, []byte, error) {
+	publicKeyBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("p256dh key is not valid base64: %w", err)
+	}
+
+	publicKey, err := ecdh.P256().NewPublicKey(publicKeyBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("p256dh key is not a valid P-256 public key: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("auth secret is not valid base64: %w", err)
+	}
+
+	if len(authSecret) != authSecretLength {
+		return nil, nil, fmt.Errorf("auth secret must be %d bytes, was %d", authSecretLength, len(authSecret))
+	}
+
+	return publicKey, authSecret, nil
+}
+
+// encrypt encrypts the given plaintext for the client with the given
+// base64-encoded P-256 public key and auth secret, using the "aes128gcm"
+// content encoding as described in RFC 8188 and RFC 8291, returning
+// the encrypted content to use as the body of a push request.
+func encrypt(plaintext []byte, p256dh string, auth string) ([]byte, error) {
+	uaPublic, authSecret, err := parseSubscriptionKeys(p256dh, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plaintext + padding delimiter + AEAD tag must fit in one record.
+	if len(plaintext)+1+16 > recordSize {
+		return nil, errors.New("plaintext too large for single record")
+	}
+
+	// Generate a one-off application server
+	// key pair to agree on a shared secret.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	// Combine the shared secret with the auth secret
+	// and both public keys (RFC 8291 section 3.4).
+	keyInfo := make([]byte, 0, 14+65+65)
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublic.Bytes()...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+
+	// Derive content encryption
+	// key and nonce (RFC 8188).
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, and the key id,
+	// which here is our one-off public key.
+	body := make([]byte, 0, 16+4+1+len(asPublic)+len(plaintext)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublic)))
+	body = append(body, asPublic...)
+
+	// Only record, so the plaintext is
+	// followed by the last record delimiter.
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02)
+
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+// hkdf implements HKDF-SHA-256 (RFC 5869) for
+// output lengths no longer than a single hash.
+func hkdf(salt []byte, ikm []byte, info []byte, length int) []byte {
+	// Extract.
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	prk := mac.Sum(nil)
+
+	// Expand.
+	mac = hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+// decodeBase64 decodes the given base64 string, which clients may
+// send in either standard or URL-safe encoding, with or without padding.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
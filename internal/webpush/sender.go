@@ -0,0 +1,457 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// How long push services should hold
+	// on to a notification that can't yet
+	// be delivered to the client.
+	pushTTL = 48 * time.Hour
+
+	// Max no. attempts at making a push
+	// request before giving up on it.
+	maxAttempts = 3
+
+	// Max length in runes
+	// of a notification body.
+	bodyMaxLength = 140
+)
+
+// Starting backoff duration
+// between push request attempts.
+var baseBackoff = time.Second
+
+// Sender contains functions for sending Web Push notifications to local accounts.
+type Sender interface {
+	// Send queues the given notification for delivery to each of the target account's
+	// Web Push subscriptions that want to receive it. Push requests are made from the
+	// WebPush worker pool, which retries temporary failures, and prunes any
+	// subscriptions that are gone; errors there are logged rather than returned.
+	//
+	// The API representation of the notification should already be filtered for the
+	// target account, as its contents are used to build the pushed notification text.
+	Send(ctx context.Context, notification *gtsmodel.Notification, apiNotification *apimodel.Notification) error
+}
+
+// HTTPClient is the subset of http.Client
+// functionality used to send Web Push requests.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewSender returns a new Web Push Sender
+// that makes push requests with the given client.
+func NewSender(httpClient HTTPClient, state *state.State) Sender {
+	return &sender{
+		httpClient: httpClient,
+		state:      state,
+	}
+}
+
+type sender struct {
+	httpClient HTTPClient
+	state      *state.State
+}
+
+func (s *sender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Fetch all of the target account's subscriptions.
+	subscriptions, err := s.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notification.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("error getting web push subscriptions for account %s: %w", notification.TargetAccountID, err)
+	}
+
+	errs := gtserror.NewMultiError(len(subscriptions))
+
+	// Narrow down to subscriptions
+	// that want this notification.
+	relevant := subscriptions[:0]
+	for _, subscription := range subscriptions {
+		ok, err := s.wantsNotification(ctx, subscription, notification)
+		if err != nil {
+			errs.Appendf("error checking subscription %s: %w", subscription.ID, err)
+			continue
+		}
+
+		if ok {
+			relevant = append(relevant, subscription)
+		}
+	}
+
+	if len(relevant) == 0 {
+		// Nothing to send.
+		return errs.Combine()
+	}
+
+	// Load the local instance's VAPID
+	// keys to identify ourselves with.
+	vapid, err := s.vapidKeys(ctx)
+	if err != nil {
+		return gtserror.Newf("error loading vapid keys: %w", err)
+	}
+
+	for _, subscription := range relevant {
+		subscription := subscription
+
+		// Hand off to the web push workers, so that slow
+		// push services don't hold up notification processing.
+		s.state.Workers.WebPush.Enqueue(func(ctx context.Context) {
+			if err := s.sendToSubscription(
+				ctx,
+				vapid,
+				subscription,
+				notification,
+				apiNotification,
+			); err != nil {
+				log.Errorf(ctx, "error sending notification %s to web push subscription %s: %v",
+					notification.ID, subscription.ID, err)
+			}
+		})
+	}
+
+	return errs.Combine()
+}
+
+// wantsNotification returns whether the given subscription's
+// alerts and policy allow the given notification to be pushed.
+func (s *sender) wantsNotification(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+) (bool, error) {
+	if !subscription.NotificationFlags.Get(notification.NotificationType) {
+		// Alerts for this
+		// type are off.
+		return false, nil
+	}
+
+	switch subscription.Policy {
+	case gtsmodel.WebPushNotificationPolicyAll:
+		return true, nil
+
+	case gtsmodel.WebPushNotificationPolicyFollowed:
+		// Only from accounts the target follows.
+		return s.state.DB.IsFollowing(ctx,
+			notification.TargetAccountID,
+			notification.OriginAccountID,
+		)
+
+	case gtsmodel.WebPushNotificationPolicyFollower:
+		// Only from accounts following the target.
+		return s.state.DB.IsFollowing(ctx,
+			notification.OriginAccountID,
+			notification.TargetAccountID,
+		)
+
+	default: // gtsmodel.WebPushNotificationPolicyNone
+		return false, nil
+	}
+}
+
+// sendToSubscription encrypts and pushes the given notification to the given
+// subscription's endpoint, scheduling a retry on temporary failures, and deleting
+// the subscription if the push service reports that the endpoint no longer exists.
+func (s *sender) sendToSubscription(
+	ctx context.Context,
+	vapid *vapidKeys,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Fetch the access token that created this subscription,
+	// which is included in the payload so that the client
+	// can use it to fetch further notification details.
+	token, err := s.state.DB.GetTokenByID(ctx, subscription.TokenID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error getting token %s: %w", subscription.TokenID, err)
+		}
+
+		// Token has been revoked,
+		// so subscription is dead too.
+		return s.prune(ctx, subscription, "access token no longer exists")
+	}
+
+	payload, err := json.Marshal(pushPayload(notification, apiNotification, token.Access))
+	if err != nil {
+		return gtserror.Newf("error marshaling payload: %w", err)
+	}
+
+	body, err := encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return gtserror.Newf("error encrypting payload: %w", err)
+	}
+
+	authorization, err := vapid.authorization(subscription.Endpoint)
+	if err != nil {
+		return gtserror.Newf("error creating vapid authorization: %w", err)
+	}
+
+	return s.push(ctx, subscription, authorization, body, 1)
+}
+
+// push makes the given attempt at pushing an encrypted notification to
+// the given subscription's endpoint. On temporary failure, a further
+// attempt is scheduled after a backoff, and placed back on the web push
+// worker queue when it's due, so that no worker is held up waiting.
+// Each further attempt first checks that the subscription still exists.
+func (s *sender) push(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	authorization string,
+	body []byte,
+	attempt int,
+) error {
+	code, err := s.post(ctx, subscription.Endpoint, authorization, body)
+	switch {
+	case err == nil && code >= 200 && code < 300:
+		// Accepted by push service.
+		return nil
+
+	case code == http.StatusNotFound || code == http.StatusGone:
+		// Subscription expired
+		// or was unsubscribed.
+		return s.prune(ctx, subscription, "push service responded "+strconv.Itoa(code))
+
+	case err == nil && code < 500 && code != http.StatusTooManyRequests:
+		// Some other error we
+		// can't fix by retrying.
+		return gtserror.Newf("push service responded %d", code)
+	}
+
+	if err == nil {
+		// Create loggable error from response status code.
+		err = gtserror.Newf("push service responded %d", code)
+	}
+
+	if attempt >= maxAttempts {
+		return gtserror.Newf("giving up after %d attempts: %w", attempt, err)
+	}
+
+	backoff := baseBackoff * time.Duration(attempt)
+	log.Debugf(ctx, "retrying in %s after push request error: %v", backoff, err)
+
+	if !s.state.Workers.Scheduler.AddOnce(
+		"webpush-"+id.NewULID(),
+		time.Now().Add(backoff),
+		func(context.Context, time.Time) {
+			// Don't block the scheduler
+			// if the worker queue is full.
+			go s.state.Workers.WebPush.Enqueue(func(ctx context.Context) {
+				if err := s.retry(
+					ctx,
+					subscription,
+					authorization,
+					body,
+					attempt+1,
+				); err != nil {
+					log.Errorf(ctx, "error sending to web push subscription %s: %v", subscription.ID, err)
+				}
+			})
+		},
+	) {
+		return gtserror.Newf("couldn't schedule retry after error: %w", err)
+	}
+
+	return nil
+}
+
+// retry reloads the given subscription, and makes the given further
+// attempt at pushing an encrypted notification to it, provided it
+// hasn't been deleted or replaced since the notification was encrypted.
+func (s *sender) retry(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	authorization string,
+	body []byte,
+	attempt int,
+) error {
+	current, err := s.state.DB.GetWebPushSubscriptionByID(ctx, subscription.ID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error getting subscription: %w", err)
+		}
+
+		// Subscription was deleted
+		// since the last attempt.
+		log.Debugf(ctx, "subscription %s gone, dropping retry", subscription.ID)
+		return nil
+	}
+
+	if current.Endpoint != subscription.Endpoint ||
+		current.P256dh != subscription.P256dh ||
+		current.Auth != subscription.Auth {
+		// Notification was encrypted for,
+		// and authorized to, another endpoint.
+		log.Debugf(ctx, "subscription %s changed, dropping retry", subscription.ID)
+		return nil
+	}
+
+	return s.push(ctx, current, authorization, body, attempt)
+}
+
+// post makes a single push request
+// and returns the response status code.
+func (s *sender) post(
+	ctx context.Context,
+	endpoint string,
+	authorization string,
+	body []byte,
+) (int, error) {
+	// We handle our own retries, since the request
+	// body can't be replayed by the retrying client.
+	ctx = gtscontext.SetFastFail(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(pushTTL/time.Second)))
+	req.Header.Set("Urgency", "normal")
+
+	rsp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = rsp.Body.Close()
+
+	return rsp.StatusCode, nil
+}
+
+// prune deletes the given dead subscription.
+func (s *sender) prune(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	reason string,
+) error {
+	log.Infof(ctx, "deleting web push subscription %s: %s", subscription.ID, reason)
+	if err := s.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID); err != nil {
+		return gtserror.Newf("error deleting subscription: %w", err)
+	}
+	return nil
+}
+
+// vapidKeys loads the local instance's VAPID keys.
+func (s *sender) vapidKeys(ctx context.Context) (*vapidKeys, error) {
+	instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return nil, gtserror.Newf("error getting local instance: %w", err)
+	}
+
+	// Push services may use the subject to
+	// contact us about our push requests.
+	subject := instance.URI
+	if instance.ContactEmail != "" {
+		subject = "mailto:" + instance.ContactEmail
+	}
+
+	return parseVAPIDKeys(instance.VAPIDPublicKey, instance.VAPIDPrivateKey, subject)
+}
+
+// pushPayload builds the Web Push
+// representation of a notification.
+func pushPayload(
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+	accessToken string,
+) *apimodel.WebPushNotification {
+	account := apiNotification.Account
+	name := account.DisplayName
+	if name == "" {
+		name = account.Username
+	}
+
+	var title string
+	switch notification.NotificationType {
+	case gtsmodel.NotificationFollow:
+		title = name + " followed you"
+	case gtsmodel.NotificationFollowRequest:
+		title = name + " requested to follow you"
+	case gtsmodel.NotificationMention:
+		title = name + " mentioned you"
+	case gtsmodel.NotificationReblog:
+		title = name + " boosted your post"
+	case gtsmodel.NotificationFave:
+		title = name + " favourited your post"
+	case gtsmodel.NotificationPoll:
+		title = "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		title = name + " just posted"
+	default:
+		title = "New notification from " + name
+	}
+
+	var body string
+	if status := apiNotification.Status; status != nil {
+		if status.SpoilerText != "" {
+			// Don't leak content
+			// behind a content warning.
+			body = status.SpoilerText
+		} else {
+			body = text.SanitizeToPlaintext(status.Content)
+		}
+	} else {
+		// Use account bio
+		// for follow (requests).
+		body = text.SanitizeToPlaintext(account.Note)
+	}
+
+	if runes := []rune(body); len(runes) > bodyMaxLength {
+		body = string(runes[:bodyMaxLength-1]) + "…"
+	}
+
+	return &apimodel.WebPushNotification{
+		AccessToken: accessToken,
+		// Notification text is
+		// only generated in English.
+		PreferredLocale:  "en",
+		NotificationID:   notification.ID,
+		NotificationType: string(notification.NotificationType),
+		Icon:             account.Avatar,
+		Title:            title,
+		Body:             body,
+	}
+}
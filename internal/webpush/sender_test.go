@@ -0,0 +1,474 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testAccounts  map[string]*gtsmodel.Account
+	testTokens    map[string]*gtsmodel.Token
+	testInstances map[string]*gtsmodel.Instance
+
+	// Stand-in push service.
+	server    *httptest.Server
+	responses []int
+	requests  []*pushRequest
+	mu        sync.Mutex
+
+	// Client keys of the test subscription.
+	uaPrivate *ecdh.PrivateKey
+	auth      []byte
+
+	sender webpush.Sender
+}
+
+// pushRequest is a request received by the stand-in push service.
+type pushRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func (suite *SenderTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testInstances = testrig.NewTestInstances()
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	testrig.StartNoopWorkers(&suite.state)
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.requests = nil
+	suite.responses = nil
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+
+		suite.requests = append(suite.requests, &pushRequest{
+			header: r.Header.Clone(),
+			body:   body,
+		})
+
+		// Respond with the next queued
+		// status code, or 201 if none.
+		code := http.StatusCreated
+		if len(suite.responses) > 0 {
+			code = suite.responses[0]
+			suite.responses = suite.responses[1:]
+		}
+		w.WriteHeader(code)
+	}))
+
+	var err error
+	suite.uaPrivate, err = ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.auth = make([]byte, 16)
+	if _, err := rand.Read(suite.auth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.sender = webpush.NewSender(suite.server.Client(), &suite.state)
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	suite.server.Close()
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// waitForRequests waits for the stand-in push
+// service to receive the given number of requests,
+// then returns all requests received so far.
+func (suite *SenderTestSuite) waitForRequests(n int) []*pushRequest {
+	if !testrig.WaitFor(func() bool {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+		return len(suite.requests) >= n
+	}) {
+		suite.FailNowf("timed out waiting for push requests", "wanted %d", n)
+	}
+
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	return suite.requests
+}
+
+// putSubscription stores a subscription for local_account_1's
+// token that pushes mentions to the stand-in push service.
+func (suite *SenderTestSuite) putSubscription(policy gtsmodel.WebPushNotificationPolicy) *gtsmodel.WebPushSubscription {
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		TokenID:   suite.testTokens["local_account_1"].ID,
+		Endpoint:  suite.server.URL + "/push/some-client",
+		Auth:      base64.RawURLEncoding.EncodeToString(suite.auth),
+		P256dh:    base64.RawURLEncoding.EncodeToString(suite.uaPrivate.PublicKey().Bytes()),
+		Policy:    policy,
+	}
+	subscription.NotificationFlags.Set(gtsmodel.NotificationMention, true)
+
+	if err := suite.db.PutWebPushSubscription(context.Background(), subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return subscription
+}
+
+// mention returns a mention notification of
+// local_account_1 by the given origin account.
+func (suite *SenderTestSuite) mention(origin *gtsmodel.Account) (*gtsmodel.Notification, *apimodel.Notification) {
+	notification := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: gtsmodel.NotificationMention,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  origin.ID,
+	}
+
+	apiNotification := &apimodel.Notification{
+		ID:   notification.ID,
+		Type: string(notification.NotificationType),
+		Account: &apimodel.Account{
+			ID:          origin.ID,
+			Username:    origin.Username,
+			DisplayName: origin.DisplayName,
+			Avatar:      "http://localhost:8080/avatar.png",
+		},
+		Status: &apimodel.Status{
+			Content: "<p>hey <span class=\"h-card\">@the_mighty_zork</span>, how's it going?</p>",
+		},
+	}
+
+	return notification, apiNotification
+}
+
+func (suite *SenderTestSuite) subscriptionExists(subscription *gtsmodel.WebPushSubscription) bool {
+	_, err := suite.db.GetWebPushSubscriptionByTokenID(context.Background(), subscription.TokenID)
+	if errors.Is(err, db.ErrNoEntries) {
+		return false
+	}
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return true
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	requests := suite.waitForRequests(1)
+	suite.Len(requests, 1)
+	request := requests[0]
+	suite.Equal("aes128gcm", request.header.Get("Content-Encoding"))
+	suite.Equal("application/octet-stream", request.header.Get("Content-Type"))
+	suite.Equal("172800", request.header.Get("TTL"))
+
+	// Check the request is signed
+	// with the instance's VAPID key.
+	suite.checkVAPID(request.header.Get("Authorization"))
+
+	// Decrypt the pushed payload as the client would.
+	plaintext := suite.decrypt(request.body)
+	payload := &apimodel.WebPushNotification{}
+	if err := json.Unmarshal(plaintext, payload); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(suite.testTokens["local_account_1"].Access, payload.AccessToken)
+	suite.Equal(notification.ID, payload.NotificationID)
+	suite.Equal("mention", payload.NotificationType)
+	suite.Equal("http://localhost:8080/avatar.png", payload.Icon)
+	suite.Equal(suite.testAccounts["admin_account"].Username+" mentioned you", payload.Title)
+	suite.Equal("hey @the_mighty_zork, how's it going?", payload.Body)
+}
+
+func (suite *SenderTestSuite) TestSendAlertOff() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	subscription.NotificationFlags.Set(gtsmodel.NotificationMention, false)
+	if err := suite.db.UpdateWebPushSubscription(context.Background(), subscription, "notification_flags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSendPolicyFollowed() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyFollowed)
+
+	// local_account_1 follows admin_account.
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(suite.waitForRequests(1), 1)
+}
+
+func (suite *SenderTestSuite) TestSendPolicyNone() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyNone)
+
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSendRetry() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	suite.responses = []int{http.StatusServiceUnavailable}
+
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// First attempt failed, second
+	// was scheduled and succeeded.
+	suite.Len(suite.waitForRequests(2), 2)
+	suite.True(suite.subscriptionExists(subscription))
+}
+
+func (suite *SenderTestSuite) TestSendRetryDeletedSubscription() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	suite.responses = []int{http.StatusServiceUnavailable}
+
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// First attempt failed, then the
+	// subscription is deleted before retry.
+	suite.waitForRequests(1)
+	if err := suite.db.DeleteWebPushSubscriptionByID(context.Background(), subscription.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Retry should be dropped.
+	time.Sleep(2 * time.Second)
+	suite.Len(suite.waitForRequests(1), 1)
+}
+
+func (suite *SenderTestSuite) TestSendGonePrunes() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	suite.responses = []int{http.StatusGone}
+
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Subscription deleted, and not retried.
+	suite.True(testrig.WaitFor(func() bool {
+		return !suite.subscriptionExists(subscription)
+	}))
+	suite.Len(suite.waitForRequests(1), 1)
+}
+
+func (suite *SenderTestSuite) TestSendRevokedTokenPrunes() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll)
+	if err := suite.db.DeleteByID(context.Background(), subscription.TokenID, &gtsmodel.Token{}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notification, apiNotification := suite.mention(suite.testAccounts["admin_account"])
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(testrig.WaitFor(func() bool {
+		return !suite.subscriptionExists(subscription)
+	}))
+	suite.Empty(suite.waitForRequests(0))
+}
+
+// checkVAPID checks that the given Authorization header value
+// carries a valid JWT signed by the local instance's VAPID key.
+func (suite *SenderTestSuite) checkVAPID(authorization string) {
+	var token, key string
+	for _, param := range strings.Split(strings.TrimPrefix(authorization, "vapid "), ", ") {
+		switch k, v, _ := strings.Cut(param, "="); k {
+		case "t":
+			token = v
+		case "k":
+			key = v
+		}
+	}
+	suite.Equal(suite.testInstances["localhost:8080"].VAPIDPublicKey, key)
+
+	keyBytes, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ecdhKey, err := ecdh.P256().NewPublicKey(keyBytes)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(ecdhKey)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	parts := strings.Split(token, ".")
+	suite.Len(parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	suite.True(ecdsa.Verify(publicKey.(*ecdsa.PublicKey), hash[:], r, s))
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(string(claims), `"aud":"`+suite.server.URL+`"`)
+	suite.Contains(string(claims), `"sub":"mailto:admin@example.org"`)
+}
+
+// decrypt decrypts the given aes128gcm
+// push message body with the client keys.
+func (suite *SenderTestSuite) decrypt(body []byte) []byte {
+	salt := body[:16]
+	suite.Equal(uint32(4096), binary.BigEndian.Uint32(body[16:20]))
+	keyIDLen := int(body[20])
+	asPublicBytes := body[21 : 21+keyIDLen]
+	ciphertext := body[21+keyIDLen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ecdhSecret, err := suite.uaPrivate.ECDH(asPublic)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	keyInfo := []byte("WebPush: info\x00")
+	keyInfo = append(keyInfo, suite.uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(suite.auth, ecdhSecret, keyInfo, 32)
+
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Strip last record delimiter.
+	suite.Equal(byte(0x02), record[len(record)-1])
+	return record[:len(record)-1]
+}
+
+func hkdf(salt []byte, ikm []byte, info []byte, length int) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	prk := mac.Sum(nil)
+
+	mac = hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, &SenderTestSuite{})
+}
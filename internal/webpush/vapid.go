@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// How long a VAPID token is valid
+// for; 24 hours at most by RFC 8292.
+const vapidTokenTTL = 12 * time.Hour
+
+// vapidKeys identifies this instance to push
+// services using Voluntary Application Server
+// Identification (VAPID), as in RFC 8292.
+type vapidKeys struct {
+	// Base64url-encoded uncompressed public
+	// key, as given to clients as server_key.
+	publicKey string
+
+	// Private key to sign tokens with.
+	privateKey *ecdsa.PrivateKey
+
+	// Contact URI for push services.
+	subject string
+}
+
+// parseVAPIDKeys parses the given base64url-encoded P-256
+// key pair, as stored on the local instance, into vapidKeys.
+func parseVAPIDKeys(publicKey string, privateKey string, subject string) (*vapidKeys, error) {
+	if publicKey == "" || privateKey == "" {
+		return nil, errors.New("local instance has no vapid key pair")
+	}
+
+	privateKeyBytes, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding private key: %w", err)
+	}
+
+	ecdhKey, err := ecdh.P256().NewPrivateKey(privateKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	// Round-trip through PKCS #8 to get
+	// an ECDSA key we can sign with.
+	der, err := x509.MarshalPKCS8PrivateKey(ecdhKey)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling private key: %w", err)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling private key: %w", err)
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected private key type %T", key)
+	}
+
+	return &vapidKeys{
+		publicKey:  publicKey,
+		privateKey: ecdsaKey,
+		subject:    subject,
+	}, nil
+}
+
+// authorization returns an Authorization header
+// value for a push request to the given endpoint.
+func (v *vapidKeys) authorization(endpoint string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := v.token(endpointURL.Scheme+"://"+endpointURL.Host, time.Now().Add(vapidTokenTTL))
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + token + ", k=" + v.publicKey, nil
+}
+
+// token returns a JWT signed with ES256, valid
+// until expiry for the push service at audience.
+func (v *vapidKeys) token(audience string, expiry time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "ES256",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"aud": audience,
+		"exp": expiry.Unix(),
+		"sub": v.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+
+	r, s, err := ecdsa.Sign(rand.Reader, v.privateKey, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	// JWS wants the fixed-width
+	// r || s signature encoding.
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -23,6 +23,7 @@ import (
 	"runtime"
 
 	"codeberg.org/gruf/go-runners"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
 )
@@ -61,6 +62,15 @@ type Workers struct {
 	// Media manager worker pools.
 	Media runners.WorkerPool
 
+	// Delivery provides a worker pool that works through
+	// the queue of outgoing federated deliveries, one
+	// remote inbox at a time per worker.
+	Delivery runners.WorkerPool
+
+	// WebPush provides a worker pool that sends
+	// Web Push notifications to push services.
+	WebPush runners.WorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -83,6 +93,20 @@ func (w *Workers) Start() {
 	tryUntil("starting media workerpool", 5, func() bool {
 		return w.Media.Start(8*maxprocs, 80*maxprocs)
 	})
+
+	// Clamp no. delivery workers to 1.
+	senders := config.GetAdvancedSenderMultiplier() * maxprocs
+	if senders < 1 {
+		senders = 1
+	}
+
+	tryUntil("starting delivery workerpool", 5, func() bool {
+		return w.Delivery.Start(senders, 100*senders)
+	})
+
+	tryUntil("starting web push workerpool", 5, func() bool {
+		return w.WebPush.Start(2*maxprocs, 100*maxprocs)
+	})
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
@@ -91,6 +115,8 @@ func (w *Workers) Stop() {
 	tryUntil("stopping client API workerpool", 5, w.ClientAPI.Stop)
 	tryUntil("stopping federator workerpool", 5, w.Federator.Stop)
 	tryUntil("stopping media workerpool", 5, w.Media.Stop)
+	tryUntil("stopping delivery workerpool", 5, w.Delivery.Stop)
+	tryUntil("stopping web push workerpool", 5, w.WebPush.Stop)
 }
 
 // nocopy when embedded will signal linter to
@@ -13,6 +13,7 @@ EXPECT=$(cat << "EOF"
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
     "advanced-header-filter-mode": "",
+    "advanced-persist-worker-queues": true,
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
@@ -58,6 +59,7 @@ EXPECT=$(cat << "EOF"
         "status-mem-ratio": 5,
         "tag-mem-ratio": 2,
         "thread-mute-mem-ratio": 0.2,
+        "token-mem-ratio": 0.75,
         "tombstone-mem-ratio": 0.5,
         "user-mem-ratio": 0.25,
         "visibility-mem-ratio": 2,
@@ -98,6 +100,8 @@ EXPECT=$(cat << "EOF"
         "nl",
         "en-GB"
     ],
+    "instance-subscriptions-process-every": 86400000000000,
+    "instance-subscriptions-process-from": "23:00",
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -117,6 +121,8 @@ EXPECT=$(cat << "EOF"
     "media-image-max-size": 420,
     "media-remote-cache-days": 30,
     "media-video-max-size": 420,
+    "media-video-transcode": true,
+    "media-video-transcode-max-dimension": 1280,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
     "metrics-auth-username": "",
@@ -228,6 +234,8 @@ GTS_ACCOUNTS_APPROVAL_REQUIRED=false \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
+GTS_MEDIA_VIDEO_TRANSCODE=true \
+GTS_MEDIA_VIDEO_TRANSCODE_MAX_DIMENSION=1280 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
@@ -274,6 +282,7 @@ GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_TRACING_INSECURE_TRANSPORT=true \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
+GTS_ADVANCED_PERSIST_WORKER_QUEUES=true \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
@@ -87,6 +87,8 @@ var testDefaults = config.Configuration{
 			TagStr: "en-gb",
 		},
 	},
+	InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm,
+	InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 
 	AccountsRegistrationOpen: true,
 	AccountsApprovalRequired: true,
@@ -94,15 +96,17 @@ var testDefaults = config.Configuration{
 	AccountsAllowCustomCSS:   true,
 	AccountsCustomCSSLength:  10000,
 
-	MediaImageMaxSize:        10485760, // 10MiB
-	MediaVideoMaxSize:        41943040, // 40MiB
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 500,
-	MediaRemoteCacheDays:     7,
-	MediaEmojiLocalMaxSize:   51200,          // 50KiB
-	MediaEmojiRemoteMaxSize:  102400,         // 100KiB
-	MediaCleanupFrom:         "00:00",        // midnight.
-	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaImageMaxSize:               10485760, // 10MiB
+	MediaVideoMaxSize:               41943040, // 40MiB
+	MediaVideoTranscode:             false,
+	MediaVideoTranscodeMaxDimension: 1920,
+	MediaDescriptionMinChars:        0,
+	MediaDescriptionMaxChars:        500,
+	MediaRemoteCacheDays:            7,
+	MediaEmojiLocalMaxSize:          51200,          // 50KiB
+	MediaEmojiRemoteMaxSize:         102400,         // 100KiB
+	MediaCleanupFrom:                "00:00",        // midnight.
+	MediaCleanupEvery:               24 * time.Hour, // 1/day.
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
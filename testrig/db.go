@@ -35,12 +35,16 @@ var testModels = []interface{}{
 	&gtsmodel.AccountToEmoji{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPermissionSubscription{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
 	&gtsmodel.FilterStatus{},
 	&gtsmodel.Follow{},
+	&gtsmodel.FollowedTag{},
 	&gtsmodel.FollowRequest{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
@@ -54,6 +58,7 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StatusEdit{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
@@ -69,8 +74,15 @@ var testModels = []interface{}{
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
+	&gtsmodel.ScheduledStatus{},
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
+	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.Delivery{},
+	&gtsmodel.Relay{},
+	&gtsmodel.WorkerTask{},
+	&gtsmodel.StorageBlob{},
+	&gtsmodel.StorageBlobRef{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -365,6 +377,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		log.Panic(nil, err)
 	}
 
+	// Test models were put directly rather than
+	// via their db functions, so index them now.
+	if err := db.RebuildSearchIndex(ctx); err != nil {
+		log.Panic(nil, err)
+	}
+
 	log.Debug(nil, "testing db setup complete")
 }
 
@@ -1320,6 +1320,8 @@ func NewTestInstances() map[string]*gtsmodel.Instance {
 			ContactEmail:           "admin@example.org",
 			ContactAccountUsername: "admin",
 			ContactAccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
+			VAPIDPublicKey:         "BAqMAV4mt-hh2ibAPFbw_xtyn9FkAxV_XAkCcuzIN2vSyqIFP9n0c3TK4QcVFn5xmKHcly7M51D1RI03UXurJU8",
+			VAPIDPrivateKey:        "AmA6LLRQ395cXA4U7va_v25UAcqtMUe7Xg-137uewdU",
 		},
 		"fossbros-anonymous.io": {
 			ID:        "01G5H6YMJQKR86QZKXXQ2S95FZ",
@@ -1353,6 +1355,42 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 	}
 }
 
+// NewTestDomainPermissionLists returns remote domain permission lists
+// (in each supported format) keyed by URI, for use when testing domain
+// permission subscriptions.
+func NewTestDomainPermissionLists() map[string]RemoteAttachmentFile {
+	return map[string]RemoteAttachmentFile{
+		"https://lists.example.org/blocklist.csv": {
+			Data: []byte(`#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,suspend,false,false,harassment,false
+nothanks.com,suspend,false,false,,false
+quiet.example.org,silence,false,false,,false
+`),
+			ContentType: "text/csv",
+		},
+		"https://lists.example.org/blocklist.json": {
+			Data: []byte(`[
+  {"domain":"bumfaces.net","public_comment":"big jerks"},
+  {"domain":"peepee.poopoo","public_comment":"harassment"},
+  {"domain":"nothanks.com"}
+]`),
+			ContentType: "application/json",
+		},
+		"https://lists.example.org/blocklist.txt": {
+			Data: []byte(`# some blocked domains
+bumfaces.net
+peepee.poopoo
+nothanks.com
+
+# already blocked here
+replyguys.com
+`),
+			ContentType: "text/plain",
+		},
+	}
+}
+
 type filenames struct {
 	Original string
 	Small    string
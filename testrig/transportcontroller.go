@@ -66,6 +66,7 @@ type MockHTTPClient struct {
 	TestRemoteAttachments map[string]RemoteAttachmentFile
 	TestRemoteEmojis      map[string]vocab.TootEmoji
 	TestTombstones        map[string]*gtsmodel.Tombstone
+	TestDomainPermLists   map[string]RemoteAttachmentFile
 
 	SentMessages sync.Map
 }
@@ -94,6 +95,7 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 	mockHTTPClient.TestRemoteAttachments = NewTestFediAttachments(relativeMediaPath)
 	mockHTTPClient.TestRemoteEmojis = NewTestFediEmojis()
 	mockHTTPClient.TestTombstones = NewTestTombstones()
+	mockHTTPClient.TestDomainPermLists = NewTestDomainPermissionLists()
 
 	mockHTTPClient.do = func(req *http.Request) (*http.Response, error) {
 		var (
@@ -202,6 +204,11 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseBytes = attachment.Data
 			responseContentType = attachment.ContentType
 			responseContentLength = len(attachment.Data)
+		} else if list, ok := mockHTTPClient.TestDomainPermLists[reqURLString]; ok {
+			responseCode = http.StatusOK
+			responseBytes = list.Data
+			responseContentType = list.ContentType
+			responseContentLength = len(list.Data)
 		} else if _, ok := mockHTTPClient.TestTombstones[reqURLString]; ok {
 			responseCode = http.StatusGone
 			responseBytes = []byte{}
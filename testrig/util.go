@@ -48,6 +48,8 @@ func StartNoopWorkers(state *state.State) {
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
+	_ = state.Workers.Delivery.Start(1, 10)
+	_ = state.Workers.WebPush.Start(1, 10)
 }
 
 // Starts workers on the provided state using processing functions from the given
@@ -62,6 +64,8 @@ func StartWorkers(state *state.State, wProcessor *wprocessor.Processor) {
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
+	_ = state.Workers.Delivery.Start(1, 10)
+	_ = state.Workers.WebPush.Start(1, 10)
 }
 
 func StopWorkers(state *state.State) {
@@ -69,6 +73,8 @@ func StopWorkers(state *state.State) {
 	_ = state.Workers.ClientAPI.Stop()
 	_ = state.Workers.Federator.Stop()
 	_ = state.Workers.Media.Stop()
+	_ = state.Workers.Delivery.Stop()
+	_ = state.Workers.WebPush.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, converter *typeutils.Converter) {
@@ -118,6 +118,7 @@ dynamicSpoiler("text-spoiler", (spoiler) => {
 dynamicSpoiler("media-spoiler", (spoiler) => {
 	const eye = spoiler.querySelector(".eye.button");
 	const video = spoiler.querySelector(".plyr-video");
+	const audio = spoiler.querySelector(".audio-player audio");
 
 	return () => {
 		if (spoiler.open) {
@@ -127,6 +128,9 @@ dynamicSpoiler("media-spoiler", (spoiler) => {
 			if (video) {
 				video.pause();
 			}
+			if (audio) {
+				audio.pause();
+			}
 		}
 	};
 });
@@ -106,6 +106,14 @@ function ModifyAccount({ account }) {
 					name="suspend"
 					result={result}
 				/>
+				{/* Only local accounts have a second factor to reset. */}
+				{!account.acct.includes("@") &&
+					<MutationButton
+						label="Reset 2FA"
+						name="reset-2fa"
+						result={result}
+					/>
+				}
 			</div>
 		</form>
 	);
@@ -33,6 +33,7 @@ const { RoleContext } = require("./lib/navigation/util");
 const UserProfile = require("./user/profile").default;
 const UserSettings = require("./user/settings").default;
 const UserMigration = require("./user/migration").default;
+const UserTokens = require("./user/tokens").default;
 
 const DomainPerms = require("./admin/domain-permissions").default;
 const DomainPermsImportExport = require("./admin/domain-permissions/import-export").default;
@@ -46,6 +47,7 @@ const { Sidebar, ViewRouter } = createNavigation("/settings", [
 		Item("Profile", { icon: "fa-user" }, UserProfile),
 		Item("Settings", { icon: "fa-cogs" }, UserSettings),
 		Item("Migration", { icon: "fa-exchange" }, UserMigration),
+		Item("Applications", { icon: "fa-key" }, UserTokens),
 	]),
 	Menu("Moderation", {
 		url: "admin",
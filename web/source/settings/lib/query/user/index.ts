@@ -24,6 +24,12 @@ import type {
 	UpdateAliasesFormData
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
+import type {
+	TwoFactorBackupCodes,
+	TwoFactorSetup,
+	TwoFactorStatus
+} from "../../types/two-factor";
+import type { TokenInfo } from "../../types/token";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -72,6 +78,63 @@ const extended = gtsApi.injectEndpoints({
 			query: () => ({
 				url: `/api/v1/accounts/themes`
 			})
+		}),
+		twoFactorStatus: build.query<TwoFactorStatus, void>({
+			query: () => ({
+				url: `/api/v1/user/2fa`
+			}),
+			providesTags: ["TwoFactor"]
+		}),
+		twoFactorSetup: build.mutation<TwoFactorSetup, void>({
+			query: () => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/setup`
+			})
+		}),
+		twoFactorEnable: build.mutation<TwoFactorBackupCodes, { code: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/enable`,
+				body: data
+			}),
+			invalidatesTags: ["TwoFactor"]
+		}),
+		twoFactorDisable: build.mutation<any, { password: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/disable`,
+				body: data
+			}),
+			invalidatesTags: ["TwoFactor"]
+		}),
+		twoFactorBackupCodes: build.mutation<TwoFactorBackupCodes, { password: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/backup_codes`,
+				body: data
+			}),
+			invalidatesTags: ["TwoFactor"]
+		}),
+		tokens: build.query<TokenInfo[], void>({
+			query: () => ({
+				url: `/api/v1/tokens?limit=100`
+			}),
+			providesTags: ["Token"]
+		}),
+		tokenInvalidate: build.mutation<TokenInfo, string>({
+			query: (id) => ({
+				method: "POST",
+				url: `/api/v1/tokens/${id}/invalidate`
+			}),
+			invalidatesTags: ["Token"]
+		}),
+		tokensInvalidateForApp: build.mutation<TokenInfo[], string>({
+			query: (applicationID) => ({
+				method: "POST",
+				url: `/api/v1/tokens/invalidate`,
+				body: { application_id: applicationID }
+			}),
+			invalidatesTags: ["Token"]
 		})
 	})
 });
@@ -82,4 +145,12 @@ export const {
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
+	useTwoFactorStatusQuery,
+	useTwoFactorSetupMutation,
+	useTwoFactorEnableMutation,
+	useTwoFactorDisableMutation,
+	useTwoFactorBackupCodesMutation,
+	useTokensQuery,
+	useTokenInvalidateMutation,
+	useTokensInvalidateForAppMutation,
 } = extended;
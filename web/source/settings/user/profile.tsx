@@ -66,6 +66,7 @@ function UserProfileForm({ data: profile }) {
 		- file header
 		- bool enable_rss
 		- bool hide_collections
+		- bool indexable
 		- string custom_css (if enabled)
 		- string theme
 	*/
@@ -100,6 +101,7 @@ function UserProfileForm({ data: profile }) {
 		discoverable: useBoolInput("discoverable", { source: profile}),
 		enableRSS: useBoolInput("enable_rss", { source: profile }),
 		hideCollections: useBoolInput("hide_collections", { source: profile }),
+		indexable: useBoolInput("indexable", { source: profile }),
 		fields: useFieldArrayInput("fields_attributes", {
 			defaultValue: profile?.source?.fields,
 			length: instanceConfig.maxPinnedFields
@@ -206,6 +208,10 @@ function UserProfileForm({ data: profile }) {
 				field={form.discoverable}
 				label="Mark account as discoverable by search engines and directories"
 			/>
+			<Checkbox
+				field={form.indexable}
+				label="Include public posts in search results"
+			/>
 			<Checkbox
 				field={form.enableRSS}
 				label="Enable RSS feed of Public posts"
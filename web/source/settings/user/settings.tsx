@@ -30,6 +30,7 @@ import { Select, TextInput, Checkbox } from "../components/form/inputs";
 import FormWithData from "../lib/form/form-with-data";
 import Languages from "../components/languages";
 import MutationButton from "../components/form/mutation-button";
+import TwoFactor from "./two-factor";
 
 export default function UserSettings() {
 	return (
@@ -94,6 +95,7 @@ function UserSettingsForm({ data }) {
 				/>
 			</form>
 			<PasswordChange />
+			<TwoFactor />
 		</>
 	);
 }
@@ -0,0 +1,157 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useMemo } from "react";
+
+import FormWithData from "../lib/form/form-with-data";
+import MutationButton from "../components/form/mutation-button";
+import {
+	useTokenInvalidateMutation,
+	useTokensInvalidateForAppMutation,
+	useTokensQuery,
+} from "../lib/query/user";
+import type { TokenApplication, TokenInfo } from "../lib/types/token";
+
+export default function Tokens() {
+	return (
+		<div className="tokens">
+			<div className="form-section-docs">
+				<h1>Applications</h1>
+				<p>
+					These are the applications you've signed in to with your account, and the
+					access tokens they were given. Revoking a token signs that app out straight away.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/password_management/#applications-and-access-tokens"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about applications and access tokens (opens in a new tab)
+				</a>
+			</div>
+			<FormWithData
+				dataQuery={useTokensQuery}
+				DataForm={TokensList}
+			/>
+		</div>
+	);
+}
+
+interface AppTokens {
+	application: TokenApplication;
+	tokens: TokenInfo[];
+}
+
+function TokensList({ data: tokens }: { data: TokenInfo[] }) {
+	// Group tokens by the app they were
+	// created for, keeping newest first.
+	const apps = useMemo(() => {
+		const byApp = new Map<string, AppTokens>();
+		tokens.forEach((token) => {
+			const entry = byApp.get(token.application.id);
+			if (entry) {
+				entry.tokens.push(token);
+			} else {
+				byApp.set(token.application.id, {
+					application: token.application,
+					tokens: [token],
+				});
+			}
+		});
+		return Array.from(byApp.values());
+	}, [tokens]);
+
+	if (apps.length == 0) {
+		return <p>You haven't signed in to any applications.</p>;
+	}
+
+	return (
+		<div className="list">
+			{apps.map((app) => (
+				<AppEntry key={app.application.id} app={app} />
+			))}
+		</div>
+	);
+}
+
+function AppEntry({ app }: { app: AppTokens }) {
+	const [invalidateAll, result] = useTokensInvalidateForAppMutation();
+	const { application, tokens } = app;
+
+	return (
+		<div className="entry">
+			<div className="app">
+				<h3>{application.name}</h3>
+				{application.website &&
+					<a href={application.website} target="_blank" rel="noreferrer">
+						{application.website}
+					</a>
+				}
+			</div>
+			{tokens.map((token) => (
+				<TokenEntry key={token.id} token={token} />
+			))}
+			{tokens.length > 1 &&
+				<MutationButton
+					type="button"
+					disabled={false}
+					label={`Revoke all ${tokens.length} tokens for this app`}
+					result={result}
+					onClick={() => invalidateAll(application.id)}
+				/>
+			}
+		</div>
+	);
+}
+
+function TokenEntry({ token }: { token: TokenInfo }) {
+	const [invalidate, result] = useTokenInvalidateMutation();
+
+	return (
+		<div className="token">
+			<dl>
+				<div>
+					<dt>Scopes</dt>
+					<dd>{token.scope}</dd>
+				</div>
+				<div>
+					<dt>Created</dt>
+					<dd>{new Date(token.created_at).toLocaleString()}</dd>
+				</div>
+				<div>
+					<dt>Last used</dt>
+					<dd>
+						{token.last_used
+							? new Date(token.last_used).toLocaleString()
+							: <i>unknown</i>
+						}
+					</dd>
+				</div>
+			</dl>
+			<MutationButton
+				type="button"
+				disabled={false}
+				label="Revoke"
+				result={result}
+				onClick={() => invalidate(token.id)}
+			/>
+		</div>
+	);
+}
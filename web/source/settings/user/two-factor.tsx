@@ -0,0 +1,235 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useState } from "react";
+
+import FormWithData from "../lib/form/form-with-data";
+
+import { useTextInput } from "../lib/form";
+import { TextInput } from "../components/form/inputs";
+import useFormSubmit from "../lib/form/submit";
+import MutationButton from "../components/form/mutation-button";
+import {
+	useTwoFactorBackupCodesMutation,
+	useTwoFactorDisableMutation,
+	useTwoFactorEnableMutation,
+	useTwoFactorSetupMutation,
+	useTwoFactorStatusQuery,
+} from "../lib/query/user";
+import type { TwoFactorStatus } from "../lib/types/two-factor";
+
+export default function TwoFactor() {
+	return (
+		<FormWithData
+			dataQuery={useTwoFactorStatusQuery}
+			DataForm={TwoFactorForm}
+		/>
+	);
+}
+
+function TwoFactorForm({ data: status }: { data: TwoFactorStatus }) {
+	// Backup codes are only ever returned once, straight
+	// after enabling 2FA or regenerating them, so hold
+	// them here to show until the user navigates away.
+	const [backupCodes, setBackupCodes] = useState<string[]>();
+
+	return (
+		<div className="two-factor">
+			<div className="form-section-docs">
+				<h1>Two-factor authentication</h1>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/password_management/#two-factor-authentication"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about two-factor authentication (opens in a new tab)
+				</a>
+			</div>
+			{backupCodes && <BackupCodes codes={backupCodes} />}
+			{status.enabled
+				? <TwoFactorEnabled status={status} onBackupCodes={setBackupCodes} />
+				: <TwoFactorSetup onBackupCodes={setBackupCodes} />
+			}
+		</div>
+	);
+}
+
+function BackupCodes({ codes }: { codes: string[] }) {
+	return (
+		<div className="backup-codes">
+			<p>
+				These are your backup codes. Each one can be used once instead of a code
+				from your authenticator app when signing in. <strong>Store them somewhere
+				safe now; they will not be shown again!</strong>
+			</p>
+			<ul>
+				{codes.map((code) => <li key={code}><code>{code}</code></li>)}
+			</ul>
+		</div>
+	);
+}
+
+function TwoFactorSetup({ onBackupCodes }: { onBackupCodes: (_codes: string[]) => void }) {
+	const [setup, setupResult] = useTwoFactorSetupMutation();
+
+	const form = {
+		code: useTextInput("code"),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useTwoFactorEnableMutation(), {
+		changedOnly: false,
+		onFinish: (res) => {
+			if (res.data) {
+				onBackupCodes(res.data.backup_codes);
+			}
+		}
+	});
+
+	if (!setupResult.data) {
+		return (
+			<form onSubmit={(e) => { e.preventDefault(); setup(); }}>
+				<p>
+					Two-factor authentication is <strong>not enabled</strong>. Enable it to
+					require a code from an authenticator app, in addition to your password,
+					when signing in.
+				</p>
+				<MutationButton
+					disabled={false}
+					label="Set up two-factor authentication"
+					result={setupResult}
+				/>
+			</form>
+		);
+	}
+
+	return (
+		<form onSubmit={submitForm}>
+			<p>
+				Scan this QR code with your authenticator app, then enter
+				the code it shows below to finish enabling two-factor authentication.
+			</p>
+			<img
+				className="qr-code"
+				src={setupResult.data.qr_code}
+				alt="QR code containing your two-factor authentication secret"
+			/>
+			<p>
+				Can't scan the code? Enter this secret into your app instead: <code>{setupResult.data.secret}</code>
+			</p>
+			<TextInput
+				field={form.code}
+				label="Code from authenticator app"
+				autoComplete="one-time-code"
+				inputMode="numeric"
+				required
+			/>
+			<MutationButton
+				disabled={false}
+				label="Enable two-factor authentication"
+				result={result}
+			/>
+		</form>
+	);
+}
+
+function TwoFactorEnabled({ status, onBackupCodes }: { status: TwoFactorStatus, onBackupCodes: (_codes?: string[]) => void }) {
+	return (
+		<>
+			<p>
+				Two-factor authentication is <strong>enabled</strong> (since {new Date(status.enabled_at ?? "").toLocaleString()}).
+				You have {status.backup_codes_remaining} unused backup codes remaining.
+			</p>
+			<RegenerateBackupCodes onBackupCodes={onBackupCodes} />
+			<DisableTwoFactor onBackupCodes={onBackupCodes} />
+		</>
+	);
+}
+
+function RegenerateBackupCodes({ onBackupCodes }: { onBackupCodes: (_codes?: string[]) => void }) {
+	const form = {
+		password: useTextInput("password"),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useTwoFactorBackupCodesMutation(), {
+		changedOnly: false,
+		onFinish: (res) => {
+			if (res.data) {
+				form.password.reset();
+				onBackupCodes(res.data.backup_codes);
+			}
+		}
+	});
+
+	return (
+		<form onSubmit={submitForm}>
+			<h3>Backup codes</h3>
+			<p>
+				Generating new backup codes will stop any previous ones from working.
+			</p>
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+				required
+			/>
+			<MutationButton
+				disabled={false}
+				label="Generate new backup codes"
+				result={result}
+			/>
+		</form>
+	);
+}
+
+function DisableTwoFactor({ onBackupCodes }: { onBackupCodes: (_codes?: string[]) => void }) {
+	const form = {
+		password: useTextInput("password"),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useTwoFactorDisableMutation(), {
+		changedOnly: false,
+		onFinish: (res) => {
+			if (!res.error) {
+				onBackupCodes(undefined);
+			}
+		}
+	});
+
+	return (
+		<form onSubmit={submitForm}>
+			<h3>Disable two-factor authentication</h3>
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+				required
+			/>
+			<MutationButton
+				disabled={false}
+				label="Disable two-factor authentication"
+				result={result}
+			/>
+		</form>
+	);
+}